@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/sqlscript"
+)
+
+// maxStatementSize is the maximum size of a single statement.
+const maxStatementSize = 16 * 1024 * 1024
+
+const prompt = "hdbcli> "
+
+// errQuit is returned by run if the quit command was executed.
+var errQuit = errors.New("quit")
+
+// queryRE matches statements returning a resultset.
+var queryRE = regexp.MustCompile(`(?is)^\s*(select|with|values)\b`)
+
+// Command names.
+const (
+	cmdFormat   = `\format`
+	cmdDownload = `\download`
+	cmdUpload   = `\upload`
+	cmdQuit     = `\quit`
+)
+
+type cli struct {
+	conn            *sql.Conn
+	out, info       io.Writer
+	format          string
+	separator       rune
+	continueOnError bool
+}
+
+func newCLI(conn *sql.Conn, out, info io.Writer, format string, separator rune, continueOnError bool) (*cli, error) {
+	if !slices.Contains(formats, format) {
+		return nil, fmt.Errorf("invalid format %s", format)
+	}
+	return &cli{conn: conn, out: out, info: info, format: format, separator: separator, continueOnError: continueOnError}, nil
+}
+
+// scanFunc returns a split function returning commands (lines starting with a backslash) and sql statements as tokens.
+func (c *cli) scanFunc() bufio.SplitFunc {
+	scanStmt := sqlscript.ScanFunc(c.separator, false)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		start := bytes.IndexFunc(data, func(r rune) bool { return !unicode.IsSpace(r) })
+		if start == -1 || data[start] != '\\' {
+			return scanStmt(data, atEOF)
+		}
+		if end := bytes.IndexByte(data[start:], '\n'); end != -1 {
+			return start + end + 1, bytes.TrimSpace(data[start : start+end]), nil
+		}
+		if atEOF {
+			return len(data), bytes.TrimSpace(data[start:]), nil
+		}
+		return 0, nil, nil // request more data
+	}
+}
+
+// run executes the commands and statements read from rd. If interactive is true, a prompt is written before
+// reading a statement and errors are reported instead of stopping the execution.
+func (c *cli) run(ctx context.Context, rd io.Reader, interactive bool) error {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, maxStatementSize)
+	scanner.Split(c.scanFunc())
+
+	var errs []error
+	for {
+		if interactive {
+			fmt.Fprint(c.info, prompt)
+		}
+		if !scanner.Scan() {
+			break
+		}
+		if err := c.execute(ctx, scanner.Text()); err != nil {
+			if errors.Is(err, errQuit) {
+				return err
+			}
+			if interactive {
+				fmt.Fprintf(c.info, "error: %s\n", err)
+				continue
+			}
+			errs = append(errs, err)
+			if !c.continueOnError {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// execute executes a command or a sql statement.
+func (c *cli) execute(ctx context.Context, stmt string) error {
+	if !strings.HasPrefix(stmt, `\`) {
+		if queryRE.MatchString(stmt) {
+			return c.query(ctx, stmt)
+		}
+		return c.exec(ctx, stmt)
+	}
+
+	cmd, arg, _ := strings.Cut(stmt, " ")
+	arg = strings.TrimSpace(arg)
+	switch cmd {
+	case cmdFormat:
+		if !slices.Contains(formats, arg) {
+			return fmt.Errorf("invalid format %s", arg)
+		}
+		c.format = arg
+		return nil
+	case cmdDownload, cmdUpload:
+		file, stmt, ok := strings.Cut(arg, " ")
+		if !ok {
+			return fmt.Errorf("usage: %s <file> <statement>", cmd)
+		}
+		if cmd == cmdDownload {
+			return c.download(ctx, file, strings.TrimSpace(stmt))
+		}
+		return c.upload(ctx, file, strings.TrimSpace(stmt))
+	case cmdQuit:
+		return errQuit
+	default:
+		return fmt.Errorf("unknown command %s", cmd)
+	}
+}
+
+func (c *cli) exec(ctx context.Context, stmt string, args ...any) error {
+	result, err := c.conn.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil {
+		fmt.Fprintf(c.info, "%d rows affected\n", n)
+	}
+	return nil
+}
+
+func (c *cli) query(ctx context.Context, query string) error {
+	rows, err := c.conn.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	f := newFormatter(c.format, c.out, columnTypes)
+	values := make([]value, len(columnTypes))
+	dest := make([]any, len(columnTypes))
+	for i, ct := range columnTypes {
+		values[i].typeName = ct.DatabaseTypeName()
+		if _, scale, ok := ct.DecimalSize(); ok {
+			values[i].scale = int(scale)
+		}
+		dest[i] = &values[i]
+	}
+
+	numRow := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := f.row(values); err != nil {
+			return err
+		}
+		numRow++
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := f.flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.info, "%d rows selected\n", numRow)
+	return nil
+}
+
+// download writes the lob of the first column of the first row returned by query to file.
+func (c *cli) download(ctx context.Context, file, query string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	wr := bufio.NewWriter(f)
+	lob := &driver.NullLob{Lob: driver.NewLob(nil, wr)}
+	if err := c.conn.QueryRowContext(ctx, query).Scan(lob); err != nil {
+		return err
+	}
+	if !lob.Valid {
+		return errors.New("lob value is NULL")
+	}
+	if err := wr.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.info, "lob written to %s\n", file)
+	return nil
+}
+
+// upload executes stmt with the content of file as lob parameter.
+func (c *cli) upload(ctx context.Context, file, stmt string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.exec(ctx, stmt, driver.NewLob(bufio.NewReader(f), nil))
+}
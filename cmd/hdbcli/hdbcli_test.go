@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func newTestCLI(t *testing.T, format string) (*hdbtest.Server, *cli, *bytes.Buffer) {
+	t.Helper()
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	connector, err := driver.NewDSNConnector(srv.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		db.Close()
+		srv.Close()
+	})
+	out := &bytes.Buffer{}
+	c, err := newCLI(conn, out, &bytes.Buffer{}, format, ';', false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, c, out
+}
+
+func handleQuery(srv *hdbtest.Server) {
+	srv.Handle("select id, name from t", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "ID", Type: "INTEGER"}, {Name: "NAME", Type: "NVARCHAR", Length: 20, Nullable: true}},
+		Rows:    [][]any{{1, "a,b"}, {2, nil}},
+	})
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{fmtTable, "ID  NAME\n1   a,b\n2   ?\n"},
+		{fmtCSV, "ID,NAME\n1,\"a,b\"\n2,\n"},
+		{fmtJSON, "{\"ID\":1,\"NAME\":\"a,b\"}\n{\"ID\":2,\"NAME\":null}\n"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			srv, c, out := newTestCLI(t, test.format)
+			handleQuery(srv)
+			if err := c.run(context.Background(), strings.NewReader("select id, name from t;"), false); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != test.expected {
+				t.Fatalf("output\n%s\nexpected\n%s", out.String(), test.expected)
+			}
+		})
+	}
+}
+
+func TestScript(t *testing.T) {
+	srv, c, out := newTestCLI(t, fmtTable)
+	handleQuery(srv)
+	srv.Handle("insert into t values (3, 'c;d')", &hdbtest.Response{RowsAffected: 1})
+
+	script := `
+-- comment
+create table t (id integer, name nvarchar(20));
+insert into t values (3, 'c;d');
+\format csv
+select id, name from t;
+`
+	if err := c.run(context.Background(), strings.NewReader(script), false); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "ID,NAME\n1,\"a,b\"\n2,\n" {
+		t.Fatalf("invalid output %s", out.String())
+	}
+
+	var queries []string
+	for _, e := range srv.Executions() {
+		queries = append(queries, e.Query)
+	}
+	if !strings.Contains(strings.Join(queries, "\n"), "insert into t values (3, 'c;d')") {
+		t.Fatalf("statement not executed: %v", queries)
+	}
+
+	// stop on error
+	if err := c.run(context.Background(), strings.NewReader("delete from unknown; select id, name from t;"), false); err == nil {
+		t.Fatal("error expected")
+	}
+}
+
+func TestDownload(t *testing.T) {
+	srv, c, _ := newTestCLI(t, fmtTable)
+	text := strings.Repeat("lob text ", 10000)
+	srv.Handle("select text from lobs", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "TEXT", Type: "NCLOB", Nullable: true}},
+		Rows:    [][]any{{text}},
+	})
+
+	file := filepath.Join(t.TempDir(), "lob.txt")
+	if err := c.run(context.Background(), strings.NewReader(`\download `+file+` select text from lobs`), false); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != text {
+		t.Fatal("invalid lob content")
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+// Output formats.
+const (
+	fmtTable = "table"
+	fmtCSV   = "csv"
+	fmtJSON  = "json"
+)
+
+var formats = []string{fmtTable, fmtCSV, fmtJSON}
+
+// value is a column value scanned generically.
+type value struct {
+	typeName string
+	scale    int
+	v        any // nil, numeric, bool, string, []byte (binary) or time.Time
+}
+
+// isBinary returns true if the column values are binary data.
+func (v *value) isBinary() bool {
+	switch v.typeName {
+	case "BINARY", "VARBINARY", "BLOB", "ST_GEOMETRY", "ST_POINT":
+		return true
+	default:
+		return false
+	}
+}
+
+// isLob returns true if the column values are lobs.
+func (v *value) isLob() bool {
+	switch v.typeName {
+	case "BLOB", "CLOB", "NCLOB", "TEXT", "BINTEXT":
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan implements the sql.Scanner interface.
+func (v *value) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		v.v = nil
+	case []byte:
+		if v.isBinary() {
+			v.v = append([]byte(nil), src...)
+		} else {
+			v.v = string(src)
+		}
+	case *big.Rat:
+		v.v = src.FloatString(v.scale)
+	default:
+		if !v.isLob() {
+			v.v = src
+			return nil
+		}
+		var b []byte
+		if err := driver.ScanLobBytes(src, &b); err != nil {
+			return err
+		}
+		if v.isBinary() {
+			v.v = b
+		} else {
+			v.v = string(b)
+		}
+	}
+	return nil
+}
+
+// String returns the text representation of the value (binary data hex encoded).
+func (v *value) String() string {
+	switch val := v.v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return hex.EncodeToString(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+type formatter interface {
+	row(values []value) error
+	flush() error
+}
+
+func newFormatter(format string, wr io.Writer, columnTypes []*sql.ColumnType) formatter {
+	names := make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		names[i] = ct.Name()
+	}
+	switch format {
+	case fmtCSV:
+		return newCSVFormatter(wr, names)
+	case fmtJSON:
+		return newJSONFormatter(wr, names)
+	default:
+		return newTableFormatter(wr, names)
+	}
+}
+
+type tableFormatter struct {
+	names []string
+	wr    *tabwriter.Writer
+}
+
+func newTableFormatter(wr io.Writer, names []string) *tableFormatter {
+	return &tableFormatter{names: names, wr: tabwriter.NewWriter(wr, 0, 0, 2, ' ', 0)}
+}
+
+func (f *tableFormatter) line(fields []string) error {
+	_, err := io.WriteString(f.wr, strings.Join(fields, "\t")+"\n")
+	return err
+}
+
+func (f *tableFormatter) row(values []value) error {
+	if f.names != nil { // header
+		if err := f.line(f.names); err != nil {
+			return err
+		}
+		f.names = nil
+	}
+	fields := make([]string, len(values))
+	for i, v := range values {
+		if v.v == nil {
+			fields[i] = "?" // hdbsql NULL representation
+		} else {
+			fields[i] = v.String()
+		}
+	}
+	return f.line(fields)
+}
+
+func (f *tableFormatter) flush() error {
+	if f.names != nil { // header of empty resultset
+		if err := f.line(f.names); err != nil {
+			return err
+		}
+	}
+	return f.wr.Flush()
+}
+
+type csvFormatter struct {
+	names []string
+	wr    *csv.Writer
+}
+
+func newCSVFormatter(wr io.Writer, names []string) *csvFormatter {
+	return &csvFormatter{names: names, wr: csv.NewWriter(wr)}
+}
+
+func (f *csvFormatter) row(values []value) error {
+	if f.names != nil {
+		if err := f.wr.Write(f.names); err != nil {
+			return err
+		}
+		f.names = nil
+	}
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = v.String()
+	}
+	return f.wr.Write(record)
+}
+
+func (f *csvFormatter) flush() error {
+	if f.names != nil {
+		if err := f.wr.Write(f.names); err != nil {
+			return err
+		}
+	}
+	f.wr.Flush()
+	return f.wr.Error()
+}
+
+// jsonFormatter writes one JSON object per row keeping the column order.
+type jsonFormatter struct {
+	keys [][]byte
+	wr   io.Writer
+	buf  []byte
+}
+
+func newJSONFormatter(wr io.Writer, names []string) *jsonFormatter {
+	keys := make([][]byte, len(names))
+	for i, name := range names {
+		keys[i], _ = json.Marshal(name) //nolint:errcheck // string
+	}
+	return &jsonFormatter{keys: keys, wr: wr}
+}
+
+func (f *jsonFormatter) row(values []value) error {
+	f.buf = append(f.buf[:0], '{')
+	for i, v := range values {
+		if i != 0 {
+			f.buf = append(f.buf, ',')
+		}
+		f.buf = append(f.buf, f.keys[i]...)
+		f.buf = append(f.buf, ':')
+		b, err := json.Marshal(v.v)
+		if err != nil {
+			return err
+		}
+		f.buf = append(f.buf, b...)
+	}
+	f.buf = append(f.buf, '}', '\n')
+	_, err := f.wr.Write(f.buf)
+	return err
+}
+
+func (f *jsonFormatter) flush() error { return nil }
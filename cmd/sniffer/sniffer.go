@@ -8,14 +8,48 @@ import (
 	"log"
 	"net"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/SAP/go-hdb/driver"
 )
 
 func main() {
-	addr, dbAddr := cli()
+	addr, dbAddr, recordDir, replayFile, jsonFile, jsonOptions := cli()
 
-	log.Printf("listening on %s (database address %s)", addr.String(), dbAddr.String())
+	var jsonWr io.Writer
+	switch jsonFile {
+	case "":
+	case "-":
+		jsonWr = &syncWriter{wr: os.Stdout}
+	default:
+		f, err := os.Create(jsonFile)
+		if err != nil {
+			log.Panic(err)
+		}
+		defer f.Close()
+		jsonWr = &syncWriter{wr: f}
+	}
+
+	var replayer *driver.Replayer
+	if replayFile != "" {
+		f, err := os.Open(replayFile)
+		if err != nil {
+			log.Panic(err)
+		}
+		replayer, err = driver.NewReplayer(f)
+		f.Close()
+		if err != nil {
+			log.Panic(err)
+		}
+		log.Printf("listening on %s (replay of %s)", addr.String(), replayFile)
+	} else {
+		log.Printf("listening on %s (database address %s)", addr.String(), dbAddr.String())
+	}
 
 	l, err := net.Listen(addr.Network(), addr.String())
 	if err != nil {
@@ -28,11 +62,36 @@ func main() {
 			log.Panic(err)
 		}
 
-		go handler(conn, dbAddr)
+		if replayer != nil {
+			go replayHandler(conn, replayer)
+		} else {
+			go handler(conn, dbAddr, recordDir, jsonWr, jsonOptions)
+		}
 	}
 }
 
-func handler(conn net.Conn, dbAddr net.Addr) {
+func replayHandler(conn net.Conn, replayer *driver.Replayer) {
+	defer conn.Close()
+	if err := replayer.Serve(conn); err != nil {
+		log.Printf("replay error: %s - close connection - remote address %s", err, conn.RemoteAddr().String())
+	}
+}
+
+// syncWriter serializes the writes of the session handlers.
+type syncWriter struct {
+	mu sync.Mutex
+	wr io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.wr.Write(p)
+}
+
+var sessionNo atomic.Uint64
+
+func handler(conn net.Conn, dbAddr net.Addr, recordDir string, jsonWr io.Writer, jsonOptions *driver.SnifferJSONOptions) {
 	dbConn, err := net.Dial(dbAddr.Network(), dbAddr.String())
 	if err != nil {
 		log.Printf("hdb connection error: %s", err)
@@ -41,7 +100,22 @@ func handler(conn net.Conn, dbAddr net.Addr) {
 
 	defer dbConn.Close()
 
-	err = driver.NewSniffer(conn, dbConn).Run()
+	sniffer := driver.NewSniffer(conn, dbConn)
+	if recordDir != "" {
+		f, err := os.Create(filepath.Join(recordDir, fmt.Sprintf("session%d.jsonl", sessionNo.Add(1))))
+		if err != nil {
+			log.Printf("record file error: %s", err)
+			return
+		}
+		defer f.Close()
+		log.Printf("recording session to %s", f.Name())
+		sniffer.SetRecorder(f)
+	}
+	if jsonWr != nil {
+		sniffer.SetJSONWriter(jsonWr, jsonOptions)
+	}
+
+	err = sniffer.Run()
 	switch {
 	case err == nil:
 		return
@@ -78,7 +152,64 @@ func (v *addrValue) Set(s string) error {
 	return nil
 }
 
-func cli() (addr, dbAddr net.Addr) {
+type sessionIDsValue struct {
+	ids *[]int64
+}
+
+func (v sessionIDsValue) String() string {
+	if v.ids == nil {
+		return ""
+	}
+	s := make([]string, len(*v.ids))
+	for i, id := range *v.ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(s, ",")
+}
+func (v sessionIDsValue) Set(s string) error {
+	for _, f := range strings.Split(s, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+		if err != nil {
+			return err
+		}
+		*v.ids = append(*v.ids, id)
+	}
+	return nil
+}
+
+type partKindsValue struct {
+	kinds *[]string
+}
+
+func (v partKindsValue) String() string {
+	if v.kinds == nil {
+		return ""
+	}
+	return strings.Join(*v.kinds, ",")
+}
+func (v partKindsValue) Set(s string) error {
+	for _, f := range strings.Split(s, ",") {
+		*v.kinds = append(*v.kinds, strings.TrimSpace(f))
+	}
+	return nil
+}
+
+type regexpValue struct {
+	re **regexp.Regexp
+}
+
+func (v regexpValue) String() string {
+	if v.re == nil || *v.re == nil {
+		return ""
+	}
+	return (*v.re).String()
+}
+func (v regexpValue) Set(s string) (err error) {
+	*v.re, err = regexp.Compile(s)
+	return err
+}
+
+func cli() (addr, dbAddr net.Addr, recordDir, replayFile, jsonFile string, jsonOptions *driver.SnifferJSONOptions) {
 	const usageText = `
 %[1]s is a Hana Network Protocol analyzer. It lets you see whats happening
 on protocol level connecting a client to the database server.
@@ -100,8 +231,17 @@ Usage of %[1]s:
 	}
 	args.Var(a, "s", "<host:port>: Sniffer address to accept connections. (required)")
 	args.Var(dba, "db", "<host:port>: Database address to connect to. (required)")
+	args.StringVar(&recordDir, "record", "", "<directory>: Record each session to a file in directory (replayable by -replay).")
+	args.StringVar(&replayFile, "replay", "", "<file>: Replay a recorded session to clients instead of connecting to the database.")
+
+	jsonOptions = &driver.SnifferJSONOptions{}
+	args.StringVar(&jsonFile, "json", "", "<file>: Write decoded messages as JSON lines to file ('-': stdout) instead of logging them.")
+	args.Var(sessionIDsValue{ids: &jsonOptions.SessionIDs}, "session", "<id,...>: Write messages of these session ids only (-json).")
+	args.Var(partKindsValue{kinds: &jsonOptions.PartKinds}, "parts", "<kind,...>: Write parts of these kinds only, e.g. PkCommand,PkResultset (-json).")
+	args.Var(regexpValue{re: &jsonOptions.Statement}, "stmt", "<regexp>: Write exchanges of statements matching the pattern only (-json).")
+	args.BoolVar(&jsonOptions.ShowSecrets, "secrets", false, "Write authentication parts unredacted (-json).")
 
 	args.Parse(os.Args[1:]) //nolint:errcheck
 
-	return a, dba
+	return a, dba, recordDir, replayFile, jsonFile, jsonOptions
 }
@@ -0,0 +1,60 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// AuditParameter contains the metadata of a statement parameter provided to an Auditor.
+// The parameter value itself is not provided.
+type AuditParameter struct {
+	Ordinal int
+	Name    string
+	// Type is the Go type of the parameter value (empty for NULL values).
+	Type string
+	// DatabaseTypeName is the database type of the parameter (empty for statements executed without prepare).
+	DatabaseTypeName string
+	Null             bool
+}
+
+/*
+An Auditor is called before a statement is executed (see SetAuditor).
+
+Audit is called with the SQL text and the parameter metadata of the statement. The parameter values are not
+provided, so that auditing does not leak sensitive data. If Audit returns an error the statement is not executed
+and the error is returned to the caller, which can be used to implement e.g. statement allow-lists.
+For bulk statements Audit is called once with the parameters of all rows.
+*/
+type Auditor interface {
+	Audit(ctx context.Context, query string, prms []AuditParameter) error
+}
+
+// auditParameters returns the audit parameter metadata of nvargs.
+func auditParameters(fields []*p.ParameterField, nvargs []driver.NamedValue) []AuditParameter {
+	if len(nvargs) == 0 {
+		return nil
+	}
+	prms := make([]AuditParameter, len(nvargs))
+	for i, nv := range nvargs {
+		prms[i] = AuditParameter{Ordinal: nv.Ordinal, Name: nv.Name, Null: nv.Value == nil}
+		if nv.Value != nil {
+			prms[i].Type = fmt.Sprintf("%T", nv.Value)
+		}
+		if len(fields) != 0 {
+			prms[i].DatabaseTypeName = fields[i%len(fields)].TypeName()
+		}
+	}
+	return prms
+}
+
+// audit calls the auditor of the connection (if any) before query is executed.
+func (c *conn) audit(ctx context.Context, query string, fields []*p.ParameterField, nvargs []driver.NamedValue) error {
+	auditor := c.attrs._auditor
+	if auditor == nil {
+		return nil
+	}
+	return auditor.Audit(ctx, query, auditParameters(fields, nvargs))
+}
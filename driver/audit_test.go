@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testAuditor struct {
+	query string
+	prms  []AuditParameter
+	err   error
+}
+
+func (a *testAuditor) Audit(ctx context.Context, query string, prms []AuditParameter) error {
+	a.query, a.prms = query, prms
+	return a.err
+}
+
+func TestAudit(t *testing.T) {
+	errVeto := errors.New("statement not allowed")
+
+	nvargs := []driver.NamedValue{{Ordinal: 1, Value: int64(42)}, {Ordinal: 2, Name: "secret", Value: "password"}, {Ordinal: 3}}
+	prms := []AuditParameter{
+		{Ordinal: 1, Type: "int64"},
+		{Ordinal: 2, Name: "secret", Type: "string"},
+		{Ordinal: 3, Null: true},
+	}
+
+	tests := []struct {
+		err error
+	}{
+		{nil},
+		{errVeto},
+	}
+
+	for _, test := range tests {
+		auditor := &testAuditor{err: test.err}
+		attrs := newConnAttrs()
+		attrs.SetAuditor(auditor)
+		c := &conn{attrs: attrs}
+
+		if err := c.audit(context.Background(), "select ? from dummy", nil, nvargs); !errors.Is(err, test.err) {
+			t.Fatalf("error %v - expected %v", err, test.err)
+		}
+		if auditor.query != "select ? from dummy" {
+			t.Fatalf("query %s - expected %s", auditor.query, "select ? from dummy")
+		}
+		if !reflect.DeepEqual(auditor.prms, prms) {
+			t.Fatalf("parameters %v - expected %v", auditor.prms, prms)
+		}
+	}
+}
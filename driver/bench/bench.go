@@ -0,0 +1,201 @@
+/*
+Package bench provides standardized driver workloads runnable against any database to measure the driver performance.
+
+A workload run reports the latency distribution, the memory allocations and the number of protocol roundtrips
+and bytes per operation, so that performance regressions across driver versions can be measured.
+
+	connector, err := driver.NewDSNConnector(dsn)
+	...
+	for _, w := range bench.DefaultWorkloads() {
+		r, err := bench.Run(ctx, connector, w, 1000)
+		...
+		fmt.Println(r)
+	}
+
+Workloads can be executed as Go benchmarks as well (see Benchmark).
+This package is currently experimental and its public interface might be changed in an incompatible way at any time.
+*/
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+// Op executes a single operation of a workload. i is the number of the operation (0-based).
+type Op func(ctx context.Context, i int) error
+
+// A Workload is a database operation executed repeatedly.
+type Workload struct {
+	Name string
+	// Setup prepares the database objects (e.g. tables and data) of the workload (optional).
+	Setup func(ctx context.Context, db *sql.DB) error
+	// Prepare returns the operation and a function releasing the operation resources like prepared statements.
+	Prepare func(ctx context.Context, db *sql.DB) (op Op, release func() error, err error)
+	// Teardown drops the database objects created by Setup (optional).
+	Teardown func(ctx context.Context, db *sql.DB) error
+}
+
+// Latency is the latency distribution of the operations of a workload run.
+type Latency struct {
+	Min, P50, P90, P99, Max time.Duration
+}
+
+func newLatency(d []time.Duration) Latency {
+	if len(d) == 0 {
+		return Latency{}
+	}
+	slices.Sort(d)
+	percentile := func(p int) time.Duration { return d[(len(d)-1)*p/100] }
+	return Latency{Min: d[0], P50: percentile(50), P90: percentile(90), P99: percentile(99), Max: d[len(d)-1]}
+}
+
+// Result is the result of a workload run.
+type Result struct {
+	Workload string
+	N        int           // Number of executed operations.
+	Duration time.Duration // Total duration of the operations.
+	Latency  Latency
+	// Totals of the operations.
+	Roundtrips   uint64
+	ReadBytes    uint64
+	WrittenBytes uint64
+	Allocs       uint64
+	AllocBytes   uint64
+}
+
+func (r *Result) perOp(v uint64) float64 {
+	if r.N == 0 {
+		return 0
+	}
+	return float64(v) / float64(r.N)
+}
+
+// NsPerOp returns the duration per operation in nanoseconds.
+func (r *Result) NsPerOp() float64 { return r.perOp(uint64(r.Duration.Nanoseconds())) }
+
+// RoundtripsPerOp returns the number of protocol roundtrips per operation.
+func (r *Result) RoundtripsPerOp() float64 { return r.perOp(r.Roundtrips) }
+
+// AllocsPerOp returns the number of memory allocations per operation.
+func (r *Result) AllocsPerOp() float64 { return r.perOp(r.Allocs) }
+
+// AllocBytesPerOp returns the number of allocated bytes per operation.
+func (r *Result) AllocBytesPerOp() float64 { return r.perOp(r.AllocBytes) }
+
+func (r *Result) String() string {
+	return fmt.Sprintf("%s n %d %.0f ns/op %.1f roundtrips/op %.0f read B/op %.0f written B/op %.0f allocs/op %.0f B/op latency min %s p50 %s p90 %s p99 %s max %s",
+		r.Workload,
+		r.N,
+		r.NsPerOp(),
+		r.RoundtripsPerOp(),
+		r.perOp(r.ReadBytes),
+		r.perOp(r.WrittenBytes),
+		r.AllocsPerOp(),
+		r.AllocBytesPerOp(),
+		r.Latency.Min,
+		r.Latency.P50,
+		r.Latency.P90,
+		r.Latency.P99,
+		r.Latency.Max,
+	)
+}
+
+// session executes fn with a prepared operation on a single database connection and returns the
+// database statistics after closing the database, so that all protocol roundtrips are accounted.
+func session(ctx context.Context, connector *driver.Connector, w *Workload, fn func(op Op) error) (stats *driver.Stats, err error) {
+	db := driver.OpenDB(connector)
+	defer func() {
+		err = errors.Join(err, db.Close())
+		stats = db.ExStats()
+	}()
+	db.SetMaxOpenConns(1)
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+	op, release, err := w.Prepare(ctx, db.DB)
+	if err != nil {
+		return nil, err
+	}
+	err = fn(op)
+	return nil, errors.Join(err, release())
+}
+
+/*
+Run executes n operations of workload w sequentially on a single database connection.
+
+The roundtrips and bytes of establishing the connection and preparing the operation are not accounted,
+as they are measured by an additional session without operations.
+*/
+func Run(ctx context.Context, connector *driver.Connector, w *Workload, n int) (result *Result, err error) {
+	if w.Setup != nil || w.Teardown != nil {
+		db := sql.OpenDB(connector)
+		defer db.Close()
+		if w.Setup != nil {
+			if err := w.Setup(ctx, db); err != nil {
+				return nil, err
+			}
+		}
+		if w.Teardown != nil {
+			defer func() { err = errors.Join(err, w.Teardown(ctx, db)) }()
+		}
+	}
+
+	// base session without operations
+	base, err := session(ctx, connector, w, func(op Op) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+
+	result = &Result{Workload: w.Name, N: n}
+	latencies := make([]time.Duration, n)
+	var before, after runtime.MemStats
+	stats, err := session(ctx, connector, w, func(op Op) error {
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			opStart := time.Now()
+			if err := op(ctx, i); err != nil {
+				return err
+			}
+			latencies[i] = time.Since(opStart)
+		}
+		result.Duration = time.Since(start)
+		runtime.ReadMemStats(&after)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Latency = newLatency(latencies)
+	result.Roundtrips = stats.Roundtrips - base.Roundtrips
+	result.ReadBytes = stats.ReadBytes - base.ReadBytes
+	result.WrittenBytes = stats.WrittenBytes - base.WrittenBytes
+	result.Allocs = after.Mallocs - before.Mallocs
+	result.AllocBytes = after.TotalAlloc - before.TotalAlloc
+	return result, nil
+}
+
+// Benchmark runs workload w as Go benchmark reporting the Run results as benchmark metrics.
+func Benchmark(b *testing.B, connector *driver.Connector, w *Workload) {
+	b.StopTimer()
+	r, err := Run(context.Background(), connector, w, b.N)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportMetric(r.NsPerOp(), "ns/op")
+	b.ReportMetric(r.RoundtripsPerOp(), "roundtrips/op")
+	b.ReportMetric(r.AllocsPerOp(), "allocs/op")
+	b.ReportMetric(r.AllocBytesPerOp(), "B/op")
+	b.ReportMetric(float64(r.Latency.P99.Nanoseconds()), "p99-ns")
+}
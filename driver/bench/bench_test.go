@@ -0,0 +1,59 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestRun(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	srv.Handle("select name from t where id = ?", &hdbtest.Response{
+		Params:  []hdbtest.Column{{Name: "ID", Type: "INTEGER"}},
+		Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}},
+		Rows:    [][]any{{"name"}},
+	})
+
+	connector, err := driver.NewDSNConnector(srv.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setup, teardown := false, false
+	w := &Workload{
+		Name:     "test",
+		Setup:    func(ctx context.Context, db *sql.DB) error { setup = true; return nil },
+		Teardown: func(ctx context.Context, db *sql.DB) error { teardown = true; return nil },
+		Prepare: prepared("select name from t where id = ?", func(stmt *sql.Stmt) Op {
+			return func(ctx context.Context, i int) error {
+				var name string
+				return stmt.QueryRowContext(ctx, i).Scan(&name)
+			}
+		}),
+	}
+
+	const n = 10
+	r, err := Run(context.Background(), connector, w, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !setup || !teardown {
+		t.Fatalf("setup %t teardown %t - expected true", setup, teardown)
+	}
+	if r.N != n || r.Duration == 0 || r.Latency.Max == 0 || r.Latency.Min > r.Latency.P50 || r.Latency.P99 > r.Latency.Max {
+		t.Fatalf("invalid result %s", r)
+	}
+	if r.RoundtripsPerOp() != 1 { // execute of prepared statement only
+		t.Fatalf("roundtrips per operation %f - expected 1", r.RoundtripsPerOp())
+	}
+	if r.ReadBytes == 0 || r.WrittenBytes == 0 {
+		t.Fatalf("invalid result %s", r)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !unit
+
+package bench_test
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver/bench"
+)
+
+// Example demonstrates running the standard workloads against a database.
+func Example() {
+	const envDSN = "GOHDBDSN"
+
+	dsn := os.Getenv(envDSN)
+	// exit if dsn is missing.
+	if dsn == "" {
+		return
+	}
+
+	connector, err := driver.NewDSNConnector(dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, w := range bench.DefaultWorkloads() {
+		r, err := bench.Run(context.Background(), connector, w, 100)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println(r)
+	}
+	// output:
+}
@@ -0,0 +1,200 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+// Workload names.
+const (
+	WlPointReads = "pointReads"
+	WlBulkInsert = "bulkInsert"
+	WlWideScan   = "wideScan"
+	WlLobStream  = "lobStream"
+)
+
+// DefaultWorkloads returns the standard workloads with default parameters.
+func DefaultWorkloads() []*Workload {
+	return []*Workload{
+		PointReads(10000),
+		BulkInsert(1000),
+		WideScan(50, 1000),
+		LobStream(1024 * 1024),
+	}
+}
+
+func dropTable(table driver.Identifier) func(ctx context.Context, db *sql.DB) error {
+	return func(ctx context.Context, db *sql.DB) error {
+		_, err := db.ExecContext(ctx, fmt.Sprintf("drop table %s", table))
+		return err
+	}
+}
+
+// fillTable inserts numRow rows via a function based bulk insert setting the arguments by fn.
+func fillTable(ctx context.Context, db *sql.DB, query string, numRow int, fn func(args []any, i int)) error {
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	i := 0
+	_, err = stmt.ExecContext(ctx, func(args []any) error {
+		if i >= numRow {
+			return driver.ErrEndOfRows
+		}
+		fn(args, i)
+		i++
+		return nil
+	})
+	return err
+}
+
+// prepared returns a Prepare function executing op with a prepared statement of query.
+func prepared(query string, op func(stmt *sql.Stmt) Op) func(ctx context.Context, db *sql.DB) (Op, func() error, error) {
+	return func(ctx context.Context, db *sql.DB) (Op, func() error, error) {
+		stmt, err := db.PrepareContext(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		return op(stmt), stmt.Close, nil
+	}
+}
+
+// PointReads returns an OLTP workload reading single rows by primary key of a table with numRow rows.
+func PointReads(numRow int) *Workload {
+	table := driver.RandomIdentifier("bench_")
+	return &Workload{
+		Name: WlPointReads,
+		Setup: func(ctx context.Context, db *sql.DB) error {
+			if _, err := db.ExecContext(ctx, fmt.Sprintf("create column table %s (id integer primary key, name nvarchar(100))", table)); err != nil {
+				return err
+			}
+			return fillTable(ctx, db, fmt.Sprintf("insert into %s values (?, ?)", table), numRow, func(args []any, i int) {
+				args[0], args[1] = i, fmt.Sprintf("name %d", i)
+			})
+		},
+		Prepare: prepared(fmt.Sprintf("select name from %s where id = ?", table), func(stmt *sql.Stmt) Op {
+			return func(ctx context.Context, i int) error {
+				var name string
+				return stmt.QueryRowContext(ctx, i%numRow).Scan(&name)
+			}
+		}),
+		Teardown: dropTable(table),
+	}
+}
+
+// BulkInsert returns a workload inserting batchSize rows per operation via a function based bulk insert.
+func BulkInsert(batchSize int) *Workload {
+	table := driver.RandomIdentifier("bench_")
+	return &Workload{
+		Name: WlBulkInsert,
+		Setup: func(ctx context.Context, db *sql.DB) error {
+			_, err := db.ExecContext(ctx, fmt.Sprintf("create column table %s (id integer, name nvarchar(100), value double)", table))
+			return err
+		},
+		Prepare: prepared(fmt.Sprintf("insert into %s values (?, ?, ?)", table), func(stmt *sql.Stmt) Op {
+			return func(ctx context.Context, i int) error {
+				j := 0
+				_, err := stmt.ExecContext(ctx, func(args []any) error {
+					if j >= batchSize {
+						return driver.ErrEndOfRows
+					}
+					id := i*batchSize + j
+					args[0], args[1], args[2] = id, fmt.Sprintf("name %d", id), float64(id)/2
+					j++
+					return nil
+				})
+				return err
+			}
+		}),
+		Teardown: dropTable(table),
+	}
+}
+
+// WideScan returns a workload reading all rows of a table with numColumn columns and numRow rows.
+func WideScan(numColumn, numRow int) *Workload {
+	table := driver.RandomIdentifier("bench_")
+	return &Workload{
+		Name: WlWideScan,
+		Setup: func(ctx context.Context, db *sql.DB) error {
+			columns := make([]string, numColumn)
+			prms := make([]string, numColumn)
+			for i := range columns {
+				columns[i] = fmt.Sprintf("c%d nvarchar(20)", i)
+				prms[i] = "?"
+			}
+			if _, err := db.ExecContext(ctx, fmt.Sprintf("create column table %s (%s)", table, strings.Join(columns, ", "))); err != nil {
+				return err
+			}
+			return fillTable(ctx, db, fmt.Sprintf("insert into %s values (%s)", table, strings.Join(prms, ", ")), numRow, func(args []any, i int) {
+				for j := range args {
+					args[j] = fmt.Sprintf("value %d %d", i, j)
+				}
+			})
+		},
+		Prepare: prepared(fmt.Sprintf("select * from %s", table), func(stmt *sql.Stmt) Op {
+			values := make([]sql.RawBytes, numColumn)
+			dest := make([]any, numColumn)
+			for i := range values {
+				dest[i] = &values[i]
+			}
+			return func(ctx context.Context, i int) error {
+				rows, err := stmt.QueryContext(ctx)
+				if err != nil {
+					return err
+				}
+				defer rows.Close()
+				for rows.Next() {
+					if err := rows.Scan(dest...); err != nil {
+						return err
+					}
+				}
+				return rows.Err()
+			}
+		}),
+		Teardown: dropTable(table),
+	}
+}
+
+// LobStream returns a workload writing and reading back a binary lob of size bytes per operation.
+func LobStream(size int) *Workload {
+	table := driver.RandomIdentifier("bench_")
+	content := bytes.Repeat([]byte{0xab}, size)
+	return &Workload{
+		Name: WlLobStream,
+		Setup: func(ctx context.Context, db *sql.DB) error {
+			_, err := db.ExecContext(ctx, fmt.Sprintf("create column table %s (id integer, data blob)", table))
+			return err
+		},
+		Prepare: func(ctx context.Context, db *sql.DB) (Op, func() error, error) {
+			insertStmt, err := db.PrepareContext(ctx, fmt.Sprintf("insert into %s values (?, ?)", table))
+			if err != nil {
+				return nil, nil, err
+			}
+			selectStmt, err := db.PrepareContext(ctx, fmt.Sprintf("select data from %s where id = ?", table))
+			if err != nil {
+				insertStmt.Close()
+				return nil, nil, err
+			}
+			op := func(ctx context.Context, i int) error {
+				if _, err := insertStmt.ExecContext(ctx, i, driver.NewLob(bytes.NewReader(content), nil)); err != nil {
+					return err
+				}
+				lob := driver.NewLob(nil, io.Discard)
+				return selectStmt.QueryRowContext(ctx, i).Scan(lob)
+			}
+			release := func() error {
+				insertStmt.Close()
+				return selectStmt.Close()
+			}
+			return op, release, nil
+		},
+		Teardown: dropTable(table),
+	}
+}
@@ -0,0 +1,165 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// A Preparer prepares statements and is implemented by sql.DB, sql.Conn and sql.Tx.
+type Preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// columnsQuery returns a statement for the columns of table with one parameter per column.
+func columnsQuery(verb string, table Identifier, columns []Identifier, suffix string) string {
+	b := new(strings.Builder)
+	b.WriteString(verb)
+	b.WriteString(" ")
+	b.WriteString(table.String())
+	b.WriteString(" (")
+	for i, column := range columns {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(column.String())
+	}
+	b.WriteString(") values (")
+	for i := range columns {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("?")
+	}
+	b.WriteString(")")
+	b.WriteString(suffix)
+	return b.String()
+}
+
+// UpsertQuery returns an UPSERT ... WITH PRIMARY KEY statement for the columns of table with one parameter per column.
+func UpsertQuery(table Identifier, columns ...Identifier) string {
+	return columnsQuery("upsert", table, columns, " with primary key")
+}
+
+/*
+BulkUpsert inserts or updates (by primary key) the rows provided by the rows function into the columns of table
+via a prepared bulk statement (see UpsertQuery).
+
+The rows function is called with one argument per column for each row and needs to return ErrEndOfRows
+after the last row (see function based bulk execution). The arguments are converted according to the
+parameter metadata of the prepared statement.
+
+Like any bulk execution the operation is not atomic: in case of errors rows might have been upserted
+partially (use a transaction to avoid). Row specific database errors are reported via Error,
+where StmtNo is the index of the failed row.
+*/
+func BulkUpsert(ctx context.Context, preparer Preparer, table Identifier, columns []Identifier, rows func(args []any) error) (int64, error) {
+	if len(columns) == 0 {
+		return 0, errors.New("bulk upsert: no columns provided")
+	}
+	stmt, err := preparer.PrepareContext(ctx, UpsertQuery(table, columns...))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	r, err := stmt.ExecContext(ctx, rows)
+	if err != nil {
+		return 0, err
+	}
+	return r.RowsAffected()
+}
+
+/*
+A BulkError is returned by bulk executions in case the execution of single rows failed.
+
+As the database server continues executing the remaining rows of a server call and the driver
+continues with the remaining server calls of a bulk execution, all rows not reported by
+RowErrors are executed successfully unless the bulk execution was aborted due to a non row
+specific error (see Aborted).
+*/
+type BulkError struct {
+	errs    []error   // errors of all failed server calls
+	rowErrs []DBError // row specific errors
+	aborted bool
+}
+
+func (e *BulkError) Error() string { return errors.Join(e.errs...).Error() }
+
+// Unwrap returns the errors of all failed server calls (errors.As(err, Error) returns the first database error).
+func (e *BulkError) Unwrap() []error { return e.errs }
+
+// RowErrors returns the row specific errors. DBError.StmtNo is the index of the failed row.
+func (e *BulkError) RowErrors() []DBError { return e.rowErrs }
+
+// FailedRows returns the indices of the failed rows.
+func (e *BulkError) FailedRows() []int {
+	rows := make([]int, 0, len(e.rowErrs))
+	for _, err := range e.rowErrs {
+		rows = append(rows, err.StmtNo())
+	}
+	slices.Sort(rows)
+	return slices.Compact(rows)
+}
+
+// Aborted returns true if the bulk execution was aborted due to a non row specific error, false otherwise.
+func (e *BulkError) Aborted() bool { return e.aborted }
+
+// add adds the row specific errors of err and returns true, or false if err is not row specific.
+func (e *BulkError) add(err error) bool {
+	var bulkErr *BulkError
+	if errors.As(err, &bulkErr) {
+		if bulkErr.aborted {
+			return false
+		}
+		e.errs = append(e.errs, bulkErr.errs...)
+		e.rowErrs = append(e.rowErrs, bulkErr.rowErrs...)
+		return true
+	}
+
+	var hdbErrs *p.HdbErrors
+	if !errors.As(err, &hdbErrs) {
+		return false
+	}
+	rowErrs := make([]DBError, 0, hdbErrs.NumError())
+	for _, err := range hdbErrs.Unwrap() {
+		hdbErr := err.(*p.HdbError)
+		if hdbErr.IsFatal() {
+			return false
+		}
+		if hdbErr.IsError() {
+			rowErrs = append(rowErrs, hdbErr)
+		}
+	}
+	e.errs = append(e.errs, err)
+	e.rowErrs = append(e.rowErrs, rowErrs...)
+	return true
+}
+
+// abort adds the non row specific error err and returns the bulk error, or err in case no row errors were collected.
+func (e *BulkError) abort(err error) error {
+	if e == nil || len(e.errs) == 0 {
+		return err
+	}
+	var bulkErr *BulkError
+	if errors.As(err, &bulkErr) { // aborted bulk error of a server call
+		e.rowErrs = append(e.rowErrs, bulkErr.rowErrs...)
+		e.errs = append(e.errs, bulkErr.errs...)
+	} else {
+		e.errs = append(e.errs, err)
+	}
+	e.aborted = true
+	return e
+}
+
+// errOrNil returns e if row errors were collected, nil otherwise.
+func (e *BulkError) errOrNil() error {
+	if e == nil || len(e.errs) == 0 {
+		return nil
+	}
+	return e
+}
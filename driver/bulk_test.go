@@ -7,6 +7,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -384,6 +386,259 @@ func testBulkGeo(t *testing.T, ctr *Connector, db *sql.DB) {
 	}
 }
 
+func testBulkByteSize(t *testing.T, ctr *Connector, db *sql.DB) {
+	const numRows = 100
+
+	ctx := context.Background()
+
+	ctr = ctr.clone()
+	ctr.SetBulkByteSize(minBulkByteSize) // split bulk insert in several server calls
+	db = sql.OpenDB(ctr)
+	defer db.Close()
+
+	tableName := RandomIdentifier("bulkByteSize")
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer primary key, s nvarchar(200))", tableName)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, fmt.Sprintf("insert into %s values (?, ?)", tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	args := make([]any, 0, numRows*2)
+	for i := 0; i < numRows; i++ {
+		args = append(args, i, strings.Repeat("x", i*2))
+	}
+	args[len(args)-2] = 0 // duplicate in last server call
+
+	_, err = stmt.Exec(args...)
+	var hdbErr Error
+	if !errors.As(err, &hdbErr) {
+		t.Fatalf("driver.Error expected - got %v", err)
+	}
+	if hdbErr.StmtNo() != numRows-1 {
+		t.Fatalf("actual StmtNo %d - expected StmtNo %d", hdbErr.StmtNo(), numRows-1)
+	}
+	// all rows except the duplicate should be inserted
+	numRec := 0
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s", tableName)).Scan(&numRec); err != nil {
+		t.Fatal(err)
+	}
+	if numRec != numRows-1 {
+		t.Fatalf("invalid number of records %d - %d expected", numRec, numRows-1)
+	}
+}
+
+func testBulkPipeline(t *testing.T, ctr *Connector, db *sql.DB) {
+	const (
+		bulkSize = 10
+		numRows  = 105 // last batch not complete
+	)
+
+	ctx := context.Background()
+
+	ctr = ctr.clone()
+	ctr.setBulkSize(bulkSize)
+	ctr.SetBulkPipeline(true)
+	db = sql.OpenDB(ctr)
+	defer db.Close()
+
+	tableName := RandomIdentifier("bulkPipeline")
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer primary key, s nvarchar(20))", tableName)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, fmt.Sprintf("insert into %s values (?, ?)", tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	checkCount := func(expected int) {
+		numRec := 0
+		if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s", tableName)).Scan(&numRec); err != nil {
+			t.Fatal(err)
+		}
+		if numRec != expected {
+			t.Fatalf("invalid number of records %d - %d expected", numRec, expected)
+		}
+	}
+
+	// many
+	args := make([]any, 0, numRows*2)
+	for i := 0; i < numRows; i++ {
+		args = append(args, i, strconv.Itoa(i))
+	}
+	r, err := stmt.Exec(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAffected, _ := r.RowsAffected(); rowsAffected != numRows {
+		t.Fatalf("rows affected %d - expected %d", rowsAffected, numRows)
+	}
+	checkCount(numRows)
+
+	// function with duplicate in later batch
+	i := numRows
+	_, err = stmt.Exec(func(args []any) error {
+		if i >= 2*numRows {
+			return ErrEndOfRows
+		}
+		args[0], args[1] = i, strconv.Itoa(i)
+		if i == 2*numRows-bulkSize {
+			args[0] = 0
+		}
+		i++
+		return nil
+	})
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("bulk error expected - got %v", err)
+	}
+	if failedRows := bulkErr.FailedRows(); !slices.Equal(failedRows, []int{numRows - bulkSize}) {
+		t.Fatalf("failed rows %v - expected %v", failedRows, []int{numRows - bulkSize})
+	}
+	checkCount(2*numRows - 1)
+}
+
+func testBulkUpsert(t *testing.T, ctr *Connector, db *sql.DB) {
+	const numRows = 10
+
+	ctx := context.Background()
+
+	tableName := RandomIdentifier("bulkUpsert")
+	columns := []Identifier{"K", "V"}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (k integer primary key, v nvarchar(20))", tableName)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	upsert := func(value string) {
+		i := 0
+		rowsAffected, err := BulkUpsert(ctx, db, tableName, columns, func(args []any) error {
+			if i >= numRows {
+				return ErrEndOfRows
+			}
+			args[0], args[1] = i, value
+			i++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rowsAffected != numRows {
+			t.Fatalf("rows affected %d - expected %d", rowsAffected, numRows)
+		}
+	}
+
+	upsert("insert")
+	upsert("update")
+
+	var numRec int
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s where v = 'update'", tableName)).Scan(&numRec); err != nil {
+		t.Fatal(err)
+	}
+	if numRec != numRows {
+		t.Fatalf("invalid number of records %d - %d expected", numRec, numRows)
+	}
+
+	// row specific error
+	_, err := BulkUpsert(ctx, db, tableName, columns, func() func(args []any) error {
+		i := 0
+		return func(args []any) error {
+			if i >= numRows {
+				return ErrEndOfRows
+			}
+			args[0], args[1] = i, "value"
+			if i == 5 {
+				args[1] = "value exceeding column length"
+			}
+			i++
+			return nil
+		}
+	}())
+	var hdbErr Error
+	if !errors.As(err, &hdbErr) {
+		t.Fatalf("driver.Error expected - got %v", err)
+	}
+	if hdbErr.StmtNo() != 5 {
+		t.Fatalf("actual StmtNo %d - expected StmtNo %d", hdbErr.StmtNo(), 5)
+	}
+}
+
+func testBulkError(t *testing.T, ctr *Connector, db *sql.DB) {
+	ctx := context.Background()
+	bulkSize := ctr.BulkSize()
+
+	tableName := RandomIdentifier("bulkError")
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (k integer primary key, v integer)", tableName)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, fmt.Sprintf("insert into %s values (?,?)", tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	// duplicates in first and second server call
+	numRow := bulkSize * 2
+	duplRows := []int{10, bulkSize + 10}
+	args := make([]any, numRow*2)
+	for i := 0; i < numRow; i++ {
+		args[i*2], args[i*2+1] = i, i
+	}
+	for _, row := range duplRows {
+		args[row*2] = 0
+	}
+
+	_, err = stmt.Exec(args...)
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("bulk error expected - got %v", err)
+	}
+	if bulkErr.Aborted() {
+		t.Fatal("bulk execution should not be aborted")
+	}
+	if failedRows := bulkErr.FailedRows(); !slices.Equal(failedRows, duplRows) {
+		t.Fatalf("failed rows %v - expected %v", failedRows, duplRows)
+	}
+	var hdbErr Error
+	if !errors.As(err, &hdbErr) {
+		t.Fatal("driver.Error expected")
+	}
+
+	// all rows except the duplicates should be inserted
+	numRec := 0
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s", tableName)).Scan(&numRec); err != nil {
+		t.Fatal(err)
+	}
+	if numRec != numRow-len(duplRows) {
+		t.Fatalf("invalid number of records %d - %d expected", numRec, numRow-len(duplRows))
+	}
+}
+
+func testUpsertQuery(t *testing.T, ctr *Connector, db *sql.DB) {
+	tests := []struct {
+		table   Identifier
+		columns []Identifier
+		query   string
+	}{
+		{"T", []Identifier{"K"}, "upsert T (K) values (?) with primary key"},
+		{"t", []Identifier{"K", "v"}, `upsert "t" (K, "v") values (?, ?) with primary key`},
+	}
+	for _, test := range tests {
+		if query := UpsertQuery(test.table, test.columns...); query != test.query {
+			t.Fatalf("query %s - expected %s", query, test.query)
+		}
+	}
+}
+
 func TestBulk(t *testing.T) {
 	t.Parallel()
 
@@ -396,6 +651,11 @@ func TestBulk(t *testing.T) {
 		{"testBulkBlob", testBulkBlob},
 		{"testBulkBlob106", testBulkBlob106},
 		{"testBulkGeo", testBulkGeo},
+		{"testBulkByteSize", testBulkByteSize},
+		{"testBulkPipeline", testBulkPipeline},
+		{"testBulkUpsert", testBulkUpsert},
+		{"testUpsertQuery", testUpsertQuery},
+		{"testBulkError", testBulkError},
 	}
 
 	ctr := MT.NewConnector()
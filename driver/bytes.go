@@ -1,7 +1,12 @@
 package driver
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
 )
 
 // NullBytes represents an []byte that may be null.
@@ -25,3 +30,88 @@ func (n NullBytes) Value() (driver.Value, error) {
 	}
 	return n.Bytes, nil
 }
+
+var errBinaryNull = errors.New("binary: cannot assign NULL value")
+
+// byteArrayValue returns the reflect value of v if v is a byte array (e.g. [16]byte).
+func byteArrayValue(v any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+type binaryAssigner struct {
+	dest any
+}
+
+/*
+NewBinaryAssigner returns a scanner assigning binary database field values (BINARY, VARBINARY) to dest.
+Supported destinations are
+
+  - pointers to byte arrays like *[16]byte (the value length must match the array length)
+  - encoding.BinaryUnmarshaler implementations like *uuid.UUID of github.com/google/uuid.
+
+NULL values are not supported.
+*/
+func NewBinaryAssigner(dest any) sql.Scanner { return binaryAssigner{dest: dest} }
+
+// Scan implements the Scanner interface.
+func (a binaryAssigner) Scan(src any) error {
+	if src == nil {
+		return errBinaryNull
+	}
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("binary: invalid data type %T", src)
+	}
+	if unmarshaler, ok := a.dest.(encoding.BinaryUnmarshaler); ok {
+		return unmarshaler.UnmarshalBinary(b)
+	}
+	rv := reflect.ValueOf(a.dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("binary: invalid destination type %T", a.dest)
+	}
+	av, ok := byteArrayValue(rv.Elem().Interface())
+	if !ok {
+		return fmt.Errorf("binary: invalid destination type %T", a.dest)
+	}
+	if av.Len() != len(b) {
+		return fmt.Errorf("binary: value length %d does not match destination length %d", len(b), av.Len())
+	}
+	reflect.Copy(rv.Elem(), reflect.ValueOf(b))
+	return nil
+}
+
+type binaryValuer struct {
+	v any
+}
+
+/*
+NewBinaryValuer returns a valuer binding v as binary value. Supported values are
+
+  - byte arrays like [16]byte
+  - encoding.BinaryMarshaler implementations like uuid.UUID of github.com/google/uuid.
+
+Binary marshaling takes precedence over any driver.Valuer implementation of v (uuid.UUID values
+would be bound as string otherwise). A nil v is bound as NULL value.
+*/
+func NewBinaryValuer(v any) driver.Valuer { return binaryValuer{v: v} }
+
+// Value implements the driver Valuer interface.
+func (bv binaryValuer) Value() (driver.Value, error) {
+	if bv.v == nil {
+		return nil, nil
+	}
+	if marshaler, ok := bv.v.(encoding.BinaryMarshaler); ok {
+		return marshaler.MarshalBinary()
+	}
+	av, ok := byteArrayValue(bv.v)
+	if !ok {
+		return nil, fmt.Errorf("binary: invalid data type %T", bv.v)
+	}
+	b := make([]byte, av.Len())
+	reflect.Copy(reflect.ValueOf(b), av)
+	return b, nil
+}
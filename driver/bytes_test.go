@@ -0,0 +1,90 @@
+package driver
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// testUUID mimics uuid.UUID of github.com/google/uuid.
+type testUUID [16]byte
+
+func (u testUUID) MarshalBinary() ([]byte, error) { return u[:], nil }
+
+func (u *testUUID) UnmarshalBinary(b []byte) error {
+	if len(b) != 16 {
+		return errors.New("invalid UUID length")
+	}
+	copy(u[:], b)
+	return nil
+}
+
+func testBinaryAssigner(t *testing.T) {
+	src := []byte{0: 0x01, 15: 0xff}
+
+	var a [16]byte
+	if err := NewBinaryAssigner(&a).Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a[:], src) {
+		t.Fatalf("array %v - expected %v", a, src)
+	}
+	if err := NewBinaryAssigner(&a).Scan(src[:8]); err == nil {
+		t.Fatal("length mismatch: error expected")
+	}
+
+	var u testUUID
+	if err := NewBinaryAssigner(&u).Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(u[:], src) {
+		t.Fatalf("uuid %v - expected %v", u, src)
+	}
+
+	if err := NewBinaryAssigner(&a).Scan(nil); err == nil {
+		t.Fatal("null value: error expected")
+	}
+	if err := NewBinaryAssigner(&a).Scan("text"); err == nil {
+		t.Fatal("invalid data type: error expected")
+	}
+	var s []int
+	if err := NewBinaryAssigner(&s).Scan(src); err == nil {
+		t.Fatal("invalid destination type: error expected")
+	}
+}
+
+func testBinaryValuer(t *testing.T) {
+	expected := []byte{0: 0x01, 15: 0xff}
+
+	for _, v := range []any{[16]byte(expected), testUUID(expected)} {
+		b, err := NewBinaryValuer(v).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.([]byte), expected) {
+			t.Fatalf("%T: value %v - expected %v", v, b, expected)
+		}
+	}
+	if v, err := NewBinaryValuer(nil).Value(); v != nil || err != nil {
+		t.Fatalf("value %v error %v - expected nil", v, err)
+	}
+	if _, err := NewBinaryValuer(42).Value(); err == nil {
+		t.Fatal("invalid data type: error expected")
+	}
+}
+
+func TestBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"binaryAssigner", testBinaryAssigner},
+		{"binaryValuer", testBinaryValuer},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/SAP/go-hdb/driver"
 )
@@ -55,6 +57,15 @@ end
 		}
 	}
 
+	testQueryNamedPrm := func() { // query - output parameters as result set
+		if err := db.QueryRow(fmt.Sprintf("call %s(?, ?)", proc), sql.Named("IDATA", txt)).Scan(&out); err != nil {
+			t.Fatal(err)
+		}
+		if out != txt {
+			t.Fatalf("value %s - expected %s", out, txt)
+		}
+	}
+
 	tests := []struct {
 		name string
 		fct  func()
@@ -62,6 +73,7 @@ end
 		{"ExecInvNamedPrm", testExecInvNamedPrm},
 		{"Exec", testExec},
 		{"ExecRndPrms", testExecRndPrms},
+		{"QueryNamedPrm", testQueryNamedPrm},
 	}
 
 	for _, test := range tests {
@@ -206,6 +218,27 @@ end
 	check(data[0], &resultRows1)
 	check(data[1], &resultRows2)
 	check(data[2], &resultRows3)
+
+	// table results do not need a prepared statement
+	var tableResult1, tableResult2, tableResult3 driver.TableResult
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("call %s(?, ?, ?, ?)", proc),
+		1,
+		sql.Named("T1", sql.Out{Dest: &tableResult1}),
+		sql.Named("T2", sql.Out{Dest: &tableResult2}),
+		sql.Named("T3", sql.Out{Dest: &tableResult3}),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tableResult := range []*driver.TableResult{&tableResult1, &tableResult2, &tableResult3} {
+		rows, err := tableResult.Rows()
+		if err != nil {
+			t.Fatal(err)
+		}
+		check(data[i], rows)
+		rows.Close()
+	}
 }
 
 func testCallNoPrm(t *testing.T, db *sql.DB) {
@@ -274,6 +307,136 @@ end
 	checkTable(conn, ctx, table)
 }
 
+func testCallQueryResultSets(t *testing.T, db *sql.DB) {
+	const procResultSets = `create procedure %[1]s (in i integer, out t1 %[2]s, out o integer, out t2 %[2]s)
+language SQLSCRIPT as
+begin
+  o := i * 2;
+  t1 = select i as i, 'A' as x from dummy;
+  t2 = select i + 1 as i, 'B' as x from dummy union all select i + 2 as i, 'C' as x from dummy;
+end
+`
+	tableType := driver.RandomIdentifier("tableType_")
+	proc := driver.RandomIdentifier("procResultSets_")
+
+	if _, err := db.Exec(fmt.Sprintf("create type %s as table (i integer, x varchar(10))", tableType)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(fmt.Sprintf(procResultSets, proc, tableType)); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Query(fmt.Sprintf("call %s(?, ?, ?, ?)", proc), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	numTableRow, numOutRow := 0, 0
+	for {
+		cols, err := rows.Columns()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			switch len(cols) {
+			case 1: // output parameters
+				var o int
+				if err := rows.Scan(&o); err != nil {
+					t.Fatal(err)
+				}
+				if o != 2 {
+					t.Fatalf("output parameter %d - expected %d", o, 2)
+				}
+				numOutRow++
+			case 2: // table results
+				var i int
+				var x string
+				if err := rows.Scan(&i, &x); err != nil {
+					t.Fatal(err)
+				}
+				numTableRow++
+			default:
+				t.Fatalf("invalid number of columns %d", len(cols))
+			}
+		}
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if numOutRow != 1 {
+		t.Fatalf("number of output parameter rows %d - expected %d", numOutRow, 1)
+	}
+	if numTableRow != 3 {
+		t.Fatalf("number of table rows %d - expected %d", numTableRow, 3)
+	}
+}
+
+func testCallInOut(t *testing.T, db *sql.DB) {
+	const procInOut = `create procedure %[1]s (
+  inout i integer, inout d decimal(10,2), inout b boolean, inout dt date, inout ts timestamp, inout f double, inout s nvarchar(25))
+language SQLSCRIPT as
+begin
+  i := i + 1;
+  d := d * 2;
+  b := not b;
+  dt := add_days(dt, 1);
+  ts := add_seconds(ts, 60);
+  f := f / 2;
+  s := concat(s, '!');
+end
+`
+	proc := driver.RandomIdentifier("procInOut_")
+	if _, err := db.Exec(fmt.Sprintf(procInOut, proc)); err != nil {
+		t.Fatal(err)
+	}
+
+	i := 41
+	d := (*driver.Decimal)(big.NewRat(3, 2))
+	b := true
+	dt := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	f := 1.0
+	s := "Hello World"
+
+	if _, err := db.Exec(fmt.Sprintf("call %s(?, ?, ?, ?, ?, ?, ?)", proc),
+		sql.Out{Dest: &i, In: true},
+		sql.Out{Dest: d, In: true},
+		sql.Out{Dest: &b, In: true},
+		sql.Out{Dest: &dt, In: true},
+		sql.Out{Dest: &ts, In: true},
+		sql.Out{Dest: &f, In: true},
+		sql.Out{Dest: &s, In: true},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if i != 42 {
+		t.Fatalf("integer %d - expected %d", i, 42)
+	}
+	if (*big.Rat)(d).Cmp(big.NewRat(3, 1)) != 0 {
+		t.Fatalf("decimal %s - expected %d", (*big.Rat)(d), 3)
+	}
+	if b {
+		t.Fatalf("boolean %t - expected %t", b, false)
+	}
+	if expected := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC); !dt.Equal(expected) {
+		t.Fatalf("date %s - expected %s", dt, expected)
+	}
+	if expected := time.Date(2000, 1, 1, 12, 1, 0, 0, time.UTC); !ts.Equal(expected) {
+		t.Fatalf("timestamp %s - expected %s", ts, expected)
+	}
+	if f != 0.5 {
+		t.Fatalf("double %f - expected %f", f, 0.5)
+	}
+	if s != "Hello World!" {
+		t.Fatalf("string %s - expected %s", s, "Hello World!")
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 
@@ -286,6 +449,8 @@ func TestCall(t *testing.T) {
 		{"tableOut", testCallTableOut},
 		{"noPrm", testCallNoPrm},
 		{"noOut", testCallNoOut},
+		{"queryResultSets", testCallQueryResultSets},
+		{"inOut", testCallInOut},
 	}
 
 	db := driver.MT.DB()
@@ -44,9 +44,9 @@ func (c *callConn) QueryContext(ctx context.Context, query string, args []driver
 	if len(args) != 1 {
 		return nil, fmt.Errorf("invalid argument length %d - expected 1", len(args))
 	}
-	cr, ok := args[0].Value.(*callResult)
+	rows, ok := args[0].Value.(driver.Rows) // callResult or tableRows
 	if !ok {
 		return nil, fmt.Errorf("invalid argument type %T", args[0])
 	}
-	return cr, nil
+	return rows, nil
 }
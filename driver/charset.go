@@ -0,0 +1,53 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+	"golang.org/x/text/transform"
+)
+
+// A CharsetPolicy defines the handling of invalid UTF-8 in string parameters and of invalid CESU-8 sent by the database.
+type CharsetPolicy int
+
+// CharsetPolicy constants.
+const (
+	// CharsetError returns an error for invalid encoded data (default).
+	CharsetError CharsetPolicy = iota
+	// CharsetReplace replaces invalid encoded data by the unicode replacement character U+FFFD.
+	CharsetReplace
+	// CharsetPassThrough passes invalid encoded data unchanged.
+	CharsetPassThrough
+)
+
+func (p CharsetPolicy) String() string {
+	switch p {
+	case CharsetError:
+		return "error"
+	case CharsetReplace:
+		return "replace"
+	case CharsetPassThrough:
+		return "passThrough"
+	default:
+		return fmt.Sprintf("CharsetPolicy(%d)", int(p))
+	}
+}
+
+var (
+	replaceDecoder     = cesu8.NewDecoder(cesu8.ReplaceErrorHandler)
+	replaceEncoder     = cesu8.NewEncoder(cesu8.ReplaceErrorHandler)
+	passThroughDecoder = cesu8.NewDecoder(cesu8.PassThroughErrorHandler)
+	passThroughEncoder = cesu8.NewEncoder(cesu8.PassThroughErrorHandler)
+)
+
+// transformers returns the CESU-8 decoder and encoder of the policy.
+func (p CharsetPolicy) transformers() (decoder, encoder func() transform.Transformer) {
+	switch p {
+	case CharsetReplace:
+		return func() transform.Transformer { return replaceDecoder }, func() transform.Transformer { return replaceEncoder }
+	case CharsetPassThrough:
+		return func() transform.Transformer { return passThroughDecoder }, func() transform.Transformer { return passThroughEncoder }
+	default:
+		return cesu8.DefaultDecoder, cesu8.DefaultEncoder
+	}
+}
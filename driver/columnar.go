@@ -0,0 +1,134 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+A Column holds the values of a result column for the rows of a ColumnBatch.
+
+Depending on the database type the values are stored in
+  - Int64s: TINYINT, SMALLINT, INTEGER and BIGINT columns
+  - Float64s: REAL and DOUBLE columns
+  - Mantissas and Exponents: DECIMAL columns with value Mantissas[i] * 10^Exponents[i] and
+    Rats: the DECIMAL values as rational numbers
+  - Times: DATE, TIME, SECONDDATE and TIMESTAMP columns
+  - Values: all other columns (with the same values as returned by the driver in row-wise scanning)
+
+Valid reports for each row if the value is not NULL.
+*/
+type Column struct {
+	Name             string
+	DatabaseTypeName string
+	Int64s           []int64
+	Float64s         []float64
+	Mantissas        []big.Int
+	Exponents        []int
+	Rats             []big.Rat
+	Times            []time.Time
+	Values           []any
+	Valid            []bool
+}
+
+// A ColumnBatch is a batch of rows of a query result in columnar representation.
+type ColumnBatch struct {
+	NumRows int
+	Columns []Column
+}
+
+func (b *ColumnBatch) set(qr *queryResult) {
+	rs := qr.resSet
+	b.NumRows = rs.NumColumnRows()
+	for i, f := range qr.fields {
+		c := &rs.Columns[i]
+		b.Columns[i] = Column{
+			Name:             f.Name(),
+			DatabaseTypeName: f.TypeName(),
+			Int64s:           c.Int64s,
+			Float64s:         c.Float64s,
+			Mantissas:        c.Mantissas,
+			Exponents:        c.Exponents,
+			Rats:             c.Rats,
+			Times:            c.Times,
+			Values:           c.Values,
+			Valid:            c.Valid,
+		}
+		for _, v := range c.Values[:min(len(c.Values), b.NumRows)] {
+			if v, ok := v.(p.LobDecoderSetter); ok {
+				v.SetDecoder(qr.conn.decodeLob)
+				qr.lobs.track(v)
+			}
+		}
+	}
+}
+
+/*
+QueryColumns executes query with args on connection sqlConn and calls fn for each batch of result rows.
+
+In contrast to row-wise scanning, the database values are decoded directly into column buffers
+without creating a driver.Value per field (numeric columns), which is useful for analytical workloads
+feeding columnar data structures (e.g. Apache Arrow record batches).
+The batch size is defined by the fetch size and the connector attribute MaxBufferedRows.
+Batch and column buffers are reused across fetches and are only valid during the call of fn.
+Once the buffers are grown, decoding numeric columns does not allocate.
+Scan converters are not applied to column values.
+*/
+func QueryColumns(ctx context.Context, sqlConn *sql.Conn, query string, args []any, fn func(b *ColumnBatch) error) error {
+	return sqlConn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*conn)
+		if !ok {
+			return fmt.Errorf("invalid driver connection type %T", driverConn)
+		}
+
+		rows, err := c.queryAll(withColumnar(ctx), query, args)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		dr := unwrapRows(rows)
+		if dr == noResult {
+			return nil
+		}
+		qr, ok := dr.(*queryResult)
+		if !ok {
+			return fmt.Errorf("columnar decoding is not supported for result type %T", dr)
+		}
+		if qr.resSet == nil { // no resultset part read
+			return nil
+		}
+
+		b := &ColumnBatch{Columns: make([]Column, len(qr.fields))}
+		for {
+			if len(qr.decodeErrors) != 0 {
+				return qr.decodeErrors[0]
+			}
+			if qr.resSet.NumColumnRows() != 0 {
+				b.set(qr)
+				if err := fn(b); err != nil {
+					return err
+				}
+			}
+			ok, err := qr.decodeNext()
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+			if qr.attrs.LastPacket() {
+				return nil
+			}
+			if err := c.fetchNext(ctx, qr); err != nil {
+				qr.lastErr = err
+				return err
+			}
+		}
+	})
+}
@@ -0,0 +1,92 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func testQueryColumns(t *testing.T, db *sql.DB) {
+	const numRow = 1000
+
+	ctx := context.Background()
+	tableName := RandomIdentifier("queryColumns_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i integer, d double, s nvarchar(20))", tableName)); err != nil {
+		t.Fatal(err)
+	}
+	stmt, err := db.Prepare(fmt.Sprintf("insert into %s values (?, ?, ?)", tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	for i := 0; i < numRow; i++ {
+		var d any // every second double is NULL
+		if i%2 == 0 {
+			d = float64(i) / 2
+		}
+		if _, err := stmt.Exec(i, d, fmt.Sprintf("row %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for _, args := range [][]any{nil, {numRow}} {
+		query := fmt.Sprintf("select i, d, s from %s where i < %d order by i", tableName, numRow)
+		if args != nil {
+			query = fmt.Sprintf("select i, d, s from %s where i < ? order by i", tableName)
+		}
+		row := 0
+		if err := QueryColumns(ctx, conn, query, args, func(b *ColumnBatch) error {
+			for i := 0; i < b.NumRows; i++ {
+				if v := b.Columns[0].Int64s[i]; v != int64(row) {
+					return fmt.Errorf("row %d: integer value %d - expected %d", row, v, row)
+				}
+				if valid := b.Columns[1].Valid[i]; valid != (row%2 == 0) {
+					return fmt.Errorf("row %d: valid %t - expected %t", row, valid, row%2 == 0)
+				}
+				if b.Columns[1].Valid[i] && b.Columns[1].Float64s[i] != float64(row)/2 {
+					return fmt.Errorf("row %d: double value %f - expected %f", row, b.Columns[1].Float64s[i], float64(row)/2)
+				}
+				if v, s := b.Columns[2].Values[i], fmt.Sprintf("row %d", row); v != s {
+					return fmt.Errorf("row %d: string value %v - expected %s", row, v, s)
+				}
+				row++
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if row != numRow {
+			t.Fatalf("number of rows %d - expected %d", row, numRow)
+		}
+	}
+}
+
+func TestColumnar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, db *sql.DB)
+	}{
+		{"queryColumns", testQueryColumns},
+	}
+
+	db := MT.DB()
+	for _, test := range tests {
+		test := test // new test to run in parallel
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.fct(t, db)
+		})
+	}
+}
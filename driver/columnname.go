@@ -0,0 +1,94 @@
+package driver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A ColumnNameCase defines the case of the column names reported for query results (see sql.Rows.Columns).
+type ColumnNameCase int
+
+// ColumnNameCase constants.
+const (
+	// ColumnNameAsIs reports column names as returned by the database (default).
+	ColumnNameAsIs ColumnNameCase = iota
+	// ColumnNameLower reports lower-cased column names.
+	ColumnNameLower
+	// ColumnNameUpper reports upper-cased column names.
+	ColumnNameUpper
+)
+
+func (c ColumnNameCase) String() string {
+	switch c {
+	case ColumnNameAsIs:
+		return "asIs"
+	case ColumnNameLower:
+		return "lower"
+	case ColumnNameUpper:
+		return "upper"
+	default:
+		return fmt.Sprintf("ColumnNameCase(%d)", int(c))
+	}
+}
+
+// A DuplicateColumnPolicy defines how duplicate column names of query results are reported (see sql.Rows.Columns).
+type DuplicateColumnPolicy int
+
+// DuplicateColumnPolicy constants.
+const (
+	// DuplicateColumnKeep reports duplicate column names as returned by the database (default).
+	DuplicateColumnKeep DuplicateColumnPolicy = iota
+	// DuplicateColumnSuffix makes column names unique by appending the suffix _<n> to the n-th occurrence of a
+	// name (e.g. ID, ID_2, ID_3), skipping suffixed names already used by other columns.
+	DuplicateColumnSuffix
+)
+
+func (p DuplicateColumnPolicy) String() string {
+	switch p {
+	case DuplicateColumnKeep:
+		return "keep"
+	case DuplicateColumnSuffix:
+		return "suffix"
+	default:
+		return fmt.Sprintf("DuplicateColumnPolicy(%d)", int(p))
+	}
+}
+
+// columnNames applies the column name case and duplicate column policy of attrs to names.
+// The case is applied first, so that names only differing in case are treated as duplicates if not reported as-is.
+func columnNames(attrs *connAttrs, names []string) []string {
+	switch attrs._columnNameCase {
+	case ColumnNameLower:
+		for i, name := range names {
+			names[i] = strings.ToLower(name)
+		}
+	case ColumnNameUpper:
+		for i, name := range names {
+			names[i] = strings.ToUpper(name)
+		}
+	}
+	if attrs._dupColumnPolicy != DuplicateColumnSuffix {
+		return names
+	}
+	used := make(map[string]bool, len(names))
+	for _, name := range names {
+		used[name] = true
+	}
+	seen := make(map[string]int, len(names))
+	for i, name := range names {
+		seen[name]++
+		if seen[name] == 1 {
+			continue
+		}
+		for n := seen[name]; ; n++ {
+			if suffixed := name + "_" + strconv.Itoa(n); !used[suffixed] {
+				names[i] = suffixed
+				used[suffixed] = true
+				seen[name] = n
+				break
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,30 @@
+package driver
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestColumnNames(t *testing.T) {
+	tests := []struct {
+		nameCase ColumnNameCase
+		policy   DuplicateColumnPolicy
+		names    []string
+		expected []string
+	}{
+		{ColumnNameAsIs, DuplicateColumnKeep, []string{"ID", "Name", "ID"}, []string{"ID", "Name", "ID"}},
+		{ColumnNameLower, DuplicateColumnKeep, []string{"ID", "Name", "ID"}, []string{"id", "name", "id"}},
+		{ColumnNameUpper, DuplicateColumnKeep, []string{"ID", "Name", "ID"}, []string{"ID", "NAME", "ID"}},
+		{ColumnNameAsIs, DuplicateColumnSuffix, []string{"ID", "Name", "ID", "ID"}, []string{"ID", "Name", "ID_2", "ID_3"}},
+		{ColumnNameAsIs, DuplicateColumnSuffix, []string{"ID", "ID", "ID_2"}, []string{"ID", "ID_3", "ID_2"}},
+		{ColumnNameAsIs, DuplicateColumnSuffix, []string{"Name", "NAME"}, []string{"Name", "NAME"}},
+		{ColumnNameLower, DuplicateColumnSuffix, []string{"Name", "NAME"}, []string{"name", "name_2"}},
+	}
+
+	for _, test := range tests {
+		attrs := &connAttrs{_columnNameCase: test.nameCase, _dupColumnPolicy: test.policy}
+		if names := columnNames(attrs, slices.Clone(test.names)); !slices.Equal(names, test.expected) {
+			t.Fatalf("%s %s %v: names %v - expected %v", test.nameCase, test.policy, test.names, names, test.expected)
+		}
+	}
+}
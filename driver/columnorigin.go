@@ -0,0 +1,35 @@
+package driver
+
+import (
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+A ColumnOrigin describes the origin of a query result column as provided by the result metadata of the
+database server, so that the columns of joined or aliased results can be mapped back to their source tables.
+
+The origin names are empty for columns not originating from a table column (e.g. expressions or constants).
+*/
+type ColumnOrigin struct {
+	// Name is the result column name, i.e. the column alias if provided (see sql.Rows.Columns).
+	Name string
+	// SchemaName is the schema of the source table.
+	SchemaName string
+	// TableName is the name of the source table (view).
+	TableName string
+	// ColumnName is the name of the source table column.
+	ColumnName string
+}
+
+func newColumnOrigins(fields []*p.ResultField) []*ColumnOrigin {
+	origins := make([]*ColumnOrigin, len(fields))
+	for i, field := range fields {
+		origins[i] = &ColumnOrigin{
+			Name:       field.Name(),
+			SchemaName: field.SchemaName(),
+			TableName:  field.TableName(),
+			ColumnName: field.ColumnName(),
+		}
+	}
+	return origins
+}
@@ -1,14 +1,17 @@
 package driver
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"database/sql/driver"
 	"fmt"
 	"log/slog"
 	"maps"
 	"math"
 	"os"
 	"path"
+	"slices"
 	"sync"
 	"time"
 
@@ -37,6 +40,9 @@ const (
 	minTimeout  = 0 * time.Second // minimal timeout value.
 	minBulkSize = 1               // minimal bulkSize value.
 	maxBulkSize = p.MaxNumArg     // maximum bulk size.
+
+	minBulkByteSize = 1024          // minimal bulkByteSize value.
+	maxBulkByteSize = p.MaxPartSize // maximum bulk byte size.
 )
 
 const (
@@ -53,33 +59,89 @@ const (
 
 // connAttrs is holding connection relevant attributes.
 type connAttrs struct {
-	mu                sync.RWMutex
-	_timeout          time.Duration
-	_pingInterval     time.Duration
-	_bufferSize       int
-	_bulkSize         int
-	_tcpKeepAlive     time.Duration // see net.Dialer
-	_tlsConfig        *tls.Config
-	_defaultSchema    string
-	_dialer           dial.Dialer
-	_applicationName  string
-	_sessionVariables map[string]string
-	_locale           string
-	_fetchSize        int
-	_lobChunkSize     int
-	_dfv              int
-	_cesu8Decoder     func() transform.Transformer
-	_cesu8Encoder     func() transform.Transformer
-	_emptyDateAsNull  bool
-	_logger           *slog.Logger
+	mu                    sync.RWMutex
+	_timeout              time.Duration
+	_dialTimeout          time.Duration
+	_readTimeout          time.Duration
+	_writeTimeout         time.Duration
+	_pingInterval         time.Duration
+	_idleTimeout          time.Duration
+	_maxStatements        int
+	_maxBytes             int64
+	_ctxQueryTimeout      bool
+	_serverCancel         bool
+	_replayable           func(query string) bool
+	_bufferSize           int
+	_bulkSize             int
+	_bulkByteSize         int
+	_bulkPipeline         bool
+	_stmtCacheSize        int
+	_holdCursors          bool
+	_tcpKeepAlive         time.Duration // see net.Dialer
+	_tcpKeepAliveIntv     time.Duration
+	_tcpNoDelay           bool
+	_readBufferSize       int // socket receive buffer size
+	_writeBufferSize      int // socket send buffer size
+	_tlsConfig            *tls.Config
+	_getTLSConfig         func(ctx context.Context) (*tls.Config, error)
+	_tlsServerName        string
+	_tlsVerifyPeer        func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error
+	_tlsSessionCache      tls.ClientSessionCache
+	_defaultSchema        string
+	_dialer               dial.Dialer
+	_applicationName      string
+	_sessionVariables     map[string]string
+	_locale               string
+	_fetchSize            int
+	_maxFetchSize         int
+	_maxBufferedRows      int
+	_maxResultBytes       int
+	_decodeParallelism    int
+	_lobChunkSize         int
+	_dfv                  int
+	_cesu8Decoder         func() transform.Transformer
+	_cesu8Encoder         func() transform.Transformer
+	_charsetPolicy        CharsetPolicy
+	_emptyDateAsNull      bool
+	_alphanumPadding      bool
+	_timeZonePolicy       TimeZonePolicy
+	_columnNameCase       ColumnNameCase
+	_dupColumnPolicy      DuplicateColumnPolicy
+	_scanTypePolicy       ScanTypePolicy
+	_timeLocation         *time.Location
+	_decimalRounding      DecimalRoundingPolicy
+	_logger               *slog.Logger
+	_logLevel             slog.Leveler
+	_retryPolicy          *RetryPolicy
+	_beforeConnect        func(ctx context.Context, connector *Connector) error
+	_afterConnect         func(ctx context.Context, conn driver.Conn) error
+	_slowQueryThreshold   time.Duration
+	_slowQueryHook        func(ctx context.Context, sq *SlowQuery)
+	_slowQueryExplainPlan bool
+	_auditor              Auditor
+	_connEventSubscriber  ConnEventSubscriber
+	_warningHandler       func(ctx context.Context, warning DBError)
+	_stmtRetryPolicy      *RetryPolicy
+	_errorContext         *ErrorContextConfig
+	_resetPolicy          *ResetPolicy
+	_middlewares          []Middleware
+	_resultCache          *resultCache
+	_metadataCache        *metadataCache
+	_hostResolver         *hostResolver
+	_fault                faultInjector
 }
 
 func newConnAttrs() *connAttrs {
 	return &connAttrs{
 		_timeout:         defaultTimeout,
+		_dialTimeout:     defaultTimeout,
+		_readTimeout:     defaultTimeout,
+		_writeTimeout:    defaultTimeout,
 		_bufferSize:      defaultBufferSize,
 		_bulkSize:        defaultBulkSize,
+		_bulkByteSize:    maxBulkByteSize,
 		_tcpKeepAlive:    defaultTCPKeepAlive,
+		_tcpNoDelay:      true,
 		_dialer:          dial.DefaultDialer,
 		_applicationName: defaultApplicationName,
 		_fetchSize:       defaultFetchSize,
@@ -101,24 +163,75 @@ func (c *connAttrs) clone() *connAttrs {
 	defer c.mu.RUnlock()
 
 	return &connAttrs{
-		_timeout:          c._timeout,
-		_pingInterval:     c._pingInterval,
-		_bufferSize:       c._bufferSize,
-		_bulkSize:         c._bulkSize,
-		_tcpKeepAlive:     c._tcpKeepAlive,
-		_tlsConfig:        c._tlsConfig.Clone(),
-		_defaultSchema:    c._defaultSchema,
-		_dialer:           c._dialer,
-		_applicationName:  c._applicationName,
-		_sessionVariables: maps.Clone(c._sessionVariables),
-		_locale:           c._locale,
-		_fetchSize:        c._fetchSize,
-		_lobChunkSize:     c._lobChunkSize,
-		_dfv:              c._dfv,
-		_cesu8Decoder:     c._cesu8Decoder,
-		_cesu8Encoder:     c._cesu8Encoder,
-		_emptyDateAsNull:  c._emptyDateAsNull,
-		_logger:           c._logger,
+		_timeout:              c._timeout,
+		_dialTimeout:          c._dialTimeout,
+		_readTimeout:          c._readTimeout,
+		_writeTimeout:         c._writeTimeout,
+		_pingInterval:         c._pingInterval,
+		_idleTimeout:          c._idleTimeout,
+		_maxStatements:        c._maxStatements,
+		_maxBytes:             c._maxBytes,
+		_ctxQueryTimeout:      c._ctxQueryTimeout,
+		_serverCancel:         c._serverCancel,
+		_replayable:           c._replayable,
+		_bufferSize:           c._bufferSize,
+		_bulkSize:             c._bulkSize,
+		_bulkByteSize:         c._bulkByteSize,
+		_bulkPipeline:         c._bulkPipeline,
+		_stmtCacheSize:        c._stmtCacheSize,
+		_holdCursors:          c._holdCursors,
+		_tcpKeepAlive:         c._tcpKeepAlive,
+		_tcpKeepAliveIntv:     c._tcpKeepAliveIntv,
+		_tcpNoDelay:           c._tcpNoDelay,
+		_readBufferSize:       c._readBufferSize,
+		_writeBufferSize:      c._writeBufferSize,
+		_tlsConfig:            c._tlsConfig.Clone(),
+		_getTLSConfig:         c._getTLSConfig,
+		_tlsServerName:        c._tlsServerName,
+		_tlsVerifyPeer:        c._tlsVerifyPeer,
+		_tlsSessionCache:      c._tlsSessionCache, // shared by all connections of the connector
+		_defaultSchema:        c._defaultSchema,
+		_dialer:               c._dialer,
+		_applicationName:      c._applicationName,
+		_sessionVariables:     maps.Clone(c._sessionVariables),
+		_locale:               c._locale,
+		_fetchSize:            c._fetchSize,
+		_maxFetchSize:         c._maxFetchSize,
+		_maxBufferedRows:      c._maxBufferedRows,
+		_maxResultBytes:       c._maxResultBytes,
+		_decodeParallelism:    c._decodeParallelism,
+		_lobChunkSize:         c._lobChunkSize,
+		_dfv:                  c._dfv,
+		_cesu8Decoder:         c._cesu8Decoder,
+		_cesu8Encoder:         c._cesu8Encoder,
+		_charsetPolicy:        c._charsetPolicy,
+		_emptyDateAsNull:      c._emptyDateAsNull,
+		_alphanumPadding:      c._alphanumPadding,
+		_timeZonePolicy:       c._timeZonePolicy,
+		_columnNameCase:       c._columnNameCase,
+		_dupColumnPolicy:      c._dupColumnPolicy,
+		_scanTypePolicy:       c._scanTypePolicy,
+		_timeLocation:         c._timeLocation,
+		_decimalRounding:      c._decimalRounding,
+		_logger:               c._logger,
+		_logLevel:             c._logLevel,
+		_retryPolicy:          c._retryPolicy,
+		_beforeConnect:        c._beforeConnect,
+		_afterConnect:         c._afterConnect,
+		_slowQueryThreshold:   c._slowQueryThreshold,
+		_slowQueryHook:        c._slowQueryHook,
+		_slowQueryExplainPlan: c._slowQueryExplainPlan,
+		_auditor:              c._auditor,
+		_connEventSubscriber:  c._connEventSubscriber,
+		_warningHandler:       c._warningHandler,
+		_stmtRetryPolicy:      c._stmtRetryPolicy,
+		_errorContext:         c._errorContext,
+		_resetPolicy:          c._resetPolicy,
+		_middlewares:          slices.Clone(c._middlewares),
+		_resultCache:          c._resultCache,   // cache is shared
+		_metadataCache:        c._metadataCache, // cache is shared
+		_hostResolver:         c._hostResolver,  // resolved addresses are shared
+		_fault:                c._fault,
 	}
 }
 
@@ -127,6 +240,7 @@ func (c *connAttrs) setTimeout(timeout time.Duration) {
 		timeout = minTimeout
 	}
 	c._timeout = timeout
+	c._dialTimeout, c._readTimeout, c._writeTimeout = timeout, timeout, timeout
 }
 func (c *connAttrs) setBulkSize(bulkSize int) {
 	switch {
@@ -194,6 +308,9 @@ func (c *connAttrs) Timeout() time.Duration { c.mu.RLock(); defer c.mu.RUnlock()
 /*
 SetTimeout sets the timeout of the connector.
 
+The timeout is used as dial, read and write timeout. Use SetDialTimeout, SetReadTimeout
+and SetWriteTimeout to set the network timeouts individually after calling SetTimeout.
+
 For more information please see DSNTimeout.
 */
 func (c *connAttrs) SetTimeout(timeout time.Duration) {
@@ -202,6 +319,53 @@ func (c *connAttrs) SetTimeout(timeout time.Duration) {
 	c.setTimeout(timeout)
 }
 
+// DialTimeout returns the dial timeout of the connector.
+func (c *connAttrs) DialTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._dialTimeout
+}
+
+// SetDialTimeout sets the timeout for establishing the network connection (0: no timeout).
+func (c *connAttrs) SetDialTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._dialTimeout = max(timeout, minTimeout)
+}
+
+// ReadTimeout returns the read timeout of the connector.
+func (c *connAttrs) ReadTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._readTimeout
+}
+
+/*
+SetReadTimeout sets the read timeout of the connector (0: no timeout).
+
+The read timeout applies to a whole protocol roundtrip: it starts after a request message
+is sent and covers the server processing time and reading the reply message.
+*/
+func (c *connAttrs) SetReadTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._readTimeout = max(timeout, minTimeout)
+}
+
+// WriteTimeout returns the write timeout of the connector.
+func (c *connAttrs) WriteTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._writeTimeout
+}
+
+// SetWriteTimeout sets the timeout for writing a request message (0: no timeout).
+func (c *connAttrs) SetWriteTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._writeTimeout = max(timeout, minTimeout)
+}
+
 // PingInterval returns the connection ping interval of the connector.
 func (c *connAttrs) PingInterval() time.Duration {
 	c.mu.RLock()
@@ -227,6 +391,136 @@ func (c *connAttrs) SetPingInterval(d time.Duration) {
 	c._pingInterval = d
 }
 
+// IdleSessionTimeout returns the idle session timeout of the connector.
+func (c *connAttrs) IdleSessionTimeout() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._idleTimeout
+}
+
+/*
+SetIdleSessionTimeout sets the idle session timeout of the connector.
+
+The database server closes sessions being idle for longer than the server side configured
+idle connection timeout (see indexserver.ini parameter idle_connection_timeout). As this value
+is not reported by the server as part of the connection process it needs to be set client side.
+
+If d is not zero, a connection of the connection pool which was not accessed for a duration greater
+or equal than d is reported as invalid and therefore is discarded instead of being reused,
+avoiding a failure of the first request after a long idle period.
+To recycle connections before the server closes them d should be set slightly lower than the
+server side timeout.
+*/
+func (c *connAttrs) SetIdleSessionTimeout(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._idleTimeout = d
+}
+
+// ConnMaxStatements returns the maximum number of statements executed by a connection of the connector.
+func (c *connAttrs) ConnMaxStatements() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._maxStatements
+}
+
+/*
+SetConnMaxStatements sets the maximum number of statements a connection of the connector
+does execute before it is retired.
+
+A connection having executed n or more statements is reported as invalid and is closed
+by the connection pool instead of being reused. If n is less or equal zero (default)
+the number of executed statements is not limited.
+*/
+func (c *connAttrs) SetConnMaxStatements(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._maxStatements = max(n, 0)
+}
+
+// ConnMaxBytes returns the maximum number of bytes transferred by a connection of the connector.
+func (c *connAttrs) ConnMaxBytes() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._maxBytes
+}
+
+/*
+SetConnMaxBytes sets the maximum number of bytes (read and written) a connection of the connector
+does transfer before it is retired.
+
+A connection having transferred n or more bytes is reported as invalid and is closed
+by the connection pool instead of being reused. If n is less or equal zero (default)
+the number of transferred bytes is not limited.
+*/
+func (c *connAttrs) SetConnMaxBytes(n int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._maxBytes = max(n, 0)
+}
+
+// ContextQueryTimeout returns true if context deadlines are transferred as server side query timeouts, false otherwise.
+func (c *connAttrs) ContextQueryTimeout() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._ctxQueryTimeout
+}
+
+/*
+SetContextQueryTimeout sets the ContextQueryTimeout flag of the connector.
+
+If true, the remaining time of a context deadline is sent to the database server as query timeout
+(rounded up to seconds) with each statement execution, so that the database server cancels
+long running statements itself even if the client is not able to do so (e.g. the client process died).
+The query timeout support needs to be enabled for statement timeouts set by hdbctx.WithStatementTimeout as well.
+*/
+func (c *connAttrs) SetContextQueryTimeout(contextQueryTimeout bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._ctxQueryTimeout = contextQueryTimeout
+}
+
+// ServerCancel returns true if cancelled statements are cancelled on the database server, false otherwise.
+func (c *connAttrs) ServerCancel() bool { c.mu.RLock(); defer c.mu.RUnlock(); return c._serverCancel }
+
+/*
+SetServerCancel sets the ServerCancel flag of the connector.
+
+If true and the context of a running database call is cancelled, a short-lived control connection
+is opened to cancel the statement on the database server via ALTER SYSTEM CANCEL SESSION instead
+of only abandoning the connection. The database user needs to be allowed to cancel its own sessions.
+*/
+func (c *connAttrs) SetServerCancel(serverCancel bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._serverCancel = serverCancel
+}
+
+// Replayable returns the replayable statement classification function of the connector.
+func (c *connAttrs) Replayable() func(query string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._replayable
+}
+
+/*
+SetReplayable enables the automatic reconnect of lost database connections.
+
+If a connection is lost outside of a transaction while executing a statement without parameters
+(Exec or Query with no arguments) and replayable classifies the statement as idempotent, the connection
+is re-established transparently and the statement is executed again. Otherwise a *ConnLostError is returned.
+Statements prepared on a lost connection are not replayed.
+The connection is re-established like a new connection of the connector (including the BeforeConnect and
+AfterConnect hooks) and connection events are emitted for the lost and the new session (see SetConnEventSubscriber).
+
+A nil function disables the automatic reconnect (default).
+*/
+func (c *connAttrs) SetReplayable(replayable func(query string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._replayable = replayable
+}
+
 // BufferSize returns the bufferSize of the connector.
 func (c *connAttrs) BufferSize() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._bufferSize }
 
@@ -249,6 +543,79 @@ func (c *connAttrs) SetBulkSize(bulkSize int) {
 	c.setBulkSize(bulkSize)
 }
 
+// BulkByteSize returns the bulkByteSize of the connector.
+func (c *connAttrs) BulkByteSize() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._bulkByteSize }
+
+/*
+SetBulkByteSize sets the bulkByteSize of the connector.
+
+In addition to the bulkSize (number of rows) a bulk execution is split into several server calls
+as soon as the encoded size of the rows of a server call would exceed bulkByteSize. Rows with
+variable-length data therefore neither exceed the protocol message limits nor need a small bulkSize.
+The default is the maximum protocol message part size.
+*/
+func (c *connAttrs) SetBulkByteSize(bulkByteSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._bulkByteSize = min(max(bulkByteSize, minBulkByteSize), maxBulkByteSize)
+}
+
+// HoldCursorsOverCommit returns the hold cursors over commit flag of the connector.
+func (c *connAttrs) HoldCursorsOverCommit() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._holdCursors
+}
+
+/*
+SetHoldCursorsOverCommit sets the hold cursors over commit flag of the connector.
+
+By default the database server closes all open resultsets of a session on commit. If set, queries are executed
+with the hold cursors over commit option, so that long resultset iterations survive intermediate commits
+(e.g. of statements executed in autocommit mode) in the same session. A rollback closes the resultsets nevertheless.
+*/
+func (c *connAttrs) SetHoldCursorsOverCommit(hold bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._holdCursors = hold
+}
+
+// StmtCacheSize returns the prepared statement cache size of the connector.
+func (c *connAttrs) StmtCacheSize() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._stmtCacheSize }
+
+/*
+SetStmtCacheSize sets the prepared statement cache size of the connector.
+
+If greater zero, each connection caches up to size prepared statements keyed by the sql text
+(least recently used). Statements with parameters executed via Exec or Query without an explicit
+Prepare are prepared on each execution by database/sql - a cached prepared statement avoids
+the prepare server roundtrip in this case. Cache hits and misses are reported in the driver statistics.
+The default is 0 (no caching).
+*/
+func (c *connAttrs) SetStmtCacheSize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._stmtCacheSize = max(size, 0)
+}
+
+// BulkPipeline returns the bulkPipeline flag of the connector.
+func (c *connAttrs) BulkPipeline() bool { c.mu.RLock(); defer c.mu.RUnlock(); return c._bulkPipeline }
+
+/*
+SetBulkPipeline sets the bulkPipeline flag of the connector.
+
+If set, the arguments of the next batch of a bulk execution (multiple rows or function argument)
+are read, converted and encoded while the server call(s) of the previous batch are still in flight.
+This hides the client side encoding time behind the network latency for large loads, at the
+cost of keeping two batches in memory. The argument function of a function based bulk execution
+is called from a different goroutine.
+*/
+func (c *connAttrs) SetBulkPipeline(bulkPipeline bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._bulkPipeline = bulkPipeline
+}
+
 // TCPKeepAlive returns the tcp keep-alive value of the connector.
 func (c *connAttrs) TCPKeepAlive() time.Duration {
 	c.mu.RLock()
@@ -267,6 +634,65 @@ func (c *connAttrs) SetTCPKeepAlive(tcpKeepAlive time.Duration) {
 	c._tcpKeepAlive = tcpKeepAlive
 }
 
+// TCPKeepAliveInterval returns the tcp keep-alive probe interval of the connector.
+func (c *connAttrs) TCPKeepAliveInterval() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._tcpKeepAliveIntv
+}
+
+/*
+SetTCPKeepAliveInterval sets the interval between tcp keep-alive probes of the connector.
+
+If zero, the tcp keep-alive value is used as probe interval. Setting the interval is
+supported on linux, freebsd and netbsd only and is ignored on other platforms.
+*/
+func (c *connAttrs) SetTCPKeepAliveInterval(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._tcpKeepAliveIntv = interval
+}
+
+// TCPNoDelay returns the TCP_NODELAY flag of the connector.
+func (c *connAttrs) TCPNoDelay() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._tcpNoDelay
+}
+
+// SetTCPNoDelay sets the TCP_NODELAY flag of the connector (default true). If false, Nagle's algorithm is enabled.
+func (c *connAttrs) SetTCPNoDelay(noDelay bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._tcpNoDelay = noDelay
+}
+
+// SocketBufferSizes returns the socket receive and send buffer sizes of the connector.
+func (c *connAttrs) SocketBufferSizes() (readBufferSize, writeBufferSize int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._readBufferSize, c._writeBufferSize
+}
+
+// SetSocketBufferSizes sets the socket receive and send buffer sizes of the connector (0: system default).
+func (c *connAttrs) SetSocketBufferSizes(readBufferSize, writeBufferSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._readBufferSize, c._writeBufferSize = max(readBufferSize, 0), max(writeBufferSize, 0)
+}
+
+// dialerOptions returns the dialer options of the connector.
+func (c *connAttrs) dialerOptions() dial.DialerOptions {
+	return dial.DialerOptions{
+		Timeout:              c._dialTimeout,
+		TCPKeepAlive:         c._tcpKeepAlive,
+		TCPKeepAliveInterval: c._tcpKeepAliveIntv,
+		TCPDelay:             !c._tcpNoDelay,
+		ReadBufferSize:       c._readBufferSize,
+		WriteBufferSize:      c._writeBufferSize,
+	}
+}
+
 // DefaultSchema returns the database default schema of the connector.
 func (c *connAttrs) DefaultSchema() string {
 	c.mu.RLock()
@@ -302,10 +728,127 @@ func (c *connAttrs) SetTLSConfig(tlsConfig *tls.Config) {
 	c._tlsConfig = tlsConfig.Clone()
 }
 
+// GetTLSConfig returns the TLS configuration callback of the connector.
+func (c *connAttrs) GetTLSConfig() func(ctx context.Context) (*tls.Config, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._getTLSConfig
+}
+
+/*
+SetGetTLSConfig sets a TLS configuration callback of the connector.
+
+The callback is evaluated for each connection attempt and takes precedence over the static
+TLS configuration set by SetTLS or SetTLSConfig, so that e.g. rotated server CAs or client
+certificates take effect for new connections without restarting the process.
+In case the callback returns a nil configuration the static TLS configuration is used.
+*/
+func (c *connAttrs) SetGetTLSConfig(getTLSConfig func(ctx context.Context) (*tls.Config, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._getTLSConfig = getTLSConfig
+}
+
+// TLSServerName returns the TLS server name (SNI) override of the connector.
+func (c *connAttrs) TLSServerName() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._tlsServerName
+}
+
+/*
+SetTLSServerName sets the TLS server name (SNI) override of the connector.
+
+If set, the server name overrides the server name of the TLS configuration. This is used
+for the SNI extension and the host name verification of the server certificate, e.g. when
+connecting through a TCP load balancer whose address does not match the server certificate.
+*/
+func (c *connAttrs) SetTLSServerName(serverName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._tlsServerName = serverName
+}
+
+// TLSVerifyPeerCertificate returns the custom TLS peer certificate verification function of the connector.
+func (c *connAttrs) TLSVerifyPeerCertificate() func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._tlsVerifyPeer
+}
+
+/*
+SetTLSVerifyPeerCertificate sets a custom TLS peer certificate verification function of the connector.
+
+If set, the standard certificate chain and host name verification is disabled (InsecureSkipVerify) and
+verifyPeer is the only verification of the server certificates. As verifiedChains is always nil in this
+case verifyPeer needs to verify rawCerts itself, e.g. by pinning the server certificate
+(see VerifyPinnedCertificate).
+*/
+func (c *connAttrs) SetTLSVerifyPeerCertificate(verifyPeer func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._tlsVerifyPeer = verifyPeer
+}
+
+// TLSClientSessionCache returns the TLS client session cache of the connector.
+func (c *connAttrs) TLSClientSessionCache() tls.ClientSessionCache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._tlsSessionCache
+}
+
+/*
+SetTLSClientSessionCache sets the TLS client session cache of the connector.
+
+The cache is shared by all connections of the connector and enables TLS session resumption,
+which avoids full handshakes when opening new connections e.g. for connection pools with high
+connection churn. A client session cache of the TLS configuration takes precedence.
+See also tls.NewLRUClientSessionCache.
+*/
+func (c *connAttrs) SetTLSClientSessionCache(cache tls.ClientSessionCache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._tlsSessionCache = cache
+}
+
+// tlsConfig returns the TLS configuration for a new connection.
+func (c *connAttrs) tlsConfig(ctx context.Context) (*tls.Config, error) {
+	tlsConfig := c._tlsConfig
+	if c._getTLSConfig != nil {
+		config, err := c._getTLSConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			tlsConfig = config
+		}
+	}
+	setSessionCache := c._tlsSessionCache != nil && tlsConfig != nil && tlsConfig.ClientSessionCache == nil
+	if tlsConfig == nil || (c._tlsServerName == "" && c._tlsVerifyPeer == nil && !setSessionCache) {
+		return tlsConfig, nil
+	}
+	tlsConfig = tlsConfig.Clone()
+	if setSessionCache {
+		tlsConfig.ClientSessionCache = c._tlsSessionCache
+	}
+	if c._tlsServerName != "" {
+		tlsConfig.ServerName = c._tlsServerName
+	}
+	if c._tlsVerifyPeer != nil {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+		tlsConfig.VerifyPeerCertificate = c._tlsVerifyPeer
+	}
+	return tlsConfig, nil
+}
+
 // Dialer returns the dialer object of the connector.
 func (c *connAttrs) Dialer() dial.Dialer { c.mu.RLock(); defer c.mu.RUnlock(); return c._dialer }
 
-// SetDialer sets the dialer object of the connector.
+/*
+SetDialer sets the dialer object of the connector.
+
+For establishing connections via proxies, SSH tunnels or cloud bastions please see dial.WrapContextDialer.
+*/
 func (c *connAttrs) SetDialer(dialer dial.Dialer) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -364,58 +907,161 @@ func (c *connAttrs) SetFetchSize(fetchSize int) {
 	c.setFetchSize(fetchSize)
 }
 
-// LobChunkSize returns the lobChunkSize of the connector.
-func (c *connAttrs) LobChunkSize() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._lobChunkSize }
+// MaxFetchSize returns the maximum fetchSize of the connector.
+func (c *connAttrs) MaxFetchSize() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._maxFetchSize }
 
-// SetLobChunkSize sets the lobChunkSize of the connector.
-func (c *connAttrs) SetLobChunkSize(lobChunkSize int) {
+/*
+SetMaxFetchSize sets the maximum fetchSize of the connector.
+
+If maxFetchSize is greater than fetchSize, the fetch size of a resultset is adapted for each fetch roundtrip:
+starting with fetchSize for a low latency of the first rows, the fetch size grows toward maxFetchSize as long
+as the rows are consumed faster than fetched, limited by an upper bound of fetched bytes based on the observed
+row size. Otherwise (default) fetchSize is used for all fetch roundtrips.
+*/
+func (c *connAttrs) SetMaxFetchSize(maxFetchSize int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.setLobChunkSize(lobChunkSize)
+	c._maxFetchSize = max(maxFetchSize, 0)
 }
 
-// Dfv returns the client data format version of the connector.
-func (c *connAttrs) Dfv() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._dfv }
-
-// SetDfv sets the client data format version of the connector.
-func (c *connAttrs) SetDfv(dfv int) { c.mu.Lock(); defer c.mu.Unlock(); c.setDfv(dfv) }
-
-// CESU8Decoder returns the CESU-8 decoder of the connector.
-func (c *connAttrs) CESU8Decoder() func() transform.Transformer {
+// MaxBufferedRows returns the maximum number of buffered decoded rows of a resultset.
+func (c *connAttrs) MaxBufferedRows() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c._cesu8Decoder
+	return c._maxBufferedRows
 }
 
-// SetCESU8Decoder sets the CESU-8 decoder of the connector.
-func (c *connAttrs) SetCESU8Decoder(cesu8Decoder func() transform.Transformer) {
+/*
+SetMaxBufferedRows sets the maximum number of buffered decoded rows of a resultset.
+
+By default all rows of a fetch roundtrip are decoded at once. If maxBufferedRows is greater zero,
+the rows of a fetch roundtrip are kept encoded and decoded in chunks of at most maxBufferedRows rows
+as the resultset is consumed. This limits the memory of slowly consumed large resultsets, as
+the encoded representation of rows is usually much smaller than the decoded one. The next fetch
+roundtrip is only executed after all rows of the previous one are consumed.
+*/
+func (c *connAttrs) SetMaxBufferedRows(maxBufferedRows int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if cesu8Decoder == nil {
-		cesu8Decoder = cesu8.DefaultDecoder
-	}
-	c._cesu8Decoder = cesu8Decoder
+	c._maxBufferedRows = max(maxBufferedRows, 0)
 }
 
-// CESU8Encoder returns the CESU-8 encoder of the connector.
-func (c *connAttrs) CESU8Encoder() func() transform.Transformer {
+// MaxResultBytes returns the maximum number of bytes of a query result buffered by the client.
+func (c *connAttrs) MaxResultBytes() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c._cesu8Encoder
+	return c._maxResultBytes
 }
 
-// SetCESU8Encoder sets the CESU-8 encoder of the connector.
-func (c *connAttrs) SetCESU8Encoder(cesu8Encoder func() transform.Transformer) {
+/*
+SetMaxResultBytes sets the maximum number of bytes of a query result buffered by the client (0: no limit).
+
+The buffered bytes of a query result are the encoded rows of a fetch roundtrip and the rows decoded thereof
+(see SetMaxBufferedRows). In case a fetch roundtrip exceeds maxResultBytes the query respectively the Next call of
+the rows fails with ErrResultTooLarge instead of decoding the rows. This protects clients executing arbitrary queries
+(e.g. multi-tenant services executing user-provided SQL) from running out of memory.
+The limit can be set per statement via hdbctx.WithMaxResultBytes.
+*/
+func (c *connAttrs) SetMaxResultBytes(maxResultBytes int) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if cesu8Encoder == nil {
-		cesu8Encoder = cesu8.DefaultEncoder
-	}
-	c._cesu8Encoder = cesu8Encoder
+	c._maxResultBytes = max(maxResultBytes, 0)
 }
 
-/*
-EmptyDateAsNull returns NULL for empty dates ('0000-00-00') if true, otherwise:
+// DecodeParallelism returns the maximum number of goroutines used for decoding a database reply.
+func (c *connAttrs) DecodeParallelism() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._decodeParallelism
+}
+
+/*
+SetDecodeParallelism sets the maximum number of goroutines used for decoding a database reply.
+
+By default (decodeParallelism <= 1) replies are decoded sequentially. Otherwise up to decodeParallelism
+goroutines are used, which might speed up the decoding of large replies and very wide result sets on multicore clients:
+  - the resultset and output parameter parts of procedure call replies are decoded in parallel and
+  - the date and decimal columns of columnar decoded resultsets (see QueryColumns) are converted in parallel.
+*/
+func (c *connAttrs) SetDecodeParallelism(decodeParallelism int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._decodeParallelism = max(decodeParallelism, 0)
+}
+
+// LobChunkSize returns the lobChunkSize of the connector.
+func (c *connAttrs) LobChunkSize() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._lobChunkSize }
+
+// SetLobChunkSize sets the lobChunkSize of the connector.
+func (c *connAttrs) SetLobChunkSize(lobChunkSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLobChunkSize(lobChunkSize)
+}
+
+// Dfv returns the client data format version of the connector.
+func (c *connAttrs) Dfv() int { c.mu.RLock(); defer c.mu.RUnlock(); return c._dfv }
+
+// SetDfv sets the client data format version of the connector.
+func (c *connAttrs) SetDfv(dfv int) { c.mu.Lock(); defer c.mu.Unlock(); c.setDfv(dfv) }
+
+// CESU8Decoder returns the CESU-8 decoder of the connector.
+func (c *connAttrs) CESU8Decoder() func() transform.Transformer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._cesu8Decoder
+}
+
+// SetCESU8Decoder sets the CESU-8 decoder of the connector.
+func (c *connAttrs) SetCESU8Decoder(cesu8Decoder func() transform.Transformer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cesu8Decoder == nil {
+		cesu8Decoder = cesu8.DefaultDecoder
+	}
+	c._cesu8Decoder = cesu8Decoder
+}
+
+// CESU8Encoder returns the CESU-8 encoder of the connector.
+func (c *connAttrs) CESU8Encoder() func() transform.Transformer {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._cesu8Encoder
+}
+
+// SetCESU8Encoder sets the CESU-8 encoder of the connector.
+func (c *connAttrs) SetCESU8Encoder(cesu8Encoder func() transform.Transformer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cesu8Encoder == nil {
+		cesu8Encoder = cesu8.DefaultEncoder
+	}
+	c._cesu8Encoder = cesu8Encoder
+}
+
+// CharsetPolicy returns the charset policy of the connector.
+func (c *connAttrs) CharsetPolicy() CharsetPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._charsetPolicy
+}
+
+/*
+SetCharsetPolicy sets the charset policy of the connector.
+
+The policy defines the handling of invalid UTF-8 in string parameters and of invalid CESU-8 sent by the
+database by setting the CESU-8 encoder and decoder of the connector. A subsequent call of SetCESU8Decoder
+or SetCESU8Encoder overwrites the respective transformer of the policy.
+*/
+func (c *connAttrs) SetCharsetPolicy(policy CharsetPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._charsetPolicy = policy
+	c._cesu8Decoder, c._cesu8Encoder = policy.transformers()
+}
+
+/*
+EmptyDateAsNull returns NULL for empty dates ('0000-00-00') if true, otherwise:
 
 For data format version 1 the backend does return the NULL indicator for empty date fields.
 For data format version non equal 1 (field type daydate) the NULL indicator is not set and the return value is 0.
@@ -437,6 +1083,138 @@ func (c *connAttrs) SetEmptyDateAsNull(emptyDateAsNull bool) {
 	c._emptyDateAsNull = emptyDateAsNull
 }
 
+/*
+AlphanumPadding returns true if numeric ALPHANUM values are padded with leading zeroes to the field length.
+
+For data format version 1 ALPHANUM values are transferred as VARCHAR values, so that numeric values are
+returned by the backend with leading zeroes already. For data format version non equal 1 numeric values
+are transferred without leading zeroes and are only padded to the field length if AlphanumPadding is set.
+*/
+func (c *connAttrs) AlphanumPadding() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._alphanumPadding
+}
+
+// SetAlphanumPadding sets the AlphanumPadding flag of the connector.
+func (c *connAttrs) SetAlphanumPadding(alphanumPadding bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._alphanumPadding = alphanumPadding
+}
+
+// TimeZonePolicy returns the time zone policy of the connector.
+func (c *connAttrs) TimeZonePolicy() TimeZonePolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._timeZonePolicy
+}
+
+/*
+SetTimeZonePolicy sets the time zone policy of the connector.
+
+The database stores TIMESTAMP values as wall clock without time zone information. The policy defines
+in which location the wall clock is interpreted when scanning and to which location bound time.Time
+values are converted before storing their wall clock.
+*/
+func (c *connAttrs) SetTimeZonePolicy(policy TimeZonePolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._timeZonePolicy = policy
+}
+
+// DecimalRoundingPolicy returns the decimal rounding policy of the connector.
+func (c *connAttrs) DecimalRoundingPolicy() DecimalRoundingPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._decimalRounding
+}
+
+/*
+SetDecimalRoundingPolicy sets the decimal rounding policy of the connector.
+
+The policy defines how parameter values (e.g. float64, big.Rat or Decimal) exceeding the scale of DECIMAL or
+SMALLDECIMAL parameters respectively the precision of floating point DECIMAL parameters are rounded.
+Values exceeding the precision of a parameter before the decimal point are never rounded but ErrDecimalOutOfRange
+is returned.
+*/
+func (c *connAttrs) SetDecimalRoundingPolicy(policy DecimalRoundingPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._decimalRounding = policy
+}
+
+// ColumnNameCase returns the column name case of the connector.
+func (c *connAttrs) ColumnNameCase() ColumnNameCase {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._columnNameCase
+}
+
+/*
+SetColumnNameCase sets the column name case of the connector.
+
+HANA reports unquoted identifiers upper-cased, so that struct or map based mappers matching column names
+case-sensitively might need lower-cased names.
+*/
+func (c *connAttrs) SetColumnNameCase(nameCase ColumnNameCase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._columnNameCase = nameCase
+}
+
+// DuplicateColumnPolicy returns the duplicate column policy of the connector.
+func (c *connAttrs) DuplicateColumnPolicy() DuplicateColumnPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._dupColumnPolicy
+}
+
+/*
+SetDuplicateColumnPolicy sets the duplicate column policy of the connector.
+
+HANA does not enforce unique column names of query results (e.g. 'select a.id, b.id from a join b ...'), whereas
+mappers scanning rows into maps or structs by column name lose or reject duplicate columns.
+*/
+func (c *connAttrs) SetDuplicateColumnPolicy(policy DuplicateColumnPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._dupColumnPolicy = policy
+}
+
+// ScanTypePolicy returns the scan type policy of the connector.
+func (c *connAttrs) ScanTypePolicy() ScanTypePolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._scanTypePolicy
+}
+
+/*
+SetScanTypePolicy sets the scan type policy of the connector.
+
+Reflection based mappers allocate scan destinations by the scan type of a column (see sql.ColumnType.ScanType).
+The policy defines whether null types (default) or pointer types are reported for nullable columns.
+*/
+func (c *connAttrs) SetScanTypePolicy(policy ScanTypePolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._scanTypePolicy = policy
+}
+
+// TimeLocation returns the time location used by time zone policy TzLocation (nil if not set).
+func (c *connAttrs) TimeLocation() *time.Location {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._timeLocation
+}
+
+// SetTimeLocation sets the time location used by time zone policy TzLocation. A nil location defaults to UTC.
+func (c *connAttrs) SetTimeLocation(loc *time.Location) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._timeLocation = loc
+}
+
 // Logger returns the Logger instance of the connector.
 func (c *connAttrs) Logger() *slog.Logger {
 	c.mu.RLock()
@@ -453,3 +1231,419 @@ func (c *connAttrs) SetLogger(logger *slog.Logger) {
 	}
 	c._logger = logger
 }
+
+// LogLevel returns the minimum log level of the connector (nil if not set).
+func (c *connAttrs) LogLevel() slog.Leveler {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._logLevel
+}
+
+/*
+SetLogLevel sets the minimum log level of the connector.
+
+Records below level are discarded independently of the level of the logger handler,
+so that e.g. protocol traces of a connector can be suppressed without changing the
+application logger. A nil level disables the additional filtering.
+*/
+func (c *connAttrs) SetLogLevel(level slog.Leveler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._logLevel = level
+}
+
+// logger returns the connector logger filtered by the minimum log level.
+func (c *connAttrs) logger() *slog.Logger {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return newLevelLogger(c._logger, c._logLevel)
+}
+
+// RetryPolicy returns the connect retry policy of the connector.
+func (c *connAttrs) RetryPolicy() *RetryPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._retryPolicy == nil {
+		return nil
+	}
+	retryPolicy := *c._retryPolicy
+	return &retryPolicy
+}
+
+/*
+SetRetryPolicy sets the connect retry policy of the connector.
+
+The retry policy is applied to the establishment of new database connections
+(TCP connect, protocol handshake and authentication). If retryPolicy is nil
+(default) a failing connect attempt is not retried.
+*/
+func (c *connAttrs) SetRetryPolicy(retryPolicy *RetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if retryPolicy == nil {
+		c._retryPolicy = nil
+		return
+	}
+	rp := *retryPolicy
+	c._retryPolicy = &rp
+}
+
+// BeforeConnect returns the function called before a new database connection is established.
+func (c *connAttrs) BeforeConnect() func(ctx context.Context, connector *Connector) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._beforeConnect
+}
+
+/*
+SetBeforeConnect sets a function which is called before a new database connection is established.
+
+The function is called with a copy of the connector used for this connection attempt and can be used to
+adjust its configuration (e.g. set fresh credentials). As concurrent connection attempts use their own copies,
+changes are effective for this connection attempt only and are not visible to the connector the function is set on.
+In case of a connect retry (see SetRetryPolicy) the function is called for each attempt.
+If the function returns an error the connection attempt is aborted.
+*/
+func (c *connAttrs) SetBeforeConnect(beforeConnect func(ctx context.Context, connector *Connector) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._beforeConnect = beforeConnect
+}
+
+// AfterConnect returns the function called after a new database connection is established.
+func (c *connAttrs) AfterConnect() func(ctx context.Context, conn driver.Conn) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._afterConnect
+}
+
+/*
+SetAfterConnect sets a function which is called after a new database connection is established
+and the session is initialized.
+
+The function can be used to execute session initialization statements via the conn parameter
+implementing the database/sql/driver interfaces (e.g. driver.ExecerContext).
+If the function returns an error the connection is closed and the error is returned.
+*/
+func (c *connAttrs) SetAfterConnect(afterConnect func(ctx context.Context, conn driver.Conn) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._afterConnect = afterConnect
+}
+
+// SlowQueryThreshold returns the slow query threshold of the connector.
+func (c *connAttrs) SlowQueryThreshold() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._slowQueryThreshold
+}
+
+// SlowQueryHook returns the slow query hook of the connector.
+func (c *connAttrs) SlowQueryHook() func(ctx context.Context, sq *SlowQuery) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._slowQueryHook
+}
+
+/*
+SetSlowQueryHook sets a function which is called for each successfully executed query or exec statement
+taking at least threshold to execute.
+
+The hook is called synchronously by the connection executing the statement and should therefore return quickly,
+e.g. by logging the provided information. The duration of a query does not include fetching further rows of the
+resultset. A nil hook disables slow query reporting.
+*/
+func (c *connAttrs) SetSlowQueryHook(threshold time.Duration, hook func(ctx context.Context, sq *SlowQuery)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._slowQueryThreshold = max(threshold, 0)
+	c._slowQueryHook = hook
+}
+
+// SlowQueryExplainPlan returns true if the execution plan of slow queries is provided to the slow query hook.
+func (c *connAttrs) SlowQueryExplainPlan() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._slowQueryExplainPlan
+}
+
+/*
+SetSlowQueryExplainPlan sets whether the execution plan of slow queries is provided to the slow query hook
+(see ExplainPlan).
+
+The plan is retrieved on the connection executing the statement before the hook is called and therefore
+adds additional roundtrips to the execution time of slow statements.
+*/
+func (c *connAttrs) SetSlowQueryExplainPlan(explainPlan bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._slowQueryExplainPlan = explainPlan
+}
+
+// Auditor returns the auditor of the connector.
+func (c *connAttrs) Auditor() Auditor {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._auditor
+}
+
+/*
+SetAuditor sets the auditor of the connector, which is called before each query or exec statement
+is executed. A nil auditor disables auditing.
+*/
+func (c *connAttrs) SetAuditor(auditor Auditor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._auditor = auditor
+}
+
+// ConnEventSubscriber returns the connection event subscriber of the connector.
+func (c *connAttrs) ConnEventSubscriber() ConnEventSubscriber {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._connEventSubscriber
+}
+
+/*
+SetConnEventSubscriber sets the subscriber of the lifecycle events of the connections opened by the connector
+(see ConnEventSubscriber). A nil subscriber disables connection events.
+*/
+func (c *connAttrs) SetConnEventSubscriber(subscriber ConnEventSubscriber) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._connEventSubscriber = subscriber
+}
+
+// WarningHandler returns the warning handler of the connector.
+func (c *connAttrs) WarningHandler() func(ctx context.Context, warning DBError) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c._warningHandler
+}
+
+/*
+SetWarningHandler sets a function which is called by a connection for each warning sent by the database server.
+If no warning handler is set (default) warnings are logged.
+
+The warnings of the last executed query or exec statement are provided by hdbctx.ExecInfo as well.
+*/
+func (c *connAttrs) SetWarningHandler(warningHandler func(ctx context.Context, warning DBError)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._warningHandler = warningHandler
+}
+
+// StatementRetryPolicy returns the statement retry policy of the connector.
+func (c *connAttrs) StatementRetryPolicy() *RetryPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._stmtRetryPolicy == nil {
+		return nil
+	}
+	stmtRetryPolicy := *c._stmtRetryPolicy
+	return &stmtRetryPolicy
+}
+
+/*
+SetStatementRetryPolicy sets the statement retry policy of the connector.
+
+The retry policy is applied to query and exec statements executed outside of explicit transactions, which fail
+because the database server rolled back the statement due to a deadlock, a lock wait timeout or a serialization
+failure (see IsRetryableStatementError). Transactions can be retried as a whole by RunTx.
+Bulk executions are not retried, as the batches sent before the failing one might be committed already.
+If stmtRetryPolicy is nil (default), statements are not retried.
+*/
+func (c *connAttrs) SetStatementRetryPolicy(stmtRetryPolicy *RetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stmtRetryPolicy == nil {
+		c._stmtRetryPolicy = nil
+		return
+	}
+	rp := *stmtRetryPolicy
+	c._stmtRetryPolicy = &rp
+}
+
+// ErrorContext returns the error context configuration of the connector (nil if not enabled).
+func (c *connAttrs) ErrorContext() *ErrorContextConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._errorContext == nil {
+		return nil
+	}
+	errorContext := *c._errorContext
+	return &errorContext
+}
+
+/*
+SetErrorContext sets the error context configuration of the connector.
+
+If set, errors of failed prepare, query and exec statements are returned with context information attached:
+the SQL text of the statement (truncated and redacted as configured), the database session id and the database server
+host, so that failures in multi-host deployments can be localized by the error message. The context information can be
+retrieved by ErrorContextOf.
+If errorContext is nil (default), no context information is attached to errors.
+*/
+func (c *connAttrs) SetErrorContext(errorContext *ErrorContextConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if errorContext == nil {
+		c._errorContext = nil
+		return
+	}
+	ec := *errorContext
+	c._errorContext = &ec
+}
+
+// ResetPolicy returns the session reset policy of the connector (nil if not set).
+func (c *connAttrs) ResetPolicy() *ResetPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._resetPolicy == nil {
+		return nil
+	}
+	resetPolicy := *c._resetPolicy
+	return &resetPolicy
+}
+
+/*
+SetResetPolicy sets the session reset policy of the connector.
+
+The actions of the reset policy are performed when a connection is reused by the database/sql connection pool
+(see driver.SessionResetter), so that pooled connections are guaranteed to be in a clean state.
+If resetPolicy is nil (default), no reset actions are performed.
+*/
+func (c *connAttrs) SetResetPolicy(resetPolicy *ResetPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if resetPolicy == nil {
+		c._resetPolicy = nil
+		return
+	}
+	rp := *resetPolicy
+	c._resetPolicy = &rp
+}
+
+// Middlewares returns the middlewares of the connector.
+func (c *connAttrs) Middlewares() []Middleware {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return slices.Clone(c._middlewares)
+}
+
+/*
+SetMiddlewares sets the middlewares of the connector.
+
+The middlewares wrap the prepare, exec, query and fetch operations of the connections opened by the connector,
+where the first middleware is the outermost one (see Middleware). Middlewares are applied to connections opened
+after setting them.
+*/
+func (c *connAttrs) SetMiddlewares(middlewares ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c._middlewares = slices.Clone(middlewares)
+}
+
+// ResultCache returns the result cache configuration of the connector (nil if not enabled).
+func (c *connAttrs) ResultCache() *ResultCacheConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._resultCache == nil {
+		return nil
+	}
+	resultCache := c._resultCache.cfg
+	return &resultCache
+}
+
+/*
+SetResultCache enables the client side result cache of the connector.
+
+Results of repeated identical select queries (same sql text and arguments) are served from the cache for
+resultCache.TTL (e.g. configuration lookups). The cache is shared by all connections of the connector and is
+invalidated by any other statement and by the end of any transaction executed via the connector - changes
+made by other clients are not detected and might be visible after the TTL only.
+The cache key contains the default schema, the session variables and the locale of the connector, so connectors
+with a different session setup do not share results. Connections changing the session state by a statement
+(e.g. set schema) do not use the cache anymore.
+Queries executed within a transaction, queries executed with session context values (see hdbctx.WithSessionContext),
+queries calling functions with a different result on each execution (e.g. sequence.nextval, current_timestamp),
+queries with lob results and queries with arguments other than the database/sql standard types are not cached.
+If resultCache is nil or resultCache.TTL is less or equal zero (default), the result cache is disabled.
+Setting the result cache discards all cached results.
+*/
+func (c *connAttrs) SetResultCache(resultCache *ResultCacheConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if resultCache == nil || resultCache.TTL <= 0 {
+		c._resultCache = nil
+		return
+	}
+	cfg := *resultCache
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultResultCacheMaxEntries
+	}
+	if cfg.MaxRows <= 0 {
+		cfg.MaxRows = defaultResultCacheMaxRows
+	}
+	c._resultCache = newResultCache(cfg)
+}
+
+// MetadataCacheSize returns the prepared statement metadata cache size of the connector (0 if not enabled).
+func (c *connAttrs) MetadataCacheSize() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._metadataCache == nil {
+		return 0
+	}
+	return c._metadataCache.size
+}
+
+/*
+SetMetadataCacheSize sets the prepared statement metadata cache size of the connector.
+
+If greater zero, the parameter and result metadata of up to size prepared statements (keyed by the sql text,
+least recently used) is shared by all connections of the connector. Each connection still prepares the statements
+on the database server, but the metadata is decoded only if it differs from the cached one (e.g. after a table
+was altered), so that pools with many connections do not pay the decoding costs for the same statements on each
+connection. Cache hits and misses are reported in the driver statistics.
+The default is 0 (no caching). Setting the cache size discards all cached metadata.
+*/
+func (c *connAttrs) SetMetadataCacheSize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if size <= 0 {
+		c._metadataCache = nil
+		return
+	}
+	c._metadataCache = newMetadataCache(size)
+}
+
+// HostResolution returns the host resolution configuration of the connector (nil if not set).
+func (c *connAttrs) HostResolution() *HostResolution {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c._hostResolver == nil {
+		return nil
+	}
+	hostResolution := c._hostResolver.cfg
+	hostResolution.HostAddresses = maps.Clone(hostResolution.HostAddresses)
+	return &hostResolution
+}
+
+/*
+SetHostResolution sets the host resolution configuration of the connector.
+
+The configuration applies to the connector host as well as to hosts returned by the database server
+(e.g. the tenant database host of a system database redirect). Resolved addresses are provided to the
+dialer of the connector in order until a connection can be established.
+If hostResolution is nil (default), host names are resolved by the dialer.
+*/
+func (c *connAttrs) SetHostResolution(hostResolution *HostResolution) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hostResolution == nil {
+		c._hostResolver = nil
+		return
+	}
+	c._hostResolver = newHostResolver(*hostResolution)
+}
@@ -0,0 +1,161 @@
+package driver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"testing"
+
+	"golang.org/x/text/transform"
+)
+
+func testGetTLSConfig(t *testing.T) {
+	attrs := newConnAttrs()
+	staticConfig := &tls.Config{ServerName: "static"} //nolint:gosec
+	attrs.SetTLSConfig(staticConfig)
+
+	getConfig := func(serverName string, err error) func(ctx context.Context) (*tls.Config, error) {
+		return func(ctx context.Context) (*tls.Config, error) {
+			if err != nil || serverName == "" {
+				return nil, err
+			}
+			return &tls.Config{ServerName: serverName}, nil //nolint:gosec
+		}
+	}
+
+	errGet := errors.New("get TLS config")
+
+	tests := []struct {
+		getTLSConfig func(ctx context.Context) (*tls.Config, error)
+		serverName   string
+		err          error
+	}{
+		{nil, "static", nil},
+		{getConfig("", nil), "static", nil},
+		{getConfig("dynamic", nil), "dynamic", nil},
+		{getConfig("", errGet), "", errGet},
+	}
+	for i, test := range tests {
+		attrs.SetGetTLSConfig(test.getTLSConfig)
+		tlsConfig, err := attrs.clone().tlsConfig(context.Background())
+		if !errors.Is(err, test.err) {
+			t.Fatalf("test %d: error %v - expected %v", i, err, test.err)
+		}
+		if err == nil && tlsConfig.ServerName != test.serverName {
+			t.Fatalf("test %d: server name %s - expected %s", i, tlsConfig.ServerName, test.serverName)
+		}
+	}
+}
+
+func testTLSOverrides(t *testing.T) {
+	attrs := newConnAttrs()
+	attrs.SetTLSServerName("server")
+	attrs.SetTLSVerifyPeerCertificate(VerifyPinnedCertificate(sha256.Sum256([]byte("cert"))))
+
+	// no TLS connection - no overrides
+	if tlsConfig, _ := attrs.clone().tlsConfig(context.Background()); tlsConfig != nil {
+		t.Fatal("unexpected TLS configuration")
+	}
+
+	attrs.SetTLSConfig(&tls.Config{ServerName: "balancer"}) //nolint:gosec
+	tlsConfig, err := attrs.clone().tlsConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ServerName != "server" {
+		t.Fatalf("server name %s - expected %s", tlsConfig.ServerName, "server")
+	}
+	if !tlsConfig.InsecureSkipVerify {
+		t.Fatal("insecure skip verify expected")
+	}
+	if err := tlsConfig.VerifyPeerCertificate([][]byte{[]byte("cert")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := tlsConfig.VerifyPeerCertificate([][]byte{[]byte("other")}, nil); !errors.Is(err, ErrCertificateNotPinned) {
+		t.Fatalf("error %v - expected %v", err, ErrCertificateNotPinned)
+	}
+	// static configuration must not be changed
+	if attrs.TLSConfig().ServerName != "balancer" {
+		t.Fatal("static TLS configuration changed")
+	}
+}
+
+func testTLSClientSessionCache(t *testing.T) {
+	attrs := newConnAttrs()
+	cache := tls.NewLRUClientSessionCache(0)
+	attrs.SetTLSClientSessionCache(cache)
+	attrs.SetTLSConfig(&tls.Config{}) //nolint:gosec
+
+	// cache needs to be shared by all connections
+	for i := 0; i < 2; i++ {
+		tlsConfig, err := attrs.clone().tlsConfig(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tlsConfig.ClientSessionCache != cache {
+			t.Fatal("TLS client session cache not set")
+		}
+	}
+
+	// TLS configuration cache takes precedence
+	configCache := tls.NewLRUClientSessionCache(0)
+	attrs.SetTLSConfig(&tls.Config{ClientSessionCache: configCache}) //nolint:gosec
+	tlsConfig, err := attrs.clone().tlsConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ClientSessionCache != configCache {
+		t.Fatal("TLS configuration client session cache overwritten")
+	}
+}
+
+func testCharsetPolicy(t *testing.T) {
+	invalid := []byte("a\x80b")
+
+	tests := []struct {
+		policy CharsetPolicy
+		result string
+		err    bool
+	}{
+		{CharsetError, "", true},
+		{CharsetReplace, "a\uFFFDb", false},
+		{CharsetPassThrough, "a\x80b", false},
+	}
+
+	attrs := newConnAttrs()
+	for _, test := range tests {
+		attrs.SetCharsetPolicy(test.policy)
+		attrs := attrs.clone()
+		if attrs.CharsetPolicy() != test.policy {
+			t.Fatalf("policy %s - expected %s", attrs.CharsetPolicy(), test.policy)
+		}
+		for _, tr := range []transform.Transformer{attrs.CESU8Decoder()(), attrs.CESU8Encoder()()} {
+			b, _, err := transform.Bytes(tr, invalid)
+			if (err != nil) != test.err {
+				t.Fatalf("policy %s: error %v - expected error %t", test.policy, err, test.err)
+			}
+			if err == nil && string(b) != test.result {
+				t.Fatalf("policy %s: result %q - expected %q", test.policy, b, test.result)
+			}
+		}
+	}
+}
+
+func TestConnAttrs(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"getTLSConfig", testGetTLSConfig},
+		{"tlsOverrides", testTLSOverrides},
+		{"tlsClientSessionCache", testTLSClientSessionCache},
+		{"charsetPolicy", testCharsetPolicy},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -11,15 +11,17 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/SAP/go-hdb/driver/dial"
+	"github.com/SAP/go-hdb/driver/hdbctx"
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 	"github.com/SAP/go-hdb/driver/internal/protocol/auth"
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
@@ -31,6 +33,18 @@ import (
 // ErrUnsupportedIsolationLevel is the error raised if a transaction is started with a not supported isolation level.
 var ErrUnsupportedIsolationLevel = errors.New("unsupported isolation level")
 
+// UnsupportedIsolationLevelError is returned if a transaction is started with an isolation level not supported
+// by the database server. It wraps ErrUnsupportedIsolationLevel.
+type UnsupportedIsolationLevelError struct {
+	Level sql.IsolationLevel
+}
+
+func (e *UnsupportedIsolationLevelError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnsupportedIsolationLevel, e.Level)
+}
+
+func (e *UnsupportedIsolationLevelError) Unwrap() error { return ErrUnsupportedIsolationLevel }
+
 // ErrNestedTransaction is the error raised if a transaction is created within a transaction as this is not supported by hdb.
 var ErrNestedTransaction = errors.New("nested transactions are not supported")
 
@@ -50,6 +64,7 @@ const (
 	setAccessModeReadOnly           = "set transaction read only"
 	setAccessModeReadWrite          = "set transaction read write"
 	setDefaultSchema                = "set schema"
+	cancelSession                   = "alter system cancel session"
 )
 
 var (
@@ -61,31 +76,44 @@ var (
 
 // dbConn wraps the database tcp connection. It sets timeouts and handles driver ErrBadConn behavior.
 type dbConn struct {
-	metrics   *metrics
-	conn      net.Conn
-	timeout   time.Duration
-	logger    *slog.Logger
-	lastRead  time.Time
-	lastWrite time.Time
+	metrics      *metrics
+	conn         net.Conn
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+	logger       *slog.Logger
+	lastRead     time.Time
+	lastWrite    time.Time
+	numBytes     int64 // number of bytes read and written
+	writing      bool  // true while writing a request message, false while reading the reply
+	reading      bool
+	fault        faultInjector // nil if no faults are injected
+	numMsg       int           // number of request messages (fault injection)
 }
 
-func (c *dbConn) deadline() (deadline time.Time) {
-	if c.timeout == 0 {
+func deadline(timeout time.Duration) (deadline time.Time) {
+	if timeout == 0 {
 		return
 	}
-	return time.Now().Add(c.timeout)
+	return time.Now().Add(timeout)
 }
 
 func (c *dbConn) close() error { return c.conn.Close() }
 
 // Read implements the io.Reader interface.
 func (c *dbConn) Read(b []byte) (int, error) {
-	// set timeout
-	if err := c.conn.SetReadDeadline(c.deadline()); err != nil {
-		return 0, fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+	// set timeout once per roundtrip (first read after a request message was written)
+	if !c.reading {
+		if err := c.conn.SetReadDeadline(deadline(c.readTimeout)); err != nil {
+			return 0, fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+		}
+		c.reading, c.writing = true, false
+		if c.fault != nil {
+			time.Sleep(c.fault.replyDelay())
+		}
 	}
 	c.lastRead = time.Now()
 	n, err := c.conn.Read(b)
+	c.numBytes += int64(n)
 	c.metrics.msgCh <- timeMsg{idx: timeRead, d: time.Since(c.lastRead)}
 	c.metrics.msgCh <- counterMsg{idx: counterBytesRead, v: uint64(n)}
 	if err != nil {
@@ -98,12 +126,23 @@ func (c *dbConn) Read(b []byte) (int, error) {
 
 // Write implements the io.Writer interface.
 func (c *dbConn) Write(b []byte) (int, error) {
-	// set timeout
-	if err := c.conn.SetWriteDeadline(c.deadline()); err != nil {
-		return 0, fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+	// set timeout once per request message
+	if !c.writing {
+		if err := c.conn.SetWriteDeadline(deadline(c.writeTimeout)); err != nil {
+			return 0, fmt.Errorf("%w: %w", driver.ErrBadConn, err)
+		}
+		c.writing, c.reading = true, false
+		c.metrics.msgCh <- counterMsg{idx: counterRoundtrips, v: 1}
+		if c.fault != nil {
+			c.numMsg++
+			if c.fault.drop(c.numMsg) {
+				c.conn.Close() // subsequent write fails like on a connection dropped by the network
+			}
+		}
 	}
 	c.lastWrite = time.Now()
 	n, err := c.conn.Write(b)
+	c.numBytes += int64(n)
 	c.metrics.msgCh <- timeMsg{idx: timeWrite, d: time.Since(c.lastWrite)}
 	c.metrics.msgCh <- counterMsg{idx: counterBytesWritten, v: uint64(n)}
 	if err != nil {
@@ -143,9 +182,24 @@ var errCancelled = fmt.Errorf("%w: %w", driver.ErrBadConn, errors.New("db call c
 type Conn interface {
 	HDBVersion() *Version
 	DatabaseName() string
+	ConnectionID() int
+	ConnectOptions() map[string]any
+	ParameterTypes(ctx context.Context, query string) ([]*ParameterType, error)
+	ColumnOrigins(ctx context.Context, query string) ([]*ColumnOrigin, error)
 	DBConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error)
 }
 
+// rawConn calls fn with the driver connection of sqlConn.
+func rawConn(sqlConn *sql.Conn, fn func(c *conn) error) error {
+	return sqlConn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*conn)
+		if !ok {
+			return fmt.Errorf("invalid driver connection type %T", driverConn)
+		}
+		return fn(c)
+	})
+}
+
 var stdConnTracker = &connTracker{}
 
 type connTracker struct {
@@ -170,6 +224,9 @@ func (t *connTracker) remove() {
 	}
 }
 
+// release removes a connection replaced by a new one (reconnect) without closing the call db.
+func (t *connTracker) release() { t.mu.Lock(); t.numConn--; t.mu.Unlock() }
+
 func (t *connTracker) callDB() *sql.DB {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -189,14 +246,35 @@ type conn struct {
 
 	dbConn *dbConn
 
-	wg        sync.WaitGroup // wait for concurrent db calls when closing connections
-	inTx      bool           // in transaction
-	lastError error          // last error
-	sessionID int64
+	wg             sync.WaitGroup // wait for concurrent db calls when closing connections
+	inTx           bool           // in transaction
+	sessionChanged bool           // session state changed by a statement (e.g. set schema)
+	lastError      error          // last error
+	warnings       []DBError      // warnings of the last query or exec statement
+	txState        TxState        // transaction state reported by the database server
+	sessionID      int64
+	host           string    // database server host
+	numStmt        int       // number of executed statements
+	opened         time.Time // time the connection was opened by a connector (zero for internal connections)
+
+	registry   *connRegistry // open connections of the connector (nil for internal connections)
+	isShutdown atomic.Bool   // connector shutdown - do not reuse connection
+
+	cancelSession    func(ctx context.Context) error          // server side statement cancellation (nil if not enabled)
+	reconnectSession func(ctx context.Context) (*conn, error) // reconnect of lost sessions (nil if not enabled)
+	reconnects       int                                      // number of reconnects
+
+	stmtCache *stmtCache // prepared statement cache (nil if not enabled)
+	execer    Execer     // direct statement execution wrapped by the connector middlewares
 
 	serverOptions *p.ConnectOptions
 	hdbVersion    *Version
 
+	timeLocation *time.Location // location of timestamp values (nil: UTC)
+
+	sessCtx map[string]string // session context values set by statement contexts (see hdbctx.WithSessionContext)
+
+	enc *encoding.Encoder
 	dec *encoding.Decoder
 	pr  *p.Reader
 	pw  *p.Writer
@@ -212,10 +290,37 @@ func isAuthError(err error) bool {
 	if !errors.As(err, &hdbErrors) {
 		return false
 	}
-	return hdbErrors.Code() == p.HdbErrAuthenticationFailed
+	return ErrorCode(hdbErrors.Code()) == HdbErrAuthenticationFailed
 }
 
 func connect(ctx context.Context, host string, metrics *metrics, connAttrs *connAttrs, authAttrs *authAttrs) (driver.Conn, error) {
+	dc, err := authSession(ctx, host, metrics, connAttrs, authAttrs)
+	if err != nil {
+		return nil, err
+	}
+	c := dc.(*conn)
+	if connAttrs._serverCancel {
+		connectionID := c.ConnectionID()
+		c.cancelSession = func(ctx context.Context) error {
+			return cancelServerSession(ctx, host, metrics, connAttrs, authAttrs, connectionID)
+		}
+	}
+	return c, nil
+}
+
+// cancelServerSession cancels the statement running in session connectionID via a short-lived control connection.
+func cancelServerSession(ctx context.Context, host string, metrics *metrics, connAttrs *connAttrs, authAttrs *authAttrs, connectionID int) error {
+	dc, err := authSession(ctx, host, metrics, connAttrs, authAttrs)
+	if err != nil {
+		return err
+	}
+	c := dc.(*conn)
+	defer c.Close()
+	_, err = c.execDirect(ctx, fmt.Sprintf("%s '%d'", cancelSession, connectionID), true)
+	return err
+}
+
+func authSession(ctx context.Context, host string, metrics *metrics, connAttrs *connAttrs, authAttrs *authAttrs) (driver.Conn, error) {
 	// can we connect via cookie?
 	if auth := authAttrs.cookieAuth(); auth != nil {
 		conn, err := newSession(ctx, host, metrics, connAttrs, auth)
@@ -243,6 +348,7 @@ func connect(ctx context.Context, host string, metrics *metrics, connAttrs *conn
 			return nil, err
 		}
 
+		connAttrs.logger().LogAttrs(ctx, slog.LevelDebug, "authentication failed - refresh credentials", slog.String("host", host), slog.String("error", err.Error()))
 		if err := authAttrs.refresh(); err != nil {
 			return nil, err
 		}
@@ -282,7 +388,12 @@ func SetSQLTrace(on bool) { sqlTrace.Store(on) }
 var connNo atomic.Uint64
 
 func newConn(ctx context.Context, host string, metrics *metrics, attrs *connAttrs) (*conn, error) {
-	netConn, err := attrs._dialer.DialContext(ctx, host, dial.DialerOptions{Timeout: attrs._timeout, TCPKeepAlive: attrs._tcpKeepAlive})
+	tlsConfig, err := attrs.tlsConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	netConn, err := attrs.dial(ctx, host)
 	if err != nil {
 		return nil, err
 	}
@@ -290,13 +401,13 @@ func newConn(ctx context.Context, host string, metrics *metrics, attrs *connAttr
 	metrics.lazyInit()
 
 	// is TLS connection requested?
-	if attrs._tlsConfig != nil {
-		netConn = tls.Client(netConn, attrs._tlsConfig)
+	if tlsConfig != nil {
+		netConn = tls.Client(netConn, tlsConfig)
 	}
 
-	logger := attrs._logger.With(slog.Uint64("conn", connNo.Add(1)))
+	logger := attrs.logger().With(slog.Uint64("conn", connNo.Add(1)), slog.String("host", host))
 
-	dbConn := &dbConn{metrics: metrics, conn: netConn, timeout: attrs._timeout, logger: logger}
+	dbConn := &dbConn{metrics: metrics, conn: netConn, readTimeout: attrs._readTimeout, writeTimeout: attrs._writeTimeout, logger: logger, fault: attrs._fault}
 	// buffer connection
 	rw := bufio.NewReadWriter(bufio.NewReaderSize(dbConn, attrs._bufferSize), bufio.NewWriterSize(dbConn, attrs._bufferSize))
 
@@ -311,11 +422,24 @@ func newConn(ctx context.Context, host string, metrics *metrics, attrs *connAttr
 		dbConn:    dbConn,
 		sqlTrace:  sqlTrace.Load(),
 		logger:    logger,
+		enc:       enc,
 		dec:       dec,
 		pw:        p.NewWriter(rw.Writer, enc, protTrace, logger, attrs._cesu8Encoder, attrs._sessionVariables), // write upstream
 		pr:        p.NewDBReader(dec, protTrace, logger),                                                        // read downstream
 		sessionID: defaultSessionID,
+		host:      host,
+		txState:   TxState{DDLAutocommit: true},
+		stmtCache: newStmtCache(attrs._stmtCacheSize),
+	}
+
+	c.pw.SetHoldCursorsOverCommit(attrs._holdCursors)
+	c.pr.SetDecodeParallelism(attrs._decodeParallelism)
+	c.pr.SetWarningHandler(c.handleWarning)
+	c.pr.SetTransactionFlagsHandler(c.handleTransactionFlags)
+	if attrs._fault != nil {
+		c.pr.SetCorruptPart(attrs._fault.corruptPart)
 	}
+	c.execer = chainMiddlewares(attrs._middlewares, attrs._resultCache.execer(c, connExecer{c: c}))
 
 	if err := c.pw.WriteProlog(ctx); err != nil {
 		dbConn.close()
@@ -333,13 +457,18 @@ func newConn(ctx context.Context, host string, metrics *metrics, attrs *connAttr
 	return c, nil
 }
 
-func fetchRedirectHost(ctx context.Context, host, databaseName string, metrics *metrics, attrs *connAttrs) (string, error) {
+// fetchDBConnectInfo requests the connect information of databaseName via an unauthenticated connection to host.
+func fetchDBConnectInfo(ctx context.Context, host, databaseName string, metrics *metrics, attrs *connAttrs) (*DBConnectInfo, error) {
 	c, err := newConn(ctx, host, metrics, attrs)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer c.Close()
-	dbi, err := c.dbConnectInfo(ctx, databaseName)
+	return c.dbConnectInfo(ctx, databaseName)
+}
+
+func fetchRedirectHost(ctx context.Context, host, databaseName string, metrics *metrics, attrs *connAttrs) (string, error) {
+	dbi, err := fetchDBConnectInfo(ctx, host, databaseName, metrics, attrs)
 	if err != nil {
 		return "", err
 	}
@@ -369,15 +498,28 @@ func (c *conn) initSession(ctx context.Context, attrs *connAttrs, authHnd *p.Aut
 		return fmt.Errorf("invalid session id %d", c.sessionID)
 	}
 
+	// add connection identifying attributes
+	c.logger = c.logger.With(slog.Int("connectionID", c.ConnectionID()))
+	c.dbConn.logger = c.logger
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "session opened", slog.String("auth method", authHnd.Selected().Typ()))
+
 	c.hdbVersion = parseVersion(c.versionString())
 	c.dec.SetAlphanumDfv1(c.serverOptions.DataFormatVersion2OrZero() == p.DfvLevel1)
+	c.dec.SetAlphanumPadding(attrs._alphanumPadding)
 	c.dec.SetEmptyDateAsNull(attrs._emptyDateAsNull)
+	c.enc.SetRoundingMode(attrs._decimalRounding.mode())
 
 	if attrs._defaultSchema != "" {
 		if _, err := c.ExecContext(ctx, strings.Join([]string{setDefaultSchema, Identifier(attrs._defaultSchema).String()}, " "), nil); err != nil {
 			return err
 		}
 	}
+
+	if c.timeLocation, err = c.fetchTimeLocation(ctx, attrs); err != nil {
+		return err
+	}
+	c.enc.SetTimeLocation(c.timeLocation)
+	c.dec.SetTimeLocation(c.timeLocation)
 	return nil
 }
 
@@ -385,31 +527,89 @@ func (c *conn) versionString() (version string) { return c.serverOptions.FullVer
 
 // ResetSession implements the driver.SessionResetter interface.
 func (c *conn) ResetSession(ctx context.Context) error {
-	if c.isBad() {
+	if reason := c.invalidReason(); reason != ConnReasonNone { // would be discarded anyway - do not reset
+		c.connEvent(ctx, ConnValidationFailed, reason, time.Since(c.opened), c.badError())
 		return driver.ErrBadConn
 	}
 
 	c.lastError = nil
 
-	if c.attrs._pingInterval == 0 || c.dbConn.lastRead.IsZero() || time.Since(c.dbConn.lastRead) < c.attrs._pingInterval {
-		return nil
+	if c.attrs._pingInterval != 0 && !c.dbConn.lastRead.IsZero() && time.Since(c.dbConn.lastRead) >= c.attrs._pingInterval {
+		if err := c.ping(ctx); err != nil {
+			c.connEvent(ctx, ConnValidationFailed, ConnReasonPingFailed, time.Since(c.opened), err)
+			return driver.ErrBadConn
+		}
 	}
 
-	if _, err := c.queryDirect(ctx, dummyQuery, !c.inTx); err != nil {
-		return driver.ErrBadConn
+	if resetPolicy := c.attrs._resetPolicy; resetPolicy != nil {
+		start := time.Now()
+		if err := c.reset(ctx, resetPolicy); err != nil {
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "session reset failed", slog.String("error", err.Error()))
+			c.connEvent(ctx, ConnReset, ConnReasonResetFailed, time.Since(start), err)
+			return driver.ErrBadConn
+		}
+		c.connEvent(ctx, ConnReset, ConnReasonNone, time.Since(start), nil)
 	}
 	return nil
 }
 
-func (c *conn) isBad() bool { return errors.Is(c.lastError, driver.ErrBadConn) }
+// cancel marks the connection as bad after a cancelled db call and - if enabled - cancels
+// the running statement on the database server asynchronously.
+func (c *conn) cancel() {
+	c.lastError = errCancelled
+	if c.cancelSession == nil {
+		return
+	}
+	c.wg.Add(1) // let Close wait for the cancellation
+	go func() {
+		defer c.wg.Done()
+		ctx := context.Background()
+		if c.attrs._timeout != 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, c.attrs._timeout)
+			defer cancel()
+		}
+		if err := c.cancelSession(ctx); err != nil {
+			c.logger.LogAttrs(ctx, slog.LevelError, "server cancel error", slog.String("error", err.Error()))
+		}
+	}()
+}
+
+func (c *conn) isBad() bool {
+	var connLostError *ConnLostError
+	return errors.Is(c.lastError, driver.ErrBadConn) || errors.As(c.lastError, &connLostError)
+}
+
+// isIdleTimeout returns true if the connection was idle for at least the idle session timeout, false otherwise.
+func (c *conn) isIdleTimeout() bool {
+	idleTimeout := c.attrs._idleTimeout
+	return idleTimeout != 0 && !c.dbConn.lastRead.IsZero() && time.Since(c.dbConn.lastRead) >= idleTimeout
+}
+
+// isRetired returns true if the connection reached the maximum number of executed statements or transferred bytes, false otherwise.
+func (c *conn) isRetired() bool {
+	if maxStatements := c.attrs._maxStatements; maxStatements != 0 && c.numStmt >= maxStatements {
+		return true
+	}
+	if maxBytes := c.attrs._maxBytes; maxBytes != 0 && c.dbConn.numBytes >= maxBytes {
+		return true
+	}
+	return false
+}
 
 // IsValid implements the driver.Validator interface.
-func (c *conn) IsValid() bool { return !c.isBad() }
+func (c *conn) IsValid() bool {
+	if reason := c.invalidReason(); reason != ConnReasonNone {
+		c.connEvent(context.Background(), ConnValidationFailed, reason, time.Since(c.opened), c.badError())
+		return false
+	}
+	return true
+}
 
 // Ping implements the driver.Pinger interface.
 func (c *conn) Ping(ctx context.Context) error {
 	if c.sqlTrace {
-		defer c.logSQLTrace(ctx, time.Now(), dummyQuery, nil)
+		defer c.logSQLTrace(ctx, time.Now(), c.pingCommand(), nil)
 	}
 
 	done := make(chan struct{})
@@ -417,22 +617,27 @@ func (c *conn) Ping(ctx context.Context) error {
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		_, err = c.queryDirect(ctx, dummyQuery, !c.inTx)
+		err = c.ping(ctx)
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return ctx.Err()
+		c.cancel()
+		return classifyError(ctx.Err())
 	case <-done:
-		c.lastError = err
-		return err
+		c.setLastError(err)
+		return classifyError(err)
 	}
 }
 
 // PrepareContext implements the driver.ConnPrepareContext interface.
 func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.execer.Prepare(ctx, query)
+}
+
+func (c *conn) prepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query = readOnlyQuery(ctx, query)
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), query, nil)
 	}
@@ -444,9 +649,10 @@ func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, e
 	go func() {
 		defer c.wg.Done()
 		var pr *prepareResult
+		var cacheEntry *stmtCacheEntry
 
-		if pr, err = c.prepare(ctx, query); err == nil {
-			stmt = newStmt(c, query, pr)
+		if pr, cacheEntry, err = c.prepareCached(ctx, query); err == nil {
+			stmt = newStmt(c, query, pr, cacheEntry)
 		}
 
 		close(done)
@@ -454,11 +660,11 @@ func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, e
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, c.stmtError(query, ctx.Err())
 	case <-done:
-		c.lastError = err
-		return stmt, err
+		c.setLastError(err)
+		return stmt, c.stmtError(query, err)
 	}
 }
 
@@ -466,36 +672,57 @@ func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, e
 func (c *conn) Close() error {
 	c.wg.Wait()                                        // wait until concurrent db calls are finalized
 	c.metrics.msgCh <- gaugeMsg{idx: gaugeConn, v: -1} // decrement open connections.
-	// do not disconnect if isBad or invalid sessionID
-	if !c.isBad() && c.sessionID != defaultSessionID {
+	// event attributes before disconnect
+	reason, lifetime, badErr := c.invalidReason(), time.Since(c.opened), c.badError()
+	if reason == ConnReasonNone {
+		reason = ConnReasonClosed
+	}
+	// do not disconnect if isBad, idle session might be closed by server already or invalid sessionID
+	if !c.isBad() && !c.isIdleTimeout() && c.sessionID != defaultSessionID {
 		c.disconnect(context.Background()) //nolint:errcheck
 	}
 	err := c.dbConn.close()
 	stdConnTracker.remove()
+	c.logger.LogAttrs(context.Background(), slog.LevelDebug, "session closed", slog.Bool("bad", c.isBad()), slog.Int("statements", c.numStmt))
+	c.connEvent(context.Background(), ConnClosed, reason, lifetime, badErr)
+	if c.registry != nil {
+		c.registry.remove(c)
+	}
 	return err
 }
 
+/*
+setIsolationLevelQuery returns the statement setting the transaction isolation level level.
+
+HANA supports the isolation levels read committed (default), repeatable read and serializable. As repeatable read
+is implemented as transaction level snapshot isolation by HANA, snapshot isolation is mapped to repeatable read.
+*/
+func setIsolationLevelQuery(level sql.IsolationLevel) (string, error) {
+	switch level {
+	case sql.LevelDefault, sql.LevelReadCommitted:
+		return setIsolationLevelReadCommitted, nil
+	case sql.LevelRepeatableRead, sql.LevelSnapshot:
+		return setIsolationLevelRepeatableRead, nil
+	case sql.LevelSerializable:
+		return setIsolationLevelSerializable, nil
+	default: // read uncommitted, write committed, linearizable
+		return "", &UnsupportedIsolationLevelError{Level: level}
+	}
+}
+
 // BeginTx implements the driver.ConnBeginTx interface.
 func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
 	if c.inTx {
 		return nil, ErrNestedTransaction
 	}
 
-	var isolationLevelQuery string
-	switch sql.IsolationLevel(opts.Isolation) {
-	case sql.LevelDefault, sql.LevelReadCommitted:
-		isolationLevelQuery = setIsolationLevelReadCommitted
-	case sql.LevelRepeatableRead:
-		isolationLevelQuery = setIsolationLevelRepeatableRead
-	case sql.LevelSerializable:
-		isolationLevelQuery = setIsolationLevelSerializable
-	default:
-		return nil, ErrUnsupportedIsolationLevel
+	isolationLevelQuery, err := setIsolationLevelQuery(sql.IsolationLevel(opts.Isolation))
+	if err != nil {
+		return nil, err
 	}
 
 	done := make(chan struct{})
 	var tx driver.Tx
-	var err error
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
@@ -520,11 +747,11 @@ func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, classifyError(ctx.Err())
 	case <-done:
-		c.lastError = err
-		return tx, err
+		c.setLastError(err)
+		return tx, classifyError(err)
 	}
 }
 
@@ -533,14 +760,24 @@ var callStmt = regexp.MustCompile(`(?i)^\s*call\s+.*`) // sql statement beginnin
 // QueryContext implements the driver.QueryerContext interface.
 func (c *conn) QueryContext(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
 	if callStmt.MatchString(query) {
-		return nil, fmt.Errorf("invalid procedure call %s - please use Exec instead", query)
+		return nil, driver.ErrSkip // procedure call result sets need prepared statement
 	}
 	if len(nvargs) != 0 {
 		return nil, driver.ErrSkip // fast path not possible (prepare needed)
 	}
+	return c.execer.Query(ctx, query, nvargs)
+}
+
+func (c *conn) queryContext(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	query = readOnlyQuery(ctx, query)
+	if err := c.audit(ctx, query, nil, nvargs); err != nil {
+		return nil, err
+	}
+	c.warnings = nil
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), query, nvargs)
 	}
+	start := time.Now()
 
 	done := make(chan struct{})
 	var rows driver.Rows
@@ -548,17 +785,27 @@ func (c *conn) QueryContext(ctx context.Context, query string, nvargs []driver.N
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		rows, err = c.queryDirect(ctx, query, !c.inTx)
+		err = c.retryStatement(ctx, nil, 0, func() (err error) {
+			rows, err = c.queryDirect(ctx, query, !c.inTx)
+			return c.replay(ctx, query, err, func() (err error) { rows, err = c.queryDirect(ctx, query, !c.inTx); return })
+		})
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, c.stmtError(query, ctx.Err())
 	case <-done:
-		c.lastError = err
-		return rows, err
+		c.setLastError(err)
+		c.setExecInfo(ctx)
+		if err == nil {
+			setResultSize(ctx, rows)
+		}
+		if err == nil && c.attrs._slowQueryHook != nil {
+			c.checkSlowQuery(ctx, start, query, func() int64 { return queryRows(rows) })
+		}
+		return rows, c.stmtError(query, err)
 	}
 }
 
@@ -567,9 +814,18 @@ func (c *conn) ExecContext(ctx context.Context, query string, nvargs []driver.Na
 	if len(nvargs) != 0 {
 		return nil, driver.ErrSkip // fast path not possible (prepare needed)
 	}
+	return c.execer.Exec(ctx, query, nvargs)
+}
+
+func (c *conn) execContext(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	if err := c.audit(ctx, query, nil, nvargs); err != nil {
+		return nil, err
+	}
+	c.warnings = nil
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), query, nvargs)
 	}
+	start := time.Now()
 
 	done := make(chan struct{})
 	var result driver.Result
@@ -578,17 +834,24 @@ func (c *conn) ExecContext(ctx context.Context, query string, nvargs []driver.Na
 	go func() {
 		defer c.wg.Done()
 		// handle procesure call without parameters here as well
-		result, err = c.execDirect(ctx, query, !c.inTx)
+		err = c.retryStatement(ctx, nil, 0, func() (err error) {
+			result, err = c.execDirect(ctx, query, !c.inTx)
+			return c.replay(ctx, query, err, func() (err error) { result, err = c.execDirect(ctx, query, !c.inTx); return })
+		})
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, c.stmtError(query, ctx.Err())
 	case <-done:
-		c.lastError = err
-		return result, err
+		c.setLastError(err)
+		c.setExecInfo(ctx)
+		if err == nil && c.attrs._slowQueryHook != nil {
+			c.checkSlowQuery(ctx, start, query, func() int64 { return execRows(result) })
+		}
+		return result, c.stmtError(query, err)
 	}
 }
 
@@ -610,6 +873,35 @@ func (c *conn) HDBVersion() *Version { return c.hdbVersion }
 // DatabaseName implements the Conn interface.
 func (c *conn) DatabaseName() string { return c.serverOptions.DatabaseNameOrZero() }
 
+// ConnectionID implements the Conn interface.
+func (c *conn) ConnectionID() int { return c.serverOptions.ConnectionIDOrZero() }
+
+// ConnectOptions implements the Conn interface.
+// It returns a snapshot of the connect options negotiated with the database server.
+func (c *conn) ConnectOptions() map[string]any { return c.serverOptions.Snapshot() }
+
+// ParameterTypes implements the Conn interface.
+// It prepares query and returns the parameter types of the prepared statement.
+func (c *conn) ParameterTypes(ctx context.Context, query string) ([]*ParameterType, error) {
+	ds, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	types := ds.(*stmt).ParameterTypes()
+	return types, ds.Close()
+}
+
+// ColumnOrigins implements the Conn interface.
+// It prepares query and returns the origins of the result columns of the prepared statement.
+func (c *conn) ColumnOrigins(ctx context.Context, query string) ([]*ColumnOrigin, error) {
+	ds, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	origins := ds.(*stmt).ColumnOrigins()
+	return origins, ds.Close()
+}
+
 // DBConnectInfo implements the Conn interface.
 func (c *conn) DBConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error) {
 	done := make(chan struct{})
@@ -624,11 +916,11 @@ func (c *conn) DBConnectInfo(ctx context.Context, databaseName string) (*DBConne
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, classifyError(ctx.Err())
 	case <-done:
-		c.lastError = err
-		return ci, err
+		c.setLastError(err)
+		return ci, classifyError(err)
 	}
 }
 
@@ -637,7 +929,7 @@ func (c *conn) logSQLTrace(ctx context.Context, start time.Time, query string, n
 	l := len(nvargs)
 
 	if l == 0 {
-		c.logger.LogAttrs(ctx, slog.LevelInfo, "SQL", slog.String("query", query), slog.Int64("ms", time.Since(start).Milliseconds()))
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "SQL", traceAttrs(ctx, slog.String("query", query), slog.Int64("ms", time.Since(start).Milliseconds()))...)
 		return
 	}
 
@@ -652,13 +944,21 @@ func (c *conn) logSQLTrace(ctx context.Context, start time.Time, query string, n
 	if l > maxArg {
 		attrs = append(attrs, slog.Int("numArgSkip", l-maxArg))
 	}
-	c.logger.LogAttrs(ctx, slog.LevelInfo, "SQL", slog.String("query", query), slog.Int64("ms", time.Since(start).Milliseconds()), slog.Any("arg", slog.GroupValue(attrs...)))
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "SQL", traceAttrs(ctx, slog.String("query", query), slog.Int64("ms", time.Since(start).Milliseconds()), slog.Any("arg", slog.GroupValue(attrs...)))...)
 }
 
 func (c *conn) addTimeValue(start time.Time, k int) {
 	c.metrics.msgCh <- timeMsg{idx: k, d: time.Since(start)}
 }
 
+// setLastError sets the last error of the connection and counts execution errors.
+func (c *conn) setLastError(err error) {
+	c.lastError = err
+	if err != nil {
+		c.metrics.msgCh <- counterMsg{idx: counterErrors, v: 1}
+	}
+}
+
 func (c *conn) addSQLTimeValue(start time.Time, k int) {
 	c.metrics.msgCh <- sqlTimeMsg{idx: k, d: time.Since(start)}
 }
@@ -697,15 +997,42 @@ func (t *tx) close(rollback bool) error {
 	t.closed = true
 
 	c.inTx = false
+	// invalidate after commit respectively rollback, so that concurrent queries cannot cache the state before
+	defer c.attrs._resultCache.invalidate()
 
 	if rollback {
-		return c.rollback(context.Background())
+		return classifyError(c.rollback(context.Background()))
 	}
-	return c.commit(context.Background())
+	return classifyError(c.commit(context.Background()))
 }
 
 const defaultSessionID = -1
 
+/*
+ping checks the database connection.
+In case the database server supports DB connect info requests for authenticated sessions,
+the check is done on protocol level to avoid the overhead of a sql statement execution.
+*/
+// pingDBConnectInfo is the sql trace text of a ping sent as protocol level DB connect info request.
+const pingDBConnectInfo = "<db connect info request>"
+
+// pingCommand returns the sql trace text of what ping sends to the database server.
+func (c *conn) pingCommand() string {
+	if c.hdbVersion.hasFeature(hdbfDBConnectInfo) {
+		return pingDBConnectInfo
+	}
+	return dummyQuery
+}
+
+func (c *conn) ping(ctx context.Context) error {
+	if c.hdbVersion.hasFeature(hdbfDBConnectInfo) {
+		_, err := c.dbConnectInfo(ctx, c.DatabaseName())
+		return err
+	}
+	_, err := c.queryDirect(ctx, dummyQuery, !c.inTx)
+	return err
+}
+
 func (c *conn) dbConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error) {
 	ci := &p.DBConnectInfo{}
 	ci.SetDatabaseName(databaseName)
@@ -776,6 +1103,9 @@ func (c *conn) authenticate(ctx context.Context, authHnd *p.AuthHnd, attrs *conn
 	if attrs._locale != "" {
 		co.SetClientLocale(attrs._locale)
 	}
+	if attrs._ctxQueryTimeout {
+		co.SetQueryTimeoutSupported(true)
+	}
 
 	if err := c.pw.Write(ctx, c.sessionID, p.MtConnect, false, finalRequest, p.ClientID(clientID), co); err != nil {
 		return 0, nil, err
@@ -805,17 +1135,54 @@ func (c *conn) authenticate(ctx context.Context, authHnd *p.AuthHnd, attrs *conn
 	return c.pr.SessionID(), co, nil
 }
 
+// errStatementTimeout is returned if a statement timeout is set by the context but the query timeout support
+// of the connector is not enabled.
+var errStatementTimeout = errors.New("statement timeout requires the context query timeout of the connector (see Connector.SetContextQueryTimeout)")
+
+/*
+stmtContext returns a statement context part transferring the statement timeout set by ctx respectively the context
+deadline as server side query timeout if requested, nil otherwise. If both are set the shorter timeout is used.
+*/
+func (c *conn) stmtContext(ctx context.Context) (*p.StatementContext, error) {
+	timeout, ok := hdbctx.StatementTimeoutFrom(ctx)
+	if !c.attrs._ctxQueryTimeout {
+		if ok {
+			return nil, errStatementTimeout
+		}
+		return nil, nil
+	}
+	if deadline, hasDeadline := ctx.Deadline(); hasDeadline {
+		if untilDeadline := time.Until(deadline); !ok || untilDeadline < timeout {
+			timeout, ok = untilDeadline, true
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+	sc := &p.StatementContext{}
+	sc.SetQueryTimeout(max(int64(math.Ceil(timeout.Seconds())), 1))
+	return sc, nil
+}
+
 func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (driver.Rows, error) {
 	defer c.addSQLTimeValue(time.Now(), sqlTimeQuery)
 
 	// allow e.g inserts as query -> handle commit like in _execDirect
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecuteDirect, commit, p.Command(query)); err != nil {
+	sc, err := c.stmtContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	if err := c.pw.Write(ctx, c.sessionID, p.MtExecuteDirect, commit, p.Command(query), sc, c.clientInfo(ctx)); err != nil {
+		return nil, err
+	}
+	c.numStmt++
 
 	qr := &queryResult{conn: c}
+	qr.ctxFetchSize, _ = hdbctx.FetchSizeFrom(ctx)
+	qr.maxBytes = c.maxResultBytes(ctx)
+	numBytes := c.dbConn.numBytes
 	meta := &p.ResultMetadata{}
-	resSet := &p.Resultset{}
+	resSet := &p.Resultset{MaxRows: c.attrs._maxBufferedRows, MaxBytes: qr.maxBytes, Columnar: columnarFrom(ctx), Interner: stringInterner(ctx)}
 
 	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		switch kind {
@@ -827,6 +1194,7 @@ func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (driv
 		case p.PkResultset:
 			resSet.ResultFields = qr.fields
 			read(resSet)
+			qr.resSet = resSet
 			qr.fieldValues = resSet.FieldValues
 			qr.decodeErrors = resSet.DecodeErrors
 			qr.attrs = attrs
@@ -837,15 +1205,25 @@ func (c *conn) queryDirect(ctx context.Context, query string, commit bool) (driv
 	if qr.rsID == 0 { // non select query
 		return noResult, nil
 	}
+	qr.replyBytes = c.dbConn.numBytes - numBytes
+	if err := qr.checkResultBytes(); err != nil {
+		return nil, err
+	}
+	qr.updateRetainedBytes()
 	return qr, nil
 }
 
 func (c *conn) execDirect(ctx context.Context, query string, commit bool) (driver.Result, error) {
 	defer c.addSQLTimeValue(time.Now(), sqlTimeExec)
 
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecuteDirect, commit, p.Command(query)); err != nil {
+	sc, err := c.stmtContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.pw.Write(ctx, c.sessionID, p.MtExecuteDirect, commit, p.Command(query), sc, c.clientInfo(ctx)); err != nil {
 		return nil, err
 	}
+	c.numStmt++
 
 	rows := &p.RowsAffected{}
 	var numRow int64
@@ -874,14 +1252,27 @@ func (c *conn) prepare(ctx context.Context, query string) (*prepareResult, error
 	resMeta := &p.ResultMetadata{}
 	prmMeta := &p.ParameterMetadata{}
 
+	cache := c.attrs._metadataCache
+	var resPart, prmPart *p.EncodedPart // encoded metadata in case of cached metadata
+
 	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		switch kind {
 		case p.PkStatementID:
 			read((*p.StatementID)(&pr.stmtID))
 		case p.PkResultMetadata:
+			if cache != nil {
+				resPart = p.NewEncodedPart(kind)
+				read(resPart)
+				return
+			}
 			read(resMeta)
 			pr.resultFields = resMeta.ResultFields
 		case p.PkParameterMetadata:
+			if cache != nil {
+				prmPart = p.NewEncodedPart(kind)
+				read(prmPart)
+				return
+			}
 			read(prmMeta)
 			pr.parameterFields = prmMeta.ParameterFields
 		}
@@ -889,27 +1280,73 @@ func (c *conn) prepare(ctx context.Context, query string) (*prepareResult, error
 		return nil, err
 	}
 	pr.fc = c.pr.FunctionCode()
+	if cache != nil {
+		var hit bool
+		var err error
+		if pr.parameterFields, pr.resultFields, hit, err = cache.decode(query, prmPart, resPart); err != nil {
+			return nil, err
+		}
+		if hit {
+			c.metrics.msgCh <- counterMsg{idx: counterMetadataHits, v: 1}
+		} else {
+			c.metrics.msgCh <- counterMsg{idx: counterMetadataMisses, v: 1}
+		}
+	}
 	return pr, nil
 }
 
+// prepareCached returns the prepared statement of query from the statement cache or prepares it.
+func (c *conn) prepareCached(ctx context.Context, query string) (*prepareResult, *stmtCacheEntry, error) {
+	if c.stmtCache == nil {
+		pr, err := c.prepare(ctx, query)
+		return pr, nil, err
+	}
+	if entry, ok := c.stmtCache.get(query); ok {
+		c.metrics.msgCh <- counterMsg{idx: counterStmtCacheHits, v: 1}
+		return entry.pr, entry, nil
+	}
+	c.metrics.msgCh <- counterMsg{idx: counterStmtCacheMisses, v: 1}
+
+	pr, err := c.prepare(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry, drops := c.stmtCache.add(query, pr)
+	for _, dropPr := range drops {
+		if err := c.dropStatementID(ctx, dropPr.stmtID); err != nil {
+			c.stmtCache.release(entry)
+			return nil, nil, err
+		}
+	}
+	return pr, entry, nil
+}
+
 func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool) (driver.Rows, error) {
 	defer c.addSQLTimeValue(time.Now(), sqlTimeQuery)
 
 	// allow e.g inserts as query -> handle commit like in exec
 
-	if err := convertQueryArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.attrs._lobChunkSize); err != nil {
+	if err := convertQueryArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.lobChunkSize(ctx)); err != nil {
 		return nil, err
 	}
 	inputParameters, err := p.NewInputParameters(pr.parameterFields, nvargs)
 	if err != nil {
 		return nil, err
 	}
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecute, commit, p.StatementID(pr.stmtID), inputParameters); err != nil {
+	sc, err := c.stmtContext(ctx)
+	if err != nil {
 		return nil, err
 	}
+	if err := c.pw.Write(ctx, c.sessionID, p.MtExecute, commit, p.StatementID(pr.stmtID), inputParameters, sc, c.clientInfo(ctx)); err != nil {
+		return nil, err
+	}
+	c.numStmt++
 
 	qr := &queryResult{conn: c, fields: pr.resultFields}
-	resSet := &p.Resultset{}
+	qr.ctxFetchSize, _ = hdbctx.FetchSizeFrom(ctx)
+	qr.maxBytes = c.maxResultBytes(ctx)
+	numBytes := c.dbConn.numBytes
+	resSet := &p.Resultset{MaxRows: c.attrs._maxBufferedRows, MaxBytes: qr.maxBytes, Columnar: columnarFrom(ctx), Interner: stringInterner(ctx)}
 
 	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		switch kind {
@@ -918,6 +1355,7 @@ func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.Nam
 		case p.PkResultset:
 			resSet.ResultFields = qr.fields
 			read(resSet)
+			qr.resSet = resSet
 			qr.fieldValues = resSet.FieldValues
 			qr.decodeErrors = resSet.DecodeErrors
 			qr.attrs = attrs
@@ -928,17 +1366,34 @@ func (c *conn) query(ctx context.Context, pr *prepareResult, nvargs []driver.Nam
 	if qr.rsID == 0 { // non select query
 		return noResult, nil
 	}
+	qr.replyBytes = c.dbConn.numBytes - numBytes
+	if err := qr.checkResultBytes(); err != nil {
+		return nil, err
+	}
+	qr.updateRetainedBytes()
 	return qr, nil
 }
 
 func (c *conn) exec(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool, ofs int) (driver.Result, error) {
-	inputParameters, err := p.NewInputParameters(pr.parameterFields, nvargs)
+	return c.execInputParameters(ctx, pr, nil, nvargs, commit, ofs)
+}
+
+// execInputParameters executes with the (pre-encoded) input parameters of nvargs or, if nil, creates them.
+func (c *conn) execInputParameters(ctx context.Context, pr *prepareResult, inputParameters *p.InputParameters, nvargs []driver.NamedValue, commit bool, ofs int) (driver.Result, error) {
+	if inputParameters == nil {
+		var err error
+		if inputParameters, err = p.NewInputParameters(pr.parameterFields, nvargs); err != nil {
+			return nil, err
+		}
+	}
+	sc, err := c.stmtContext(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecute, commit, p.StatementID(pr.stmtID), inputParameters); err != nil {
+	if err := c.pw.Write(ctx, c.sessionID, p.MtExecute, commit, p.StatementID(pr.stmtID), inputParameters, sc, c.clientInfo(ctx)); err != nil {
 		return nil, err
 	}
+	c.numStmt++
 
 	rows := &p.RowsAffected{Ofs: ofs}
 	var ids []p.LocatorID
@@ -970,7 +1425,7 @@ func (c *conn) exec(ctx context.Context, pr *prepareResult, nvargs []driver.Name
 			write lob data only for the last record as lob streaming is only available for the last one
 		*/
 		startLastRec := len(nvargs) - len(pr.parameterFields)
-		if err := c.encodeLobs(nil, ids, pr.parameterFields, nvargs[startLastRec:]); err != nil {
+		if err := c.encodeLobs(nil, ids, pr.parameterFields, nvargs[startLastRec:], c.lobChunkSize(ctx)); err != nil {
 			return nil, err
 		}
 	}
@@ -982,28 +1437,28 @@ func (c *conn) exec(ctx context.Context, pr *prepareResult, nvargs []driver.Name
 }
 
 func (c *conn) execCall(ctx context.Context, outputFields []*p.ParameterField) (*callResult, []p.LocatorID, int64, error) {
-	cr := &callResult{conn: c, outputFields: outputFields}
+	cr := &callResult{conn: c, outputFields: slices.Clip(outputFields)} // table fields must not be appended to outputFields
 
 	var qr *queryResult
 	rows := &p.RowsAffected{}
 	var ids []p.LocatorID
 	outPrms := &p.OutputParameters{}
 	meta := &p.ResultMetadata{}
-	resSet := &p.Resultset{}
 	lobReply := &p.WriteLobReply{}
 	var numRow int64
 	tableRowIdx := 0
+	var tableValues []driver.Value // table results are provided after the output parameter values
+	var assigns []func()           // assignments of decoded values (parts might be decoded in parallel)
 
-	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+	if err := c.pr.IteratePartsParallel(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		switch kind {
 		case p.PkRowsAffected:
 			read(rows)
 			numRow = rows.Total()
 		case p.PkOutputParameters:
-			outPrms.OutputFields = cr.outputFields
+			outPrms.OutputFields = outputFields // table fields are appended to cr.outputFields
 			read(outPrms)
-			cr.fieldValues = outPrms.FieldValues
-			cr.decodeErrors = outPrms.DecodeErrors
+			cr.outputPos = tableRowIdx
 		case p.PkResultMetadata:
 			/*
 				procedure call with table parameters does return metadata for each table
@@ -1014,16 +1469,20 @@ func (c *conn) execCall(ctx context.Context, outputFields []*p.ParameterField) (
 			*/
 			qr = &queryResult{conn: c}
 			cr.outputFields = append(cr.outputFields, p.NewTableRowsParameterField(tableRowIdx))
-			cr.fieldValues = append(cr.fieldValues, qr)
+			tableValues = append(tableValues, qr)
 			tableRowIdx++
 			read(meta)
 			qr.fields = meta.ResultFields
 		case p.PkResultset:
-			resSet.ResultFields = qr.fields
+			resSet := &p.Resultset{ResultFields: qr.fields}
 			read(resSet)
-			qr.fieldValues = resSet.FieldValues
-			qr.decodeErrors = resSet.DecodeErrors
+			qr.resSet = resSet
 			qr.attrs = attrs
+			tableQr := qr
+			assigns = append(assigns, func() {
+				tableQr.fieldValues = resSet.FieldValues
+				tableQr.decodeErrors = resSet.DecodeErrors
+			})
 		case p.PkResultsetID:
 			read((*p.ResultsetID)(&qr.rsID))
 		case p.PkWriteLobReply:
@@ -1033,26 +1492,50 @@ func (c *conn) execCall(ctx context.Context, outputFields []*p.ParameterField) (
 	}); err != nil {
 		return nil, nil, 0, err
 	}
+	for _, assign := range assigns {
+		assign()
+	}
+	cr.fieldValues = append(outPrms.FieldValues, tableValues...)
+	cr.decodeErrors = outPrms.DecodeErrors
 	return cr, ids, numRow, nil
 }
 
 func (c *conn) fetchNext(ctx context.Context, qr *queryResult) error {
 	defer c.addSQLTimeValue(time.Now(), sqlTimeFetch)
 
-	if err := c.pw.Write(ctx, c.sessionID, p.MtFetchNext, false, p.ResultsetID(qr.rsID), p.Fetchsize(c.attrs._fetchSize)); err != nil {
+	start, numBytes := time.Now(), c.dbConn.numBytes
+
+	if err := c.pw.Write(ctx, c.sessionID, p.MtFetchNext, false, p.ResultsetID(qr.rsID), p.Fetchsize(c.fetchSize(qr))); err != nil {
 		return err
 	}
 
-	resSet := &p.Resultset{ResultFields: qr.fields, FieldValues: qr.fieldValues} // reuse field values
+	resSet := qr.resSet // reuse buffers of the last resultset part for the lifetime of the query result
+	if resSet == nil {
+		resSet = &p.Resultset{ResultFields: qr.fields}
+	}
+	resSet.MaxRows, resSet.MaxBytes = c.attrs._maxBufferedRows, qr.maxBytes
 
-	return c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+	err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 		if kind == p.PkResultset {
 			read(resSet)
+			qr.resSet = resSet
 			qr.fieldValues = resSet.FieldValues
 			qr.decodeErrors = resSet.DecodeErrors
 			qr.attrs = attrs
 		}
 	})
+	// statistics for adaptive fetch size
+	qr.fetched = time.Now()
+	qr.fetchTime, qr.fetchBytes, qr.fetchRows = qr.fetched.Sub(start), c.dbConn.numBytes-numBytes, resSet.NumRows()
+	if err != nil {
+		qr.updateRetainedBytes()
+		return err
+	}
+	if err := qr.checkResultBytes(); err != nil {
+		return err
+	}
+	qr.updateRetainedBytes()
+	return nil
 }
 
 func (c *conn) dropStatementID(ctx context.Context, id uint64) error {
@@ -1142,6 +1625,9 @@ func (c *conn) decodeLob(descr *p.LobOutDescr, wr io.Writer) error {
 	} else {
 		err = c._decodeLob(descr, wr, func(b []byte) (int, int) { return len(b), len(b) })
 	}
+	if err == nil {
+		descr.Release() // last data read
+	}
 
 	if pw, ok := wr.(*io.PipeWriter); ok { // if the writer is a pipe-end -> close at the end
 		if err != nil {
@@ -1185,6 +1671,7 @@ func (c *conn) _decodeLob(descr *p.LobOutDescr, wr io.Writer, countChars func(b
 		if err := c.pw.Write(ctx, c.sessionID, p.MtWriteLob, false, lobRequest); err != nil {
 			return err
 		}
+		c.metrics.msgCh <- counterMsg{idx: counterLobChunks, v: 1}
 
 		if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
 			if kind == p.PkReadLobReply {
@@ -1214,7 +1701,7 @@ func assertEqual[T comparable](s string, a, b T) {
 }
 
 // encodeLobs encodes (write to db) input lob parameters.
-func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.ParameterField, nvargs []driver.NamedValue) error {
+func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.ParameterField, nvargs []driver.NamedValue, lobChunkSize int) error {
 	assertEqual("lob streaming can only be done for one (the last) record", len(inPrmFields), len(nvargs))
 
 	descrs := make([]*p.WriteLobDescr, 0, len(ids))
@@ -1252,7 +1739,7 @@ func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.Pa
 
 		// TODO check total size limit
 		for _, descr := range descrs {
-			if err := descr.FetchNext(c.attrs._lobChunkSize); err != nil {
+			if err := descr.FetchNext(lobChunkSize); err != nil {
 				return err
 			}
 		}
@@ -1262,6 +1749,7 @@ func (c *conn) encodeLobs(cr *callResult, ids []p.LocatorID, inPrmFields []*p.Pa
 		if err := c.pw.Write(ctx, c.sessionID, p.MtReadLob, false, writeLobRequest); err != nil {
 			return err
 		}
+		c.metrics.msgCh <- counterMsg{idx: counterLobChunks, v: 1}
 
 		lobReply := &p.WriteLobReply{}
 		outPrms := &p.OutputParameters{}
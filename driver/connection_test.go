@@ -6,7 +6,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
 )
 
 func testCancelContext(t *testing.T, db *sql.DB) {
@@ -46,6 +50,195 @@ func testCancelContext(t *testing.T, db *sql.DB) {
 	}
 }
 
+func testServerCancel(t *testing.T, db *sql.DB) {
+	connector := MT.NewConnector()
+	connector.SetServerCancel(true)
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// long running cross join
+	if _, err := db.ExecContext(ctx, "select count(*) from sys.objects a, sys.objects b, sys.objects c"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal(err)
+	}
+	// connection should be usable after server side cancellation
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testStmtCache(t *testing.T, db *sql.DB) {
+	connector := MT.NewConnector()
+	connector.SetStmtCacheSize(1)
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	sqlConn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlConn.Close()
+
+	queries := []string{"select ? from dummy", "select ? from dummy", "select ?, 1 from dummy", "select ? from dummy"}
+	for i, query := range queries {
+		var v int
+		if err := sqlConn.QueryRowContext(ctx, query, i).Scan(&v); err != nil {
+			t.Fatal(err)
+		}
+		if v != i {
+			t.Fatalf("value %d - expected %d", v, i)
+		}
+	}
+
+	if err := sqlConn.Raw(func(driverConn any) error {
+		cache := driverConn.(*conn).stmtCache
+		if cache.ll.Len() != 1 {
+			t.Fatalf("number of cached statements %d - expected %d", cache.ll.Len(), 1)
+		}
+		if _, ok := cache.entries[queries[len(queries)-1]]; !ok {
+			t.Fatalf("statement %s not cached", queries[len(queries)-1])
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testHoldCursorsOverCommit(t *testing.T, db *sql.DB) {
+	const numRows = 100
+
+	connector := MT.NewConnector()
+	connector.SetFetchSize(10) // fetch in several roundtrips
+	connector.SetHoldCursorsOverCommit(true)
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	tableName := RandomIdentifier("holdCursors_")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer)", tableName)); err != nil {
+		t.Fatal(err)
+	}
+
+	sqlConn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlConn.Close()
+
+	if _, err := sqlConn.ExecContext(ctx, fmt.Sprintf("insert into %s select top %d 1 from sys.objects", tableName, numRows)); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := sqlConn.QueryContext(ctx, fmt.Sprintf("select i from %s", tableName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		if i == 1 { // commit (autocommit mode) while iterating the resultset
+			if _, err := sqlConn.ExecContext(ctx, fmt.Sprintf("insert into %s values (2)", tableName)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != numRows {
+		t.Fatalf("number of rows %d - expected %d", i, numRows)
+	}
+}
+
+func testAdaptiveFetchSize(t *testing.T, db *sql.DB) {
+	const numRows = 1000
+
+	connector := MT.NewConnector()
+	connector.SetFetchSize(1)
+	connector.SetMaxFetchSize(numRows)
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	rows, err := db.Query(fmt.Sprintf("select top %d object_name from sys.objects", numRows))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		i++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := 0
+	if err := db.QueryRow(fmt.Sprintf("select count(*) from (select top %d object_name from sys.objects)", numRows)).Scan(&expected); err != nil {
+		t.Fatal(err)
+	}
+	if i != expected {
+		t.Fatalf("number of rows %d - expected %d", i, expected)
+	}
+}
+
+func testSlowQueryHook(t *testing.T, db *sql.DB) {
+	const query = "select * from dummy"
+
+	var sqs []*SlowQuery
+	connector := MT.NewConnector()
+	connector.SetSlowQueryHook(0, func(ctx context.Context, sq *SlowQuery) { sqs = append(sqs, sq) })
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	db.SetMaxOpenConns(1) // hook is called synchronously by one connection only
+
+	var s string
+	if err := db.QueryRow(query).Scan(&s); err != nil {
+		t.Fatal(err)
+	}
+	if len(sqs) != 1 {
+		t.Fatalf("number of slow queries %d - expected %d", len(sqs), 1)
+	}
+	if sqs[0].Query != query {
+		t.Fatalf("query %s - expected %s", sqs[0].Query, query)
+	}
+	if sqs[0].Rows != 1 {
+		t.Fatalf("rows %d - expected %d", sqs[0].Rows, 1)
+	}
+
+	connector.SetSlowQueryHook(time.Hour, connector.SlowQueryHook())
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	if err := db.QueryRow(query).Scan(&s); err != nil {
+		t.Fatal(err)
+	}
+	if len(sqs) != 1 {
+		t.Fatalf("number of slow queries %d - expected %d", len(sqs), 1)
+	}
+}
+
+func testExecInfo(t *testing.T, db *sql.DB) {
+	info := &hdbctx.ExecInfo{ServerMemoryUsage: -1}
+	ctx := hdbctx.WithExecInfo(context.Background(), info)
+
+	var n int
+	if err := db.QueryRowContext(ctx, "select count(*) from sys.objects").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if info.ServerProcessingTime < 0 || info.ServerCPUTime < 0 || info.ServerMemoryUsage < 0 {
+		t.Fatalf("invalid exec info %v", info)
+	}
+}
+
 func testCheckCallStmt(t *testing.T, db *sql.DB) {
 	testData := []struct {
 		stmt  string
@@ -75,7 +268,13 @@ func TestConnection(t *testing.T) {
 		fct  func(t *testing.T, db *sql.DB)
 	}{
 		{"cancelContext", testCancelContext},
+		{"serverCancel", testServerCancel},
+		{"stmtCache", testStmtCache},
+		{"holdCursorsOverCommit", testHoldCursorsOverCommit},
+		{"adaptiveFetchSize", testAdaptiveFetchSize},
 		{"checkCallStmt", testCheckCallStmt},
+		{"slowQueryHook", testSlowQueryHook},
+		{"execInfo", testExecInfo},
 	}
 
 	db := MT.DB()
@@ -3,10 +3,15 @@ package driver
 import (
 	"context"
 	"database/sql/driver"
+	"log/slog"
+	"net"
 	"os"
 	"path"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/SAP/go-hdb/driver/dial"
 	"github.com/SAP/go-hdb/driver/internal/protocol/auth"
 )
 
@@ -27,7 +32,8 @@ type Connector struct {
 	*connAttrs
 	*authAttrs
 
-	metrics *metrics
+	metrics  *metrics
+	registry *connRegistry
 }
 
 // NewConnector returns a new Connector instance with default values.
@@ -36,6 +42,7 @@ func NewConnector() *Connector {
 		connAttrs: newConnAttrs(),
 		authAttrs: &authAttrs{},
 		metrics:   stdHdbDriver.metrics, // use default stdHdbDriver metrics
+		registry:  newConnRegistry(),
 	}
 }
 
@@ -95,6 +102,16 @@ func newDSNConnector(dsn *DSN) (*Connector, error) {
 	}
 	c._username = dsn.username
 	c._password = dsn.password
+	dialer, err := dsn.dialer(c._tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if dialer != nil {
+		c._dialer = dialer
+	}
+	if dsn.isWebSocket() { // TLS parameters are applied to the websocket endpoint
+		c._tlsConfig = nil
+	}
 	return c, nil
 }
 
@@ -116,6 +133,40 @@ func (c *Connector) Host() string { return c._host }
 // DatabaseName returns the tenant database name of the connector.
 func (c *Connector) DatabaseName() string { return c._databaseName }
 
+/*
+DBConnectInfo returns the connect information of the tenant database databaseName as provided by the database server
+of the connector host (e.g. the system database), i.e. the host and port of the SQL endpoint of the tenant database.
+
+Only the connect information is exchanged via a new network connection without authenticating a database session,
+so that deployment tooling can discover tenant database endpoints without credentials. In case databaseName is the
+database of the connector host itself (IsConnected), Host and Port are the ones of the connector host. If the
+connector host does not contain a numeric port (e.g. the address of a unix domain socket or custom dialer, see SetDialer),
+Host is the connector host and Port is zero.
+*/
+func (c *Connector) DBConnectInfo(ctx context.Context, databaseName string) (*DBConnectInfo, error) {
+	ci, err := fetchDBConnectInfo(ctx, c._host, databaseName, c.metrics, c.connAttrs.clone())
+	if err != nil {
+		return nil, classifyError(err)
+	}
+	if ci.IsConnected {
+		ci.Host, ci.Port = splitHostPort(c._host)
+	}
+	return ci, nil
+}
+
+// splitHostPort returns host and numeric port of address, respectively address and zero if address does not contain a numeric port.
+func splitHostPort(address string) (string, int) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, 0
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil {
+		return address, 0
+	}
+	return host, n
+}
+
 func (c *Connector) redirect(ctx context.Context) (driver.Conn, error) {
 	connAttrs := c.connAttrs.clone()
 
@@ -139,12 +190,71 @@ func (c *Connector) redirect(ctx context.Context) (driver.Conn, error) {
 	return conn, err
 }
 
+// beforeConnect calls the BeforeConnect hook with a copy of the connector and returns the copy,
+// so that changes made by the hook do not race with concurrent connection attempts.
+func (c *Connector) beforeConnect(ctx context.Context) (*Connector, error) {
+	beforeConnect := c.BeforeConnect()
+	if beforeConnect == nil {
+		return c, nil
+	}
+	nc := c.clone()
+	if err := beforeConnect(ctx, nc); err != nil {
+		return nil, err
+	}
+	return nc, nil
+}
+
+func (c *Connector) connect(ctx context.Context) (driver.Conn, error) {
+	cc, err := c.beforeConnect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var dc driver.Conn
+	if cc._databaseName != "" {
+		dc, err = cc.redirect(ctx)
+	} else {
+		dc, err = connect(ctx, cc._host, cc.metrics, cc.connAttrs.clone(), cc.authAttrs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if afterConnect := cc.AfterConnect(); afterConnect != nil {
+		if err := afterConnect(ctx, dc); err != nil {
+			dc.Close()
+			return nil, err
+		}
+	}
+	if hc, ok := dc.(*conn); ok && hc.attrs._replayable != nil {
+		// reconnect like a new connection of the connector (including the connect hooks)
+		hc.reconnectSession = func(ctx context.Context) (*conn, error) {
+			if c.registry.isShutdown() {
+				return nil, ErrConnectorShutdown
+			}
+			nc, err := c.connect(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return nc.(*conn), nil
+		}
+	}
+	return dc, nil
+}
+
 // Connect implements the database/sql/driver/Connector interface.
 func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
-	if c._databaseName != "" {
-		return c.redirect(ctx)
+	if c.registry.isShutdown() {
+		return nil, ErrConnectorShutdown
 	}
-	return connect(ctx, c._host, c.metrics, c.connAttrs.clone(), c.authAttrs)
+	start := time.Now()
+	dc, err := c.RetryPolicy().retry(ctx, c.logger().With(slog.String("host", c._host)), func() (driver.Conn, error) { return c.connect(ctx) })
+	if hc, ok := dc.(*conn); ok && !c.registry.add(hc) { // shutdown while connecting
+		hc.Close()
+		dc, err = nil, ErrConnectorShutdown
+	}
+	c.connOpenEvent(ctx, start, dc, err)
+	return dc, classifyError(err)
 }
 
 // Driver implements the database/sql/driver/Connector interface.
@@ -157,6 +267,7 @@ func (c *Connector) clone() *Connector {
 		connAttrs:     c.connAttrs.clone(),
 		authAttrs:     c.authAttrs.clone(),
 		metrics:       c.metrics,
+		registry:      newConnRegistry(),
 	}
 }
 
@@ -166,3 +277,20 @@ func (c *Connector) WithDatabase(databaseName string) *Connector {
 	nc._databaseName = databaseName
 	return nc
 }
+
+/*
+WithConn returns a new Connector using the already established network connection conn
+instead of dialing the database host (e.g. a unix domain socket connection to a sidecar proxy).
+
+As conn can only be used for one database connection, the connector should only be used
+for opening a single connection (e.g. by calling Connect directly or setting the maximum
+number of open connections of sql.DB to 1). Tenant database redirects (WithDatabase) are
+not supported as these need an additional connection.
+
+For using unix domain sockets with a connection pool please see dial.NewUnixDialer.
+*/
+func (c *Connector) WithConn(conn net.Conn) *Connector {
+	nc := c.clone()
+	nc.connAttrs.setDialer(dial.NewConnDialer(conn))
+	return nc
+}
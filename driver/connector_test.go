@@ -3,8 +3,11 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"sync/atomic"
 	"testing"
 )
 
@@ -124,6 +127,36 @@ func testRetryConnect(t *testing.T) {
 	}
 }
 
+func testConnectHooks(t *testing.T) {
+	const key, value = "goHdbHook", "afterConnect"
+
+	connector := MT.NewConnector()
+
+	var numBeforeConnect atomic.Int64
+	connector.SetBeforeConnect(func(ctx context.Context, connector *Connector) error {
+		numBeforeConnect.Add(1)
+		return nil
+	})
+	connector.SetAfterConnect(func(ctx context.Context, conn driver.Conn) error {
+		_, err := conn.(driver.ExecerContext).ExecContext(ctx, fmt.Sprintf("set '%s' = '%s'", key, value), nil)
+		return err
+	})
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var v string
+	if err := db.QueryRow(fmt.Sprintf("select session_context('%s') from dummy", key)).Scan(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v != value {
+		t.Fatalf("session variable value for %s is %s - expected %s", key, v, value)
+	}
+	if numBeforeConnect.Load() == 0 {
+		t.Fatal("before connect hook not called")
+	}
+}
+
 func TestConnector(t *testing.T) {
 	t.Parallel()
 
@@ -133,6 +166,7 @@ func TestConnector(t *testing.T) {
 	}{
 		{"testSessionVariables", testSessionVariables},
 		{"testRetryConnect", testRetryConnect},
+		{"testConnectHooks", testConnectHooks},
 	}
 
 	for _, test := range tests {
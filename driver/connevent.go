@@ -0,0 +1,207 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// ConnEventKind is the kind of a connection lifecycle event.
+type ConnEventKind int
+
+// ConnEventKind constants.
+const (
+	// ConnOpened is emitted after a connection was opened by the connector.
+	ConnOpened ConnEventKind = iota
+	// ConnOpenFailed is emitted if a connection could not be opened.
+	ConnOpenFailed
+	// ConnClosed is emitted after a connection was closed.
+	ConnClosed
+	// ConnReset is emitted after a pooled connection was reset before reuse (see SetResetPolicy).
+	ConnReset
+	// ConnValidationFailed is emitted if a pooled connection is not valid for reuse and is discarded by the pool.
+	ConnValidationFailed
+)
+
+func (k ConnEventKind) String() string {
+	switch k {
+	case ConnOpened:
+		return "opened"
+	case ConnOpenFailed:
+		return "openFailed"
+	case ConnClosed:
+		return "closed"
+	case ConnReset:
+		return "reset"
+	case ConnValidationFailed:
+		return "validationFailed"
+	default:
+		return fmt.Sprintf("ConnEventKind(%d)", int(k))
+	}
+}
+
+// ConnEventReason is the reason of a connection lifecycle event.
+type ConnEventReason int
+
+// ConnEventReason constants.
+const (
+	// ConnReasonNone is the reason of events without a specific reason (e.g. ConnOpened).
+	ConnReasonNone ConnEventReason = iota
+	// ConnReasonClosed is the reason of connections closed by the connection pool (e.g. maximum lifetime or
+	// number of idle connections exceeded) or the application.
+	ConnReasonClosed
+	// ConnReasonIdleTimeout is the reason of connections exceeding the client side idle timeout (see SetIdleTimeout).
+	ConnReasonIdleTimeout
+	// ConnReasonRetired is the reason of connections exceeding the maximum number of statements or bytes.
+	ConnReasonRetired
+	// ConnReasonCancelled is the reason of connections discarded after a db call was cancelled by the client
+	// (context cancelled or deadline exceeded).
+	ConnReasonCancelled
+	// ConnReasonTimeout is the reason of connections discarded after a client side network timeout (see SetTimeout).
+	ConnReasonTimeout
+	// ConnReasonConnLost is the reason of connections lost, e.g. because the session was killed by the database
+	// server or the network connection was interrupted.
+	ConnReasonConnLost
+	// ConnReasonPingFailed is the reason of idle connections failing the ping before reuse (see SetPingInterval).
+	ConnReasonPingFailed
+	// ConnReasonResetFailed is the reason of connections failing the reset before reuse (see SetResetPolicy).
+	ConnReasonResetFailed
+	// ConnReasonAuth is the reason of connections failed to open due to an authentication error.
+	ConnReasonAuth
+	// ConnReasonError is the reason of events caused by other errors.
+	ConnReasonError
+	// ConnReasonShutdown is the reason of connections closed by a connector shutdown (see Connector.Shutdown).
+	ConnReasonShutdown
+)
+
+func (r ConnEventReason) String() string {
+	switch r {
+	case ConnReasonNone:
+		return ""
+	case ConnReasonClosed:
+		return "closed"
+	case ConnReasonIdleTimeout:
+		return "idleTimeout"
+	case ConnReasonRetired:
+		return "retired"
+	case ConnReasonCancelled:
+		return "cancelled"
+	case ConnReasonTimeout:
+		return "timeout"
+	case ConnReasonConnLost:
+		return "connLost"
+	case ConnReasonPingFailed:
+		return "pingFailed"
+	case ConnReasonResetFailed:
+		return "resetFailed"
+	case ConnReasonAuth:
+		return "auth"
+	case ConnReasonError:
+		return "error"
+	case ConnReasonShutdown:
+		return "shutdown"
+	default:
+		return fmt.Sprintf("ConnEventReason(%d)", int(r))
+	}
+}
+
+// A ConnEvent is a connection lifecycle event.
+type ConnEvent struct {
+	Kind   ConnEventKind
+	Reason ConnEventReason
+	Host   string
+	// ConnectionID is the database connection id (0 if the connection could not be opened).
+	ConnectionID int
+	// Duration is the time spent on opening the connection (ConnOpened, ConnOpenFailed), the lifetime of the
+	// connection (ConnClosed, ConnValidationFailed) or the time spent on the reset (ConnReset).
+	Duration time.Duration
+	// Err is the error causing the event (nil if not caused by an error).
+	Err error
+}
+
+/*
+A ConnEventSubscriber is notified about the lifecycle events of the connections opened by a connector
+(see SetConnEventSubscriber), e.g. to distinguish connection pool churn caused by connections killed by the
+database server from connections discarded due to client side timeouts.
+
+ConnEvent is called synchronously by the connection and needs to be safe for concurrent use by multiple connections.
+Internal connections (e.g. for server side statement cancellation) do not emit events.
+*/
+type ConnEventSubscriber interface {
+	ConnEvent(ctx context.Context, event *ConnEvent)
+}
+
+// connEventReason returns the event reason of error err.
+func connEventReason(err error) ConnEventReason {
+	var netErr net.Error
+	var connLostErr *ConnLostError
+	switch {
+	case errors.Is(err, ErrConnectorShutdown):
+		return ConnReasonShutdown
+	case errors.Is(err, errCancelled) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return ConnReasonCancelled
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ConnReasonTimeout
+	case isAuthError(err):
+		return ConnReasonAuth
+	case errors.As(err, &connLostErr) || errors.As(err, &netErr) || errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return ConnReasonConnLost
+	default:
+		return ConnReasonError
+	}
+}
+
+// connOpenEvent emits the open event of connection dc opened since start or the open failure err.
+func (c *Connector) connOpenEvent(ctx context.Context, start time.Time, dc driver.Conn, err error) {
+	subscriber := c.ConnEventSubscriber()
+	if subscriber == nil {
+		return
+	}
+	if err != nil {
+		subscriber.ConnEvent(ctx, &ConnEvent{Kind: ConnOpenFailed, Reason: connEventReason(err), Host: c._host, Duration: time.Since(start), Err: err})
+		return
+	}
+	hc, ok := dc.(*conn)
+	if !ok {
+		return
+	}
+	hc.opened = time.Now()
+	hc.connEvent(ctx, ConnOpened, ConnReasonNone, hc.opened.Sub(start), nil)
+}
+
+// connEvent emits an event of a connection opened by a connector.
+func (c *conn) connEvent(ctx context.Context, kind ConnEventKind, reason ConnEventReason, d time.Duration, err error) {
+	subscriber := c.attrs._connEventSubscriber
+	if subscriber == nil || c.opened.IsZero() {
+		return
+	}
+	subscriber.ConnEvent(ctx, &ConnEvent{Kind: kind, Reason: reason, Host: c.host, ConnectionID: c.ConnectionID(), Duration: d, Err: err})
+}
+
+// invalidReason returns the reason why the connection cannot be reused, ConnReasonNone if the connection is valid.
+func (c *conn) invalidReason() ConnEventReason {
+	switch {
+	case c.isBad():
+		return connEventReason(c.lastError)
+	case c.isShutdown.Load():
+		return ConnReasonShutdown
+	case c.isIdleTimeout():
+		return ConnReasonIdleTimeout
+	case c.isRetired():
+		return ConnReasonRetired
+	default:
+		return ConnReasonNone
+	}
+}
+
+// badError returns the error the connection became bad by, nil if the connection is not bad.
+func (c *conn) badError() error {
+	if c.isBad() {
+		return c.lastError
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+type testConnEventSubscriber struct {
+	mu     sync.Mutex
+	events []ConnEvent
+}
+
+func (s *testConnEventSubscriber) ConnEvent(ctx context.Context, event *ConnEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, *event)
+}
+
+func (s *testConnEventSubscriber) kinds() []ConnEventKind {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kinds := make([]ConnEventKind, len(s.events))
+	for i, event := range s.events {
+		kinds[i] = event.Kind
+	}
+	return kinds
+}
+
+func TestConnEvent(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := srv.Addr()
+
+	subscriber := &testConnEventSubscriber{}
+	connector := NewBasicAuthConnector(addr, "MOCK", "password")
+	connector.SetConnEventSubscriber(subscriber)
+	connector.SetIdleSessionTimeout(time.Millisecond)
+
+	dc, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if dc.(driver.Validator).IsValid() {
+		t.Fatal("connection valid - expected idle timeout")
+	}
+	if err := dc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Close()
+	if _, err := connector.Connect(context.Background()); err == nil {
+		t.Fatal("connect succeeded - expected error")
+	}
+
+	expected := []struct {
+		kind   ConnEventKind
+		reason ConnEventReason
+	}{
+		{ConnOpened, ConnReasonNone},
+		{ConnValidationFailed, ConnReasonIdleTimeout},
+		{ConnClosed, ConnReasonIdleTimeout},
+		{ConnOpenFailed, ConnReasonConnLost},
+	}
+	events := subscriber.events
+	if len(events) != len(expected) {
+		t.Fatalf("events %v - expected %d events", subscriber.kinds(), len(expected))
+	}
+	for i, event := range events {
+		if event.Kind != expected[i].kind || event.Reason != expected[i].reason {
+			t.Fatalf("event %d: %s %s - expected %s %s", i, event.Kind, event.Reason, expected[i].kind, expected[i].reason)
+		}
+		if event.Host != addr {
+			t.Fatalf("event %d: host %s - expected %s", i, event.Host, addr)
+		}
+	}
+	if events[0].ConnectionID == 0 || events[2].ConnectionID != events[0].ConnectionID {
+		t.Fatalf("connection ids %d %d - expected same non zero id", events[0].ConnectionID, events[2].ConnectionID)
+	}
+	if events[3].Err == nil {
+		t.Fatal("open failed event without error")
+	}
+}
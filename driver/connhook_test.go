@@ -0,0 +1,55 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestBeforeConnectCopy(t *testing.T) {
+	const numConn = 5
+
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetBeforeConnect(func(ctx context.Context, c *Connector) error {
+		if c == connector {
+			t.Error("before connect hook called with the shared connector")
+		}
+		c.SetPassword("fresh password") // must not race with concurrent connection attempts
+		return nil
+	})
+	db := OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+	conns := make([]*sql.Conn, numConn)
+	var wg sync.WaitGroup
+	for i := 0; i < numConn; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var err error
+			if conns[i], err = db.Conn(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, conn := range conns {
+		if conn != nil {
+			conn.Close()
+		}
+	}
+	if password := connector.Password(); password != "password" {
+		t.Fatalf("connector password %s - expected %s", password, "password")
+	}
+}
@@ -3,11 +3,14 @@ package driver
 import (
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 	"github.com/SAP/go-hdb/driver/internal/protocol/levenshtein"
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
 	"golang.org/x/text/transform"
 )
 
@@ -38,28 +41,143 @@ func reorderNVArgs(pos int, name string, nvargs []driver.NamedValue) {
 	}
 }
 
-func convertArg(field *p.ParameterField, arg driver.Value, cesu8Encoder transform.Transformer) (any, error) {
+// ErrValueTooLong is the error wrapped by a ValueTooLongError.
+var ErrValueTooLong = errors.New("value too long")
+
+// A ValueTooLongError is returned if a bound character or binary value exceeds the maximum length of the parameter field.
+type ValueTooLongError struct {
+	Idx       int    // parameter index (0-based)
+	Name      string // parameter name (empty if not provided by the statement)
+	Length    int    // value length
+	MaxLength int    // maximum length of the parameter field
+}
+
+func (e *ValueTooLongError) Error() string {
+	if e.Name != "" {
+		return fmt.Sprintf("parameter %d (%s): value length %d exceeds maximum length %d", e.Idx, e.Name, e.Length, e.MaxLength)
+	}
+	return fmt.Sprintf("parameter %d: value length %d exceeds maximum length %d", e.Idx, e.Length, e.MaxLength)
+}
+
+// Unwrap returns ErrValueTooLong.
+func (e *ValueTooLongError) Unwrap() error { return ErrValueTooLong }
+
+func convertArg(idx int, field *p.ParameterField, arg driver.Value, cesu8Encoder transform.Transformer) (any, error) {
+	// registered application converters
+	if arg != nil && !stdConverterRegistry.empty() {
+		if c := stdConverterRegistry.toDB(reflect.TypeOf(arg), field.TypeName(), field.Name()); c != nil {
+			var err error
+			if arg, err = c.ToDB(arg); err != nil {
+				return nil, err
+			}
+		}
+	}
 	// let fields with own value converter convert themselves first (e.g. NullInt64, ...)
-	// .check nested Value converters as well (e.g. sql.Null[T] has driver.Decimal as value)
-	for !isNilArg(arg) {
-		valuer, ok := arg.(driver.Valuer)
+	arg, err := valuerValue(arg)
+	if err != nil {
+		return nil, err
+	}
+	// convert field
+	v, err := field.Convert(cesu8Encoder, arg)
+	if err != nil {
+		return nil, err
+	}
+	if length, maxLength, ok := field.CheckLength(v); !ok {
+		return nil, &ValueTooLongError{Idx: idx, Name: field.Name(), Length: length, MaxLength: maxLength}
+	}
+	return v, nil
+}
+
+// valuerValue returns the value of arg if arg implements driver.Valuer.
+// Nested Value converters are checked as well (e.g. sql.Null[T] has driver.Decimal as value).
+func valuerValue(arg any) (any, error) {
+	for i := 0; !isNilArg(arg); i++ {
+		valuer, ok := asValuer(arg)
 		if !ok {
 			break
 		}
+		if i == maxValuerDepth {
+			return nil, fmt.Errorf("invalid argument %T - nesting of driver.Valuer values exceeds %d", arg, maxValuerDepth)
+		}
 		var err error
 		if arg, err = valuer.Value(); err != nil {
 			return nil, err
 		}
 	}
-	// convert field
-	return field.Convert(cesu8Encoder, arg)
+	return arg, nil
+}
+
+// maxValuerDepth is the maximum nesting depth of driver.Valuer values (protects against cyclic values).
+const maxValuerDepth = 16
+
+/*
+asValuer returns arg as driver.Valuer. In contrast to a plain type assertion, values of types
+implementing driver.Valuer with a pointer receiver are supported as well.
+*/
+func asValuer(arg any) (driver.Valuer, bool) {
+	if valuer, ok := arg.(driver.Valuer); ok {
+		return valuer, true
+	}
+	rv := reflect.ValueOf(arg)
+	if rv.Kind() == reflect.Pointer || !reflect.PointerTo(rv.Type()).Implements(valuerType) {
+		return nil, false
+	}
+	pv := reflect.New(rv.Type())
+	pv.Elem().Set(rv)
+	return pv.Interface().(driver.Valuer), true
+}
+
+var valuerType = hdbreflect.TypeFor[driver.Valuer]()
+
+/*
+reorderNamedArgs reorders the arguments of one row according to the parameter names provided by the
+parameter metadata of the statement (procedure parameters or named placeholders).
+  - either all or none of the arguments need to be named
+*/
+func reorderNamedArgs(fields []*p.ParameterField, nvargs []driver.NamedValue) error {
+	numNamed := 0
+	for _, nvarg := range nvargs {
+		if nvarg.Name != "" {
+			numNamed++
+		}
+	}
+	if numNamed == 0 {
+		return nil
+	}
+	if numNamed != len(nvargs) {
+		return errors.New("invalid arguments - named and positional arguments cannot be mixed")
+	}
+
+	for _, nvarg := range nvargs {
+		if !slices.ContainsFunc(fields, func(field *p.ParameterField) bool { return field.Name() == nvarg.Name }) {
+			if fields[0].Name() == "" {
+				return fmt.Errorf("invalid argument name %s - statement does not provide parameter names", nvarg.Name)
+			}
+			return fmt.Errorf("invalid argument name %s - did you mean %s?",
+				nvarg.Name,
+				levenshtein.MinString(fields, func(field *p.ParameterField) string { return field.Name() }, nvarg.Name, false),
+			)
+		}
+	}
+
+	row := make([]driver.NamedValue, len(fields))
+	for i, field := range fields {
+		idx := slices.IndexFunc(nvargs, func(nvarg driver.NamedValue) bool { return nvarg.Name == field.Name() })
+		if idx == -1 {
+			return fmt.Errorf("missing argument for parameter %s", field.Name())
+		}
+		row[i] = nvargs[idx]
+		row[i].Ordinal = i + 1
+	}
+	copy(nvargs, row)
+	return nil
 }
 
 /*
 convertExecArgs
   - all fields need to be input fields
   - out parameters are not supported
-  - named parameters are not supported
+  - named parameters are mapped by the parameter names of the statement
 */
 func convertExecArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, cesu8Encoder transform.Transformer, lobChunkSize int) ([]int, error) {
 	numField := len(fields)
@@ -70,6 +188,9 @@ func convertExecArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 	addLobDataRecs := []int{}
 
 	for i := 0; i < numRow; i++ {
+		if err := reorderNamedArgs(fields, nvargs[i*numField:(i+1)*numField]); err != nil {
+			return nil, err
+		}
 		hasAddLobData := false
 		for j, field := range fields {
 			nvarg := &nvargs[(i*numField)+j]
@@ -80,11 +201,8 @@ func convertExecArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 			if _, ok := nvarg.Value.(sql.Out); ok {
 				return nil, fmt.Errorf("invalid argument %v - output not allowed", nvarg)
 			}
-			if nvarg.Name != "" {
-				return nil, fmt.Errorf("invalid argument %s - named parameters not supported", nvarg.Name)
-			}
 			var err error
-			if nvarg.Value, err = convertArg(field, nvarg.Value, cesu8Encoder); err != nil {
+			if nvarg.Value, err = convertArg(j, field, nvarg.Value, cesu8Encoder); err != nil {
 				return nil, fmt.Errorf("field %s conversion error - %w", field, err)
 			}
 			// fetch first lob chunk
@@ -108,12 +226,15 @@ func convertExecArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 _convertQueryArgs
   - all fields need to be input fields
   - out parameters are not supported
-  - named parameters are not supported
+  - named parameters are mapped by the parameter names of the statement
 */
 func convertQueryArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, cesu8Encoder transform.Transformer, lobChunkSize int) error {
 	if len(nvargs) != len(fields) {
 		return fmt.Errorf("invalid number of arguments %d - %d expected", len(nvargs), len(fields))
 	}
+	if err := reorderNamedArgs(fields, nvargs); err != nil {
+		return err
+	}
 
 	for i, field := range fields {
 		nvarg := &nvargs[i]
@@ -123,11 +244,8 @@ func convertQueryArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ce
 		if _, ok := nvarg.Value.(sql.Out); ok {
 			return fmt.Errorf("invalid argument %v - output not allowed", nvarg)
 		}
-		if nvarg.Name != "" {
-			return fmt.Errorf("invalid argument %s - named parameters not supported", nvarg.Name)
-		}
 		var err error
-		if nvarg.Value, err = convertArg(field, nvarg.Value, cesu8Encoder); err != nil {
+		if nvarg.Value, err = convertArg(i, field, nvarg.Value, cesu8Encoder); err != nil {
 			return fmt.Errorf("field %s conversion error - %w", field, err)
 		}
 		// fetch first lob chunk
@@ -184,25 +302,27 @@ func convertCallArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 
 		var err error
 		if field.In() {
+			inArg := *nvarg
 			if isOut {
 				if !out.In {
 					return nil, fmt.Errorf("argument field %s mismatch - use in argument with out field", field)
 				}
-				if out.Dest, err = convertArg(field, out.Dest, cesu8Encoder); err != nil {
+				// inout: send the value of out.Dest and keep out.Dest as scan destination
+				if inArg.Value, err = convertArg(i, field, out.Dest, cesu8Encoder); err != nil {
 					return nil, fmt.Errorf("field %s conversion error - %w", field, err)
 				}
 			} else {
-				if nvarg.Value, err = convertArg(field, nvarg.Value, cesu8Encoder); err != nil {
+				if inArg.Value, err = convertArg(i, field, nvarg.Value, cesu8Encoder); err != nil {
 					return nil, fmt.Errorf("field %s conversion error - %w", field, err)
 				}
 			}
 			// fetch first lob chunk
-			if lobInDescr, ok := nvarg.Value.(*p.LobInDescr); ok {
+			if lobInDescr, ok := inArg.Value.(*p.LobInDescr); ok {
 				if err := lobInDescr.FetchNext(lobChunkSize); err != nil {
 					return nil, err
 				}
 			}
-			callArgs.inArgs = append(callArgs.inArgs, *nvarg)
+			callArgs.inArgs = append(callArgs.inArgs, inArg)
 			callArgs.inFields = append(callArgs.inFields, field)
 		}
 
@@ -210,7 +330,8 @@ func convertCallArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 			if !isOut {
 				return nil, fmt.Errorf("argument field %s mismatch - use out argument with non-out field", field)
 			}
-			if _, ok := out.Dest.(*sql.Rows); ok {
+			switch out.Dest.(type) {
+			case *sql.Rows, *TableResult:
 				return nil, fmt.Errorf("invalid output parameter type %T", out.Dest)
 			}
 			callArgs.outArgs = append(callArgs.outArgs, *nvarg)
@@ -225,10 +346,61 @@ func convertCallArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, ces
 		if !ok {
 			return nil, fmt.Errorf("invalid parameter type %T at %d - output parameter expected", nvarg.Value, i)
 		}
-		if _, ok := out.Dest.(*sql.Rows); !ok {
-			return nil, fmt.Errorf("invalid output parameter %T at %d - sql.Rows expected", out.Dest, i)
+		switch out.Dest.(type) {
+		case *sql.Rows, *TableResult:
+		default:
+			return nil, fmt.Errorf("invalid output parameter %T at %d - sql.Rows or TableResult expected", out.Dest, i)
 		}
 		callArgs.outArgs = append(callArgs.outArgs, *nvarg)
 	}
 	return callArgs, nil
 }
+
+/*
+convertQueryCallArgs converts the arguments of a procedure call executed via Query.
+  - arguments are provided for input (in and inout) fields only
+  - output fields are returned as result set and therefore do not need an argument
+  - in case the number of arguments matches the number of fields the arguments are
+    converted like in the exec case (sql.Out arguments for output fields)
+*/
+func convertQueryCallArgs(fields []*p.ParameterField, nvargs []driver.NamedValue, cesu8Encoder transform.Transformer, lobChunkSize int) (*callArgs, error) {
+	if len(nvargs) >= len(fields) {
+		return convertCallArgs(fields, nvargs, cesu8Encoder, lobChunkSize)
+	}
+
+	numIn := 0
+	for _, field := range fields {
+		if field.In() {
+			numIn++
+		}
+	}
+	if len(nvargs) != numIn {
+		return nil, fmt.Errorf("invalid number of arguments %d - %d expected", len(nvargs), numIn)
+	}
+
+	prmnvargs := make([]driver.NamedValue, 0, len(fields))
+	i := 0
+	for _, field := range fields {
+		if !field.In() { // add output argument
+			prmnvargs = append(prmnvargs, driver.NamedValue{Name: field.Name(), Value: sql.Out{Dest: new(any)}})
+			continue
+		}
+		nvarg := nvargs[i]
+		i++
+		if nvarg.Name != "" { // named arguments: search by field name
+			idx := slices.IndexFunc(nvargs, func(nv driver.NamedValue) bool { return nv.Name == field.Name() })
+			if idx == -1 {
+				return nil, fmt.Errorf("missing argument for field %s", field.Name())
+			}
+			nvarg = nvargs[idx]
+		}
+		if _, isOut := nvarg.Value.(sql.Out); field.Out() && !isOut { // inout field: send value as input
+			nvarg.Value = sql.Out{Dest: nvarg.Value, In: true}
+		}
+		prmnvargs = append(prmnvargs, nvarg)
+	}
+	for i := range prmnvargs {
+		prmnvargs[i].Ordinal = i + 1
+	}
+	return convertCallArgs(fields, prmnvargs, cesu8Encoder, lobChunkSize)
+}
@@ -0,0 +1,66 @@
+package driver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"testing"
+)
+
+// testPtrValuer implements driver.Valuer with a pointer receiver.
+type testPtrValuer struct{ s string }
+
+func (v *testPtrValuer) Value() (driver.Value, error) { return v.s, nil }
+
+// testCyclicValuer returns itself as value.
+type testCyclicValuer struct{}
+
+func (v testCyclicValuer) Value() (driver.Value, error) { return v, nil }
+
+func testValuerValue(t *testing.T) {
+	tests := []struct {
+		arg, v any
+	}{
+		{42, 42},
+		{sql.NullString{String: "text", Valid: true}, "text"},
+		{sql.NullString{}, nil},
+		{testPtrValuer{s: "value"}, "value"},
+		{&testPtrValuer{s: "pointer"}, "pointer"},
+		{(*testPtrValuer)(nil), (*testPtrValuer)(nil)},
+		{NullDecimal{Decimal: (*Decimal)(big.NewRat(1, 2)), Valid: true}, big.NewRat(1, 2)},
+	}
+	for i, test := range tests {
+		v, err := valuerValue(test.arg)
+		if err != nil {
+			t.Fatalf("test %d: %s", i, err)
+		}
+		if r, ok := v.(*big.Rat); ok {
+			if r.Cmp(test.v.(*big.Rat)) != 0 {
+				t.Fatalf("test %d: value %v - expected %v", i, r, test.v)
+			}
+			continue
+		}
+		if v != test.v {
+			t.Fatalf("test %d: value %v - expected %v", i, v, test.v)
+		}
+	}
+
+	if _, err := valuerValue(testCyclicValuer{}); err == nil {
+		t.Fatal("cyclic valuer: error expected")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"valuerValue", testValuerValue},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+/*
+A Converter converts values between an application Go type and database fields.
+
+Converters are matched by the database type name of the field (e.g. "NVARCHAR", "DECIMAL") and / or
+by the field name (column name for query results, parameter name for procedure calls and named
+parameters). An empty TypeName and a nil NamePattern match all fields.
+
+  - ToDB is called for parameters of type GoType bound to a matching parameter field. The returned value
+    is converted like any other argument to the parameter field type.
+  - FromDB is called for the decoded (non NULL) values of matching result columns and output parameters
+    before they are passed to database/sql. Returning a value of the scan destination type enables
+    scanning database values directly into application types.
+
+Either ToDB or FromDB might be nil if the converter should only be used in one direction.
+*/
+type Converter struct {
+	TypeName    string         // database type name of matching fields (case insensitive)
+	NamePattern *regexp.Regexp // name pattern of matching fields
+	GoType      reflect.Type   // Go type converted by ToDB
+	ToDB        func(v any) (driver.Value, error)
+	FromDB      func(v driver.Value) (any, error)
+}
+
+func (c *Converter) match(typeName, name string) bool {
+	if c.TypeName != "" && !strings.EqualFold(c.TypeName, typeName) {
+		return false
+	}
+	if c.NamePattern != nil && !c.NamePattern.MatchString(name) {
+		return false
+	}
+	return true
+}
+
+type converterRegistry struct {
+	mu         sync.RWMutex
+	converters []*Converter
+}
+
+var stdConverterRegistry = &converterRegistry{}
+
+func (r *converterRegistry) register(c *Converter) error {
+	switch {
+	case c == nil:
+		return errors.New("converter: nil converter")
+	case c.ToDB == nil && c.FromDB == nil:
+		return errors.New("converter: neither ToDB nor FromDB is set")
+	case c.ToDB != nil && c.GoType == nil:
+		return errors.New("converter: GoType needs to be set for ToDB conversions")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.converters = append(r.converters, c)
+	return nil
+}
+
+// toDB returns the first matching converter for binding values of type t.
+func (r *converterRegistry) toDB(t reflect.Type, typeName, name string) *Converter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.converters {
+		if c.ToDB != nil && c.GoType == t && c.match(typeName, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// fromDB returns the first matching converter for scanning values of a field (nil if none matches).
+func (r *converterRegistry) fromDB(typeName, name string) *Converter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, c := range r.converters {
+		if c.FromDB != nil && c.match(typeName, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+func (r *converterRegistry) empty() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.converters) == 0
+}
+
+/*
+RegisterConverter registers converter c for parameter binding and row scanning of all connections.
+Converters are evaluated in registration order and the first matching converter is used.
+Converters should be registered during application initialization before any database
+access takes place.
+*/
+func RegisterConverter(c *Converter) error { return stdConverterRegistry.register(c) }
+
+type field interface {
+	TypeName() string
+	Name() string
+}
+
+// fromDBConverters returns the scan converters of fields (nil if no converter matches).
+func fromDBConverters[F field](fields []F) []*Converter {
+	if stdConverterRegistry.empty() {
+		return nil
+	}
+	var converters []*Converter
+	for i, f := range fields {
+		if c := stdConverterRegistry.fromDB(f.TypeName(), f.Name()); c != nil {
+			if converters == nil {
+				converters = make([]*Converter, len(fields))
+			}
+			converters[i] = c
+		}
+	}
+	return converters
+}
+
+// convertFromDB applies the scan converters to the row values dest.
+func convertFromDB(converters []*Converter, dest []driver.Value) error {
+	for i, c := range converters {
+		if c == nil || dest[i] == nil {
+			continue
+		}
+		v, err := c.FromDB(dest[i])
+		if err != nil {
+			return err
+		}
+		dest[i] = v
+	}
+	return nil
+}
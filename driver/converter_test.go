@@ -0,0 +1,107 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+)
+
+type testCelsius float64
+
+type testConverterField struct{ typeName, name string }
+
+func (f testConverterField) TypeName() string { return f.typeName }
+func (f testConverterField) Name() string     { return f.name }
+
+func testConverterRegistry(t *testing.T) {
+	r := &converterRegistry{}
+
+	if err := r.register(&Converter{TypeName: "DOUBLE"}); err == nil {
+		t.Fatal("missing conversion functions: error expected")
+	}
+	if err := r.register(&Converter{ToDB: func(v any) (driver.Value, error) { return v, nil }}); err == nil {
+		t.Fatal("missing go type: error expected")
+	}
+
+	celsius := &Converter{
+		TypeName:    "double",
+		NamePattern: regexp.MustCompile(`^TEMP_`),
+		GoType:      hdbreflect.TypeFor[testCelsius](),
+		ToDB:        func(v any) (driver.Value, error) { return float64(v.(testCelsius)), nil },
+		FromDB:      func(v driver.Value) (any, error) { return testCelsius(v.(float64)), nil },
+	}
+	upper := &Converter{
+		TypeName: "NVARCHAR",
+		FromDB:   func(v driver.Value) (any, error) { return strings.ToUpper(string(v.([]byte))), nil },
+	}
+	for _, c := range []*Converter{celsius, upper} {
+		if err := r.register(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		typeName, name string
+		fromDB         *Converter
+		toDB           *Converter
+	}{
+		{"DOUBLE", "TEMP_MIN", celsius, celsius},
+		{"DOUBLE", "PRESSURE", nil, nil},
+		{"REAL", "TEMP_MAX", nil, nil},
+		{"NVARCHAR", "NAME", upper, nil},
+	}
+	for i, test := range tests {
+		if c := r.fromDB(test.typeName, test.name); c != test.fromDB {
+			t.Fatalf("test %d: unexpected fromDB converter %v", i, c)
+		}
+		if c := r.toDB(hdbreflect.TypeFor[testCelsius](), test.typeName, test.name); c != test.toDB {
+			t.Fatalf("test %d: unexpected toDB converter %v", i, c)
+		}
+	}
+}
+
+func testConvertFromDB(t *testing.T) {
+	converters := []*Converter{
+		nil,
+		{FromDB: func(v driver.Value) (any, error) { return testCelsius(v.(float64)), nil }},
+	}
+	dest := []driver.Value{1.5, 21.5}
+	if err := convertFromDB(converters, dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest[0] != 1.5 || dest[1] != testCelsius(21.5) {
+		t.Fatalf("values %v - expected %v", dest, []any{1.5, testCelsius(21.5)})
+	}
+	// NULL values are not converted
+	dest = []driver.Value{nil, nil}
+	if err := convertFromDB(converters, dest); err != nil {
+		t.Fatal(err)
+	}
+	if dest[1] != nil {
+		t.Fatalf("value %v - expected nil", dest[1])
+	}
+
+	// no registered converters
+	if converters := fromDBConverters([]testConverterField{{"DOUBLE", "TEMP_MIN"}}); converters != nil {
+		t.Fatalf("converters %v - expected nil", converters)
+	}
+}
+
+func TestConverter(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"registry", testConverterRegistry},
+		{"convertFromDB", testConvertFromDB},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// CSV time value layouts tried in order.
+var csvTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+	time.TimeOnly,
+}
+
+func parseCSVTime(s string) (time.Time, error) {
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time value %s", s)
+}
+
+// csvConverter returns the conversion function of CSV values for parameter field f.
+func csvConverter(f *p.ParameterField) func(s string) (any, error) {
+	switch f.DataType() {
+	case p.DtString, p.DtBytes, p.DtLob:
+		return func(s string) (any, error) { return s, nil }
+	case p.DtTime:
+		return func(s string) (any, error) {
+			if s == "" {
+				return nil, nil
+			}
+			return parseCSVTime(s)
+		}
+	default: // numeric and boolean values are converted by the driver
+		return func(s string) (any, error) {
+			if s == "" {
+				return nil, nil
+			}
+			return s, nil
+		}
+	}
+}
+
+/*
+LoadCSV inserts the records read by rd into table via a prepared bulk insert and returns the number of inserted rows.
+
+The first record needs to be a header containing the table column names the record fields are inserted into.
+All further records need to have the same number of fields as the header (even if rd.FieldsPerRecord is negative).
+The field values are converted according to the parameter metadata of the prepared insert statement:
+  - empty fields of non character and binary columns are inserted as NULL values,
+  - time values are parsed in RFC 3339, 'YYYY-MM-DD HH:MM:SS.FFFFFFFFF', 'YYYY-MM-DD' or 'HH:MM:SS' format,
+  - all other values are converted by the driver like string arguments.
+
+Please see BulkError for the error handling of bulk executions.
+*/
+func LoadCSV(ctx context.Context, sqlConn *sql.Conn, table Identifier, rd *csv.Reader) (int64, error) {
+	header, err := rd.Read()
+	if err != nil {
+		return 0, fmt.Errorf("csv header: %w", err)
+	}
+	columns := make([]Identifier, len(header))
+	for i, name := range header {
+		columns[i] = Identifier(name)
+	}
+
+	var rowsAffected int64
+	err = sqlConn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*conn)
+		if !ok {
+			return fmt.Errorf("invalid driver connection type %T", driverConn)
+		}
+		ds, err := c.PrepareContext(ctx, columnsQuery("insert into", table, columns, ""))
+		if err != nil {
+			return err
+		}
+		defer ds.Close()
+		s := ds.(*stmt)
+
+		converters := make([]func(s string) (any, error), len(s.pr.parameterFields))
+		for i, f := range s.pr.parameterFields {
+			converters[i] = csvConverter(f)
+		}
+
+		rows := func(args []any) error {
+			record, err := rd.Read()
+			if errors.Is(err, io.EOF) {
+				return ErrEndOfRows
+			}
+			if err != nil {
+				return err
+			}
+			line, _ := rd.FieldPos(0)
+			if len(record) != len(header) {
+				return fmt.Errorf("csv line %d: number of fields %d - expected %d", line, len(record), len(header))
+			}
+			for i, field := range record {
+				if args[i], err = converters[i](field); err != nil {
+					return fmt.Errorf("csv line %d column %s: %w", line, header[i], err)
+				}
+			}
+			return nil
+		}
+
+		r, err := s.ExecContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: rows}})
+		if r != nil {
+			rowsAffected, _ = r.RowsAffected()
+		}
+		return err
+	})
+	return rowsAffected, err
+}
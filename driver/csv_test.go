@@ -0,0 +1,104 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testParseCSVTime(t *testing.T, db *sql.DB) {
+	tests := []struct {
+		s string
+		t time.Time
+	}{
+		{"2024-02-29T13:14:15.5Z", time.Date(2024, 2, 29, 13, 14, 15, 500_000_000, time.UTC)},
+		{"2024-02-29 13:14:15", time.Date(2024, 2, 29, 13, 14, 15, 0, time.UTC)},
+		{"2024-02-29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"13:14:15", time.Date(0, 1, 1, 13, 14, 15, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		v, err := parseCSVTime(test.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !v.Equal(test.t) {
+			t.Fatalf("time %s - expected %s", v, test.t)
+		}
+	}
+	if _, err := parseCSVTime("invalid"); err == nil {
+		t.Fatal("error expected")
+	}
+}
+
+func testLoadCSV(t *testing.T, db *sql.DB) {
+	const data = `I,D,S,T
+1,1.5,one,2024-02-29
+2,,,
+3,3.5,"three, with comma",2024-03-01 10:11:12
+`
+
+	ctx := context.Background()
+
+	tableName := RandomIdentifier("loadCSV")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create table %s (i integer, d decimal(10,2), s nvarchar(20), t timestamp)", tableName)); err != nil {
+		t.Fatalf("create table failed: %s", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rowsAffected, err := LoadCSV(ctx, conn, tableName, csv.NewReader(strings.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAffected != 3 {
+		t.Fatalf("rows affected %d - expected %d", rowsAffected, 3)
+	}
+
+	var numNull int
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s where d is null and t is null and s = ''", tableName)).Scan(&numNull); err != nil {
+		t.Fatal(err)
+	}
+	if numNull != 1 {
+		t.Fatalf("number of null records %d - expected %d", numNull, 1)
+	}
+
+	var s string
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("select s from %s where i = 3", tableName)).Scan(&s); err != nil {
+		t.Fatal(err)
+	}
+	if s != "three, with comma" {
+		t.Fatalf("value %s - expected %s", s, "three, with comma")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, db *sql.DB)
+	}{
+		{"parseCSVTime", testParseCSVTime},
+		{"loadCSV", testLoadCSV},
+	}
+
+	db := MT.DB()
+	for _, test := range tests {
+		test := test // new dfv to run in parallel
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.fct(t, db)
+		})
+	}
+}
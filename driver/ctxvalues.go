@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+var (
+	selectStmt = regexp.MustCompile(`(?i)^\s*(select|with)\s+`)                // sql statement beginning with select or with (common table expression)
+	hintStmt   = regexp.MustCompile(`(?i)\swith\s+hint\s*\(|\sfor\s+update\b`) // sql statement containing a hint or locking rows
+)
+
+type columnarCtxKey struct{}
+
+// withColumnar returns a copy of ctx requesting the columnar decoding of query results (see QueryColumns).
+func withColumnar(ctx context.Context) context.Context {
+	return context.WithValue(ctx, columnarCtxKey{}, true)
+}
+
+// columnarFrom returns true if ctx requests the columnar decoding of query results.
+func columnarFrom(ctx context.Context) bool {
+	columnar, _ := ctx.Value(columnarCtxKey{}).(bool)
+	return columnar
+}
+
+// lobChunkSize returns the lob chunk size set by ctx, the lob chunk size of the connector otherwise.
+func (c *conn) lobChunkSize(ctx context.Context) int {
+	if lobChunkSize, ok := hdbctx.LobChunkSizeFrom(ctx); ok {
+		return lobChunkSize
+	}
+	return c.attrs._lobChunkSize
+}
+
+// readOnlyQuery adds the system replication result lag hint to select queries if ctx marks queries as read-only.
+func readOnlyQuery(ctx context.Context, query string) string {
+	maxLag, ok := hdbctx.ReadOnlyFrom(ctx)
+	if !ok || !selectStmt.MatchString(query) || hintStmt.MatchString(query) {
+		return query
+	}
+	query = strings.TrimRight(query, "; \t\r\n")
+	if maxLag <= 0 {
+		return query + " with hint(result_lag('hana_sr'))"
+	}
+	return fmt.Sprintf("%s with hint(result_lag('hana_sr', %d))", query, int64(math.Ceil(maxLag.Seconds())))
+}
+
+// traceAttrs returns attrs extended by the trace attributes set by ctx.
+func traceAttrs(ctx context.Context, attrs ...slog.Attr) []slog.Attr {
+	if ctxAttrs, ok := hdbctx.TraceAttrsFrom(ctx); ok {
+		return append(attrs, ctxAttrs...)
+	}
+	return attrs
+}
+
+/*
+clientInfo returns the client info part of a statement execution setting the session context values of ctx
+which differ from the values set in the session by previous statements and resetting the values set by previous
+statements but not by ctx (to the connector session variable or to an empty value).
+*/
+func (c *conn) clientInfo(ctx context.Context) p.ClientInfo {
+	values, _ := hdbctx.SessionContextFrom(ctx)
+	var ci p.ClientInfo
+	set := func(k, v string) {
+		if ci == nil {
+			ci = p.ClientInfo{}
+		}
+		ci[k] = v
+	}
+	for k, v := range values {
+		if prev, ok := c.sessCtx[k]; !ok || prev != v {
+			set(k, v)
+		}
+	}
+	for k := range c.sessCtx {
+		if _, ok := values[k]; !ok {
+			set(k, c.attrs._sessionVariables[k])
+		}
+	}
+	c.sessCtx = values // values must not be modified (see hdbctx.SessionContextFrom)
+	return ci
+}
@@ -0,0 +1,117 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestReadOnlyQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		maxLag   time.Duration
+		expected string
+	}{
+		{"select * from dummy", 0, "select * from dummy with hint(result_lag('hana_sr'))"},
+		{"SELECT * FROM dummy;", 1500 * time.Millisecond, "SELECT * FROM dummy with hint(result_lag('hana_sr', 2))"},
+		{"with t as (select 1 from dummy) select * from t", 0, "with t as (select 1 from dummy) select * from t with hint(result_lag('hana_sr'))"},
+		{"select * from dummy with hint(no_cs_join)", 0, "select * from dummy with hint(no_cs_join)"},
+		{"select * from t for update", 0, "select * from t for update"},
+		{"insert into t values (1)", 0, "insert into t values (1)"},
+	}
+
+	if query := readOnlyQuery(context.Background(), tests[0].query); query != tests[0].query {
+		t.Fatalf("query %s - expected %s", query, tests[0].query)
+	}
+	for _, test := range tests {
+		if query := readOnlyQuery(hdbctx.WithReadOnly(context.Background(), test.maxLag), test.query); query != test.expected {
+			t.Fatalf("query %s - expected %s", query, test.expected)
+		}
+	}
+}
+
+func TestStmtContext(t *testing.T) {
+	c := &conn{attrs: newConnAttrs()}
+
+	if sc, err := c.stmtContext(context.Background()); err != nil || sc != nil {
+		t.Fatalf("statement context %v error %v - expected nil", sc, err)
+	}
+
+	ctx := hdbctx.WithStatementTimeout(context.Background(), 90*time.Second)
+	if _, err := c.stmtContext(ctx); !errors.Is(err, errStatementTimeout) { // query timeouts not requested
+		t.Fatalf("error %v - expected %v", err, errStatementTimeout)
+	}
+	c.attrs._ctxQueryTimeout = true
+	sc, err := c.stmtContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timeout := sc.QueryTimeoutOrZero(); timeout != 90 {
+		t.Fatalf("query timeout %d - expected %d", timeout, 90)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if sc, err = c.stmtContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if timeout := sc.QueryTimeoutOrZero(); timeout != 30 { // shorter context deadline
+		t.Fatalf("query timeout %d - expected %d", timeout, 30)
+	}
+}
+
+func TestSessionContext(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	srv.Handle("update t set x = 1", &hdbtest.Response{})
+	srv.Handle("update t set x = ?", &hdbtest.Response{})
+
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetSessionVariables(SessionVariables{"CLIENT": "000"})
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1) // same session for all statements
+
+	withSessionContext := func(values map[string]string) context.Context {
+		if values == nil {
+			return context.Background()
+		}
+		return hdbctx.WithSessionContext(context.Background(), values)
+	}
+
+	tests := []struct {
+		values   map[string]string
+		args     []any
+		expected map[string]string
+	}{
+		{map[string]string{"APPLICATIONUSER": "alice"}, nil, map[string]string{"APPLICATIONUSER": "alice", "CLIENT": "000"}},
+		{map[string]string{"APPLICATIONUSER": "bob"}, []any{"1"}, map[string]string{"APPLICATIONUSER": "bob", "CLIENT": "000"}},
+		{nil, nil, map[string]string{"CLIENT": "000"}},
+		{map[string]string{"CLIENT": "100"}, []any{"1"}, map[string]string{"CLIENT": "100"}},
+		{nil, []any{"1"}, map[string]string{"CLIENT": "000"}},
+	}
+
+	for i, test := range tests {
+		query := "update t set x = 1"
+		if test.args != nil {
+			query = "update t set x = ?"
+		}
+		if _, err := db.ExecContext(withSessionContext(test.values), query, test.args...); err != nil {
+			t.Fatal(err)
+		}
+		executions := srv.Executions()
+		if sessionContext := executions[len(executions)-1].SessionContext; !maps.Equal(sessionContext, test.expected) {
+			t.Fatalf("test %d: session context %v - expected %v", i, sessionContext, test.expected)
+		}
+	}
+}
@@ -279,6 +279,18 @@ func (dtt *dttSpatial) run(t *testing.T, db *sql.DB, dfv int) {
 			t.Fatalf("test %d: x value %v - expected %v", i, x, string(wkb))
 		}
 
+		var ng spatial.NullGeometry
+		if err := ng.Scan(x); err != nil {
+			t.Fatal(err)
+		}
+		ngWKB, err := spatial.EncodeWKB(ng.Geometry, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(ngWKB) != x {
+			t.Fatalf("test %d: geometry value %v - expected %v", i, string(ngWKB), x)
+		}
+
 		ewkb, err := spatial.EncodeEWKB(dtt.testData[i], false, srid)
 		if err != nil {
 			t.Fatal(err)
@@ -848,6 +860,15 @@ func checkAlphanum(ct types.Column, dfv int, in, out any) (bool, error) {
 	return _checkAlphanum(in, out)
 }
 
+// checkAlphanumPadding checks numeric alphanum values padded with leading zeroes (see Connector.SetAlphanumPadding).
+func checkAlphanumPadding(ct types.Column, dfv int, in, out any) (bool, error) {
+	length, ok := ct.Length()
+	if !ok {
+		return false, fmt.Errorf("cannot detect fieldlength of %v", ct)
+	}
+	return _checkAlphanumVarchar(in, out, int(length))
+}
+
 func compareLob(in, out Lob) (bool, error) {
 	if _, err := in.rd.(*bytes.Reader).Seek(0, io.SeekStart); err != nil {
 		return false, err
@@ -937,6 +958,7 @@ func TestDataType(t *testing.T) {
 		&dttDef{types.NewNullNChar(20), checkFixString, stringTestData},
 		&dttDef{types.NewNullNVarchar(20), checkString, stringTestData},
 		&dttDef{types.NewNullAlphanum(20), checkAlphanum, alphanumTestData},
+		&dttDef{types.NewNullShorttext(20), checkString, stringTestData},
 		&dttDef{types.NewNullBinary(20), checkFixBytes, binaryTestData},
 		&dttDef{types.NewNullVarbinary(20), checkBytes, binaryTestData},
 
@@ -997,3 +1019,30 @@ func TestDataType(t *testing.T) {
 		})
 	}
 }
+
+func TestAlphanumPadding(t *testing.T) {
+	t.Parallel()
+
+	test := &dttDef{types.NewNullAlphanum(20), checkAlphanumPadding, alphanumTestData}
+
+	version := int(MT.Version().Major())
+
+	for _, dfv := range p.SupportedDfvs(testing.Short()) {
+		if !test.columnType().IsSupported(version, dfv) {
+			continue
+		}
+		dfv := dfv // new dfv to run in parallel
+
+		t.Run(fmt.Sprintf("dfv %d", dfv), func(t *testing.T) {
+			t.Parallel()
+
+			connector := MT.NewConnector()
+			connector.SetDfv(dfv)
+			connector.SetAlphanumPadding(true)
+			db := sql.OpenDB(connector)
+			t.Cleanup(func() { db.Close() })
+
+			test.run(t, db, dfv)
+		})
+	}
+}
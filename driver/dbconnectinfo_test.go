@@ -0,0 +1,84 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/dial"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestConnectorDBConnectInfo(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	// no credentials needed as no session is authenticated
+	ci, err := NewBasicAuthConnector(srv.Addr(), "", "").DBConnectInfo(context.Background(), hdbtest.DatabaseName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port, err := net.SplitHostPort(srv.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci.DatabaseName != hdbtest.DatabaseName || !ci.IsConnected || ci.Host != host || strconv.Itoa(ci.Port) != port {
+		t.Fatalf("invalid connect info %s", ci)
+	}
+}
+
+func TestPingSQLTrace(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	sqlTraceOn := SQLTrace()
+	SetSQLTrace(true)
+	defer SetSQLTrace(sqlTraceOn)
+
+	buf := new(bytes.Buffer)
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetLogger(slog.New(slog.NewTextHandler(buf, nil)))
+	db := OpenDB(connector)
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	// the mock server supports protocol level pings - the dummy query must not be traced
+	if s := buf.String(); !strings.Contains(s, pingDBConnectInfo) || strings.Contains(s, dummyQuery) {
+		t.Fatalf("sql trace %q - expected %s", s, pingDBConnectInfo)
+	}
+}
+
+func TestConnectorDBConnectInfoWithoutPort(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	const host = "sidecar" // connector host without port (e.g. custom dialer or unix domain socket)
+
+	connector := NewBasicAuthConnector(host, "", "")
+	connector.SetDialer(dial.DialerFunc(func(ctx context.Context, address string, options dial.DialerOptions) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", srv.Addr())
+	}))
+	ci, err := connector.DBConnectInfo(context.Background(), hdbtest.DatabaseName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ci.IsConnected || ci.Host != host || ci.Port != 0 {
+		t.Fatalf("invalid connect info %s - expected host %s without port", ci, host)
+	}
+}
@@ -1,7 +1,9 @@
 package driver
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"math/big"
 )
@@ -11,9 +13,9 @@ type Decimal big.Rat
 
 // Scan implements the database/sql/Scanner interface.
 func (d *Decimal) Scan(src any) error {
-	r, ok := src.(*big.Rat)
-	if !ok {
-		return fmt.Errorf("decimal: invalid data type %T", src)
+	r, err := ratValue(src)
+	if err != nil {
+		return err
 	}
 	(*big.Rat)(d).Set(r)
 	return nil
@@ -38,9 +40,9 @@ func (n *NullDecimal) Scan(value any) error {
 		n.Valid = false
 		return nil
 	}
-	r, ok := value.(*big.Rat)
-	if !ok {
-		return fmt.Errorf("decimal: invalid data type %T", value)
+	r, err := ratValue(value)
+	if err != nil {
+		return err
 	}
 	n.Valid = true
 	if n.Decimal == nil {
@@ -60,3 +62,157 @@ func (n NullDecimal) Value() (driver.Value, error) {
 	}
 	return (*big.Rat)(n.Decimal), nil
 }
+
+var (
+	bigIntTwo  = big.NewInt(2)
+	bigIntFive = big.NewInt(5)
+	bigIntTen  = big.NewInt(10)
+)
+
+// decimalString returns the exact decimal representation of r and true if r has a finite decimal expansion,
+// which is always the case for decimal database field values.
+func decimalString(r *big.Rat) (string, bool) {
+	if r.IsInt() {
+		return r.Num().String(), true
+	}
+	d := new(big.Int).Set(r.Denom())
+	m := new(big.Int)
+	count := func(f *big.Int) int {
+		n := 0
+		for {
+			q, rem := new(big.Int).QuoRem(d, f, m)
+			if rem.Sign() != 0 {
+				return n
+			}
+			d = q
+			n++
+		}
+	}
+	twos, fives := count(bigIntTwo), count(bigIntFive)
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return "", false
+	}
+	return r.FloatString(max(twos, fives)), true
+}
+
+// ratValue returns the *big.Rat of a scanned decimal database field value.
+func ratValue(src any) (*big.Rat, error) {
+	switch src := src.(type) {
+	case *big.Rat:
+		return src, nil
+	case string:
+		if r, ok := new(big.Rat).SetString(src); ok {
+			return r, nil
+		}
+	case []byte:
+		if r, ok := new(big.Rat).SetString(string(src)); ok {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("decimal: invalid data type %T", src)
+}
+
+var errDecimalNull = errors.New("decimal: cannot assign NULL value")
+
+type decimalAssigner struct {
+	dest any
+}
+
+/*
+NewDecimalAssigner returns a scanner assigning decimal database field values (DECIMAL, SMALLDECIMAL)
+to dest without loss of precision. Supported destinations are
+
+  - *big.Rat
+  - *big.Int (the value must be an integer, see ScaledInt for values with fractional digits)
+  - *string (exact decimal representation like "-123.4500")
+  - sql.Scanner implementations accepting a decimal string like the ones of github.com/shopspring/decimal.
+
+NULL values are only supported for sql.Scanner destinations.
+*/
+func NewDecimalAssigner(dest any) sql.Scanner { return decimalAssigner{dest: dest} }
+
+// Scan implements the Scanner interface.
+func (a decimalAssigner) Scan(src any) error {
+	if src == nil {
+		if scanner, ok := a.dest.(sql.Scanner); ok {
+			return scanner.Scan(nil)
+		}
+		return errDecimalNull
+	}
+	r, err := ratValue(src)
+	if err != nil {
+		return err
+	}
+	switch dest := a.dest.(type) {
+	case *big.Rat:
+		dest.Set(r)
+	case *big.Int:
+		if !r.IsInt() {
+			return fmt.Errorf("decimal: value %s is not an integer", r.FloatString(6))
+		}
+		dest.Set(r.Num())
+	case *string:
+		s, ok := decimalString(r)
+		if !ok {
+			return fmt.Errorf("decimal: value %s has no finite decimal representation", r)
+		}
+		*dest = s
+	case sql.Scanner:
+		s, ok := decimalString(r)
+		if !ok {
+			return fmt.Errorf("decimal: value %s has no finite decimal representation", r)
+		}
+		return dest.Scan(s)
+	default:
+		return fmt.Errorf("decimal: invalid destination type %T", a.dest)
+	}
+	return nil
+}
+
+/*
+A ScaledInt represents a decimal value as integer Int scaled by 10^-Scale (value = Int * 10^-Scale).
+ScaledInt implements the Scanner and the Valuer interface, so it can be used to scan and bind
+decimal database field values as scaled integers.
+
+Scanning fails if the value cannot be represented with Scale fractional digits without loss.
+*/
+type ScaledInt struct {
+	Int   *big.Int
+	Scale int
+}
+
+func pow10(n int) *big.Int { return new(big.Int).Exp(bigIntTen, big.NewInt(int64(n)), nil) }
+
+// Scan implements the Scanner interface.
+func (i *ScaledInt) Scan(src any) error {
+	if src == nil {
+		return errDecimalNull
+	}
+	r, err := ratValue(src)
+	if err != nil {
+		return err
+	}
+	if i.Scale < 0 {
+		return fmt.Errorf("decimal: invalid scale %d", i.Scale)
+	}
+	r = new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(i.Scale)))
+	if !r.IsInt() {
+		return fmt.Errorf("decimal: value cannot be represented with scale %d", i.Scale)
+	}
+	if i.Int == nil {
+		i.Int = new(big.Int)
+	}
+	i.Int.Set(r.Num())
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (i ScaledInt) Value() (driver.Value, error) {
+	if i.Int == nil {
+		return nil, fmt.Errorf("invalid scaled integer value %v", i.Int)
+	}
+	if i.Scale < 0 {
+		return nil, fmt.Errorf("decimal: invalid scale %d", i.Scale)
+	}
+	return new(big.Rat).SetFrac(i.Int, pow10(i.Scale)), nil
+}
@@ -0,0 +1,138 @@
+package driver
+
+import (
+	"math/big"
+	"testing"
+)
+
+// testStringDecimal is a sql.Scanner accepting decimal strings like shopspring decimals.
+type testStringDecimal struct {
+	s     string
+	valid bool
+}
+
+func (d *testStringDecimal) Scan(src any) error {
+	if src == nil {
+		d.s, d.valid = "", false
+		return nil
+	}
+	d.s, d.valid = src.(string), true
+	return nil
+}
+
+func testDecimalAssigner(t *testing.T) {
+	src := big.NewRat(-12345, 100) // -123.45
+
+	var r big.Rat
+	if err := NewDecimalAssigner(&r).Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if r.Cmp(src) != 0 {
+		t.Fatalf("rat %s - expected %s", &r, src)
+	}
+
+	var s string
+	if err := NewDecimalAssigner(&s).Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if s != "-123.45" {
+		t.Fatalf("string %s - expected %s", s, "-123.45")
+	}
+	if err := NewDecimalAssigner(&s).Scan(big.NewRat(1, 3)); err == nil {
+		t.Fatal("infinite decimal expansion: error expected")
+	}
+	if err := NewDecimalAssigner(&s).Scan(nil); err == nil {
+		t.Fatal("null value: error expected")
+	}
+
+	var i big.Int
+	if err := NewDecimalAssigner(&i).Scan(big.NewRat(42, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if i.Int64() != 42 {
+		t.Fatalf("int %s - expected %d", &i, 42)
+	}
+	if err := NewDecimalAssigner(&i).Scan(src); err == nil {
+		t.Fatal("fractional value: error expected")
+	}
+
+	var ds testStringDecimal
+	if err := NewDecimalAssigner(&ds).Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if !ds.valid || ds.s != "-123.45" {
+		t.Fatalf("decimal string %s - expected %s", ds.s, "-123.45")
+	}
+	if err := NewDecimalAssigner(&ds).Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if ds.valid {
+		t.Fatal("decimal string: null value expected")
+	}
+
+	var f float64
+	if err := NewDecimalAssigner(&f).Scan(src); err == nil {
+		t.Fatal("invalid destination: error expected")
+	}
+}
+
+func testDecimalString(t *testing.T) {
+	tests := []struct {
+		r *big.Rat
+		s string
+	}{
+		{big.NewRat(0, 1), "0"},
+		{big.NewRat(-7, 1), "-7"},
+		{big.NewRat(1, 8), "0.125"},
+		{big.NewRat(3, 20), "0.15"},
+		{big.NewRat(123456789, 1000000), "123.456789"},
+	}
+	for _, test := range tests {
+		s, ok := decimalString(test.r)
+		if !ok || s != test.s {
+			t.Fatalf("decimal string of %s: %s - expected %s", test.r, s, test.s)
+		}
+	}
+	if _, ok := decimalString(big.NewRat(1, 3)); ok {
+		t.Fatal("infinite decimal expansion: false expected")
+	}
+}
+
+func testScaledInt(t *testing.T) {
+	si := ScaledInt{Scale: 3}
+	if err := si.Scan(big.NewRat(-12345, 100)); err != nil {
+		t.Fatal(err)
+	}
+	if si.Int.Int64() != -123450 {
+		t.Fatalf("scaled int %s - expected %d", si.Int, -123450)
+	}
+	v, err := si.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := v.(*big.Rat); r.Cmp(big.NewRat(-12345, 100)) != 0 {
+		t.Fatalf("value %s - expected %s", r, big.NewRat(-12345, 100))
+	}
+
+	si = ScaledInt{Scale: 1}
+	if err := si.Scan(big.NewRat(-12345, 100)); err == nil {
+		t.Fatal("loss of precision: error expected")
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"decimalAssigner", testDecimalAssigner},
+		{"decimalString", testDecimalString},
+		{"scaledInt", testScaledInt},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
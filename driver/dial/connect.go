@@ -0,0 +1,74 @@
+package dial
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// bufConn is a net.Conn reading data buffered by rd first.
+type bufConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+func (c *bufConn) Read(b []byte) (int, error) { return c.rd.Read(b) }
+
+/*
+NewHTTPConnectDialer returns a Dialer tunneling the database connection through the HTTP proxy given by rawURL
+(http:// or https://) via the HTTP CONNECT method. This enables connecting to the database in environments
+where only HTTP(S) egress via a proxy is allowed.
+
+tlsConfig is used for https proxies (nil: default configuration) and header is added to the CONNECT request.
+User information of rawURL is sent as basic proxy authorization.
+*/
+func NewHTTPConnectDialer(rawURL string, tlsConfig *tls.Config, header http.Header) (Dialer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("dial: invalid proxy url scheme %s", u.Scheme)
+	}
+	header = header.Clone()
+	return DialerFunc(func(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+		if options.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+			defer cancel()
+		}
+		conn, err := dialEndpoint(ctx, u, tlsConfig, options)
+		if err != nil {
+			return nil, err
+		}
+
+		req := &http.Request{Method: http.MethodConnect, URL: &url.URL{Opaque: address}, Host: address, Header: http.Header{}}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if u.User != nil {
+			password, _ := u.User.Password()
+			req.SetBasicAuth(u.User.Username(), password)
+			req.Header["Proxy-Authorization"] = req.Header["Authorization"]
+			delete(req.Header, "Authorization")
+		}
+
+		resp, rd, err := roundTrip(ctx, conn, req)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			conn.Close()
+			return nil, fmt.Errorf("dial: proxy %s CONNECT %s failed: %s", u.Redacted(), address, resp.Status)
+		}
+		if rd.Buffered() == 0 {
+			return conn, nil
+		}
+		return &bufConn{Conn: conn, rd: rd}, nil
+	}), nil
+}
@@ -3,13 +3,22 @@ package dial
 
 import (
 	"context"
+	"errors"
 	"net"
+	"sync"
 	"time"
 )
 
 // DialerOptions contains optional parameters that might be used by a Dialer.
 type DialerOptions struct {
 	Timeout, TCPKeepAlive time.Duration
+	// TCPKeepAliveInterval is the interval between tcp keep-alive probes (0: TCPKeepAlive is used).
+	// It is supported on linux, freebsd and netbsd only and ignored otherwise.
+	TCPKeepAliveInterval time.Duration
+	// TCPDelay enables Nagle's algorithm (TCP_NODELAY off). By default TCP_NODELAY is on.
+	TCPDelay bool
+	// ReadBufferSize and WriteBufferSize are the socket receive and send buffer sizes (0: system default).
+	ReadBufferSize, WriteBufferSize int
 }
 
 // The Dialer interface needs to be implemented by custom Dialers. A Dialer for providing a custom driver connection
@@ -25,6 +34,87 @@ var DefaultDialer Dialer = &dialer{}
 type dialer struct{}
 
 func (d *dialer) DialContext(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
-	dialer := net.Dialer{Timeout: options.Timeout, KeepAlive: options.TCPKeepAlive}
-	return dialer.DialContext(ctx, "tcp", address)
+	dialer := net.Dialer{Timeout: options.Timeout, KeepAlive: options.TCPKeepAlive, Control: options.control}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if err := options.setTCPOptions(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
 }
+
+// The DialerFunc type is an adapter to allow the use of ordinary functions as Dialer.
+type DialerFunc func(ctx context.Context, address string, options DialerOptions) (net.Conn, error)
+
+// DialContext implements the Dialer interface.
+func (f DialerFunc) DialContext(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+	return f(ctx, address, options)
+}
+
+/*
+A ContextDialer dials a network address with a context and is implemented by net.Dialer
+and many proxy dialer implementations (e.g. SOCKS5 dialers of golang.org/x/net/proxy).
+*/
+type ContextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+/*
+WrapContextDialer returns a Dialer establishing tcp connections via a ContextDialer.
+This enables connecting to the database via proxies, SSH tunnels or cloud bastions.
+
+As a ContextDialer does not support DialerOptions, the option Timeout is applied to the
+dial context and the option TCPKeepAlive is ignored.
+*/
+func WrapContextDialer(d ContextDialer) Dialer {
+	return DialerFunc(func(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+		if options.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+			defer cancel()
+		}
+		return d.DialContext(ctx, "tcp", address)
+	})
+}
+
+/*
+NewUnixDialer returns a Dialer connecting to the unix domain socket given by path.
+The address provided to DialContext is ignored.
+*/
+func NewUnixDialer(path string) Dialer {
+	return DialerFunc(func(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+		dialer := net.Dialer{Timeout: options.Timeout}
+		return dialer.DialContext(ctx, "unix", path)
+	})
+}
+
+// ErrConnUsed is returned by a connection dialer if the connection was already provided.
+var ErrConnUsed = errors.New("dial: connection already used")
+
+type connDialer struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (d *connDialer) DialContext(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.conn == nil {
+		return nil, ErrConnUsed
+	}
+	conn := d.conn
+	d.conn = nil
+	return conn, nil
+}
+
+/*
+NewConnDialer returns a Dialer providing the already established connection conn instead of dialing.
+This enables bypassing TCP dialing e.g. in tests or sidecar-proxy deployments.
+
+As conn can only be used for one database connection, all subsequent DialContext calls return ErrConnUsed.
+The address provided to DialContext and the DialerOptions are ignored.
+*/
+func NewConnDialer(conn net.Conn) Dialer { return &connDialer{conn: conn} }
@@ -0,0 +1,62 @@
+//go:build linux
+
+package dial
+
+import (
+	"net"
+	"syscall"
+	"testing"
+)
+
+func checkSocketOptions(t *testing.T, conn *net.TCPConn, options DialerOptions) {
+	t.Helper()
+
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	getsockoptInt := func(level, opt int) int {
+		var v int
+		if cerr := rawConn.Control(func(fd uintptr) {
+			v, err = syscall.GetsockoptInt(int(fd), level, opt)
+		}); cerr != nil {
+			t.Fatal(cerr)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	noDelay := 1
+	if options.TCPDelay {
+		noDelay = 0
+	}
+	if v := getsockoptInt(syscall.IPPROTO_TCP, syscall.TCP_NODELAY); v != noDelay {
+		t.Fatalf("TCP_NODELAY %d - expected %d", v, noDelay)
+	}
+	if options.TCPKeepAlive > 0 {
+		if v := getsockoptInt(syscall.SOL_SOCKET, syscall.SO_KEEPALIVE); v != 1 {
+			t.Fatalf("SO_KEEPALIVE %d - expected %d", v, 1)
+		}
+		if v, secs := getsockoptInt(syscall.IPPROTO_TCP, syscall.TCP_KEEPIDLE), int(options.TCPKeepAlive.Seconds()); v != secs {
+			t.Fatalf("TCP_KEEPIDLE %d - expected %d", v, secs)
+		}
+	}
+	if options.TCPKeepAliveInterval > 0 {
+		if v, secs := getsockoptInt(syscall.IPPROTO_TCP, syscall.TCP_KEEPINTVL), int(options.TCPKeepAliveInterval.Seconds()); v != secs {
+			t.Fatalf("TCP_KEEPINTVL %d - expected %d", v, secs)
+		}
+	}
+	// the kernel might adjust (e.g. double) the requested buffer sizes
+	if options.ReadBufferSize != 0 {
+		if v := getsockoptInt(syscall.SOL_SOCKET, syscall.SO_RCVBUF); v < options.ReadBufferSize {
+			t.Fatalf("SO_RCVBUF %d - expected at least %d", v, options.ReadBufferSize)
+		}
+	}
+	if options.WriteBufferSize != 0 {
+		if v := getsockoptInt(syscall.SOL_SOCKET, syscall.SO_SNDBUF); v < options.WriteBufferSize {
+			t.Fatalf("SO_SNDBUF %d - expected at least %d", v, options.WriteBufferSize)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package dial
+
+import (
+	"net"
+	"testing"
+)
+
+func checkSocketOptions(t *testing.T, conn *net.TCPConn, options DialerOptions) {
+	t.Helper()
+	t.Log("socket option checks are only supported on linux")
+}
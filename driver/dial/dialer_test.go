@@ -0,0 +1,88 @@
+package dial
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type testContextDialer struct {
+	network, address string
+}
+
+func (d *testContextDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.network, d.address = network, address
+	if _, ok := ctx.Deadline(); !ok {
+		panic("context deadline expected")
+	}
+	c1, c2 := net.Pipe()
+	c2.Close()
+	return c1, nil
+}
+
+func TestWrapContextDialer(t *testing.T) {
+	const address = "localhost:30015"
+
+	cd := &testContextDialer{}
+	conn, err := WrapContextDialer(cd).DialContext(context.Background(), address, DialerOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if cd.network != "tcp" {
+		t.Fatalf("network %s - expected %s", cd.network, "tcp")
+	}
+	if cd.address != address {
+		t.Fatalf("address %s - expected %s", cd.address, address)
+	}
+}
+
+func TestConnDialer(t *testing.T) {
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+
+	d := NewConnDialer(c1)
+	conn, err := d.DialContext(context.Background(), "", DialerOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if conn != c1 {
+		t.Fatal("connection mismatch")
+	}
+	if _, err := d.DialContext(context.Background(), "", DialerOptions{}); !errors.Is(err, ErrConnUsed) {
+		t.Fatalf("error %v - expected %v", err, ErrConnUsed)
+	}
+}
+
+func TestDefaultDialerSocketOptions(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	tests := []DialerOptions{
+		{Timeout: time.Second},
+		{
+			Timeout:              time.Second,
+			TCPKeepAlive:         2 * time.Second,
+			TCPKeepAliveInterval: 3 * time.Second,
+			TCPDelay:             true,
+			ReadBufferSize:       64 * 1024,
+			WriteBufferSize:      64 * 1024,
+		},
+	}
+
+	for _, options := range tests {
+		conn, err := DefaultDialer.DialContext(context.Background(), l.Addr().String(), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSocketOptions(t, conn.(*net.TCPConn), options)
+		conn.Close()
+	}
+}
@@ -0,0 +1,7 @@
+//go:build linux || freebsd || netbsd
+
+package dial
+
+import "syscall"
+
+const tcpKeepIntvl = syscall.TCP_KEEPINTVL
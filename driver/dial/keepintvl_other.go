@@ -0,0 +1,5 @@
+//go:build !linux && !freebsd && !netbsd
+
+package dial
+
+const tcpKeepIntvl = -1 // not supported
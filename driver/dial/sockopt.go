@@ -0,0 +1,54 @@
+package dial
+
+import (
+	"net"
+	"syscall"
+)
+
+// control sets the socket options which need to be set before connecting (see net.Dialer.Control).
+func (o DialerOptions) control(network, address string, c syscall.RawConn) error {
+	if o.ReadBufferSize == 0 && o.WriteBufferSize == 0 {
+		return nil
+	}
+	var err error
+	if cerr := c.Control(func(fd uintptr) {
+		if o.ReadBufferSize != 0 {
+			if err = setsockoptInt(fd, solSocket, soRcvBuf, o.ReadBufferSize); err != nil {
+				return
+			}
+		}
+		if o.WriteBufferSize != 0 {
+			err = setsockoptInt(fd, solSocket, soSndBuf, o.WriteBufferSize)
+		}
+	}); cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+// setTCPOptions sets the tcp options which would otherwise be overwritten by net.Dialer after connecting.
+func (o DialerOptions) setTCPOptions(conn net.Conn) error {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil
+	}
+	if o.TCPDelay {
+		if err := tcpConn.SetNoDelay(false); err != nil {
+			return err
+		}
+	}
+	if o.TCPKeepAliveInterval <= 0 || o.TCPKeepAlive < 0 || tcpKeepIntvl == -1 {
+		return nil
+	}
+	rawConn, err := tcpConn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	secs := max(int((o.TCPKeepAliveInterval+999_999_999)/1_000_000_000), 1) // round up to seconds
+	if cerr := rawConn.Control(func(fd uintptr) {
+		err = setsockoptInt(fd, ipprotoTCP, tcpKeepIntvl, secs)
+	}); cerr != nil {
+		return cerr
+	}
+	return err
+}
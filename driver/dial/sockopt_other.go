@@ -0,0 +1,16 @@
+//go:build !unix && !windows
+
+package dial
+
+import "errors"
+
+const (
+	solSocket = iota
+	soRcvBuf
+	soSndBuf
+	ipprotoTCP
+)
+
+func setsockoptInt(fd uintptr, level, opt, value int) error {
+	return errors.New("dial: socket options not supported")
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package dial
+
+import "syscall"
+
+const (
+	solSocket  = syscall.SOL_SOCKET
+	soRcvBuf   = syscall.SO_RCVBUF
+	soSndBuf   = syscall.SO_SNDBUF
+	ipprotoTCP = syscall.IPPROTO_TCP
+)
+
+func setsockoptInt(fd uintptr, level, opt, value int) error {
+	return syscall.SetsockoptInt(syscall.Handle(fd), level, opt, value)
+}
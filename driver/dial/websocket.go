@@ -0,0 +1,322 @@
+package dial
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // mandated by the websocket handshake (RFC 6455)
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocket opcodes (RFC 6455).
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xa
+)
+
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsCloseTimeout is the timeout of writing the close frame of a websocket connection.
+const wsCloseTimeout = 5 * time.Second
+
+// errWSClosed is returned if the websocket connection was closed by the server.
+var errWSClosed = errors.New("dial: websocket connection closed")
+
+// wsAccept returns the Sec-WebSocket-Accept value of key.
+func wsAccept(key string) string {
+	h := sha1.New() //nolint:gosec
+	h.Write([]byte(key + wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// wsConn is a net.Conn transferring data as binary websocket messages.
+type wsConn struct {
+	net.Conn
+	rd *bufio.Reader
+
+	rmu    sync.Mutex
+	remain int64 // remaining payload bytes of the current data frame
+	mask   []byte
+	maskOf int
+
+	wmu sync.Mutex
+}
+
+// writeFrame writes a final masked frame (client frames need to be masked).
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	hdr := make([]byte, 0, 14)
+	hdr = append(hdr, 0x80|op)
+	switch l := len(payload); {
+	case l < 126:
+		hdr = append(hdr, 0x80|byte(l))
+	case l <= 0xffff:
+		hdr = append(hdr, 0x80|126)
+		hdr = binary.BigEndian.AppendUint16(hdr, uint16(l))
+	default:
+		hdr = append(hdr, 0x80|127)
+		hdr = binary.BigEndian.AppendUint64(hdr, uint64(l))
+	}
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	hdr = append(hdr, mask[:]...)
+	buf := make([]byte, len(hdr)+len(payload))
+	copy(buf, hdr)
+	for i, b := range payload {
+		buf[len(hdr)+i] = b ^ mask[i%4]
+	}
+	_, err := c.Conn.Write(buf)
+	return err
+}
+
+// Write implements the net.Conn interface.
+func (c *wsConn) Write(b []byte) (int, error) {
+	if err := c.writeFrame(wsOpBinary, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// readHeader reads the next frame header and returns the opcode.
+func (c *wsConn) readHeader() (byte, error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.rd, hdr[:]); err != nil {
+		return 0, err
+	}
+	op := hdr[0] & 0x0f
+	length := int64(hdr[1] & 0x7f)
+	switch length {
+	case 126:
+		var l [2]byte
+		if _, err := io.ReadFull(c.rd, l[:]); err != nil {
+			return 0, err
+		}
+		length = int64(binary.BigEndian.Uint16(l[:]))
+	case 127:
+		var l [8]byte
+		if _, err := io.ReadFull(c.rd, l[:]); err != nil {
+			return 0, err
+		}
+		length = int64(binary.BigEndian.Uint64(l[:]) & (1<<63 - 1))
+	}
+	c.mask, c.maskOf = nil, 0
+	if hdr[1]&0x80 != 0 { // servers should not mask frames, but accept them anyway
+		c.mask = make([]byte, 4)
+		if _, err := io.ReadFull(c.rd, c.mask); err != nil {
+			return 0, err
+		}
+	}
+	c.remain = length
+	return op, nil
+}
+
+// readControl reads the payload of a control frame.
+func (c *wsConn) readControl() ([]byte, error) {
+	if c.remain > 125 {
+		return nil, fmt.Errorf("dial: invalid websocket control frame length %d", c.remain)
+	}
+	payload := make([]byte, c.remain)
+	if _, err := io.ReadFull(c.rd, payload); err != nil {
+		return nil, err
+	}
+	c.unmask(payload)
+	c.remain = 0
+	return payload, nil
+}
+
+func (c *wsConn) unmask(b []byte) {
+	if c.mask == nil {
+		return
+	}
+	for i := range b {
+		b[i] ^= c.mask[c.maskOf%4]
+		c.maskOf++
+	}
+}
+
+// Read implements the net.Conn interface.
+func (c *wsConn) Read(b []byte) (int, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+
+	for c.remain == 0 {
+		op, err := c.readHeader()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case wsOpContinuation, wsOpText, wsOpBinary:
+			// data
+		case wsOpPing:
+			payload, err := c.readControl()
+			if err != nil {
+				return 0, err
+			}
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return 0, err
+			}
+		case wsOpPong:
+			if _, err := c.readControl(); err != nil {
+				return 0, err
+			}
+		case wsOpClose:
+			payload, err := c.readControl()
+			if err != nil {
+				return 0, err
+			}
+			c.writeFrame(wsOpClose, payload) //nolint:errcheck // echo close frame
+			return 0, errWSClosed
+		default:
+			return 0, fmt.Errorf("dial: invalid websocket opcode %d", op)
+		}
+	}
+
+	if int64(len(b)) > c.remain {
+		b = b[:c.remain]
+	}
+	n, err := c.rd.Read(b)
+	c.unmask(b[:n])
+	c.remain -= int64(n)
+	return n, err
+}
+
+// Close implements the net.Conn interface.
+func (c *wsConn) Close() error {
+	// do not block on an unresponsive endpoint
+	c.Conn.SetWriteDeadline(time.Now().Add(wsCloseTimeout)) //nolint:errcheck
+	c.writeFrame(wsOpClose, []byte{0x03, 0xe8})             //nolint:errcheck // normal closure (1000)
+	return c.Conn.Close()
+}
+
+// dialEndpoint connects to the host of the http(s) or ws(s) url u.
+func dialEndpoint(ctx context.Context, u *url.URL, tlsConfig *tls.Config, options DialerOptions) (net.Conn, error) {
+	secure := u.Scheme == "https" || u.Scheme == "wss"
+	address := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if secure {
+			port = "443"
+		}
+		address = net.JoinHostPort(u.Hostname(), port)
+	}
+	conn, err := DefaultDialer.DialContext(ctx, address, options)
+	if err != nil {
+		return nil, err
+	}
+	if !secure {
+		return conn, nil
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{} //nolint:gosec // default settings
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// roundTrip writes req to conn and reads the response.
+func roundTrip(ctx context.Context, conn net.Conn, req *http.Request) (*http.Response, *bufio.Reader, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)          //nolint:errcheck
+		defer conn.SetDeadline(time.Time{}) //nolint:errcheck
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, nil, err
+	}
+	rd := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(rd, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp.Body.Close()
+	return resp, rd, nil
+}
+
+/*
+NewWebSocketDialer returns a Dialer tunneling the database connection through the websocket endpoint
+given by rawURL (ws:// or wss://). The data of the database protocol is transferred as binary websocket messages.
+This enables connecting to the database in environments where only HTTP(S) egress is allowed.
+
+tlsConfig is used for wss endpoints (nil: default configuration) and header is added to the handshake request
+(e.g. for authentication). The address provided to DialContext is ignored as the websocket endpoint
+determines the database server.
+*/
+func NewWebSocketDialer(rawURL string, tlsConfig *tls.Config, header http.Header) (Dialer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return nil, fmt.Errorf("dial: invalid websocket url scheme %s", u.Scheme)
+	}
+	header = header.Clone()
+	return DialerFunc(func(ctx context.Context, address string, options DialerOptions) (net.Conn, error) {
+		if options.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+			defer cancel()
+		}
+		conn, err := dialEndpoint(ctx, u, tlsConfig, options)
+		if err != nil {
+			return nil, err
+		}
+
+		var key [16]byte
+		if _, err := rand.Read(key[:]); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		secKey := base64.StdEncoding.EncodeToString(key[:])
+
+		hu := *u
+		hu.Scheme = strings.Replace(hu.Scheme, "ws", "http", 1)
+		req := &http.Request{Method: http.MethodGet, URL: &hu, Host: u.Host, Header: http.Header{}}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Sec-WebSocket-Key", secKey)
+		req.Header.Set("Sec-WebSocket-Version", "13")
+
+		resp, rd, err := roundTrip(ctx, conn, req)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusSwitchingProtocols ||
+			!strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") ||
+			resp.Header.Get("Sec-WebSocket-Accept") != wsAccept(secKey) {
+			conn.Close()
+			return nil, fmt.Errorf("dial: websocket handshake with %s failed: %s", u.Redacted(), resp.Status)
+		}
+		return &wsConn{Conn: conn, rd: rd}, nil
+	}), nil
+}
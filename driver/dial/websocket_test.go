@@ -0,0 +1,208 @@
+package dial
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wsEchoHandler is a minimal websocket server echoing binary messages after sending a ping.
+func wsEchoHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test") != "test" {
+			http.Error(w, "missing header", http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", wsAccept(r.Header.Get("Sec-WebSocket-Key")))
+		rw.Write([]byte{0x80 | wsOpPing, 0x01, 'p'})
+		rw.Flush()
+
+		for {
+			op, payload, err := readClientFrame(rw.Reader)
+			if err != nil {
+				return
+			}
+			switch op {
+			case wsOpBinary:
+				hdr := []byte{0x80 | wsOpBinary, 126}
+				hdr = binary.BigEndian.AppendUint16(hdr, uint16(len(payload)))
+				rw.Write(hdr)
+				rw.Write(payload)
+				rw.Flush()
+			case wsOpPong:
+				if string(payload) != "p" {
+					t.Errorf("pong payload %s - expected %s", payload, "p")
+				}
+			case wsOpClose:
+				return
+			}
+		}
+	}
+}
+
+func readClientFrame(rd *bufio.Reader) (byte, []byte, error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(rd, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+	length := int(hdr[1] & 0x7f)
+	switch length {
+	case 126:
+		var l [2]byte
+		if _, err := io.ReadFull(rd, l[:]); err != nil {
+			return 0, nil, err
+		}
+		length = int(binary.BigEndian.Uint16(l[:]))
+	case 127:
+		var l [8]byte
+		if _, err := io.ReadFull(rd, l[:]); err != nil {
+			return 0, nil, err
+		}
+		length = int(binary.BigEndian.Uint64(l[:]))
+	}
+	var mask [4]byte
+	if _, err := io.ReadFull(rd, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(rd, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return hdr[0] & 0x0f, payload, nil
+}
+
+func testEcho(t *testing.T, conn net.Conn) {
+	for _, size := range []int{10, 200, 1000} {
+		data := []byte(strings.Repeat("x", size))
+		if _, err := conn.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			t.Fatal(err)
+		}
+		if string(buf) != string(data) {
+			t.Fatalf("echo %s - expected %s", buf, data)
+		}
+	}
+}
+
+func TestWebSocketDialer(t *testing.T) {
+	server := httptest.NewServer(wsEchoHandler(t))
+	defer server.Close()
+
+	d, err := NewWebSocketDialer(strings.Replace(server.URL, "http", "ws", 1)+"/hana", nil, http.Header{"X-Test": {"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := d.DialContext(context.Background(), "ignored:30015", DialerOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	testEcho(t, conn)
+
+	// handshake failure
+	d, err = NewWebSocketDialer(strings.Replace(server.URL, "http", "ws", 1), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.DialContext(context.Background(), "", DialerOptions{}); err == nil {
+		t.Fatal("handshake error expected")
+	}
+	if _, err := NewWebSocketDialer(server.URL, nil, nil); err == nil {
+		t.Fatal("invalid scheme error expected")
+	}
+}
+
+func TestSecureWebSocketDialer(t *testing.T) {
+	server := httptest.NewUnstartedServer(wsEchoHandler(t))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // expected handshake errors
+	server.StartTLS()
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	tlsConfig := &tls.Config{RootCAs: rootCAs, ServerName: "example.com"} //nolint:gosec
+
+	d, err := NewWebSocketDialer(strings.Replace(server.URL, "https", "wss", 1)+"/hana", tlsConfig, http.Header{"X-Test": {"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := d.DialContext(context.Background(), "ignored:30015", DialerOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	testEcho(t, conn)
+
+	// server certificate not trusted by default configuration
+	d, err = NewWebSocketDialer(strings.Replace(server.URL, "https", "wss", 1)+"/hana", nil, http.Header{"X-Test": {"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.DialContext(context.Background(), "", DialerOptions{Timeout: time.Second}); err == nil {
+		t.Fatal("certificate error expected")
+	}
+}
+
+func TestHTTPConnectDialer(t *testing.T) {
+	const target = "hana:30015"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect || r.Host != target || r.Header.Get("Proxy-Authorization") == "" {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 200 Connection established\r\n\r\n")
+		rw.Flush()
+		io.Copy(conn, rw) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	d, err := NewHTTPConnectDialer(strings.Replace(server.URL, "http://", "http://user:password@", 1), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := d.DialContext(context.Background(), target, DialerOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	testEcho(t, conn)
+
+	// missing proxy authorization
+	d, err = NewHTTPConnectDialer(server.URL, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.DialContext(context.Background(), target, DialerOptions{}); err == nil {
+		t.Fatal("connect error expected")
+	}
+}
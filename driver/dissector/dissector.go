@@ -0,0 +1,97 @@
+/*
+Package dissector exports the wire format of the SAP HANA SQL Command Network Protocol as understood by go-hdb.
+
+The header layouts and the enumerations of the header field values are provided programmatically (Headers, Enums)
+and as generated Wireshark Lua dissector (WriteLua), so that protocol analysis tools can stay in sync with the driver.
+
+Generate the Lua dissector and install it as Wireshark plugin (e.g. ~/.local/lib/wireshark/plugins/hdb.lua):
+
+	err := dissector.WriteLua(f)
+
+The generated dissector decodes the message, segment and part headers. Part buffers are shown as raw bytes.
+*/
+package dissector
+
+import (
+	_ "embed" // embed lua template
+	"io"
+	"text/template"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// Field types.
+const (
+	FtInt8   = p.FtInt8
+	FtInt16  = p.FtInt16
+	FtInt32  = p.FtInt32
+	FtInt64  = p.FtInt64
+	FtUint32 = p.FtUint32
+	FtBool   = p.FtBool
+	FtBytes  = p.FtBytes
+)
+
+type (
+	// Field describes a header field (offset and size in bytes). All numeric fields are encoded little endian.
+	Field = p.FieldLayout
+	// Header describes a protocol header.
+	Header = p.HeaderLayout
+	// EnumValue is a named value of an enumeration.
+	EnumValue = p.EnumValue
+	// Enum is the table of the named values of an enumeration.
+	Enum = p.EnumTable
+)
+
+// Header names.
+const (
+	MessageHeader        = p.HlMessageHeader
+	RequestSegmentHeader = p.HlRequestSegmentHeader
+	ReplySegmentHeader   = p.HlReplySegmentHeader
+	PartHeader           = p.HlPartHeader
+)
+
+// Prolog sizes (exchanged before the first message on a connection).
+const (
+	InitRequestSize = p.InitRequestSize
+	InitReplySize   = p.InitReplySize
+)
+
+// PartPadding is the alignment of part buffers in bytes.
+const PartPadding = p.PartPadding
+
+// Headers returns the layouts of the message, segment (request and reply) and part headers.
+func Headers() []*Header { return p.HeaderLayouts() }
+
+// Enums returns the enumerations of the header field values referenced by Field.Enum.
+func Enums() []*Enum { return p.EnumTables() }
+
+//go:embed hdb.lua.tmpl
+var luaTemplate string
+
+var luaTmpl = template.Must(template.New("lua").Parse(luaTemplate))
+
+// luaData is the data of the lua template.
+type luaData struct {
+	Headers         map[string]*Header
+	HeaderList      []*Header
+	Enums           []*Enum
+	InitRequestSize int
+	InitReplySize   int
+	PartPadding     int
+}
+
+// WriteLua writes a Wireshark Lua dissector of the protocol to wr.
+func WriteLua(wr io.Writer) error {
+	data := &luaData{
+		Headers:         map[string]*Header{},
+		HeaderList:      Headers(),
+		Enums:           Enums(),
+		InitRequestSize: InitRequestSize,
+		InitReplySize:   InitReplySize,
+		PartPadding:     PartPadding,
+	}
+	for _, h := range data.HeaderList {
+		data.Headers[h.Name] = h
+	}
+	return luaTmpl.Execute(wr, data)
+}
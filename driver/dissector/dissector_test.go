@@ -0,0 +1,33 @@
+package dissector
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteLua(t *testing.T) {
+	b := &bytes.Buffer{}
+	if err := WriteLua(b); err != nil {
+		t.Fatal(err)
+	}
+	lua := b.String()
+
+	for _, h := range Headers() {
+		for _, f := range h.Fields {
+			if !strings.Contains(lua, `name = "`+f.Name+`"`) {
+				t.Fatalf("header %s field %s missing", h.Name, f.Name)
+			}
+		}
+	}
+	for _, e := range Enums() {
+		if !strings.Contains(lua, "local "+e.Name+"Values = {") {
+			t.Fatalf("enumeration %s missing", e.Name)
+		}
+		for _, v := range e.Values {
+			if !strings.Contains(lua, v.Name) {
+				t.Fatalf("enumeration %s value %s missing", e.Name, v.Name)
+			}
+		}
+	}
+}
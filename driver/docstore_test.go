@@ -44,4 +44,8 @@ func TestDocstore(t *testing.T) {
 	if _, err := db.Exec(fmt.Sprintf("insert into %s values(?)", collectionName), v); err != nil {
 		t.Fatal(err)
 	}
+	// marshaled by driver
+	if _, err := db.Exec(fmt.Sprintf("insert into %s values(?)", collectionName), driver.JSON{V: testData}); err != nil {
+		t.Fatal(err)
+	}
 }
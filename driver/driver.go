@@ -118,9 +118,14 @@ func OpenDB(c *Connector) *DB {
 
 // Close closes the database. It also calls the Close method of the sql package and returns its error.
 func (db *DB) Close() error {
+	err := db.DB.Close() // close connections first as they are reporting to metrics
 	db.metrics.close()
-	return db.DB.Close()
+	return err
 }
 
 // ExStats returns the extended database statistics.
 func (db *DB) ExStats() *Stats { return db.metrics.stats() }
+
+// PublishExpvar publishes the extended database statistics as expvar variable name.
+// Like expvar.Publish it panics if a variable with the same name is already published.
+func (db *DB) PublishExpvar(name string) { publishExpvar(name, db.ExStats) }
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver/hdbctx"
 )
 
 func testConnection(t *testing.T, db *sql.DB) {
@@ -95,6 +96,24 @@ end
 	if _, err := db.Exec(fmt.Sprintf("call %s", procedure)); err != nil {
 		t.Fatal(err)
 	}
+
+	// warning handler and exec info
+	var warnings []driver.DBError
+	connector := driver.MT.NewConnector()
+	connector.SetWarningHandler(func(ctx context.Context, warning driver.DBError) { warnings = append(warnings, warning) })
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	info := &hdbctx.ExecInfo{}
+	if _, err := db.ExecContext(hdbctx.WithExecInfo(context.Background(), info), fmt.Sprintf("call %s", procedure)); err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) == 0 || !warnings[0].IsWarning() {
+		t.Fatalf("warnings %v - expected warning", warnings)
+	}
+	if len(info.Warnings) != len(warnings) {
+		t.Fatalf("number of exec info warnings %d - expected %d", len(info.Warnings), len(warnings))
+	}
 }
 
 func testQueryAttributeAlias(t *testing.T, db *sql.DB) {
@@ -217,6 +236,13 @@ func testQueryArgs(t *testing.T, db *sql.DB) {
 	if err := db.QueryRow(fmt.Sprintf("select count(*) from %s where i = ? and j = :3", table), 1, "arg not used", 2).Scan(&i); err != nil {
 		t.Fatal(err)
 	}
+
+	// named args without parameter names provided by the statement
+	if err := db.QueryRow(fmt.Sprintf("select count(*) from %s where i = ? and j = ?", table), sql.Named("I", 1), sql.Named("J", 2)).Scan(&i); err == nil {
+		t.Fatal("invalid argument name error expected")
+	} else {
+		t.Log(err)
+	}
 }
 
 func testComments(t *testing.T, db *sql.DB) {
@@ -0,0 +1,91 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"os"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+Error classes wrapped by the errors returned by the driver, so that errors can be classified via errors.Is
+(e.g. by retry middleware) without evaluating error messages. An error might belong to more than one class,
+e.g. a failed authentication reported by the database server is of class ErrAuth and ErrServer.
+*/
+var (
+	// ErrNetwork is the class of errors caused by the network connection to the database server.
+	ErrNetwork = errors.New("network error")
+	// ErrProtocol is the class of errors caused by invalid or unsupported protocol data.
+	ErrProtocol = p.ErrProtocol
+	// ErrServer is the class of errors returned by the database server (see Error).
+	ErrServer = errors.New("database server error")
+	// ErrAuth is the class of authentication errors.
+	ErrAuth = errors.New("authentication error")
+	// ErrTimeout is the class of errors caused by exceeded deadlines or timeouts.
+	ErrTimeout = errors.New("timeout error")
+)
+
+// classError wraps an error with its error classes keeping the error message.
+type classError struct {
+	err     error
+	classes []error
+}
+
+func (e *classError) Error() string   { return e.err.Error() }
+func (e *classError) Unwrap() []error { return append([]error{e.err}, e.classes...) }
+
+// isNetworkError returns true if err is caused by the network connection, false otherwise.
+func isNetworkError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && !errors.Is(err, context.DeadlineExceeded) // context.DeadlineExceeded implements net.Error
+}
+
+// isTimeoutError returns true if err is caused by an exceeded deadline or timeout, false otherwise.
+func isTimeoutError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return hasErrorCode(err, HdbErrExecutionTimeout)
+}
+
+// classifyError wraps err with its error classes.
+func classifyError(err error) error {
+	// errors compared by database/sql via equality must not be wrapped
+	if err == nil || err == driver.ErrSkip || err == driver.ErrRemoveArgument || err == io.EOF { //nolint:errorlint
+		return err
+	}
+	var ce *classError
+	if errors.As(err, &ce) { // already classified
+		return err
+	}
+
+	var classes []error
+	var dbErr DBError
+	if isNetworkError(err) {
+		classes = append(classes, ErrNetwork)
+	}
+	if errors.As(err, &dbErr) {
+		classes = append(classes, ErrServer)
+	}
+	if isAuthError(err) {
+		classes = append(classes, ErrAuth)
+	}
+	if isTimeoutError(err) {
+		classes = append(classes, ErrTimeout)
+	}
+	if len(classes) == 0 {
+		return err
+	}
+	return &classError{err: err, classes: classes}
+}
@@ -0,0 +1,58 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestErrorClass(t *testing.T) {
+	classes := []error{ErrNetwork, ErrProtocol, ErrServer, ErrAuth, ErrTimeout}
+
+	tests := []struct {
+		err     error
+		classes []error
+	}{
+		{errors.New("unclassified"), nil},
+		{fmt.Errorf("%w: %w", driver.ErrBadConn, io.ErrUnexpectedEOF), []error{ErrNetwork}},
+		{fmt.Errorf("%w: %w", driver.ErrBadConn, os.ErrDeadlineExceeded), []error{ErrNetwork, ErrTimeout}},
+		{context.DeadlineExceeded, []error{ErrTimeout}},
+		{&testDBError{code: int(HdbErrUniqueViolation)}, []error{ErrServer}},
+		{&testDBError{code: int(HdbErrExecutionTimeout)}, []error{ErrServer, ErrTimeout}},
+	}
+
+	for i, test := range tests {
+		err := classifyError(test.err)
+		if err.Error() != test.err.Error() {
+			t.Fatalf("test %d: error message %s - expected %s", i, err, test.err)
+		}
+		if !errors.Is(err, test.err) {
+			t.Fatalf("test %d: error %v does not wrap %v", i, err, test.err)
+		}
+		for _, class := range classes {
+			expected := false
+			for _, c := range test.classes {
+				if c == class { //nolint:errorlint
+					expected = true
+				}
+			}
+			if errors.Is(err, class) != expected {
+				t.Fatalf("test %d: error class %v %t - expected %t", i, class, !expected, expected)
+			}
+		}
+		if classifyError(err) != err { //nolint:errorlint
+			t.Fatalf("test %d: error classified twice", i)
+		}
+	}
+
+	// errors compared by database/sql must not be wrapped
+	for _, err := range []error{driver.ErrSkip, driver.ErrRemoveArgument, io.EOF} {
+		if classifyError(err) != err { //nolint:errorlint
+			t.Fatalf("error %v wrapped", err)
+		}
+	}
+}
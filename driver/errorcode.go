@@ -0,0 +1,128 @@
+package driver
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ErrorCode represents a database server error code (see DBError.Code).
+type ErrorCode int
+
+// Well-known HANA database error codes.
+const (
+	HdbErrAuthenticationFailed     ErrorCode = 10
+	HdbErrTransactionRolledBack    ErrorCode = 129 // transaction rolled back by an internal error
+	HdbErrLockWaitTimeout          ErrorCode = 131 // transaction rolled back by lock wait timeout
+	HdbErrDeadlock                 ErrorCode = 133 // transaction rolled back by detected deadlock
+	HdbErrSerializationFailure     ErrorCode = 138 // transaction serialization failure
+	HdbErrCancelled                ErrorCode = 139 // current operation cancelled by request and transaction rolled back
+	HdbErrResourceBusy             ErrorCode = 146 // resource busy and NOWAIT specified
+	HdbErrSQLSyntax                ErrorCode = 257
+	HdbErrInsufficientPrivilege    ErrorCode = 258
+	HdbErrInvalidTableName         ErrorCode = 259
+	HdbErrInvalidColumnName        ErrorCode = 260
+	HdbErrInvalidIndexName         ErrorCode = 261
+	HdbErrValueTooLarge            ErrorCode = 274 // inserted value too large for column
+	HdbErrNotNullViolation         ErrorCode = 287 // cannot insert NULL or update to NULL
+	HdbErrDuplicateTableName       ErrorCode = 288
+	HdbErrUniqueViolation          ErrorCode = 301 // unique constraint violated
+	HdbErrNumericOverflow          ErrorCode = 314
+	HdbErrInvalidProcedureName     ErrorCode = 328 // invalid name of function or procedure
+	HdbErrInvalidNumber            ErrorCode = 339
+	HdbErrInvalidSchemaName        ErrorCode = 362
+	HdbErrInvalidatedView          ErrorCode = 391
+	HdbErrInvalidObjectName        ErrorCode = 397
+	HdbErrInvalidatedProcedure     ErrorCode = 430
+	HdbErrForcedPasswordChange     ErrorCode = 414 // user is forced to change password
+	HdbErrForeignKeyViolation      ErrorCode = 461 // foreign key constraint violation
+	HdbErrForeignKeyUpdateDelete   ErrorCode = 462 // failed on update or delete by foreign key constraint violation
+	HdbErrExecutionTimeout         ErrorCode = 613 // execution aborted by timeout
+	HdbErrWhileParsingProtocol     ErrorCode = 1033
+	HdbErrColumnStore              ErrorCode = 2048
+	HdbErrOnlySecureConnectAllowed ErrorCode = 4321 // only secure connections are allowed
+)
+
+var errorCodeTexts = map[ErrorCode]string{
+	HdbErrAuthenticationFailed:     "authentication failed",
+	HdbErrTransactionRolledBack:    "transaction rolled back by an internal error",
+	HdbErrLockWaitTimeout:          "transaction rolled back by lock wait timeout",
+	HdbErrDeadlock:                 "transaction rolled back by detected deadlock",
+	HdbErrSerializationFailure:     "transaction serialization failure",
+	HdbErrCancelled:                "current operation cancelled by request and transaction rolled back",
+	HdbErrResourceBusy:             "resource busy and NOWAIT specified",
+	HdbErrSQLSyntax:                "sql syntax error",
+	HdbErrInsufficientPrivilege:    "insufficient privilege",
+	HdbErrInvalidTableName:         "invalid table name",
+	HdbErrInvalidColumnName:        "invalid column name",
+	HdbErrInvalidIndexName:         "invalid index name",
+	HdbErrValueTooLarge:            "inserted value too large for column",
+	HdbErrNotNullViolation:         "cannot insert NULL or update to NULL",
+	HdbErrDuplicateTableName:       "cannot use duplicate table name",
+	HdbErrUniqueViolation:          "unique constraint violated",
+	HdbErrNumericOverflow:          "numeric overflow",
+	HdbErrInvalidProcedureName:     "invalid name of function or procedure",
+	HdbErrInvalidNumber:            "invalid number",
+	HdbErrInvalidSchemaName:        "invalid schema name",
+	HdbErrInvalidatedView:          "invalidated view",
+	HdbErrInvalidObjectName:        "invalid object name",
+	HdbErrInvalidatedProcedure:     "invalidated procedure",
+	HdbErrForcedPasswordChange:     "user is forced to change password",
+	HdbErrForeignKeyViolation:      "foreign key constraint violation",
+	HdbErrForeignKeyUpdateDelete:   "failed on update or delete by foreign key constraint violation",
+	HdbErrExecutionTimeout:         "execution aborted by timeout",
+	HdbErrWhileParsingProtocol:     "error while parsing protocol",
+	HdbErrColumnStore:              "column store error",
+	HdbErrOnlySecureConnectAllowed: "only secure connections are allowed",
+}
+
+func (c ErrorCode) String() string {
+	if s, ok := errorCodeTexts[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("error code %d", int(c))
+}
+
+// hasErrorCode returns true if err or any error wrapped by err is a DBError with one of the error codes.
+func hasErrorCode(err error, codes ...ErrorCode) bool {
+	switch err := err.(type) {
+	case nil:
+		return false
+	case interface{ Unwrap() []error }: // error collection (e.g. bulk statement errors)
+		for _, err := range err.Unwrap() {
+			if hasErrorCode(err, codes...) {
+				return true
+			}
+		}
+		return false
+	case interface{ Unwrap() error }:
+		return hasErrorCode(err.Unwrap(), codes...)
+	case DBError:
+		return slices.Contains(codes, ErrorCode(err.Code()))
+	default:
+		return false
+	}
+}
+
+// HasErrorCode returns true if err is or wraps a database error with error code code.
+// In case of an error collection (see Error) all database errors are checked.
+func HasErrorCode(err error, code ErrorCode) bool { return hasErrorCode(err, code) }
+
+// IsLockTimeout returns true if err is or wraps a lock wait timeout database error.
+func IsLockTimeout(err error) bool { return hasErrorCode(err, HdbErrLockWaitTimeout) }
+
+// IsDeadlock returns true if err is or wraps a deadlock database error.
+func IsDeadlock(err error) bool { return hasErrorCode(err, HdbErrDeadlock) }
+
+// IsSerializationFailure returns true if err is or wraps a transaction serialization failure database error.
+func IsSerializationFailure(err error) bool { return hasErrorCode(err, HdbErrSerializationFailure) }
+
+// IsUniqueViolation returns true if err is or wraps a unique constraint violation database error.
+func IsUniqueViolation(err error) bool { return hasErrorCode(err, HdbErrUniqueViolation) }
+
+// IsForeignKeyViolation returns true if err is or wraps a foreign key constraint violation database error.
+func IsForeignKeyViolation(err error) bool {
+	return hasErrorCode(err, HdbErrForeignKeyViolation, HdbErrForeignKeyUpdateDelete)
+}
+
+// IsInvalidatedView returns true if err is or wraps an invalidated view database error.
+func IsInvalidatedView(err error) bool { return hasErrorCode(err, HdbErrInvalidatedView) }
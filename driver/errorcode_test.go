@@ -0,0 +1,53 @@
+package driver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type testDBError struct{ code int }
+
+func (e *testDBError) Error() string   { return fmt.Sprintf("SQL Error %d", e.code) }
+func (e *testDBError) StmtNo() int     { return 0 }
+func (e *testDBError) Code() int       { return e.code }
+func (e *testDBError) Position() int   { return 0 }
+func (e *testDBError) Level() int      { return HdbError }
+func (e *testDBError) Text() string    { return "" }
+func (e *testDBError) IsWarning() bool { return false }
+func (e *testDBError) IsError() bool   { return true }
+func (e *testDBError) IsFatal() bool   { return false }
+
+func TestErrorCode(t *testing.T) {
+	lockTimeout := &testDBError{code: int(HdbErrLockWaitTimeout)}
+	uniqueViolation := &testDBError{code: int(HdbErrUniqueViolation)}
+
+	tests := []struct {
+		err             error
+		lockTimeout     bool
+		uniqueViolation bool
+	}{
+		{nil, false, false},
+		{errors.New("no database error"), false, false},
+		{lockTimeout, true, false},
+		{fmt.Errorf("wrapped: %w", lockTimeout), true, false},
+		{errors.Join(&testDBError{code: int(HdbErrSQLSyntax)}, uniqueViolation), false, true},
+		{fmt.Errorf("wrapped: %w", errors.Join(lockTimeout, uniqueViolation)), true, true},
+	}
+
+	for i, test := range tests {
+		if v := IsLockTimeout(test.err); v != test.lockTimeout {
+			t.Fatalf("test %d: lock timeout %t - expected %t", i, v, test.lockTimeout)
+		}
+		if v := IsUniqueViolation(test.err); v != test.uniqueViolation {
+			t.Fatalf("test %d: unique violation %t - expected %t", i, v, test.uniqueViolation)
+		}
+	}
+
+	if s := HdbErrDeadlock.String(); s != "transaction rolled back by detected deadlock" {
+		t.Fatalf("error code text %s", s)
+	}
+	if s := ErrorCode(-1).String(); s != "error code -1" {
+		t.Fatalf("error code text %s", s)
+	}
+}
@@ -0,0 +1,79 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorContextConfig configures the context information attached to statement errors (see SetErrorContext).
+type ErrorContextConfig struct {
+	// MaxQueryLength is the maximum number of bytes of the SQL text attached to errors
+	// (0: SQL text is not attached, < 0: SQL text is not truncated).
+	MaxQueryLength int
+	// Redact is called with the SQL text before it is attached to errors (e.g. to remove literals).
+	// If Redact is nil the SQL text is attached as is.
+	Redact func(query string) string
+}
+
+// ErrorContext contains the context information of a failed statement (see SetErrorContext and ErrorContextOf).
+type ErrorContext struct {
+	// Query is the (truncated or redacted) SQL text of the statement (empty if not attached).
+	Query string
+	// SessionID is the database session id of the connection.
+	SessionID int64
+	// Host is the database server host the connection is established to.
+	Host string
+}
+
+func (c *ErrorContext) String() string {
+	if c.Query == "" {
+		return fmt.Sprintf("session id %d host %s", c.SessionID, c.Host)
+	}
+	return fmt.Sprintf("session id %d host %s query %q", c.SessionID, c.Host, c.Query)
+}
+
+// contextError wraps a statement error with its error context.
+type contextError struct {
+	err  error
+	ectx *ErrorContext
+}
+
+func (e *contextError) Error() string { return fmt.Sprintf("%s (%s)", e.err, e.ectx) }
+func (e *contextError) Unwrap() error { return e.err }
+
+// ErrorContextOf returns the context information of a failed statement attached to err
+// or nil if no context information is available.
+func ErrorContextOf(err error) *ErrorContext {
+	var ce *contextError
+	if !errors.As(err, &ce) {
+		return nil
+	}
+	return ce.ectx
+}
+
+// contextQuery returns the SQL text of query to be attached to errors.
+func (cfg *ErrorContextConfig) contextQuery(query string) string {
+	if cfg.MaxQueryLength == 0 {
+		return ""
+	}
+	if cfg.Redact != nil {
+		query = cfg.Redact(query)
+	}
+	if cfg.MaxQueryLength > 0 && len(query) > cfg.MaxQueryLength {
+		// do not cut utf-8 characters
+		query = strings.ToValidUTF8(query[:cfg.MaxQueryLength], "") + "..."
+	}
+	return query
+}
+
+// stmtError classifies the error of statement query and attaches the error context if configured.
+func (c *conn) stmtError(query string, err error) error {
+	err = classifyError(err)
+	cfg := c.attrs._errorContext
+	if cfg == nil || err == nil || err == driver.ErrSkip || err == driver.ErrRemoveArgument { //nolint:errorlint
+		return err
+	}
+	return &contextError{err: err, ectx: &ErrorContext{Query: cfg.contextQuery(query), SessionID: c.sessionID, Host: c.host}}
+}
@@ -0,0 +1,74 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestErrorContext(t *testing.T) {
+	literals := regexp.MustCompile(`'[^']*'`)
+	redact := func(query string) string { return literals.ReplaceAllString(query, "?") }
+
+	const query = "select * from dummy where dummy = 'secret'"
+
+	tests := []struct {
+		cfg   *ErrorContextConfig
+		query string
+	}{
+		{&ErrorContextConfig{}, ""},
+		{&ErrorContextConfig{MaxQueryLength: -1}, query},
+		{&ErrorContextConfig{MaxQueryLength: 8}, "select *..."},
+		{&ErrorContextConfig{MaxQueryLength: -1, Redact: redact}, "select * from dummy where dummy = ?"},
+		{&ErrorContextConfig{MaxQueryLength: 1000, Redact: redact}, "select * from dummy where dummy = ?"},
+	}
+
+	dbErr := &testDBError{code: int(HdbErrInvalidTableName)}
+
+	for i, test := range tests {
+		attrs := newConnAttrs()
+		attrs.SetErrorContext(test.cfg)
+		c := &conn{attrs: attrs, sessionID: 4711, host: "myhost:30015"}
+
+		err := c.stmtError(query, dbErr)
+		if !errors.Is(err, dbErr) || !errors.Is(err, ErrServer) {
+			t.Fatalf("test %d: error %v does not wrap %v", i, err, dbErr)
+		}
+		ectx := ErrorContextOf(err)
+		if ectx == nil {
+			t.Fatalf("test %d: error context missing", i)
+		}
+		if ectx.Query != test.query || ectx.SessionID != 4711 || ectx.Host != "myhost:30015" {
+			t.Fatalf("test %d: error context %v - expected query %q", i, ectx, test.query)
+		}
+		if !strings.Contains(err.Error(), "myhost:30015") {
+			t.Fatalf("test %d: error message %s does not contain host", i, err)
+		}
+		if strings.Contains(err.Error(), "secret") != (test.query == query) {
+			t.Fatalf("test %d: error message %s", i, err)
+		}
+	}
+
+	// truncation must not split utf-8 characters
+	cfg := &ErrorContextConfig{MaxQueryLength: 4}
+	if q := cfg.contextQuery("abcäöü"); q != "abc..." {
+		t.Fatalf("truncated query %q - expected %q", q, "abc...")
+	}
+
+	// errors compared by database/sql and nil must not be wrapped
+	attrs := newConnAttrs()
+	attrs.SetErrorContext(&ErrorContextConfig{})
+	c := &conn{attrs: attrs}
+	for _, err := range []error{nil, driver.ErrSkip, driver.ErrRemoveArgument} {
+		if c.stmtError(query, err) != err { //nolint:errorlint
+			t.Fatalf("error %v wrapped", err)
+		}
+	}
+	// no error context attached if not configured
+	c = &conn{attrs: newConnAttrs()}
+	if ErrorContextOf(c.stmtError(query, dbErr)) != nil {
+		t.Fatal("unexpected error context")
+	}
+}
@@ -57,3 +57,123 @@ func ExampleConn_DBConnectInfo() {
 	}
 	// output:
 }
+
+// ExampleConn-ConnectOptions shows how to retrieve the connection id and the negotiated connect options with the help of sql.Conn.Raw().
+func ExampleConn_ConnectOptions() {
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	// Grab connection.
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		log.Panic(err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn any) error {
+		// Access driver.Conn methods.
+		log.Printf("connection id: %d", driverConn.(driver.Conn).ConnectionID())
+		log.Printf("connect options: %v", driverConn.(driver.Conn).ConnectOptions())
+		return nil
+	}); err != nil {
+		log.Panic(err)
+	}
+	// output:
+}
+
+// ExampleConn-ParameterTypes shows how to retrieve the parameter metadata of a statement with the help of sql.Conn.Raw().
+func ExampleConn_ParameterTypes() {
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	// Grab connection.
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		log.Panic(err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn any) error {
+		// Access driver.Conn methods.
+		types, err := driverConn.(driver.Conn).ParameterTypes(context.Background(), "select * from dummy where dummy = ?")
+		if err != nil {
+			return err
+		}
+		for _, t := range types {
+			length, _ := t.Length()
+			log.Printf("type %s length %d in %t out %t", t.DatabaseTypeName(), length, t.In(), t.Out())
+		}
+		return nil
+	}); err != nil {
+		log.Panic(err)
+	}
+	// output:
+}
+
+// ExampleConn-ColumnOrigins shows how to retrieve the source tables of the result columns of a query with the help of sql.Conn.Raw().
+func ExampleConn_ColumnOrigins() {
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	// Grab connection.
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		log.Panic(err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn any) error {
+		// Access driver.Conn methods.
+		origins, err := driverConn.(driver.Conn).ColumnOrigins(context.Background(), "select d.dummy as d1, e.dummy as d2 from dummy d, dummy e")
+		if err != nil {
+			return err
+		}
+		for _, o := range origins {
+			log.Printf("column %s origin %s.%s.%s", o.Name, o.SchemaName, o.TableName, o.ColumnName)
+		}
+		return nil
+	}); err != nil {
+		log.Panic(err)
+	}
+	// output:
+}
+
+// ExampleRawConn shows how to fetch a query result in chunks of a given size with the help of sql.Conn.Raw() and the unstable raw connection API.
+func ExampleRawConn() {
+	db := sql.OpenDB(driver.MT.Connector())
+	defer db.Close()
+
+	ctx := context.Background()
+
+	// Grab connection.
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		log.Panic(err)
+	}
+	defer conn.Close()
+
+	if err := conn.Raw(func(driverConn any) error {
+		rc, err := driver.NewRawConn(driverConn)
+		if err != nil {
+			return err
+		}
+		reply, err := rc.ExecuteDirect(ctx, "select * from sys.tables", true)
+		if err != nil {
+			return err
+		}
+		rs := reply.Resultset
+		defer rs.Close(ctx)
+		for {
+			log.Printf("fetched %d rows of columns %v", len(rs.Rows()), rs.Columns())
+			if rs.LastPacket() {
+				return nil
+			}
+			if err := rs.Fetch(ctx, 10); err != nil {
+				return err
+			}
+		}
+	}); err != nil {
+		log.Panic(err)
+	}
+	// output:
+}
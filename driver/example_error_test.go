@@ -11,10 +11,6 @@ import (
 	"github.com/SAP/go-hdb/driver"
 )
 
-const (
-	errCodeInvalidTableName = 259
-)
-
 func ExampleError() {
 	db := sql.OpenDB(driver.MT.Connector())
 	defer db.Close()
@@ -29,8 +25,8 @@ func ExampleError() {
 	if err != nil {
 		// Check if error is driver.Error.
 		if errors.As(err, &dbError) {
-			switch dbError.Code() {
-			case errCodeInvalidTableName:
+			switch driver.ErrorCode(dbError.Code()) {
+			case driver.HdbErrInvalidTableName:
 				fmt.Print("invalid table name")
 			default:
 				log.Panicf("code %d text %s", dbError.Code(), dbError.Text())
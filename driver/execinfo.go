@@ -0,0 +1,25 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+)
+
+// setExecInfo sets the execution info requested by ctx (if any) from the statement context of the last reply
+// (see hdbctx.WithExecInfo).
+func (c *conn) setExecInfo(ctx context.Context) {
+	info, ok := hdbctx.ExecInfoFrom(ctx)
+	if !ok {
+		return
+	}
+	sc := c.pr.StatementContext()
+	*info = hdbctx.ExecInfo{
+		ServerProcessingTime: sc.ServerProcessingTimeOrZero(),
+		ServerCPUTime:        sc.ServerCPUTimeOrZero(),
+		ServerMemoryUsage:    sc.ServerMemoryUsageOrZero(),
+	}
+	for _, warning := range c.warnings {
+		info.Warnings = append(info.Warnings, warning)
+	}
+}
@@ -0,0 +1,131 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver/internal/rand/alphanum"
+)
+
+// PlanOperator represents an operator of a query execution plan (see ExplainPlan).
+type PlanOperator struct {
+	ID              int64
+	Name            string
+	Details         string
+	ExecutionEngine string
+	SchemaName      string
+	TableName       string
+	OutputSize      float64 // estimated number of output rows
+	SubtreeCost     float64 // estimated cost of the operator subtree
+	Children        []*PlanOperator
+}
+
+func (o *PlanOperator) format(sb *strings.Builder, level int) {
+	fmt.Fprintf(sb, "%s%s", strings.Repeat("  ", level), o.Name)
+	if o.TableName != "" {
+		fmt.Fprintf(sb, " %s", Identifier(o.TableName))
+	}
+	if o.Details != "" {
+		fmt.Fprintf(sb, " (%s)", o.Details)
+	}
+	sb.WriteByte('\n')
+	for _, child := range o.Children {
+		child.format(sb, level+1)
+	}
+}
+
+// String returns the operator tree in an indented, human readable form.
+func (o *PlanOperator) String() string {
+	sb := &strings.Builder{}
+	o.format(sb, 0)
+	return sb.String()
+}
+
+const explainPlanQuery = `select operator_id, parent_operator_id, operator_name, operator_details, execution_engine,
+schema_name, table_name, output_size, subtree_cost from explain_plan_table where statement_name = '%s' order by operator_id`
+
+// explainString converts a character field value to a string.
+func explainString(v driver.Value) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+// explainPlan returns the root operator of the execution plan of query.
+//
+// The plan statements are executed without commit, so that open resultsets of the connection are not closed
+// (the explain plan table content is session local).
+func (c *conn) explainPlan(ctx context.Context, query string) (*PlanOperator, error) {
+	stmtName := "go-hdb-" + alphanum.ReadString(16)
+
+	if _, err := c.execDirect(ctx, fmt.Sprintf("explain plan set statement_name = '%s' for %s", stmtName, query), false); err != nil {
+		return nil, err
+	}
+	defer c.execDirect(ctx, fmt.Sprintf("delete from explain_plan_table where statement_name = '%s'", stmtName), false) //nolint:errcheck
+
+	rows, err := c.queryDirect(ctx, fmt.Sprintf(explainPlanQuery, stmtName), false)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var root *PlanOperator
+	ops := map[int64]*PlanOperator{}
+	dest := make([]driver.Value, len(rows.Columns()))
+	for {
+		if err := rows.Next(dest); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		op := &PlanOperator{
+			Name:            explainString(dest[2]),
+			Details:         explainString(dest[3]),
+			ExecutionEngine: explainString(dest[4]),
+			SchemaName:      explainString(dest[5]),
+			TableName:       explainString(dest[6]),
+		}
+		op.ID, _ = dest[0].(int64)
+		op.OutputSize, _ = dest[7].(float64)
+		op.SubtreeCost, _ = dest[8].(float64)
+		ops[op.ID] = op
+
+		parentID, ok := dest[1].(int64)
+		if parent, found := ops[parentID]; ok && found {
+			parent.Children = append(parent.Children, op)
+		} else if root == nil {
+			root = op
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no execution plan available for query %s", query)
+	}
+	return root, nil
+}
+
+/*
+ExplainPlan executes EXPLAIN PLAN for query on the database connection sqlConn and returns the root operator
+of the execution plan tree.
+
+Using the connection the statement is executed on, the plan reflects the session context (e.g. the current schema).
+query might contain parameter markers, so that the plan of a prepared statement can be retrieved by its SQL text.
+*/
+func ExplainPlan(ctx context.Context, sqlConn *sql.Conn, query string) (*PlanOperator, error) {
+	var root *PlanOperator
+	err := rawConn(sqlConn, func(c *conn) (err error) {
+		root, err = c.explainPlan(ctx, query)
+		return err
+	})
+	return root, err
+}
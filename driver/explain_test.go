@@ -0,0 +1,90 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func testExplainPlan(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+	sqlConn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlConn.Close()
+
+	root, err := ExplainPlan(ctx, sqlConn, "select * from dummy where dummy = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Name == "" {
+		t.Fatalf("missing root operator name in plan\n%s", root)
+	}
+}
+
+func testEstimateResultRows(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+	sqlConn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlConn.Close()
+
+	numRow, err := EstimateResultRows(ctx, sqlConn, "select * from dummy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numRow != 1 {
+		t.Fatalf("estimated number of rows %f - expected %d", numRow, 1)
+	}
+}
+
+func testSlowQueryExplainPlan(t *testing.T, db *sql.DB) {
+	var plan *PlanOperator
+	connector := MT.NewConnector()
+	connector.SetSlowQueryHook(0, func(ctx context.Context, sq *SlowQuery) { plan = sq.Plan })
+	connector.SetSlowQueryExplainPlan(true)
+	db = sql.OpenDB(connector)
+	defer db.Close()
+
+	rows, err := db.Query("select * from dummy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	// resultset needs to be still available after the execution plan is retrieved
+	for rows.Next() {
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if plan == nil {
+		t.Fatal("missing slow query execution plan")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, db *sql.DB)
+	}{
+		{"explainPlan", testExplainPlan},
+		{"slowQueryExplainPlan", testSlowQueryExplainPlan},
+		{"estimateResultRows", testEstimateResultRows},
+	}
+
+	db := MT.DB()
+	for _, test := range tests {
+		test := test // new test to run in parallel
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.fct(t, db)
+		})
+	}
+}
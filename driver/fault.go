@@ -0,0 +1,17 @@
+package driver
+
+import (
+	"time"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// faultInjector injects faults into the protocol roundtrips of connections for testing (see build tag hdbfault).
+type faultInjector interface {
+	// drop returns true if the network connection should be closed before sending request message number n.
+	drop(n int) bool
+	// replyDelay returns the delay before reading a reply.
+	replyDelay() time.Duration
+	// corruptPart returns true if reply parts of kind should be corrupted.
+	corruptPart(kind p.PartKind) bool
+}
@@ -0,0 +1,56 @@
+//go:build hdbfault
+
+package driver
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+A Fault defines faults injected into the connections of a connector, so that applications can test their retry
+and failover logic deterministically (see SetFault). Fault injection is only available if the driver is built with
+build tag hdbfault (e.g. go test -tags hdbfault) and must not be used in production.
+
+Request messages are counted per network connection starting with the protocol prolog and the authentication
+messages (the first statement of a basic authenticated connection is sent with message number 4).
+*/
+type Fault struct {
+	// DropAfter closes the network connection before sending request message number DropAfter + 1 (0: no drop),
+	// so that the request fails like on a connection dropped by the network.
+	DropAfter int
+	// ReplyDelay delays reading the reply of each request message, e.g. to exceed a read timeout or a context deadline.
+	ReplyDelay time.Duration
+	// CorruptParts are the kinds of reply parts which are corrupted, so that decoding the reply fails with a
+	// protocol error while the connection stays usable, e.g. "Resultset", "ResultMetadata" or "RowsAffected".
+	CorruptParts []string
+}
+
+func (f *Fault) drop(n int) bool           { return f.DropAfter > 0 && n > f.DropAfter }
+func (f *Fault) replyDelay() time.Duration { return f.ReplyDelay }
+func (f *Fault) corruptPart(kind p.PartKind) bool {
+	return slices.Contains(f.CorruptParts, strings.TrimPrefix(kind.String(), "Pk"))
+}
+
+// Fault returns the fault injected into new connections of the connector (nil if none).
+func (c *connAttrs) Fault() *Fault {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	f, _ := c._fault.(*Fault)
+	return f
+}
+
+// SetFault sets the fault injected into new connections of the connector (nil: no fault injection).
+// fault must not be changed after calling SetFault.
+func (c *connAttrs) SetFault(fault *Fault) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if fault == nil {
+		c._fault = nil
+		return
+	}
+	c._fault = fault
+}
@@ -0,0 +1,63 @@
+//go:build hdbfault
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestFault(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	srv.Handle("select name from t", &hdbtest.Response{Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}}, Rows: [][]any{{"name"}}})
+
+	query := func(fault *Fault, timeout time.Duration) (*sql.DB, error) {
+		connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+		connector.SetFault(fault)
+		db := OpenDB(connector)
+		db.SetMaxIdleConns(1)
+		ctx := context.Background()
+		if timeout != 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		var name string
+		return db.DB, db.QueryRowContext(ctx, "select name from t").Scan(&name)
+	}
+
+	t.Run("dropAfter", func(t *testing.T) {
+		// connection dropped when sending the query: database/sql retries on new connections which are dropped as well
+		db, err := query(&Fault{DropAfter: 3}, 0)
+		defer db.Close()
+		if !errors.Is(err, ErrNetwork) {
+			t.Fatalf("error %v - expected %v", err, ErrNetwork)
+		}
+	})
+
+	t.Run("replyDelay", func(t *testing.T) {
+		db, err := query(&Fault{ReplyDelay: 20 * time.Millisecond}, 10*time.Millisecond)
+		defer db.Close()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("error %v - expected %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("corruptParts", func(t *testing.T) {
+		db, err := query(&Fault{CorruptParts: []string{"Resultset"}}, 0)
+		defer db.Close()
+		if !errors.Is(err, ErrProtocol) {
+			t.Fatalf("error %v - expected %v", err, ErrProtocol)
+		}
+	})
+}
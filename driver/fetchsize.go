@@ -0,0 +1,45 @@
+package driver
+
+import (
+	"time"
+)
+
+// maxFetchByteSize is the upper limit of the estimated bytes fetched per roundtrip by adaptive fetch sizing.
+const maxFetchByteSize = 4 * 1024 * 1024
+
+/*
+adaptiveFetchSize returns the fetch size of the next fetch roundtrip of a resultset.
+
+The fetch size is doubled as long as the rows of the previous fetch were consumed faster than the fetch
+roundtrip took (the consumer is waiting for the network), limited by maxFetchSize and by maxFetchByteSize
+based on the observed row size. In case the consumer is slower than the network the fetch size is kept,
+as larger fetches would only increase the memory of buffered rows. The fetch size is never decreased
+below fetchSize.
+*/
+func adaptiveFetchSize(size, fetchSize, maxFetchSize int, rowSize float64, fetchTime, consumeTime time.Duration) int {
+	if consumeTime < fetchTime {
+		size *= 2
+	}
+	if rowSize > 0 {
+		size = min(size, int(maxFetchByteSize/rowSize))
+	}
+	return max(min(size, maxFetchSize), fetchSize)
+}
+
+// fetchSize returns the fetch size of the next fetch roundtrip of resultset qr.
+func (c *conn) fetchSize(qr *queryResult) int {
+	if qr.ctxFetchSize != 0 {
+		return qr.ctxFetchSize
+	}
+	fetchSize, maxFetchSize := c.attrs._fetchSize, c.attrs._maxFetchSize
+	if maxFetchSize <= fetchSize { // static fetch size
+		return fetchSize
+	}
+	if qr.fetchSize == 0 { // first fetch
+		qr.fetchSize = fetchSize
+		return fetchSize
+	}
+	rowSize := float64(qr.fetchBytes) / float64(max(qr.fetchRows, 1))
+	qr.fetchSize = adaptiveFetchSize(qr.fetchSize, fetchSize, maxFetchSize, rowSize, qr.fetchTime, time.Since(qr.fetched))
+	return qr.fetchSize
+}
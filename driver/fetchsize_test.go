@@ -0,0 +1,33 @@
+package driver
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptiveFetchSize(t *testing.T) {
+	const (
+		fetchSize    = 10
+		maxFetchSize = 1000
+	)
+
+	tests := []struct {
+		size        int
+		rowSize     float64
+		fetchTime   time.Duration
+		consumeTime time.Duration
+		expected    int
+	}{
+		{10, 100, 10 * time.Millisecond, time.Millisecond, 20},          // fast consumer: grow
+		{10, 100, time.Millisecond, 10 * time.Millisecond, 10},          // slow consumer: keep
+		{800, 100, 10 * time.Millisecond, time.Millisecond, 1000},       // cap by maxFetchSize
+		{100, 1024 * 1024, 10 * time.Millisecond, time.Millisecond, 10}, // cap by byte size but not below fetchSize
+		{100, 64 * 1024, 10 * time.Millisecond, time.Millisecond, 64},   // cap by byte size
+	}
+
+	for _, test := range tests {
+		if size := adaptiveFetchSize(test.size, fetchSize, maxFetchSize, test.rowSize, test.fetchTime, test.consumeTime); size != test.expected {
+			t.Fatalf("size %d row size %f: fetch size %d - expected %d", test.size, test.rowSize, size, test.expected)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Package hdbcontainer provides a SAP HANA Express docker container for integration tests.
+
+Start starts a HANA Express container (or attaches to an already running one with the same name or to an existing
+database via DSN), waits until the database accepts connections and returns the container providing a ready Connector:
+
+	func TestMain(m *testing.M) {
+		c, err := hdbcontainer.Start(context.Background(), &hdbcontainer.Options{Password: "HXEHana1", Keep: true})
+		...
+		connector = c.Connector()
+		code := m.Run()
+		c.Stop(context.Background())
+		os.Exit(code)
+	}
+
+Within a single test ForTest starts the container and stops it as part of the test cleanup.
+
+The docker command line interface (docker) needs to be installed. The start of a HANA Express container takes
+several minutes, so that keeping the container running between test runs (Options.Keep) is recommended.
+This package is currently experimental and its public interface might be changed in an incompatible way at any time.
+*/
+package hdbcontainer
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver"
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// Defaults.
+const (
+	DefaultImage        = "saplabs/hanaexpress:latest"
+	DefaultName         = "go-hdb-hxe"
+	DefaultHost         = "localhost"
+	DefaultPort         = 39041 // SQL port of tenant database HXE
+	DefaultUser         = "SYSTEM"
+	DefaultReadyTimeout = 20 * time.Minute
+	DefaultPollInterval = 5 * time.Second
+)
+
+// Options are the options of a HANA Express container.
+type Options struct {
+	// Image is the docker image (default: DefaultImage).
+	Image string
+	// Name is the container name (default: DefaultName). A running container with this name is attached to.
+	Name string
+	// Password is the master password of the database (required unless DSN is set).
+	Password string
+	// Host is the host the container ports are published on (default: DefaultHost).
+	Host string
+	// Port is the SQL port of the database (default: DefaultPort).
+	Port int
+	// DSN of an existing database to attach to instead of starting a container.
+	DSN string
+	// ReadyTimeout is the maximum time to wait for the database to accept connections (default: DefaultReadyTimeout).
+	ReadyTimeout time.Duration
+	// PollInterval is the time between readiness checks (default: DefaultPollInterval).
+	PollInterval time.Duration
+	// Keep keeps a started container running on Stop (e.g. to attach to it by subsequent test runs).
+	Keep bool
+}
+
+func (o *Options) withDefaults() *Options {
+	r := *o
+	if r.Image == "" {
+		r.Image = DefaultImage
+	}
+	if r.Name == "" {
+		r.Name = DefaultName
+	}
+	if r.Host == "" {
+		r.Host = DefaultHost
+	}
+	if r.Port == 0 {
+		r.Port = DefaultPort
+	}
+	if r.ReadyTimeout == 0 {
+		r.ReadyTimeout = DefaultReadyTimeout
+	}
+	if r.PollInterval == 0 {
+		r.PollInterval = DefaultPollInterval
+	}
+	return &r
+}
+
+// ErrDockerNotFound is returned by Start if a container needs to be started but docker is not installed.
+var ErrDockerNotFound = errors.New("hdbcontainer: docker command not found")
+
+// dockerCmd is the docker command line interface.
+var dockerCmd = "docker"
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, dockerCmd, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", ErrDockerNotFound
+		}
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// runArgs returns the docker run arguments of a HANA Express container (see HANA Express docker installation guide).
+func runArgs(o *Options) []string {
+	publish := func(ports string) []string { return []string{"-p", o.Host + ":" + ports + ":" + ports} }
+	args := []string{"run", "-d", "--name", o.Name, "--hostname", "hxehost"}
+	for _, ports := range []string{"39013", "39017", "39041-39045", "1128-1129", "59013-59014"} {
+		args = append(args, publish(ports)...)
+	}
+	if o.Port != DefaultPort {
+		args = append(args, "-p", o.Host+":"+strconv.Itoa(o.Port)+":"+strconv.Itoa(DefaultPort))
+	}
+	return append(args,
+		"--ulimit", "nofile=1048576:1048576",
+		"--sysctl", "kernel.shmmax=1073741824",
+		"--sysctl", "net.ipv4.ip_local_port_range=40000 60999",
+		"--sysctl", "kernel.shmmni=524288",
+		"--sysctl", "kernel.shmall=8388608",
+		o.Image,
+		"--agree-to-sap-license",
+		"--master-password", o.Password,
+	)
+}
+
+// A Container is a started or attached HANA Express database.
+type Container struct {
+	name      string
+	started   bool
+	keep      bool
+	connector *driver.Connector
+}
+
+/*
+Start starts a HANA Express container and waits until the database is ready to accept connections.
+
+If Options.DSN is set, Start attaches to the database of the DSN. Otherwise, if a container with name Options.Name is
+running, Start attaches to this container, or starts a stopped container respectively runs a new container.
+*/
+func Start(ctx context.Context, options *Options) (*Container, error) {
+	o := options.withDefaults()
+
+	c := &Container{name: o.Name, keep: o.Keep}
+	if o.DSN != "" {
+		connector, err := driver.NewDSNConnector(o.DSN)
+		if err != nil {
+			return nil, err
+		}
+		c.connector = connector
+	} else {
+		if o.Password == "" {
+			return nil, errors.New("hdbcontainer: password missing")
+		}
+		running, err := docker(ctx, "inspect", "--format", "{{.State.Running}}", o.Name)
+		switch {
+		case errors.Is(err, ErrDockerNotFound):
+			return nil, err
+		case err != nil: // container does not exist
+			if _, err := docker(ctx, runArgs(o)...); err != nil {
+				return nil, err
+			}
+			c.started = true
+		case running != "true":
+			if _, err := docker(ctx, "start", o.Name); err != nil {
+				return nil, err
+			}
+			c.started = true
+		}
+		c.connector = driver.NewBasicAuthConnector(net.JoinHostPort(o.Host, strconv.Itoa(o.Port)), DefaultUser, o.Password)
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, o.ReadyTimeout)
+	defer cancel()
+	if err := WaitReady(readyCtx, c.connector, o.PollInterval); err != nil {
+		return nil, errors.Join(err, c.Stop(ctx))
+	}
+	return c, nil
+}
+
+// Connector returns a connector of the database.
+func (c *Container) Connector() *driver.Connector { return c.connector }
+
+// Stop removes the container if it was started by Start and not requested to be kept.
+func (c *Container) Stop(ctx context.Context) error {
+	if !c.started || c.keep {
+		return nil
+	}
+	_, err := docker(ctx, "rm", "--force", c.name)
+	return err
+}
+
+// probe checks whether the database of connector is ready by a protocol handshake and a database ping.
+func probe(ctx context.Context, connector *driver.Connector) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", connector.Host())
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline) //nolint:errcheck
+	}
+	err = p.Handshake(ctx, conn)
+	conn.Close()
+	if err != nil {
+		return err
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	return db.PingContext(ctx)
+}
+
+// WaitReady polls the database of connector in intervals until it accepts connections or ctx is done.
+func WaitReady(ctx context.Context, connector *driver.Connector, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := probe(ctx, connector)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("hdbcontainer: database not ready: %w", errors.Join(ctx.Err(), err))
+		case <-ticker.C:
+		}
+	}
+}
+
+// ForTest starts a container for test tb and stops it as part of the test cleanup.
+// The test is skipped if docker is not installed.
+func ForTest(tb testing.TB, options *Options) *driver.Connector {
+	tb.Helper()
+	c, err := Start(context.Background(), options)
+	if errors.Is(err, ErrDockerNotFound) {
+		tb.Skip(err)
+	}
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		if err := c.Stop(context.Background()); err != nil {
+			tb.Error(err)
+		}
+	})
+	return c.Connector()
+}
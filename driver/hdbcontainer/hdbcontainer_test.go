@@ -0,0 +1,72 @@
+package hdbcontainer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestAttachDSN(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	c, err := Start(context.Background(), &Options{DSN: srv.DSN(), PollInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Connector().Host() != srv.Addr() {
+		t.Fatalf("host %s - expected %s", c.Connector().Host(), srv.Addr())
+	}
+	if err := c.Stop(context.Background()); err != nil { // no container started
+		t.Fatal(err)
+	}
+}
+
+func TestWaitReady(t *testing.T) {
+	// reserve a port without listener
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = WaitReady(ctx, driver.NewBasicAuthConnector(addr, "MOCK", "MOCK"), 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestDockerNotFound(t *testing.T) {
+	dockerCmd = "go-hdb-docker-not-installed"
+	defer func() { dockerCmd = "docker" }()
+
+	if _, err := Start(context.Background(), &Options{Password: "HXEHana1"}); !errors.Is(err, ErrDockerNotFound) {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		ForTest(t, &Options{Password: "HXEHana1"})
+		t.Fatal("test not skipped")
+	})
+}
+
+func TestRunArgs(t *testing.T) {
+	args := runArgs((&Options{Password: "HXEHana1", Port: 49041}).withDefaults())
+	for _, arg := range []string{DefaultName, DefaultImage, "--agree-to-sap-license", "HXEHana1", "localhost:49041:39041"} {
+		if !slices.Contains(args, arg) {
+			t.Fatalf("argument %s missing in %v", arg, args)
+		}
+	}
+}
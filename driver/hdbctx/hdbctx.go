@@ -0,0 +1,258 @@
+/*
+Package hdbctx provides the context values evaluated by go-hdb for statements executed with a context.
+
+Context values override the respective connector settings for a single statement:
+
+  - WithFetchSize: fetch size of the query resultset
+  - WithLobChunkSize: chunk size of lob parameters written to the database
+  - WithReadOnly: route queries to a read enabled secondary system replication site
+  - WithTraceAttrs: additional attributes of the sql trace log entries
+  - WithStatementTimeout: server side timeout of the statement
+  - WithStringInterning: string interning of character column values
+  - WithSessionContext: session context values (e.g. APPLICATIONUSER) of the statement execution
+  - WithMaxResultBytes: maximum number of bytes of a query result buffered by the client
+
+Further context values let the driver provide information about the statement execution:
+
+  - WithExecInfo: execution statistics of statements reported by the database server
+  - WithResultSize: size information of query results
+
+The functions are extension points for code wrapping the driver (e.g. middlewares or request handlers),
+which can set statement specific values without access to the connector. Values not set by a context
+are taken from the connector.
+*/
+package hdbctx
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"time"
+)
+
+type (
+	fetchSizeCtxKey        struct{}
+	lobChunkSizeCtxKey     struct{}
+	readOnlyCtxKey         struct{}
+	traceAttrsCtxKey       struct{}
+	statementTimeoutCtxKey struct{}
+	stringInterningCtxKey  struct{}
+	maxResultBytesCtxKey   struct{}
+	sessionContextCtxKey   struct{}
+	execInfoCtxKey         struct{}
+	resultSizeCtxKey       struct{}
+)
+
+// WithFetchSize returns a copy of ctx setting the fetch size of query resultsets.
+// The fetch size is used for all fetch roundtrips of the resultset (no adaptive fetch sizing).
+func WithFetchSize(ctx context.Context, fetchSize int) context.Context {
+	return context.WithValue(ctx, fetchSizeCtxKey{}, fetchSize)
+}
+
+// FetchSizeFrom returns the fetch size set by ctx. ok is false if ctx does not set a valid (positive) fetch size.
+func FetchSizeFrom(ctx context.Context) (fetchSize int, ok bool) {
+	if fetchSize, ok = ctx.Value(fetchSizeCtxKey{}).(int); ok && fetchSize > 0 {
+		return fetchSize, true
+	}
+	return 0, false
+}
+
+// WithLobChunkSize returns a copy of ctx setting the chunk size of lob parameters written to the database.
+func WithLobChunkSize(ctx context.Context, lobChunkSize int) context.Context {
+	return context.WithValue(ctx, lobChunkSizeCtxKey{}, lobChunkSize)
+}
+
+// LobChunkSizeFrom returns the lob chunk size set by ctx. ok is false if ctx does not set a valid (positive) lob chunk size.
+func LobChunkSizeFrom(ctx context.Context) (lobChunkSize int, ok bool) {
+	if lobChunkSize, ok = ctx.Value(lobChunkSizeCtxKey{}).(int); ok && lobChunkSize > 0 {
+		return lobChunkSize, true
+	}
+	return 0, false
+}
+
+/*
+WithReadOnly returns a copy of ctx marking queries as read-only.
+
+Read-only queries are routed to a read enabled secondary site of a system replication (active/active read enabled)
+via the RESULT_LAG('hana_sr') hint. If maxLag is greater than zero, the query is executed on the primary site
+in case the replication delay of the secondary site exceeds maxLag (second precision).
+Statements other than select queries and queries containing a hint already are not affected.
+*/
+func WithReadOnly(ctx context.Context, maxLag time.Duration) context.Context {
+	return context.WithValue(ctx, readOnlyCtxKey{}, maxLag)
+}
+
+// ReadOnlyFrom returns true and the maximal replication lag if ctx marks queries as read-only.
+func ReadOnlyFrom(ctx context.Context) (maxLag time.Duration, ok bool) {
+	maxLag, ok = ctx.Value(readOnlyCtxKey{}).(time.Duration)
+	return maxLag, ok
+}
+
+// WithTraceAttrs returns a copy of ctx adding attrs to the sql trace log entries of statements
+// (e.g. request or tracing ids). Attributes set by ctx already are kept.
+func WithTraceAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	prev, _ := TraceAttrsFrom(ctx)
+	return context.WithValue(ctx, traceAttrsCtxKey{}, append(prev[:len(prev):len(prev)], attrs...))
+}
+
+// TraceAttrsFrom returns the trace attributes set by ctx.
+func TraceAttrsFrom(ctx context.Context) ([]slog.Attr, bool) {
+	attrs, ok := ctx.Value(traceAttrsCtxKey{}).([]slog.Attr)
+	return attrs, ok
+}
+
+/*
+WithStatementTimeout returns a copy of ctx setting the timeout of statements.
+
+The timeout is transferred to the database server (second precision), so that the server cancels statements
+exceeding the timeout. In contrast to a context deadline the timeout applies to each statement executed
+with the returned context. The query timeout support of the connector needs to be enabled
+(see driver.Connector.SetContextQueryTimeout), otherwise statements executed with the returned context fail.
+*/
+func WithStatementTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, statementTimeoutCtxKey{}, timeout)
+}
+
+// StatementTimeoutFrom returns the statement timeout set by ctx. ok is false if ctx does not set a valid (positive) timeout.
+func StatementTimeoutFrom(ctx context.Context) (timeout time.Duration, ok bool) {
+	if timeout, ok = ctx.Value(statementTimeoutCtxKey{}).(time.Duration); ok && timeout > 0 {
+		return timeout, true
+	}
+	return 0, false
+}
+
+/*
+WithStringInterning returns a copy of ctx enabling string interning for queries executed with the returned context.
+
+Repeated values of character columns (NCHAR, NVARCHAR, SHORTTEXT) are decoded into the same string instance instead
+of allocating a new value per row, which considerably reduces allocations for low-cardinality (e.g. dimension) columns.
+At most maxValues distinct values are interned per query result - further values are decoded as usual.
+Interned values are provided as string instead of []byte values.
+*/
+func WithStringInterning(ctx context.Context, maxValues int) context.Context {
+	return context.WithValue(ctx, stringInterningCtxKey{}, maxValues)
+}
+
+// StringInterningFrom returns the maximum number of interned values per query result set by ctx.
+// ok is false if ctx does not enable string interning.
+func StringInterningFrom(ctx context.Context) (maxValues int, ok bool) {
+	if maxValues, ok = ctx.Value(stringInterningCtxKey{}).(int); ok && maxValues > 0 {
+		return maxValues, true
+	}
+	return 0, false
+}
+
+// WithMaxResultBytes returns a copy of ctx setting the maximum number of bytes of a query result buffered by the client
+// (see driver.Connector.SetMaxResultBytes).
+func WithMaxResultBytes(ctx context.Context, maxResultBytes int) context.Context {
+	return context.WithValue(ctx, maxResultBytesCtxKey{}, maxResultBytes)
+}
+
+// MaxResultBytesFrom returns the maximum number of query result bytes set by ctx.
+// ok is false if ctx does not set a valid (positive) limit.
+func MaxResultBytesFrom(ctx context.Context) (maxResultBytes int, ok bool) {
+	if maxResultBytes, ok = ctx.Value(maxResultBytesCtxKey{}).(int); ok && maxResultBytes > 0 {
+		return maxResultBytes, true
+	}
+	return 0, false
+}
+
+/*
+WithSessionContext returns a copy of ctx setting session context values (e.g. APPLICATIONUSER or CLIENT)
+for statements executed with the returned context. Values set by ctx already are kept unless overridden by values.
+
+The values are sent to the database server via the client info of the statement execution, so that they are
+available by the SESSION_CONTEXT function (e.g. in row-level security filters like analytic privileges or views)
+even if the effective end user changes per request on a pooled connection. Session context values set by a
+statement are reset for the next statement executed on the same connection without them (to the respective
+connector session variable or to an empty value).
+*/
+func WithSessionContext(ctx context.Context, values map[string]string) context.Context {
+	prev, _ := SessionContextFrom(ctx)
+	sessionContext := maps.Clone(prev)
+	if sessionContext == nil {
+		sessionContext = make(map[string]string, len(values))
+	}
+	maps.Copy(sessionContext, values)
+	return context.WithValue(ctx, sessionContextCtxKey{}, sessionContext)
+}
+
+// SessionContextFrom returns the session context values set by ctx. The returned map must not be modified.
+func SessionContextFrom(ctx context.Context) (map[string]string, bool) {
+	values, ok := ctx.Value(sessionContextCtxKey{}).(map[string]string)
+	return values, ok
+}
+
+// ExecInfo contains the execution statistics of a statement reported by the database server.
+// Values not reported by the server are zero.
+type ExecInfo struct {
+	// ServerProcessingTime is the processing time of the statement on the server.
+	ServerProcessingTime time.Duration
+	// ServerCPUTime is the cpu time used by the server to process the statement.
+	ServerCPUTime time.Duration
+	// ServerMemoryUsage is the peak memory (in bytes) used by the server to process the statement.
+	ServerMemoryUsage int64
+	// Warnings are the warnings (driver.DBError) sent by the server while executing the statement.
+	Warnings []error
+}
+
+/*
+WithExecInfo returns a copy of ctx which lets the driver provide the execution statistics of statements
+executed with the returned context in info.
+
+info is set after each query or exec call, so that it contains the statistics of the last executed statement.
+The statistics of a query do not include fetching further rows of the resultset.
+*/
+func WithExecInfo(ctx context.Context, info *ExecInfo) context.Context {
+	return context.WithValue(ctx, execInfoCtxKey{}, info)
+}
+
+// ExecInfoFrom returns the execution info set by ctx. ok is false if ctx does not set an execution info.
+func ExecInfoFrom(ctx context.Context) (info *ExecInfo, ok bool) {
+	info, ok = ctx.Value(execInfoCtxKey{}).(*ExecInfo)
+	return info, ok && info != nil
+}
+
+/*
+ResultSize contains size information of a query result available right after the query call, i.e. before the
+application fetches the rows. The query call reply contains the rows of the first fetch roundtrip, so that the
+result size of small results is known exactly and the size of larger results can be extrapolated, e.g. based on
+the number of rows estimated by the database optimizer (see driver.EstimateResultRows).
+
+Values are zero if not available (e.g. for procedure call results).
+*/
+type ResultSize struct {
+	// Complete is true if the query call returned all result rows, so that Rows is the exact number of result rows.
+	Complete bool
+	// Rows is the number of rows returned by the query call (first fetch roundtrip).
+	Rows int
+	// Bytes is the number of bytes of the query call reply.
+	Bytes int64
+}
+
+// BytesPerRow returns the average number of reply bytes per row of the first fetch roundtrip (0 if no rows were returned).
+func (s *ResultSize) BytesPerRow() float64 {
+	if s.Rows == 0 {
+		return 0
+	}
+	return float64(s.Bytes) / float64(s.Rows)
+}
+
+// EstimateBytes returns the estimated number of bytes transferred for numRow result rows based on the first fetch roundtrip.
+func (s *ResultSize) EstimateBytes(numRow float64) float64 { return numRow * s.BytesPerRow() }
+
+/*
+WithResultSize returns a copy of ctx which lets the driver provide the size information of query results
+executed with the returned context in size.
+
+size is set after each query call, so that it contains the size information of the last executed query.
+*/
+func WithResultSize(ctx context.Context, size *ResultSize) context.Context {
+	return context.WithValue(ctx, resultSizeCtxKey{}, size)
+}
+
+// ResultSizeFrom returns the result size set by ctx. ok is false if ctx does not set a result size.
+func ResultSizeFrom(ctx context.Context) (size *ResultSize, ok bool) {
+	size, ok = ctx.Value(resultSizeCtxKey{}).(*ResultSize)
+	return size, ok && size != nil
+}
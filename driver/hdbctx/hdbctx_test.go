@@ -0,0 +1,89 @@
+package hdbctx
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestContextValues(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := FetchSizeFrom(ctx); ok {
+		t.Fatal("fetch size set by empty context")
+	}
+	if _, ok := FetchSizeFrom(WithFetchSize(ctx, 0)); ok {
+		t.Fatal("invalid fetch size accepted")
+	}
+	if fetchSize, ok := FetchSizeFrom(WithFetchSize(ctx, 1000)); !ok || fetchSize != 1000 {
+		t.Fatalf("fetch size %d - expected %d", fetchSize, 1000)
+	}
+	if lobChunkSize, ok := LobChunkSizeFrom(WithLobChunkSize(ctx, 4096)); !ok || lobChunkSize != 4096 {
+		t.Fatalf("lob chunk size %d - expected %d", lobChunkSize, 4096)
+	}
+	if maxLag, ok := ReadOnlyFrom(WithReadOnly(ctx, 0)); !ok || maxLag != 0 {
+		t.Fatalf("read only %t max lag %s - expected read only without max lag", ok, maxLag)
+	}
+	if timeout, ok := StatementTimeoutFrom(WithStatementTimeout(ctx, time.Minute)); !ok || timeout != time.Minute {
+		t.Fatalf("statement timeout %s - expected %s", timeout, time.Minute)
+	}
+	if maxValues, ok := StringInterningFrom(WithStringInterning(ctx, 100)); !ok || maxValues != 100 {
+		t.Fatalf("string interning max values %d - expected %d", maxValues, 100)
+	}
+	if _, ok := ExecInfoFrom(WithExecInfo(ctx, nil)); ok {
+		t.Fatal("nil exec info accepted")
+	}
+	if info, ok := ExecInfoFrom(WithExecInfo(ctx, &ExecInfo{})); !ok || info == nil {
+		t.Fatal("exec info not set")
+	}
+	if size, ok := ResultSizeFrom(WithResultSize(ctx, &ResultSize{Rows: 2, Bytes: 100})); !ok || size.BytesPerRow() != 50 {
+		t.Fatalf("result size %v - expected %d bytes per row", size, 50)
+	}
+}
+
+func TestTraceAttrs(t *testing.T) {
+	parent := WithTraceAttrs(context.Background(), slog.String("requestID", "4711"))
+	child1 := WithTraceAttrs(parent, slog.String("span", "a"))
+	child2 := WithTraceAttrs(parent, slog.String("span", "b"))
+
+	for _, test := range []struct {
+		ctx  context.Context
+		keys []string
+		span string
+	}{
+		{parent, []string{"requestID"}, ""},
+		{child1, []string{"requestID", "span"}, "a"},
+		{child2, []string{"requestID", "span"}, "b"},
+	} {
+		attrs, ok := TraceAttrsFrom(test.ctx)
+		if !ok || len(attrs) != len(test.keys) {
+			t.Fatalf("trace attributes %v - expected keys %v", attrs, test.keys)
+		}
+		for i, key := range test.keys {
+			if attrs[i].Key != key {
+				t.Fatalf("trace attribute key %s - expected %s", attrs[i].Key, key)
+			}
+		}
+		if test.span != "" && attrs[1].Value.String() != test.span {
+			t.Fatalf("span %s - expected %s", attrs[1].Value, test.span)
+		}
+	}
+}
+
+func TestSessionContext(t *testing.T) {
+	if _, ok := SessionContextFrom(context.Background()); ok {
+		t.Fatal("session context set by empty context")
+	}
+	parent := WithSessionContext(context.Background(), map[string]string{"APPLICATIONUSER": "alice", "CLIENT": "100"})
+	child := WithSessionContext(parent, map[string]string{"APPLICATIONUSER": "bob"})
+
+	values, _ := SessionContextFrom(parent)
+	if values["APPLICATIONUSER"] != "alice" || values["CLIENT"] != "100" {
+		t.Fatalf("parent session context %v", values)
+	}
+	values, _ = SessionContextFrom(child)
+	if values["APPLICATIONUSER"] != "bob" || values["CLIENT"] != "100" {
+		t.Fatalf("child session context %v", values)
+	}
+}
@@ -0,0 +1,777 @@
+/*
+Package hdbtest provides an in-process mock database server for unit tests of applications using go-hdb.
+
+The mock server speaks the hdb wire protocol, so that the driver is used unchanged and tests do not need
+a database. Statements are answered by registered responses:
+
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer srv.Close()
+
+	srv.Handle("select id, name from customers where id = ?", &hdbtest.Response{
+		Params:  []hdbtest.Column{{Name: "ID", Type: "INTEGER"}},
+		Columns: []hdbtest.Column{{Name: "ID", Type: "INTEGER"}, {Name: "NAME", Type: "NVARCHAR", Length: 100}},
+		Rows:    [][]any{{1, "Jane"}},
+	})
+
+	db := sql.OpenDB(driver.NewDSNConnector(srv.DSN()))
+
+Limitations:
+  - the server accepts any credentials (SCRAMSHA256 and JWT authentication)
+  - lob parameters need to fit into the first lob chunk (lob chunk size of the connector)
+  - database procedures, XA transactions and table parameters are not supported
+  - the server does not parse sql: statements are matched by their text (whitespace normalized)
+*/
+package hdbtest
+
+import (
+	"bufio"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+	"golang.org/x/text/transform"
+)
+
+const (
+	// DatabaseName is the database name reported by the mock server.
+	DatabaseName = "MOCK"
+	// FullVersion is the database version reported by the mock server.
+	FullVersion = "2.00.076.00.1705400033"
+)
+
+const (
+	initialFetchSize = 32   // number of rows returned by a query execution
+	lobInlineSize    = 1024 // maximum number of lob bytes included in a resultset
+)
+
+// error codes of mock server errors.
+const (
+	errCodeGeneral   = 2
+	errCodeAuth      = 10
+	errCodeSQLSyntax = 257
+)
+
+const (
+	dummyQuery           = "select 1 from dummy"
+	sessionTimeZoneQuery = "select seconds_between(current_utctimestamp, current_timestamp) from dummy"
+)
+
+// Column describes a result column or an input parameter of a statement.
+type Column struct {
+	Name string
+	// Type is the database type name (BOOLEAN, TINYINT, SMALLINT, INTEGER, BIGINT, REAL, DOUBLE, DECIMAL,
+	// DATE, TIME, SECONDDATE, TIMESTAMP, VARCHAR, NVARCHAR, VARBINARY, CLOB, NCLOB or BLOB).
+	Type     string
+	Nullable bool
+	Length   int // length of variable length types respectively precision of decimals
+	Scale    int
+}
+
+func serverFields(columns []Column) []p.ServerField {
+	fields := make([]p.ServerField, len(columns))
+	for i, c := range columns {
+		fields[i] = p.ServerField{Name: c.Name, TypeName: c.Type, Nullable: c.Nullable, Length: c.Length, Scale: c.Scale}
+	}
+	return fields
+}
+
+/*
+Response is the answer of the mock server to a statement.
+
+  - Params describes the input parameters of the statement. If nil, each '?' placeholder of the statement
+    is declared as nullable NVARCHAR parameter.
+  - Columns describes the result columns of a query. Statements without columns return RowsAffected.
+  - Rows are the result rows of a query. Values of lob columns are provided as string or []byte.
+  - Err is returned instead of a result (use *Error for database errors with error code).
+  - Func, if not nil, computes rows, rows affected or error per execution from the statement arguments.
+*/
+type Response struct {
+	Params       []Column
+	Columns      []Column
+	Rows         [][]any
+	RowsAffected int64
+	Err          error
+	Func         func(args []driver.Value) ([][]any, int64, error)
+}
+
+func (r *Response) result(args []driver.Value) ([][]any, int64, error) {
+	if r.Func != nil {
+		return r.Func(args)
+	}
+	return r.Rows, r.RowsAffected, r.Err
+}
+
+// Error is a database error returned by the mock server.
+type Error struct {
+	Code int
+	Text string
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("SQL Error %d - %s", e.Code, e.Text) }
+
+// Execution is a statement execution recorded by the mock server.
+type Execution struct {
+	Query          string
+	Args           []driver.Value
+	SessionContext map[string]string // session context values set via client info (nil if none)
+}
+
+func normalize(query string) string { return strings.Join(strings.Fields(query), " ") }
+
+// Server is a mock database server.
+type Server struct {
+	ln        net.Listener
+	sessionID atomic.Int64
+	wg        sync.WaitGroup
+
+	mu         sync.Mutex
+	responses  map[string]*Response
+	executions []Execution
+	conns      map[net.Conn]struct{}
+}
+
+// NewServer starts a mock database server listening on a local tcp port.
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{ln: ln, responses: map[string]*Response{}, conns: map[net.Conn]struct{}{}}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() string { return s.ln.Addr().String() }
+
+// DSN returns a data source name connecting to the server.
+func (s *Server) DSN() string { return "hdb://MOCK:MOCK@" + s.Addr() }
+
+// Handle registers the response r of the sql statement query.
+func (s *Server) Handle(query string, r *Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[normalize(query)] = r
+}
+
+// Executions returns the statement executions of all connections in the order of execution.
+// Commits and rollbacks are recorded with the query COMMIT respectively ROLLBACK.
+func (s *Server) Executions() []Execution {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	executions := make([]Execution, len(s.executions))
+	copy(executions, s.executions)
+	return executions
+}
+
+// Close stops the server and closes all client connections.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			newSession(s, conn).run()
+		}()
+	}
+}
+
+func (s *Server) record(query string, args []driver.Value, sessionContext map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executions = append(s.executions, Execution{Query: query, Args: args, SessionContext: sessionContext})
+}
+
+var (
+	dummyResponse = &Response{
+		Columns: []Column{{Name: "1", Type: "INTEGER"}},
+		Rows:    [][]any{{1}},
+	}
+	sessionTimeZoneResponse = &Response{
+		Columns: []Column{{Name: "SECONDS_BETWEEN", Type: "BIGINT"}},
+		Rows:    [][]any{{0}},
+	}
+	ddlResponse = &Response{}
+)
+
+// response returns the response of query.
+func (s *Server) response(query string) (*Response, error) {
+	query = normalize(query)
+	s.mu.Lock()
+	r, ok := s.responses[query]
+	s.mu.Unlock()
+	if ok {
+		return r, nil
+	}
+	switch {
+	case strings.EqualFold(query, dummyQuery):
+		return dummyResponse, nil
+	case strings.EqualFold(query, sessionTimeZoneQuery):
+		return sessionTimeZoneResponse, nil
+	case p.ReplyFunctionCode(p.MtExecuteDirect, query) == p.FcDDL:
+		return ddlResponse, nil
+	}
+	return nil, &Error{Code: errCodeSQLSyntax, Text: fmt.Sprintf("sql syntax error: statement not registered in mock server: %s", query)}
+}
+
+// params returns the input parameters of query.
+func (r *Response) params(query string) []Column {
+	if r.Params != nil {
+		return r.Params
+	}
+	var params []Column
+	quoted := false
+	for _, ch := range query {
+		switch {
+		case ch == '\'':
+			quoted = !quoted
+		case ch == '?' && !quoted:
+			params = append(params, Column{Name: fmt.Sprintf("P%d", len(params)+1), Type: "NVARCHAR", Nullable: true, Length: 5000})
+		}
+	}
+	return params
+}
+
+type statement struct {
+	query     string
+	r         *Response
+	prmFields []*p.ParameterField
+	params    []p.ServerField
+}
+
+type resultset struct {
+	stmtID uint64 // id of the prepared statement (0: direct execution)
+	fields []p.ServerField
+	rows   [][]any
+	pos    int
+}
+
+type lob struct {
+	b         []byte // cesu8 encoded data for character based lobs
+	charBased bool
+}
+
+// session is a client connection of the server.
+type session struct {
+	srv  *Server
+	id   int64
+	conn net.Conn
+	bw   *bufio.Writer
+	rd   *p.Reader
+	wr   *p.Writer
+
+	authMethod string
+	lastID     uint64
+	stmts      map[uint64]*statement
+	resultsets map[uint64]*resultset
+	lobs       map[p.LocatorID]*lob
+	sessCtx    map[string]string // session context values set via client info
+}
+
+func newSession(srv *Server, conn net.Conn) *session {
+	logger := slog.Default().With(slog.String("mock", conn.RemoteAddr().String()))
+	bw := bufio.NewWriter(conn)
+	enc := encoding.NewEncoder(bw, cesu8.DefaultEncoder)
+	dec := encoding.NewDecoder(bufio.NewReader(conn), cesu8.DefaultDecoder)
+	return &session{
+		srv:        srv,
+		id:         srv.sessionID.Add(1),
+		conn:       conn,
+		bw:         bw,
+		rd:         p.NewClientReader(dec, false, logger),
+		wr:         p.NewWriter(bw, enc, false, logger, cesu8.DefaultEncoder, nil),
+		stmts:      map[uint64]*statement{},
+		resultsets: map[uint64]*resultset{},
+		lobs:       map[p.LocatorID]*lob{},
+	}
+}
+
+func (s *session) nextID() uint64 { s.lastID++; return s.lastID }
+
+// record records the execution of query with the current session context.
+func (s *session) record(query string, args []driver.Value) {
+	s.srv.record(query, args, maps.Clone(s.sessCtx))
+}
+
+// setSessionContext sets the session context values of client info ci (empty values unset the respective key).
+func (s *session) setSessionContext(ci p.ClientInfo) {
+	for k, v := range ci {
+		if v == "" {
+			delete(s.sessCtx, k)
+			continue
+		}
+		if s.sessCtx == nil {
+			s.sessCtx = map[string]string{}
+		}
+		s.sessCtx[k] = v
+	}
+	if len(s.sessCtx) == 0 {
+		s.sessCtx = nil
+	}
+}
+
+func (s *session) run() {
+	ctx := context.Background()
+	if err := s.rd.ReadProlog(ctx); err != nil {
+		return
+	}
+	if err := s.wr.WriteInitReply(ctx); err != nil {
+		return
+	}
+	for {
+		req, err := s.readRequest(ctx)
+		if err != nil { // read stream is broken
+			return
+		}
+		if err := s.handle(ctx, req); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			code, text := errCodeGeneral, err.Error()
+			var hdbErr *Error
+			if errors.As(err, &hdbErr) {
+				code, text = hdbErr.Code, hdbErr.Text
+			}
+			if err := s.wr.WriteErrorReply(ctx, s.id, code, text); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// request is a decoded client request.
+type request struct {
+	mt        p.MessageType
+	query     p.Command
+	stmtID    p.StatementID
+	rsID      p.ResultsetID
+	fetchSize p.Fetchsize
+	initReq   *p.AuthInitRequest
+	co        *p.ConnectOptions
+	ci        *p.DBConnectInfo
+	lobReqs   []*p.ReadLobRequest
+	prms      *p.InputParameters
+	sessCtx   p.ClientInfo
+}
+
+func (s *session) readRequest(ctx context.Context) (*request, error) {
+	req := &request{
+		initReq: &p.AuthInitRequest{},
+		co:      &p.ConnectOptions{},
+		ci:      &p.DBConnectInfo{},
+		prms:    &p.InputParameters{},
+	}
+	if err := s.rd.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+		switch kind {
+		case p.PkAuthentication:
+			if s.rd.MessageType() == p.MtAuthenticate {
+				read(req.initReq)
+			}
+		case p.PkConnectOptions:
+			read(req.co)
+		case p.PkDBConnectInfo:
+			read(req.ci)
+		case p.PkCommand:
+			read(&req.query)
+		case p.PkStatementID:
+			read(&req.stmtID)
+		case p.PkResultsetID:
+			read(&req.rsID)
+		case p.PkFetchSize:
+			read(&req.fetchSize)
+		case p.PkReadLobRequest:
+			lobReq := &p.ReadLobRequest{}
+			read(lobReq)
+			req.lobReqs = append(req.lobReqs, lobReq)
+		case p.PkClientInfo:
+			read(&req.sessCtx)
+		case p.PkParameters:
+			if stmt, ok := s.stmts[uint64(req.stmtID)]; ok { // statement id precedes the parameters
+				req.prms.InputFields = stmt.prmFields
+			}
+			read(req.prms)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	req.mt = s.rd.MessageType()
+	return req, nil
+}
+
+func (s *session) reply(ctx context.Context, mt p.MessageType, query string, parts ...p.Part) error {
+	return s.wr.WriteReply(ctx, s.id, p.ReplyFunctionCode(mt, query), parts...)
+}
+
+func (s *session) handle(ctx context.Context, req *request) error {
+	s.setSessionContext(req.sessCtx)
+	switch req.mt {
+	case p.MtAuthenticate:
+		return s.authenticate(ctx, req)
+	case p.MtConnect:
+		return s.connect(ctx, req)
+	case p.MtDBConnectInfo:
+		req.ci.SetIsConnected(true)
+		return s.reply(ctx, req.mt, "", req.ci)
+	case p.MtExecuteDirect:
+		return s.executeDirect(ctx, req)
+	case p.MtPrepare:
+		return s.prepare(ctx, req)
+	case p.MtExecute:
+		return s.execute(ctx, req)
+	case p.MtFetchNext:
+		return s.fetchNext(ctx, req)
+	case p.MtWriteLob: // read lob request (sic)
+		return s.readLob(ctx, req)
+	case p.MtCloseResultset:
+		delete(s.resultsets, uint64(req.rsID))
+		return s.reply(ctx, req.mt, "")
+	case p.MtDropStatementID:
+		delete(s.stmts, uint64(req.stmtID))
+		// like the database server, dropping a statement closes its open resultsets
+		maps.DeleteFunc(s.resultsets, func(id uint64, rs *resultset) bool { return rs.stmtID == uint64(req.stmtID) })
+		return s.reply(ctx, req.mt, "")
+	case p.MtCommit:
+		s.record("COMMIT", nil)
+		return s.reply(ctx, req.mt, "")
+	case p.MtRollback:
+		s.record("ROLLBACK", nil)
+		return s.reply(ctx, req.mt, "")
+	case p.MtDisconnect:
+		return io.EOF // client does not read the reply
+	default:
+		return &Error{Code: errCodeGeneral, Text: fmt.Sprintf("message type %s not supported by mock server", req.mt)}
+	}
+}
+
+func (s *session) authenticate(ctx context.Context, req *request) error {
+	logonname, methods := req.initReq.Methods()
+	for _, mt := range methods {
+		part, err := p.AuthInitReplyPart(mt, logonname)
+		if err != nil { // method not supported
+			continue
+		}
+		s.authMethod = mt
+		return s.reply(ctx, p.MtAuthenticate, "", part)
+	}
+	return &Error{Code: errCodeAuth, Text: fmt.Sprintf("authentication failed: methods %v not supported by mock server", methods)}
+}
+
+func (s *session) connect(ctx context.Context, req *request) error {
+	part, err := p.AuthFinalReplyPart(s.authMethod)
+	if err != nil {
+		return &Error{Code: errCodeAuth, Text: err.Error()}
+	}
+	req.co.SetConnectionID(int(s.id))
+	req.co.SetFullVersion(FullVersion)
+	req.co.SetDatabaseName(DatabaseName)
+	return s.reply(ctx, req.mt, "", part, req.co)
+}
+
+func (s *session) rowsAffected(ctx context.Context, mt p.MessageType, query string, rows ...int64) error {
+	part, err := p.RowsAffectedPart(rows...)
+	if err != nil {
+		return err
+	}
+	return s.reply(ctx, mt, query, part)
+}
+
+// resultset replies the first rows of a query result.
+func (s *session) resultset(ctx context.Context, mt p.MessageType, stmtID uint64, query string, columns []Column, rows [][]any) error {
+	rs := &resultset{stmtID: stmtID, fields: serverFields(columns), rows: rows}
+	rsID := s.nextID()
+	part, last, err := s.fetch(rs, initialFetchSize)
+	if err != nil {
+		return err
+	}
+	if !last {
+		s.resultsets[rsID] = rs
+	}
+	if mt == p.MtExecute { // result metadata is provided by prepare
+		return s.reply(ctx, mt, query, p.ResultsetID(rsID), part)
+	}
+	meta, err := p.ResultMetadataPart(rs.fields)
+	if err != nil {
+		return err
+	}
+	return s.reply(ctx, mt, query, meta, p.ResultsetID(rsID), part)
+}
+
+// fetch returns a resultset part of the next rows of rs and if these are the last rows.
+func (s *session) fetch(rs *resultset, size int) (*p.ReplyPart, bool, error) {
+	end := min(rs.pos+max(size, 1), len(rs.rows))
+	rows := make([][]driver.Value, 0, end-rs.pos)
+	for _, row := range rs.rows[rs.pos:end] {
+		values := make([]driver.Value, len(row))
+		for i, v := range row {
+			if i < len(rs.fields) && rs.fields[i].DataType() == p.DtLob {
+				var err error
+				if v, err = s.serverLob(v, rs.fields[i].IsCharBased()); err != nil {
+					return nil, false, fmt.Errorf("field %s: %w", rs.fields[i].Name, err)
+				}
+			}
+			values[i] = v
+		}
+		rows = append(rows, values)
+	}
+	rs.pos = end
+	last := rs.pos == len(rs.rows)
+	part, err := p.ResultsetPart(rs.fields, rows, last)
+	return part, last, err
+}
+
+func (s *session) executeDirect(ctx context.Context, req *request) error {
+	query := string(req.query)
+	r, err := s.srv.response(query)
+	if err != nil {
+		return err
+	}
+	s.record(query, nil)
+	rows, n, err := r.result(nil)
+	if err != nil {
+		return err
+	}
+	if r.Columns == nil {
+		return s.rowsAffected(ctx, req.mt, query, n)
+	}
+	return s.resultset(ctx, req.mt, 0, query, r.Columns, rows)
+}
+
+func (s *session) prepare(ctx context.Context, req *request) error {
+	query := string(req.query)
+	r, err := s.srv.response(query)
+	if err != nil {
+		return err
+	}
+	params := serverFields(r.params(query))
+	prmFields, err := p.ParameterFields(params)
+	if err != nil {
+		return err
+	}
+	id := s.nextID()
+	s.stmts[id] = &statement{query: query, r: r, prmFields: prmFields, params: params}
+
+	parts := []p.Part{p.StatementID(id)}
+	if r.Columns != nil {
+		part, err := p.ResultMetadataPart(serverFields(r.Columns))
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+	}
+	if len(params) != 0 {
+		part, err := p.ParameterMetadataPart(params)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+	}
+	return s.reply(ctx, req.mt, query, parts...)
+}
+
+// arg returns the statement argument of the decoded parameter value v.
+func arg(f p.ServerField, v any) driver.Value {
+	b, ok := v.([]byte)
+	if !ok {
+		return v
+	}
+	switch f.DataType() {
+	case p.DtString:
+		return string(b)
+	case p.DtLob:
+		if !strings.EqualFold(f.TypeName, "BLOB") {
+			return string(b)
+		}
+	}
+	return b
+}
+
+func (s *session) execute(ctx context.Context, req *request) error {
+	stmt, ok := s.stmts[uint64(req.stmtID)]
+	if !ok {
+		return &Error{Code: errCodeGeneral, Text: fmt.Sprintf("invalid statement id %d", req.stmtID)}
+	}
+	nvargs := req.prms.Args()
+	numColumn := len(stmt.params)
+	numRow := 1
+	if numColumn != 0 {
+		numRow = len(nvargs) / numColumn
+	}
+	rowsAffected := make([]int64, 0, numRow)
+	for i := 0; i < numRow; i++ {
+		var args []driver.Value
+		if numColumn != 0 {
+			args = make([]driver.Value, numColumn)
+			for j, nv := range nvargs[i*numColumn : (i+1)*numColumn] {
+				args[j] = arg(stmt.params[j], nv.Value)
+			}
+		}
+		s.record(stmt.query, args)
+		rows, n, err := stmt.r.result(args)
+		if err != nil {
+			return err
+		}
+		if stmt.r.Columns != nil {
+			return s.resultset(ctx, req.mt, uint64(req.stmtID), stmt.query, stmt.r.Columns, rows)
+		}
+		rowsAffected = append(rowsAffected, n)
+	}
+	return s.rowsAffected(ctx, req.mt, stmt.query, rowsAffected...)
+}
+
+func (s *session) fetchNext(ctx context.Context, req *request) error {
+	rs, ok := s.resultsets[uint64(req.rsID)]
+	if !ok {
+		return &Error{Code: errCodeGeneral, Text: fmt.Sprintf("invalid resultset id %d", req.rsID)}
+	}
+	part, last, err := s.fetch(rs, int(req.fetchSize))
+	if err != nil {
+		return err
+	}
+	if last {
+		delete(s.resultsets, uint64(req.rsID))
+	}
+	return s.reply(ctx, req.mt, "", part)
+}
+
+// lobBytes returns the number of bytes of the first numChar characters of b.
+func (l *lob) lobBytes(b []byte, numChar int64) int {
+	if !l.charBased {
+		return int(min(numChar, int64(len(b))))
+	}
+	i := 0
+	for numChar > 0 && i < len(b) {
+		_, width := cesu8.DecodeRune(b[i:])
+		i += width
+		if width == cesu8.CESUMax {
+			numChar -= 2 // hdb counts 2 chars in case of surrogate pair
+		} else {
+			numChar--
+		}
+	}
+	return i
+}
+
+// numChar returns the number of characters of the lob.
+func (l *lob) numChar() int64 {
+	if !l.charBased {
+		return int64(len(l.b))
+	}
+	var numChar int64
+	for b := l.b; len(b) > 0; {
+		_, width := cesu8.DecodeRune(b)
+		b = b[width:]
+		if width == cesu8.CESUMax {
+			numChar += 2
+		} else {
+			numChar++
+		}
+	}
+	return numChar
+}
+
+// inlineSize returns the number of lob bytes included in a resultset (not splitting characters).
+func (l *lob) inlineSize() int {
+	if !l.charBased || len(l.b) <= lobInlineSize {
+		return min(len(l.b), lobInlineSize)
+	}
+	size := 0
+	for size < len(l.b) {
+		_, width := cesu8.DecodeRune(l.b[size:])
+		if size+width > lobInlineSize {
+			break
+		}
+		size += width
+	}
+	return size
+}
+
+// serverLob returns the resultset lob value of v (string or []byte).
+func (s *session) serverLob(v any, charBased bool) (*p.ServerLob, error) {
+	var b []byte
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return nil, fmt.Errorf("invalid lob value type %T - string or []byte expected", v)
+	}
+	if charBased {
+		var err error
+		if b, _, err = transform.Bytes(cesu8.DefaultEncoder(), b); err != nil {
+			return nil, err
+		}
+	}
+	l := &lob{b: b, charBased: charBased}
+	id := p.LocatorID(s.nextID())
+	size := l.inlineSize()
+	last := size == len(b)
+	if !last {
+		s.lobs[id] = l
+	}
+	return &p.ServerLob{ID: id, NumChar: l.numChar(), NumByte: int64(len(b)), B: b[:size], Last: last}, nil
+}
+
+func (s *session) readLob(ctx context.Context, req *request) error {
+	parts := make([]p.Part, 0, len(req.lobReqs))
+	for _, lobReq := range req.lobReqs {
+		id := lobReq.ID
+		l, ok := s.lobs[id]
+		if !ok {
+			return &Error{Code: errCodeGeneral, Text: fmt.Sprintf("invalid lob locator id %d", id)}
+		}
+		start := l.lobBytes(l.b, lobReq.Ofs-1) // offset is 1-based
+		end := start + l.lobBytes(l.b[start:], int64(lobReq.ChunkSize))
+		last := end == len(l.b)
+		if last {
+			delete(s.lobs, id)
+		}
+		part, err := p.ReadLobReplyPart(id, l.b[start:end], last)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+	}
+	return s.reply(ctx, req.mt, "", parts...)
+}
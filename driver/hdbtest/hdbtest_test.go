@@ -0,0 +1,221 @@
+package hdbtest_test
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func newTestDB(t *testing.T) (*hdbtest.Server, *sql.DB) {
+	t.Helper()
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	connector, err := driver.NewDSNConnector(srv.DSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() {
+		db.Close()
+		srv.Close()
+	})
+	return srv, db
+}
+
+func testQuery(t *testing.T, srv *hdbtest.Server, db *sql.DB) {
+	const numRow = 100
+
+	rows := make([][]any, numRow)
+	for i := range rows {
+		rows[i] = []any{i, fmt.Sprintf("name %d", i)}
+	}
+	srv.Handle("select id, name from test", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "ID", Type: "INTEGER"}, {Name: "NAME", Type: "NVARCHAR", Length: 20, Nullable: true}},
+		Rows:    rows,
+	})
+
+	r, err := db.Query("select id, name from test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	i := 0
+	for r.Next() {
+		var id int
+		var name string
+		if err := r.Scan(&id, &name); err != nil {
+			t.Fatal(err)
+		}
+		if id != i || name != fmt.Sprintf("name %d", i) {
+			t.Fatalf("row %d: invalid values %d %s", i, id, name)
+		}
+		i++
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != numRow {
+		t.Fatalf("number of rows %d - expected %d", i, numRow)
+	}
+}
+
+func testExec(t *testing.T, srv *hdbtest.Server, db *sql.DB) {
+	srv.Handle("insert into test values (?, ?)", &hdbtest.Response{
+		Params:       []hdbtest.Column{{Name: "ID", Type: "INTEGER"}, {Name: "NAME", Type: "NVARCHAR", Length: 20, Nullable: true}},
+		RowsAffected: 1,
+	})
+
+	result, err := db.Exec("insert into test values (?, ?)", 42, "answer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("rows affected %d - expected 1", n)
+	}
+
+	executions := srv.Executions()
+	last := executions[len(executions)-1]
+	if last.Query != "insert into test values (?, ?)" || len(last.Args) != 2 || last.Args[0] != int64(42) || last.Args[1] != "answer" {
+		t.Fatalf("invalid execution %v", last)
+	}
+}
+
+func testPreparedQuery(t *testing.T, srv *hdbtest.Server, db *sql.DB) {
+	srv.Handle("select name from test where id = ?", &hdbtest.Response{
+		Params:  []hdbtest.Column{{Name: "ID", Type: "INTEGER"}},
+		Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}},
+		Func: func(args []sqldriver.Value) ([][]any, int64, error) {
+			return [][]any{{fmt.Sprintf("name %d", args[0])}}, 0, nil
+		},
+	})
+
+	stmt, err := db.Prepare("select name from test where id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+
+	for i := 0; i < 3; i++ {
+		var name string
+		if err := stmt.QueryRow(i).Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		if name != fmt.Sprintf("name %d", i) {
+			t.Fatalf("invalid name %s", name)
+		}
+	}
+}
+
+func testError(t *testing.T, srv *hdbtest.Server, db *sql.DB) {
+	srv.Handle("delete from test", &hdbtest.Response{Err: &hdbtest.Error{Code: 259, Text: "invalid table name: TEST"}})
+
+	_, err := db.Exec("delete from test")
+	var dbErr driver.Error
+	if !errors.As(err, &dbErr) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if dbErr.Code() != 259 || dbErr.Text() != "invalid table name: TEST" {
+		t.Fatalf("invalid error code %d text %s", dbErr.Code(), dbErr.Text())
+	}
+
+	// statements not registered
+	if _, err := db.Query("select * from unknown"); !errors.As(err, &dbErr) || dbErr.Code() != 257 {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// connection is still usable
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testLob(t *testing.T, srv *hdbtest.Server, db *sql.DB) {
+	text := strings.Repeat("abc€😀", 20000) // multi chunk lob including surrogate pairs
+
+	srv.Handle("select text from lobs", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "TEXT", Type: "NCLOB", Nullable: true}},
+		Rows:    [][]any{{text}, {nil}},
+	})
+
+	r, err := db.Query("select text from lobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	var values []sql.NullString
+	for r.Next() {
+		var lob driver.NullLob
+		lob.Lob = &driver.Lob{}
+		b := new(strings.Builder)
+		lob.Lob.SetWriter(b)
+		if err := r.Scan(&lob); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, sql.NullString{String: b.String(), Valid: lob.Valid})
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || !values[0].Valid || values[0].String != text || values[1].Valid {
+		t.Fatal("invalid lob values")
+	}
+}
+
+func testTx(t *testing.T, srv *hdbtest.Server, db *sql.DB) {
+	srv.Handle("update test set name = ?", &hdbtest.Response{RowsAffected: 5})
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec("update test set name = ?", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	executions := srv.Executions()
+	if len(executions) < 2 {
+		t.Fatalf("invalid number of executions %d", len(executions))
+	}
+	if executions[len(executions)-2].Query != "update test set name = ?" || executions[len(executions)-1].Query != "COMMIT" {
+		t.Fatalf("invalid executions %v", executions)
+	}
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, srv *hdbtest.Server, db *sql.DB)
+	}{
+		{"query", testQuery},
+		{"exec", testExec},
+		{"preparedQuery", testPreparedQuery},
+		{"error", testError},
+		{"lob", testLob},
+		{"tx", testTx},
+	}
+
+	srv, db := newTestDB(t)
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t, srv, db)
+		})
+	}
+}
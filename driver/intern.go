@@ -0,0 +1,23 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+)
+
+// WithStringInterning returns a copy of ctx enabling string interning for queries executed with the returned context.
+// It is equivalent to hdbctx.WithStringInterning.
+func WithStringInterning(ctx context.Context, maxValues int) context.Context {
+	return hdbctx.WithStringInterning(ctx, maxValues)
+}
+
+// stringInterner returns a new string interner if string interning is enabled by ctx (nil otherwise).
+func stringInterner(ctx context.Context) *encoding.StringInterner {
+	maxValues, ok := hdbctx.StringInterningFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return encoding.NewStringInterner(maxValues)
+}
@@ -86,6 +86,9 @@ type AuthInitRequest struct {
 func (r *AuthInitRequest) String() string { return r.prms.String() }
 func (r *AuthInitRequest) size() int      { return r.prms.Size() }
 func (r *AuthInitRequest) decode(dec *encoding.Decoder) error {
+	if r.prms == nil {
+		r.prms = &auth.Prms{}
+	}
 	return r.prms.Decode(dec)
 }
 func (r *AuthInitRequest) encode(enc *encoding.Encoder) error { return r.prms.Encode(enc) }
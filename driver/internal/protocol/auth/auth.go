@@ -112,7 +112,7 @@ func (s *subPrmsSize) decode(d *encoding.Decoder) {
 	case b == subPrmsSize2ByteIndicator:
 		*s = subPrmsSize(d.Uint16ByteOrder(binary.BigEndian))
 	default:
-		panic(fmt.Sprintf("invalid sub parameter size indicator %d", b))
+		d.SetError(fmt.Errorf("invalid sub parameter size indicator %d", b))
 	}
 }
 
@@ -219,13 +219,15 @@ func (p *Prms) Encode(enc *encoding.Encoder) error {
 	return nil
 }
 
-// Decode decodes the parameters.
+// Decode decodes the parameters of an initial authentication request (all parameters are decoded as bytes).
 func (p *Prms) Decode(dec *encoding.Decoder) error {
 	numPrms := int(dec.Int16())
+	p.prms = make([]any, 0, numPrms)
 	for i := 0; i < numPrms; i++ {
-
+		_, b := dec.LIBytes()
+		p.prms = append(p.prms, b)
 	}
-	return nil
+	return dec.Error()
 }
 
 func checkAuthMethodType(mt, expected string) error {
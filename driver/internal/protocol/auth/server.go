@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// Server side authentication (authentication stub of a mock server accepting any credentials).
+
+// InitRequestMethods returns the logon name and the authentication method types of decoded initial
+// authentication request parameters.
+func (p *Prms) InitRequestMethods() (string, []string) {
+	if len(p.prms) == 0 {
+		return "", nil
+	}
+	logonname, _ := p.prms[0].([]byte)
+	var methods []string
+	for i := 1; i < len(p.prms); i += 2 { // method type, method parameter
+		if mt, ok := p.prms[i].([]byte); ok {
+			methods = append(methods, string(mt))
+		}
+	}
+	return string(logonname), methods
+}
+
+func randomBytes(size int) []byte {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// StubInitReply returns the parameters of the initial authentication reply of an authentication stub for method type mt.
+func StubInitReply(mt, logonname string) (*Prms, error) {
+	prms := &Prms{}
+	prms.addString(mt)
+	switch mt {
+	case MtSCRAMSHA256:
+		subPrms := prms.addPrms()
+		subPrms.addBytes(randomBytes(saltSize))
+		subPrms.addBytes(randomBytes(serverChallengeSize))
+	case MtJWT:
+		prms.AddCESU8String(logonname)
+	default:
+		return nil, fmt.Errorf("authentication method %s not supported", mt)
+	}
+	return prms, nil
+}
+
+// StubFinalReply returns the parameters of the final authentication reply of an authentication stub for method type mt.
+func StubFinalReply(mt string) (*Prms, error) {
+	prms := &Prms{}
+	prms.addString(mt)
+	switch mt {
+	case MtSCRAMSHA256:
+		prms.addEmpty() // no server proof
+	case MtJWT:
+		prms.addBytes(randomBytes(32)) // session cookie
+	default:
+		return nil, fmt.Errorf("authentication method %s not supported", mt)
+	}
+	return prms, nil
+}
@@ -0,0 +1,215 @@
+package protocol
+
+import (
+	"math/big"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+)
+
+/*
+A Column holds the decoded values of a result field for the rows of a resultset part (columnar decoding).
+Depending on the field type the values are stored in
+  - Int64s: integer fields (TINYINT, SMALLINT, INTEGER, BIGINT)
+  - Float64s: floating point fields (REAL, DOUBLE)
+  - Mantissas and Exponents: decimal fields (DECIMAL, SMALLDECIMAL and fixed decimals) with value Mantissas[i] * 10^Exponents[i]
+    and Rats: the decimal values as rational numbers
+  - Times: date and time fields (DAYDATE, SECONDTIME, SECONDDATE, LONGDATE)
+  - Values: all other fields (same values as in row-wise decoding)
+
+Valid reports for each row if the value is not NULL. The slices (including the memory of the mantissas) are reused
+by subsequent decoding, so that decoding numeric fields does not allocate once the buffers are grown.
+
+Date and decimal fields are decoded into their raw representation first and converted for all rows
+of a decoded chunk at once after the chunk is decoded.
+*/
+type Column struct {
+	Int64s    []int64
+	Float64s  []float64
+	Mantissas []big.Int
+	Exponents []int
+	Rats      []big.Rat
+	Times     []time.Time
+	Values    []any
+	Valid     []bool
+
+	raw []int64 // raw date and time values
+}
+
+func (c *Column) retainedBytes() int {
+	const wordSize = int(unsafe.Sizeof(big.Word(0)))
+
+	n := cap(c.Int64s)*8 + cap(c.Float64s)*8 + cap(c.Exponents)*int(unsafe.Sizeof(int(0))) +
+		cap(c.Times)*int(unsafe.Sizeof(time.Time{})) + cap(c.Values)*int(unsafe.Sizeof(any(nil))) + cap(c.Valid) + cap(c.raw)*8
+	ms := c.Mantissas[:cap(c.Mantissas)]
+	for i := range ms {
+		n += int(unsafe.Sizeof(ms[i])) + cap(ms[i].Bits())*wordSize
+	}
+	rs := c.Rats[:cap(c.Rats)]
+	for i := range rs {
+		n += int(unsafe.Sizeof(rs[i])) + (cap(rs[i].Num().Bits())+cap(rs[i].Denom().Bits()))*wordSize
+	}
+	return n
+}
+
+func (c *Column) resize(tc typeCode, numRow int) {
+	c.Valid = resizeSlice(c.Valid, numRow)
+	switch {
+	case tc.isInteger():
+		c.Int64s = resizeSlice(c.Int64s, numRow)
+	case tc.isFloat():
+		c.Float64s = resizeSlice(c.Float64s, numRow)
+	case tc.isDecimalType():
+		c.Mantissas = resizeSlice(c.Mantissas, numRow)
+		c.Exponents = resizeSlice(c.Exponents, numRow)
+		c.Rats = resizeSlice(c.Rats, numRow)
+	case tc.isDatetime():
+		c.raw = resizeSlice(c.raw, numRow)
+		c.Times = resizeSlice(c.Times, numRow)
+	default:
+		c.Values = resizeSlice(c.Values, numRow)
+	}
+}
+
+// decode decodes the value of field f for row i.
+func (c *Column) decode(f *ResultField, dec *encoding.Decoder, i int) error {
+	switch f.tc {
+	case tcTinyint:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			c.Int64s[i] = int64(dec.Byte())
+		}
+	case tcSmallint:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			c.Int64s[i] = int64(dec.Int16())
+		}
+	case tcInteger:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			c.Int64s[i] = int64(dec.Int32())
+		}
+	case tcBigint:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			c.Int64s[i] = dec.Int64()
+		}
+	case tcReal:
+		c.Float64s[i], c.Valid[i] = dec.RealValue()
+	case tcDouble:
+		c.Float64s[i], c.Valid[i] = dec.DoubleValue()
+	case tcDecimal, tcSmalldecimal:
+		var err error
+		c.Exponents[i], c.Valid[i], err = dec.DecimalInto(&c.Mantissas[i])
+		return err
+	case tcFixed8:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			c.Mantissas[i].SetInt64(dec.Int64())
+			c.Exponents[i] = -f.scale
+		}
+	case tcFixed12:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			dec.FixedInto(&c.Mantissas[i], encoding.Fixed12FieldSize)
+			c.Exponents[i] = -f.scale
+		}
+	case tcFixed16:
+		if c.Valid[i] = dec.Bool(); c.Valid[i] {
+			dec.FixedInto(&c.Mantissas[i], encoding.Fixed16FieldSize)
+			c.Exponents[i] = -f.scale
+		}
+	case tcLongdate:
+		c.raw[i], c.Valid[i] = dec.LongdateValue()
+	case tcSeconddate:
+		c.raw[i], c.Valid[i] = dec.SeconddateValue()
+	case tcDaydate:
+		c.raw[i], c.Valid[i] = dec.DaydateValue()
+	case tcSecondtime:
+		c.raw[i], c.Valid[i] = dec.SecondtimeValue()
+	default:
+		v, err := f.decodeResult(dec)
+		c.Values[i], c.Valid[i] = v, v != nil
+		return err
+	}
+	return nil
+}
+
+// needsConversion returns true if the raw values of a field of type tc need to be converted after decoding.
+func needsConversion(tc typeCode) bool { return tc.isDecimalType() || tc.isDatetime() }
+
+// convert converts the raw decoded values of a field of type tc.
+func (c *Column) convert(tc typeCode, dec *encoding.Decoder) {
+	switch tc {
+	case tcLongdate:
+		dec.ConvertLongdates(c.Times, c.raw, c.Valid)
+	case tcSeconddate:
+		dec.ConvertSeconddates(c.Times, c.raw, c.Valid)
+	case tcDaydate:
+		encoding.ConvertDaydates(c.Times, c.raw, c.Valid)
+	case tcSecondtime:
+		encoding.ConvertSecondtimes(c.Times, c.raw, c.Valid)
+	case tcDecimal, tcSmalldecimal, tcFixed8, tcFixed12, tcFixed16:
+		encoding.ConvertDecimals(c.Rats, c.Mantissas, c.Exponents, c.Valid)
+	}
+}
+
+// convertColumns converts the raw decoded column values - in parallel if requested and more than one column needs a conversion.
+func (r *Resultset) convertColumns(dec *encoding.Decoder) {
+	if r.parallelism <= 1 {
+		for j, f := range r.ResultFields {
+			r.Columns[j].convert(f.tc, dec)
+		}
+		return
+	}
+
+	var idxs []int
+	for j, f := range r.ResultFields {
+		if needsConversion(f.tc) {
+			idxs = append(idxs, j)
+		}
+	}
+	if len(idxs) <= 1 {
+		for _, j := range idxs {
+			r.Columns[j].convert(r.ResultFields[j].tc, dec)
+		}
+		return
+	}
+
+	ch := make(chan int)
+	var wg sync.WaitGroup
+	for k := 0; k < min(r.parallelism, len(idxs)); k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range ch {
+				r.Columns[j].convert(r.ResultFields[j].tc, dec)
+			}
+		}()
+	}
+	for _, j := range idxs {
+		ch <- j
+	}
+	close(ch)
+	wg.Wait()
+}
+
+func (r *Resultset) decodeColumns(dec *encoding.Decoder, numArg int) error {
+	r.Columns = resizeSlice(r.Columns, len(r.ResultFields))
+	for j, f := range r.ResultFields {
+		r.Columns[j].resize(f.tc, numArg)
+	}
+	r.numColumnRow = numArg
+
+	for i := 0; i < numArg; i++ {
+		for j, f := range r.ResultFields {
+			if err := r.Columns[j].decode(f, dec, i); err != nil {
+				r.DecodeErrors = append(r.DecodeErrors, &DecodeError{row: i, fieldName: f.Name(), s: err.Error()}) // collect decode / conversion errors
+			}
+		}
+	}
+	if err := dec.Error(); err != nil {
+		return err
+	}
+	r.convertColumns(dec)
+	return nil
+}
+
+// NumColumnRows returns the number of rows of the columnar decoded values.
+func (r *Resultset) NumColumnRows() int { return r.numColumnRow }
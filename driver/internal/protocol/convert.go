@@ -77,8 +77,6 @@ func convertBool(tc typeCode, v any) (any, error) {
 	switch v := v.(type) {
 	case bool:
 		return v, nil
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
-		return v != 0, nil
 	case string:
 		b, err := strconv.ParseBool(v)
 		if err != nil {
@@ -116,10 +114,18 @@ func convertBool(tc typeCode, v any) (any, error) {
 	}
 }
 
+// boolToInt64 maps boolean values to integers (e.g. for databases with a data format version not supporting BOOLEAN, where booleans are represented as TINYINT).
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func convertInteger(tc typeCode, v any, min, max int64) (any, error) { //nolint: gocyclo
 	switch v := v.(type) {
 	case bool:
-		return v, nil
+		return boolToInt64(v), nil
 	case int:
 		i64 := int64(v)
 		if i64 > max || i64 < min {
@@ -218,7 +224,7 @@ func convertInteger(tc typeCode, v any, min, max int64) (any, error) { //nolint:
 	rv := reflect.ValueOf(v)
 	switch rv.Kind() {
 	case reflect.Bool:
-		return v, nil
+		return boolToInt64(rv.Bool()), nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		i64 := rv.Int()
 		if i64 > max || i64 < min {
@@ -488,17 +494,25 @@ func convertDecimal(tc typeCode, v any) (any, error) { //nolint: gocyclo
 		if r == nil {
 			return nil, newConvertError(tc, v, ErrRatConversion)
 		}
+		return r, nil
 	case float64:
 		r := new(big.Rat).SetFloat64(v)
 		if r == nil {
 			return nil, newConvertError(tc, v, ErrRatConversion)
 		}
+		return r, nil
 	case string:
 		r, ok := new(big.Rat).SetString(v)
 		if !ok {
 			return nil, newConvertError(tc, v, ErrRatConversion)
 		}
 		return r, nil
+	case []byte:
+		r, ok := new(big.Rat).SetString(string(v))
+		if !ok {
+			return nil, newConvertError(tc, v, ErrRatConversion)
+		}
+		return r, nil
 	}
 
 	rv := reflect.ValueOf(v)
@@ -531,8 +545,8 @@ func convertDecimal(tc typeCode, v any) (any, error) { //nolint: gocyclo
 		return convertDecimal(tc, rv.Elem().Interface())
 	default:
 		if rv.Type().ConvertibleTo(ratReflectType) {
-			tv := rv.Convert(ratReflectType)
-			return tv.Interface().(big.Rat), nil
+			r := rv.Convert(ratReflectType).Interface().(big.Rat)
+			return &r, nil // encoding expects *big.Rat
 		}
 		return nil, newConvertError(tc, v, nil)
 	}
@@ -610,6 +624,17 @@ func convertLob(tc typeCode, v any, t transform.Transformer) (any, error) {
 }
 
 func convertField(tc typeCode, v any, t transform.Transformer) (any, error) {
+	// dereference pointers to interfaces (e.g. *any used as inout parameter destination)
+	for v != nil {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr || rv.Type().Elem().Kind() != reflect.Interface {
+			break
+		}
+		if rv.IsNil() {
+			return nil, nil
+		}
+		v = rv.Elem().Interface()
+	}
 	if v == nil {
 		return nil, nil
 	}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -193,6 +194,95 @@ func testConvertBytes(t *testing.T) {
 	assertEqualBytes(t, tcBinary, &bytesValue, bytesValue)
 }
 
+func assertEqualBool(t *testing.T, tc typeCode, v any, r bool) {
+	cv, err := convertField(tc, v, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cv.(bool) != r {
+		t.Fatalf("assert equal bool failed %v - %t expected", cv, r)
+	}
+}
+
+func testConvertBool(t *testing.T) {
+	type testCustomBool bool
+
+	boolValue := true
+
+	// bool data type
+	assertEqualBool(t, tcBoolean, boolValue, boolValue)
+
+	// custom bool data type
+	assertEqualBool(t, tcBoolean, testCustomBool(boolValue), boolValue)
+
+	// bool reference
+	assertEqualBool(t, tcBoolean, &boolValue, boolValue)
+
+	// bool as string
+	assertEqualBool(t, tcBoolean, "true", boolValue)
+	assertEqualBool(t, tcBoolean, "0", false)
+
+	// bool as number
+	assertEqualBool(t, tcBoolean, 1, true)
+	assertEqualBool(t, tcBoolean, uint8(0), false)
+	assertEqualBool(t, tcBoolean, 0.5, true)
+
+	// bool as integer (TINYINT representation)
+	assertEqualInt(t, tcTinyint, true, 1)
+	assertEqualInt(t, tcTinyint, false, 0)
+	assertEqualInt(t, tcTinyint, testCustomBool(true), 1)
+}
+
+func assertEqualDecimal(t *testing.T, tc typeCode, v any, r *big.Rat) {
+	cv, err := convertField(tc, v, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cv.(*big.Rat).Cmp(r) != 0 {
+		t.Fatalf("assert equal decimal failed %v - %v expected", cv, r)
+	}
+}
+
+func testConvertDecimal(t *testing.T) {
+	type testCustomDecimal big.Rat
+
+	ratValue := big.NewRat(3, 2)
+
+	// decimal data type
+	assertEqualDecimal(t, tcDecimal, ratValue, ratValue)
+
+	// custom decimal data type
+	assertEqualDecimal(t, tcDecimal, testCustomDecimal(*ratValue), ratValue)
+
+	// float and decimal as string
+	assertEqualDecimal(t, tcDecimal, 1.5, ratValue)
+	assertEqualDecimal(t, tcDecimal, float32(1.5), ratValue)
+	assertEqualDecimal(t, tcDecimal, "3/2", ratValue)
+	assertEqualDecimal(t, tcDecimal, "1.5", ratValue)
+	assertEqualDecimal(t, tcDecimal, []byte("1.5"), ratValue)
+
+	// big.Int and big.Float
+	assertEqualDecimal(t, tcDecimal, big.NewInt(42), big.NewRat(42, 1))
+	assertEqualDecimal(t, tcDecimal, big.NewFloat(1.5), ratValue)
+}
+
+func testConvertInterfaceReference(t *testing.T) {
+	// interface references (e.g. inout parameter destinations)
+	var v any = int64(42)
+	assertEqualInt(t, tcInteger, &v, 42)
+
+	var null any
+	for _, tc := range []typeCode{tcBoolean, tcInteger, tcDouble, tcTimestamp, tcDecimal, tcString} {
+		cv, err := convertField(tc, &null, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cv != nil {
+			t.Fatalf("%s: nil value expected - got %v", tc, cv)
+		}
+	}
+}
+
 func TestConverter(t *testing.T) {
 	tests := []struct {
 		name string
@@ -203,6 +293,9 @@ func TestConverter(t *testing.T) {
 		{"convertTime", testConvertTime},
 		{"convertString", testConvertString},
 		{"convertBytes", testConvertBytes},
+		{"convertBool", testConvertBool},
+		{"convertDecimal", testConvertDecimal},
+		{"convertInterfaceReference", testConvertInterfaceReference},
 	}
 
 	for _, test := range tests {
@@ -63,3 +63,15 @@ func (dt DataType) ScanType(nullable bool) reflect.Type {
 	}
 	return scanTypes[dt].scanType
 }
+
+// ScanPointerType returns the pointer to the scan type of the corresponding data type as alternative scan type of
+// nullable fields (nil pointer: NULL). Data types already representing NULL by a nil value (e.g. []byte)
+// are returned unchanged.
+func (dt DataType) ScanPointerType() reflect.Type {
+	switch dt {
+	case DtUnknown, DtBytes, DtRows:
+		return scanTypes[dt].scanType
+	default:
+		return reflect.PointerTo(scanTypes[dt].scanType)
+	}
+}
@@ -86,7 +86,7 @@ func decodeResult(tc typeCode, d *encoding.Decoder, scale int) (any, error) {
 	case tcText, tcNclob, tcNlocator:
 		return decodeLobResult(d, true)
 	default:
-		panic(fmt.Sprintf("invalid type code %s", tc))
+		return nil, fmt.Errorf("invalid type code %s", tc)
 	}
 }
 
@@ -153,6 +153,6 @@ func decodeParameter(tc typeCode, d *encoding.Decoder, scale int) (any, error) {
 	case tcText, tcNclob, tcNlocator:
 		return decodeLobParameter(d)
 	default:
-		panic(fmt.Sprintf("invalid type code %s", tc))
+		return nil, fmt.Errorf("invalid type code %s", tc)
 	}
 }
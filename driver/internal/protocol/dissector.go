@@ -0,0 +1,166 @@
+package protocol
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Wire format description (e.g. for protocol dissectors).
+
+// PartPadding is the alignment of part buffers in bytes.
+const PartPadding = padding
+
+// Field types of header layouts.
+const (
+	FtInt8   = "int8"
+	FtInt16  = "int16"
+	FtInt32  = "int32"
+	FtInt64  = "int64"
+	FtUint32 = "uint32"
+	FtBool   = "bool"
+	FtBytes  = "bytes" // reserved
+)
+
+// FieldLayout describes a header field.
+type FieldLayout struct {
+	Name   string
+	Offset int
+	Size   int
+	Type   string
+	// Enum is the name of the enumeration (see EnumTable) of the field values (empty if not enumerated).
+	Enum string
+}
+
+// HeaderLayout describes a protocol header.
+type HeaderLayout struct {
+	Name   string
+	Size   int
+	Fields []*FieldLayout
+}
+
+// Field returns the field with name, nil if the header does not contain the field.
+func (l *HeaderLayout) Field(name string) *FieldLayout {
+	for _, f := range l.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// Names of header layouts.
+const (
+	HlMessageHeader        = "messageHeader"
+	HlRequestSegmentHeader = "requestSegmentHeader"
+	HlReplySegmentHeader   = "replySegmentHeader"
+	HlPartHeader           = "partHeader"
+)
+
+// segmentHeaderFields are the fields of request and reply segment headers.
+func segmentHeaderFields(fields ...*FieldLayout) []*FieldLayout {
+	return append([]*FieldLayout{
+		{Name: "segmentLength", Offset: 0, Size: 4, Type: FtInt32},
+		{Name: "segmentOfs", Offset: 4, Size: 4, Type: FtInt32},
+		{Name: "noOfParts", Offset: 8, Size: 2, Type: FtInt16},
+		{Name: "segmentNo", Offset: 10, Size: 2, Type: FtInt16},
+		{Name: "segmentKind", Offset: 12, Size: 1, Type: FtInt8, Enum: EnSegmentKind},
+	}, fields...)
+}
+
+// HeaderLayouts returns the layouts of the message, segment and part headers
+// (see messageHeader, segmentHeader and partHeader encode and decode).
+func HeaderLayouts() []*HeaderLayout {
+	return []*HeaderLayout{
+		{Name: HlMessageHeader, Size: messageHeaderSize, Fields: []*FieldLayout{
+			{Name: "sessionID", Offset: 0, Size: 8, Type: FtInt64},
+			{Name: "packetCount", Offset: 8, Size: 4, Type: FtInt32},
+			{Name: "varPartLength", Offset: varPartLengthOfs, Size: 4, Type: FtUint32},
+			{Name: "varPartSize", Offset: 16, Size: 4, Type: FtUint32},
+			{Name: "noOfSegm", Offset: 20, Size: 2, Type: FtInt16},
+			{Name: "reserved", Offset: 22, Size: 10, Type: FtBytes},
+		}},
+		{Name: HlRequestSegmentHeader, Size: segmentHeaderSize, Fields: segmentHeaderFields(
+			&FieldLayout{Name: "messageType", Offset: 13, Size: 1, Type: FtInt8, Enum: EnMessageType},
+			&FieldLayout{Name: "commit", Offset: 14, Size: 1, Type: FtBool},
+			&FieldLayout{Name: "commandOptions", Offset: 15, Size: 1, Type: FtInt8, Enum: EnCommandOptions},
+			&FieldLayout{Name: "reserved", Offset: 16, Size: 8, Type: FtBytes},
+		)},
+		{Name: HlReplySegmentHeader, Size: segmentHeaderSize, Fields: segmentHeaderFields(
+			&FieldLayout{Name: "reserved1", Offset: 13, Size: 1, Type: FtBytes},
+			&FieldLayout{Name: "functionCode", Offset: 14, Size: 2, Type: FtInt16, Enum: EnFunctionCode},
+			&FieldLayout{Name: "reserved2", Offset: 16, Size: 8, Type: FtBytes},
+		)},
+		{Name: HlPartHeader, Size: partHeaderSize, Fields: []*FieldLayout{
+			{Name: "partKind", Offset: 0, Size: 1, Type: FtInt8, Enum: EnPartKind},
+			{Name: "partAttributes", Offset: 1, Size: 1, Type: FtInt8, Enum: EnPartAttributes},
+			{Name: "argumentCount", Offset: 2, Size: 2, Type: FtInt16},
+			{Name: "bigArgumentCount", Offset: 4, Size: 4, Type: FtInt32},
+			{Name: "bufferLength", Offset: 8, Size: 4, Type: FtInt32},
+			{Name: "bufferSize", Offset: 12, Size: 4, Type: FtInt32},
+		}},
+	}
+}
+
+// EnumValue is a named value of an enumeration.
+type EnumValue struct {
+	Value int
+	Name  string
+}
+
+// EnumTable is the table of the named values of an enumeration.
+type EnumTable struct {
+	Name string
+	// Flags is true if the values are bit flags which can be combined.
+	Flags  bool
+	Values []EnumValue
+}
+
+// Names of enumerations.
+const (
+	EnSegmentKind    = "segmentKind"
+	EnMessageType    = "messageType"
+	EnCommandOptions = "commandOptions"
+	EnFunctionCode   = "functionCode"
+	EnPartKind       = "partKind"
+	EnPartAttributes = "partAttributes"
+)
+
+// enumValues returns the values in the range of [minValue, maxValue] having a name
+// (stringer returns type(value) for unnamed values).
+func enumValues[T ~int8 | ~int16](minValue, maxValue T, name func(T) string) []EnumValue {
+	var values []EnumValue
+	for v := int(minValue); v <= int(maxValue); v++ {
+		s := name(T(v))
+		if strings.HasSuffix(s, "("+strconv.Itoa(v)+")") {
+			continue
+		}
+		values = append(values, EnumValue{Value: v, Name: s})
+	}
+	return values
+}
+
+func flagValues[T ~int8](list []T, text []string) []EnumValue {
+	values := make([]EnumValue, 0, len(list))
+	for i, v := range list {
+		if v == 0 {
+			continue
+		}
+		values = append(values, EnumValue{Value: int(v), Name: text[i]})
+	}
+	return values
+}
+
+// maxFunctionCode is the upper bound of the function code value range scanned for named values.
+const maxFunctionCode = 1024
+
+// EnumTables returns the enumerations of the header field values.
+func EnumTables() []*EnumTable {
+	return []*EnumTable{
+		{Name: EnSegmentKind, Values: enumValues(0, 127, segmentKind.String)},
+		{Name: EnMessageType, Values: enumValues(0, 127, MessageType.String)},
+		{Name: EnCommandOptions, Flags: true, Values: flagValues(coList, coListText)},
+		{Name: EnFunctionCode, Values: enumValues(0, maxFunctionCode, FunctionCode.String)},
+		{Name: EnPartKind, Values: enumValues(0, 127, PartKind.String)},
+		{Name: EnPartAttributes, Flags: true, Values: flagValues(paList[:], paListText[:])},
+	}
+}
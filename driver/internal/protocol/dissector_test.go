@@ -0,0 +1,116 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// fieldValue returns the value of field f in the encoded header b.
+func fieldValue(b []byte, f *FieldLayout) int64 {
+	b = b[f.Offset : f.Offset+f.Size]
+	switch f.Type {
+	case FtInt8, FtBool:
+		return int64(int8(b[0]))
+	case FtInt16:
+		return int64(int16(binary.LittleEndian.Uint16(b)))
+	case FtInt32:
+		return int64(int32(binary.LittleEndian.Uint32(b)))
+	case FtUint32:
+		return int64(binary.LittleEndian.Uint32(b))
+	case FtInt64:
+		return int64(binary.LittleEndian.Uint64(b))
+	default: // reserved
+		if !bytes.Equal(b, make([]byte, len(b))) {
+			return -1
+		}
+		return 0
+	}
+}
+
+func TestHeaderLayouts(t *testing.T) {
+	mh := &messageHeader{sessionID: 1, packetCount: 2, varPartLength: 3, varPartSize: 4, noOfSegm: 5}
+	rqsh := &segmentHeader{segmentLength: 1, segmentOfs: 2, noOfParts: 3, segmentNo: 4, segmentKind: skRequest, messageType: MtExecute, commit: true, commandOptions: coSelfetchOff}
+	rpsh := &segmentHeader{segmentLength: 1, segmentOfs: 2, noOfParts: 3, segmentNo: 4, segmentKind: skReply, functionCode: fcSelect}
+	ph := &partHeader{partKind: PkResultset, partAttributes: paLastPacket, argumentCount: 2, bigArgumentCount: 3, bufferLength: 4, bufferSize: 5}
+
+	headers := map[string]struct {
+		encode func(enc *encoding.Encoder) error
+		values map[string]int64
+	}{
+		HlMessageHeader: {mh.encode, map[string]int64{"sessionID": 1, "packetCount": 2, "varPartLength": 3, "varPartSize": 4, "noOfSegm": 5}},
+		HlRequestSegmentHeader: {rqsh.encode, map[string]int64{
+			"segmentLength": 1, "segmentOfs": 2, "noOfParts": 3, "segmentNo": 4, "segmentKind": int64(skRequest),
+			"messageType": int64(MtExecute), "commit": 1, "commandOptions": int64(coSelfetchOff),
+		}},
+		HlReplySegmentHeader: {rpsh.encode, map[string]int64{
+			"segmentLength": 1, "segmentOfs": 2, "noOfParts": 3, "segmentNo": 4, "segmentKind": int64(skReply),
+			"functionCode": int64(fcSelect),
+		}},
+		HlPartHeader: {ph.encode, map[string]int64{
+			"partKind": int64(PkResultset), "partAttributes": int64(paLastPacket), "argumentCount": 2, "bigArgumentCount": 3, "bufferLength": 4, "bufferSize": 5,
+		}},
+	}
+
+	for _, l := range HeaderLayouts() {
+		header, ok := headers[l.Name]
+		if !ok {
+			t.Fatalf("untested header layout %s", l.Name)
+		}
+		buf := &bytes.Buffer{}
+		enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+		if err := header.encode(enc); err != nil {
+			t.Fatal(err)
+		}
+		b := buf.Bytes()
+		if len(b) != l.Size {
+			t.Fatalf("header %s: size %d - expected %d", l.Name, l.Size, len(b))
+		}
+		size := 0
+		for _, f := range l.Fields {
+			if f.Offset != size {
+				t.Fatalf("header %s field %s: offset %d - expected %d", l.Name, f.Name, f.Offset, size)
+			}
+			size += f.Size
+			if v := fieldValue(b, f); v != header.values[f.Name] {
+				t.Fatalf("header %s field %s: value %d - expected %d", l.Name, f.Name, v, header.values[f.Name])
+			}
+		}
+		if size != l.Size {
+			t.Fatalf("header %s: field size %d - expected %d", l.Name, size, l.Size)
+		}
+	}
+}
+
+func TestEnumTables(t *testing.T) {
+	tables := map[string]*EnumTable{}
+	for _, table := range EnumTables() {
+		tables[table.Name] = table
+	}
+	for _, l := range HeaderLayouts() {
+		for _, f := range l.Fields {
+			if f.Enum != "" && tables[f.Enum] == nil {
+				t.Fatalf("header %s field %s: enumeration %s not found", l.Name, f.Name, f.Enum)
+			}
+		}
+	}
+
+	contains := func(name string, value int, text string) {
+		for _, v := range tables[name].Values {
+			if v.Value == value {
+				if v.Name != text {
+					t.Fatalf("enumeration %s value %d: name %s - expected %s", name, value, v.Name, text)
+				}
+				return
+			}
+		}
+		t.Fatalf("enumeration %s: value %d not found", name, value)
+	}
+	contains(EnMessageType, int(MtExecute), "MtExecute")
+	contains(EnFunctionCode, int(fcSelect), fcSelect.String())
+	contains(EnPartKind, int(PkResultset), "PkResultset")
+	contains(EnPartAttributes, int(paResultsetClosed), "resultsetClosed")
+}
@@ -0,0 +1,79 @@
+package protocol
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+)
+
+/*
+EncodedPart is a part read in its encoded form, which can be decoded later on by Decode
+(e.g. only if the encoded data differs from the data of a previously decoded part).
+An EncodedPart can be written as well, sending the already encoded data (see NewWritableEncodedPart).
+*/
+type EncodedPart struct {
+	partKind  PartKind
+	partAttrs PartAttributes
+	argCount  int
+	Buf       []byte
+	dec       *encoding.Decoder // decoder settings of the reader
+}
+
+// NewEncodedPart returns a new EncodedPart instance reading parts of kind.
+func NewEncodedPart(kind PartKind) *EncodedPart { return &EncodedPart{partKind: kind} }
+
+// NewWritableEncodedPart returns a new EncodedPart instance writing the encoded data buf of a part of kind.
+func NewWritableEncodedPart(kind PartKind, attrs PartAttributes, numArg int, buf []byte) *EncodedPart {
+	return &EncodedPart{partKind: kind, partAttrs: attrs, argCount: numArg, Buf: buf}
+}
+
+func (p *EncodedPart) kind() PartKind { return p.partKind }
+
+func (p *EncodedPart) String() string {
+	return fmt.Sprintf("encoded %s numArg %d length %d", p.partKind, p.argCount, len(p.Buf))
+}
+
+// Kind returns the part kind.
+func (p *EncodedPart) Kind() PartKind { return p.partKind }
+
+// Attributes returns the part attributes.
+func (p *EncodedPart) Attributes() PartAttributes { return p.partAttrs }
+
+// NumArg returns the number of arguments of the part.
+func (p *EncodedPart) NumArg() int { return p.argCount }
+
+// SetAttributes sets the part attributes (e.g. of a read part).
+func (p *EncodedPart) SetAttributes(attrs PartAttributes) { p.partAttrs = attrs }
+
+func (p *EncodedPart) attributes() PartAttributes         { return p.partAttrs }
+func (p *EncodedPart) numArg() int                        { return p.argCount }
+func (p *EncodedPart) size() int                          { return len(p.Buf) }
+func (p *EncodedPart) encode(enc *encoding.Encoder) error { enc.Bytes(p.Buf); return nil }
+
+func (p *EncodedPart) decodeNumArgBufLen(dec *encoding.Decoder, numArg, bufLen int) error {
+	p.argCount = numArg
+	p.Buf = make([]byte, bufLen)
+	dec.Bytes(p.Buf)
+	p.dec = dec.SubDecoder(nil)
+	return dec.Error()
+}
+
+// Decode decodes the encoded part into part.
+func (p *EncodedPart) Decode(part Part) error {
+	if part.kind() != p.partKind {
+		return fmt.Errorf("invalid part kind %s - expected %s", part.kind(), p.partKind)
+	}
+	if p.dec == nil {
+		return fmt.Errorf("encoded part %s not read", p.partKind)
+	}
+	dec := p.dec.SubDecoder(bytes.NewReader(p.Buf))
+	dec.SetLimit(len(p.Buf))
+	if err := decodePart(dec, part, p.argCount, len(p.Buf)); err != nil {
+		return err
+	}
+	return dec.Error()
+}
+
+// check if EncodedPart implements the attributesPart interface.
+var _ attributesPart = (*EncodedPart)(nil)
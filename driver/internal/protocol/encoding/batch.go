@@ -0,0 +1,86 @@
+package encoding
+
+import (
+	"math/big"
+	"time"
+)
+
+// LongdateValue decodes a raw longdate value and returns false in case of a null value.
+func (d *Decoder) LongdateValue() (int64, bool) {
+	v := d.Int64()
+	return v, v != longdateNullValue
+}
+
+// SeconddateValue decodes a raw seconddate value and returns false in case of a null value.
+func (d *Decoder) SeconddateValue() (int64, bool) {
+	v := d.Int64()
+	return v, v != seconddateNullValue
+}
+
+// DaydateValue decodes a raw daydate value and returns false in case of a null value.
+func (d *Decoder) DaydateValue() (int64, bool) {
+	v := d.Int32()
+	return int64(v), !(v == daydateNullValue || (d.emptyDateAsNull && v == 0))
+}
+
+// SecondtimeValue decodes a raw secondtime value and returns false in case of a null value.
+func (d *Decoder) SecondtimeValue() (int64, bool) {
+	v := d.Int32()
+	return int64(v), v != secondtimeNullValue
+}
+
+// Batch conversions of raw field values decoded for a set of rows (columnar decoding).
+// Entries of rows with NULL values (valid[i] == false) are skipped.
+
+// ConvertLongdates converts raw longdate values into time values.
+func (d *Decoder) ConvertLongdates(ts []time.Time, raw []int64, valid []bool) {
+	for i, v := range raw {
+		if valid[i] {
+			ts[i] = d.inTimeLocation(convertLongdateToTime(v))
+		}
+	}
+}
+
+// ConvertSeconddates converts raw seconddate values into time values.
+func (d *Decoder) ConvertSeconddates(ts []time.Time, raw []int64, valid []bool) {
+	for i, v := range raw {
+		if valid[i] {
+			ts[i] = d.inTimeLocation(convertSeconddateToTime(v))
+		}
+	}
+}
+
+// ConvertDaydates converts raw daydate values into time values.
+func ConvertDaydates(ts []time.Time, raw []int64, valid []bool) {
+	for i, v := range raw {
+		if valid[i] {
+			ts[i] = convertDaydateToTime(v)
+		}
+	}
+}
+
+// ConvertSecondtimes converts raw secondtime values into time values.
+func ConvertSecondtimes(ts []time.Time, raw []int64, valid []bool) {
+	for i, v := range raw {
+		if valid[i] {
+			ts[i] = convertSecondtimeToTime(int(v))
+		}
+	}
+}
+
+// ConvertDecimals converts decimal mantissas and exponents into rational values reusing the memory of rs.
+func ConvertDecimals(rs []big.Rat, ms []big.Int, exps []int, valid []bool) {
+	for i := range ms {
+		if !valid[i] {
+			continue
+		}
+		r := &rs[i]
+		r.SetInt(&ms[i])
+		switch exp := exps[i]; {
+		case exp < 0:
+			r.Denom().Set(exp10(-exp))
+		case exp > 0:
+			r.Num().Mul(r.Num(), exp10(exp))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package encoding
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func testTimeLocation(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	in := time.Date(2024, time.March, 1, 12, 30, 45, 123456700, cet)
+
+	testData := []struct {
+		name string
+		loc  *time.Location
+		wall time.Time // expected wall clock stored in the database
+	}{
+		{"utc", nil, time.Date(2024, time.March, 1, 11, 30, 45, 123456700, time.UTC)},
+		{"location", cet, time.Date(2024, time.March, 1, 12, 30, 45, 123456700, time.UTC)},
+	}
+
+	for _, d := range testData {
+		t.Run(d.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			enc := NewEncoder(buf, cesu8.DefaultEncoder)
+			enc.SetTimeLocation(d.loc)
+			if err := enc.LongdateField(in); err != nil {
+				t.Fatal(err)
+			}
+
+			// database wall clock
+			dec := NewDecoder(bytes.NewReader(buf.Bytes()), cesu8.DefaultDecoder)
+			out, err := dec.LongdateField()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if wall := out.(time.Time); !wall.Equal(d.wall) {
+				t.Fatalf("wall clock %s - expected %s", wall, d.wall)
+			}
+
+			// round trip
+			dec = NewDecoder(bytes.NewReader(buf.Bytes()), cesu8.DefaultDecoder)
+			dec.SetTimeLocation(d.loc)
+			if out, err = dec.LongdateField(); err != nil {
+				t.Fatal(err)
+			}
+			if rt := out.(time.Time); !rt.Equal(in) {
+				t.Fatalf("time %s - expected %s", rt, in)
+			}
+		})
+	}
+}
+
+func TestDatetime(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"timeLocation", testTimeLocation},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
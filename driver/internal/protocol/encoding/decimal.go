@@ -11,6 +11,35 @@ import (
 // ErrDecimalOutOfRange means that a big.Rat exceeds the size of hdb decimal fields.
 var ErrDecimalOutOfRange = errors.New("decimal out of range error")
 
+// ErrDecimalNotExact means that a big.Rat cannot be represented exactly by a hdb decimal field (see RoundExact).
+var ErrDecimalNotExact = errors.New("decimal not exact error")
+
+// A RoundingMode defines how values exceeding the precision (scale) of hdb decimal fields are rounded.
+type RoundingMode byte
+
+// RoundingMode constants.
+const (
+	RoundHalfUp   RoundingMode = iota // round half away from zero (business rounding)
+	RoundHalfEven                     // round half to even (banker's rounding)
+	RoundDown                         // round towards zero (truncate)
+	RoundExact                        // do not round (ErrDecimalNotExact)
+)
+
+// roundUp returns true if the magnitude of the mantissa m truncated by a division with rest r and divisor b
+// needs to be incremented.
+func (mode RoundingMode) roundUp(m, r, b *big.Int) bool {
+	var r2 big.Int
+	c := r2.Add(r, r).CmpAbs(b)
+	switch mode {
+	case RoundHalfUp:
+		return c >= 0
+	case RoundHalfEven:
+		return c > 0 || (c == 0 && m.Bit(0) == 1)
+	default:
+		return false
+	}
+}
+
 const _S = bits.UintSize / 8 // word size in bytes
 // http://en.wikipedia.org/wiki/Decimal128_floating-point_format
 const dec128Bias = 6176
@@ -64,7 +93,7 @@ func digits10(p *big.Int) int {
 	}
 	// i <= digit10(p)
 	for ; ; i++ {
-		if p.Cmp(exp10(i)) < 0 {
+		if p.CmpAbs(exp10(i)) < 0 {
 			return i
 		}
 	}
@@ -77,7 +106,7 @@ const (
 	dfUnderflow
 )
 
-func convertRatToDecimal(x *big.Rat, m *big.Int, digits, minExp, maxExp int) (int, byte) {
+func convertRatToDecimal(x *big.Rat, m *big.Int, digits, minExp, maxExp int, mode RoundingMode) (int, byte) {
 	if x.Num().Cmp(natZero) == 0 { // zero
 		m.Set(natZero)
 		return 0, 0
@@ -102,7 +131,7 @@ func convertRatToDecimal(x *big.Rat, m *big.Int, digits, minExp, maxExp int) (in
 		case shift > 0:
 			b.Mul(b, exp10(shift))
 		}
-		if a.Cmp(b) == -1 {
+		if a.CmpAbs(b) == -1 {
 			exp = shift - 1
 		} else {
 			exp = shift
@@ -133,17 +162,24 @@ func convertRatToDecimal(x *big.Rat, m *big.Int, digits, minExp, maxExp int) (in
 
 	m.QuoRem(a, b, a) // reuse a as rest
 	if a.Cmp(natZero) != 0 {
-		// round (business >= 0.5 up)
 		df |= dfNotExact
-		if a.Add(a, a).Cmp(b) >= 0 {
-			m.Add(m, natOne)
-			if m.Cmp(exp10(digits)) == 0 {
+		if mode.roundUp(m, a, b) {
+			neg := a.Sign() < 0
+			if neg {
+				m.Sub(m, natOne)
+			} else {
+				m.Add(m, natOne)
+			}
+			if m.CmpAbs(exp10(digits)) == 0 {
 				shift := min(digits, maxExp-exp)
 				if shift < 1 { // overflow -> shift one at minimum
 					df |= dfOverflow
 					shift = 1
 				}
 				m.Set(exp10(digits - shift))
+				if neg {
+					m.Neg(m)
+				}
 				exp += shift
 			}
 		}
@@ -183,7 +219,7 @@ func convertDecimalToRat(m *big.Int, exp int) *big.Rat {
 	return v
 }
 
-func convertRatToFixed(r *big.Rat, m *big.Int, prec, scale int) byte {
+func convertRatToFixed(r *big.Rat, m *big.Int, prec, scale int, mode RoundingMode) byte {
 	if scale < 0 {
 		panic(fmt.Sprintf("fixed: invalid scale: %d", scale))
 	}
@@ -199,17 +235,19 @@ func convertRatToFixed(r *big.Rat, m *big.Int, prec, scale int) byte {
 	a := c.Num()
 	b := c.Denom()
 
-	if b.Cmp(natZero) == 0 { //
+	if b.Cmp(natOne) == 0 { // exact
 		m.Set(a)
-		return df
-	}
-
-	m.QuoRem(a, b, a) // reuse a as rest
-	if a.Cmp(natZero) != 0 {
-		// round (business >= 0.5 up)
-		df |= dfNotExact
-		if a.Add(a, a).Cmp(b) >= 0 {
-			m.Add(m, natOne)
+	} else {
+		m.QuoRem(a, b, a) // reuse a as rest
+		if a.Cmp(natZero) != 0 {
+			df |= dfNotExact
+			if mode.roundUp(m, a, b) {
+				if a.Sign() < 0 {
+					m.Sub(m, natOne)
+				} else {
+					m.Add(m, natOne)
+				}
+			}
 		}
 	}
 
@@ -1,8 +1,11 @@
 package encoding
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
+
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
 )
 
 func testDigits10(t *testing.T) {
@@ -86,7 +89,7 @@ func testConvertRatToDecimal(t *testing.T) {
 
 	for i := 0; i < 1; i++ { // use for performance tests
 		for j, d := range testData {
-			exp, df := convertRatToDecimal(d.x, m, d.digits, d.minExp, d.maxExp)
+			exp, df := convertRatToDecimal(d.x, m, d.digits, d.minExp, d.maxExp, RoundHalfUp)
 			if m.Cmp(d.cmp) != 0 || exp != d.exp || df != d.df {
 				t.Fatalf("converted %d value m %s exp %d df %b - expected m %s exp %d df %b", j, m, exp, df, d.cmp, d.exp, d.df)
 			}
@@ -109,8 +112,10 @@ func testConvertRatToFixed(t *testing.T) {
 		{new(big.Rat).SetFrac64(-1, 1), 1, 0, new(big.Int).SetInt64(-1), 0},  // convert -1
 		{new(big.Rat).SetFrac64(1, -10), 2, 1, new(big.Int).SetInt64(-1), 0}, // convert -1/10
 
-		{new(big.Rat).SetFrac64(1, 2), 1, 0, new(big.Int).SetInt64(1), dfNotExact},        // convert 1/2 - should round to 1
-		{new(big.Rat).SetFrac64(4999, 10000), 1, 0, new(big.Int).SetInt64(0), dfNotExact}, // convert 0,4999 - should round to 0
+		{new(big.Rat).SetFrac64(1, 2), 1, 0, new(big.Int).SetInt64(1), dfNotExact},         // convert 1/2 - should round to 1
+		{new(big.Rat).SetFrac64(4999, 10000), 1, 0, new(big.Int).SetInt64(0), dfNotExact},  // convert 0,4999 - should round to 0
+		{new(big.Rat).SetFrac64(-1, 2), 1, 0, new(big.Int).SetInt64(-1), dfNotExact},       // convert -1/2 - should round to -1
+		{new(big.Rat).SetFrac64(-4999, 10000), 1, 0, new(big.Int).SetInt64(0), dfNotExact}, // convert -0,4999 - should round to 0
 
 		{new(big.Rat).SetFrac64(1000, 1), 3, 0, new(big.Int).SetInt64(1000), dfOverflow}, // convert 1000 - prec 3 - should overflow
 		{new(big.Rat).SetFrac64(10, 1), 3, 2, new(big.Int).SetInt64(1000), dfOverflow},   // convert 10 - prec 3, scale 2 - should overflow
@@ -120,7 +125,7 @@ func testConvertRatToFixed(t *testing.T) {
 
 	for i := 0; i < 1; i++ { // use for performance tests
 		for j, d := range testData {
-			df := convertRatToFixed(d.x, m, d.prec, d.scale)
+			df := convertRatToFixed(d.x, m, d.prec, d.scale, RoundHalfUp)
 			if m.Cmp(d.cmp) != 0 || df != d.df {
 				t.Fatalf("converted %d value m %s df %b - expected m %s df %b (prec %d scale %d)", j, m, df, d.cmp, d.df, d.prec, d.scale)
 			}
@@ -128,6 +133,100 @@ func testConvertRatToFixed(t *testing.T) {
 	}
 }
 
+func testFixedField(t *testing.T) {
+	exp := func(n int64) *big.Int { return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil) }
+	maxValue := func(prec int64) *big.Int { return new(big.Int).Sub(exp(prec), natOne) }
+
+	testData := []struct {
+		size  int
+		prec  int
+		scale int
+		m     *big.Int
+	}{
+		{Fixed8FieldSize, 18, 2, big.NewInt(0)},
+		{Fixed8FieldSize, 18, 2, big.NewInt(-12345)},
+		{Fixed8FieldSize, 18, 0, maxValue(18)},
+		{Fixed8FieldSize, 18, 0, new(big.Int).Neg(maxValue(18))},
+		{Fixed12FieldSize, 28, 4, big.NewInt(-1)},
+		{Fixed12FieldSize, 28, 4, maxValue(28)},
+		{Fixed12FieldSize, 28, 4, new(big.Int).Neg(maxValue(28))},
+		{Fixed16FieldSize, 38, 8, big.NewInt(123456789)},
+		{Fixed16FieldSize, 38, 8, maxValue(38)},
+		{Fixed16FieldSize, 38, 8, new(big.Int).Neg(maxValue(38))},
+	}
+
+	for i, d := range testData {
+		in := new(big.Rat).SetFrac(d.m, exp(int64(d.scale)))
+
+		buf := new(bytes.Buffer)
+		enc := NewEncoder(buf, cesu8.DefaultEncoder)
+		var err error
+		switch d.size {
+		case Fixed8FieldSize:
+			err = enc.Fixed8Field(in, d.prec, d.scale)
+		case Fixed12FieldSize:
+			err = enc.Fixed12Field(in, d.prec, d.scale)
+		default:
+			err = enc.Fixed16Field(in, d.prec, d.scale)
+		}
+		if err != nil {
+			t.Fatalf("test %d: %s", i, err)
+		}
+		if buf.Len() != d.size {
+			t.Fatalf("test %d: size %d - expected %d", i, buf.Len(), d.size)
+		}
+
+		dec := NewDecoder(bytes.NewReader(append([]byte{1}, buf.Bytes()...)), cesu8.DefaultDecoder) // prepend not null indicator
+		var out any
+		switch d.size {
+		case Fixed8FieldSize:
+			out, err = dec.Fixed8Field(d.scale)
+		case Fixed12FieldSize:
+			out, err = dec.Fixed12Field(d.scale)
+		default:
+			out, err = dec.Fixed16Field(d.scale)
+		}
+		if err != nil {
+			t.Fatalf("test %d: %s", i, err)
+		}
+		if r, ok := out.(*big.Rat); !ok || r.Cmp(in) != 0 {
+			t.Fatalf("test %d: value %v - expected %s", i, out, in)
+		}
+	}
+}
+
+func testRoundingMode(t *testing.T) {
+	testData := []struct {
+		x    *big.Rat
+		mode RoundingMode
+		// out
+		fixed   int64 // prec 2 scale 0
+		decimal int64 // 2 digits
+	}{
+		{new(big.Rat).SetFrac64(25, 2), RoundHalfUp, 13, 13},
+		{new(big.Rat).SetFrac64(25, 2), RoundHalfEven, 12, 12},
+		{new(big.Rat).SetFrac64(25, 2), RoundDown, 12, 12},
+		{new(big.Rat).SetFrac64(27, 2), RoundHalfEven, 14, 14},
+		{new(big.Rat).SetFrac64(127, 10), RoundHalfEven, 13, 13},
+		{new(big.Rat).SetFrac64(129, 10), RoundDown, 12, 12},
+		{new(big.Rat).SetFrac64(-25, 2), RoundHalfUp, -13, -13},
+		{new(big.Rat).SetFrac64(-25, 2), RoundHalfEven, -12, -12},
+		{new(big.Rat).SetFrac64(-129, 10), RoundDown, -12, -12},
+		{new(big.Rat).SetFrac64(129, 10), RoundExact, 12, 12},
+	}
+
+	m := new(big.Int)
+	for i, d := range testData {
+		if df := convertRatToFixed(d.x, m, 2, 0, d.mode); m.Int64() != d.fixed || df != dfNotExact {
+			t.Fatalf("test %d: fixed m %s df %b - expected m %d df %b", i, m, df, d.fixed, dfNotExact)
+		}
+		exp, df := convertRatToDecimal(d.x, m, 2, -2, 2, d.mode)
+		if m.Int64() != d.decimal || exp != 0 || df != dfNotExact {
+			t.Fatalf("test %d: decimal m %s exp %d df %b - expected m %d exp 0 df %b", i, m, exp, df, d.decimal, dfNotExact)
+		}
+	}
+}
+
 func TestDecimal(t *testing.T) {
 	tests := []struct {
 		name string
@@ -136,6 +235,8 @@ func TestDecimal(t *testing.T) {
 		{"digits10", testDigits10},
 		{"convertRatToDecimal", testConvertRatToDecimal},
 		{"convertRatToFixed", testConvertRatToFixed},
+		{"roundingMode", testRoundingMode},
+		{"fixedField", testFixedField},
 	}
 
 	for _, test := range tests {
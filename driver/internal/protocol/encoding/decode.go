@@ -27,29 +27,73 @@ type Decoder struct {
 	tr  transform.Transformer
 	cnt int
 
+	decoder func() transform.Transformer // transformer factory (see SubDecoder)
+
+	limited bool // see SetLimit
+	maxCnt  int  // maximum byte read counter value for allocations
+
 	// decoder options
 	alphanumDfv1    bool
+	alphanumPadding bool
 	emptyDateAsNull bool
+	loc             *time.Location
+	interner        *StringInterner
 }
 
 // NewDecoder creates a new Decoder instance based on an io.Reader.
 func NewDecoder(rd io.Reader, decoder func() transform.Transformer) *Decoder {
 	return &Decoder{
-		rd: rd,
-		b:  make([]byte, readScratchSize),
-		tr: decoder(),
+		rd:      rd,
+		b:       make([]byte, readScratchSize),
+		tr:      decoder(),
+		decoder: decoder,
+	}
+}
+
+// SubDecoder returns a decoder reading from rd with the decoder options of d. As sub decoders might be
+// used concurrently (see protocol.Reader.IteratePartsParallel) the sub decoder gets its own transformer instance.
+func (d *Decoder) SubDecoder(rd io.Reader) *Decoder {
+	return &Decoder{
+		rd:              rd,
+		b:               make([]byte, readScratchSize),
+		tr:              d.decoder(),
+		decoder:         d.decoder,
+		alphanumDfv1:    d.alphanumDfv1,
+		alphanumPadding: d.alphanumPadding,
+		emptyDateAsNull: d.emptyDateAsNull,
+		loc:             d.loc,
+		interner:        d.interner,
 	}
 }
 
+// SetStringInterner sets the string interner used for decoding character fields (nil: no interning).
+func (d *Decoder) SetStringInterner(interner *StringInterner) { d.interner = interner }
+
 // SetAlphanumDfv1 sets the alphanum dfv1 flag decoder.
 func (d *Decoder) SetAlphanumDfv1(alphanumDfv1 bool) { d.alphanumDfv1 = alphanumDfv1 }
 
+// SetAlphanumPadding sets the flag padding numeric alphanum values with leading zeroes.
+func (d *Decoder) SetAlphanumPadding(alphanumPadding bool) { d.alphanumPadding = alphanumPadding }
+
 // EmptyDateAsNull returns the empty date as null flag.
 func (d *Decoder) EmptyDateAsNull() bool { return d.emptyDateAsNull }
 
 // SetEmptyDateAsNull sets the empty date as null flag.
 func (d *Decoder) SetEmptyDateAsNull(emptyDateAsNull bool) { d.emptyDateAsNull = emptyDateAsNull }
 
+// SetTimeLocation sets the location timestamp values are interpreted in (nil: UTC).
+func (d *Decoder) SetTimeLocation(loc *time.Location) { d.loc = loc }
+
+// inTimeLocation returns a time with the wall clock of t in the decoder time location.
+func (d *Decoder) inTimeLocation(t time.Time) time.Time {
+	if d.loc == nil {
+		return t
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), d.loc)
+}
+
 // Cnt returns the value of the byte read counter.
 func (d *Decoder) Cnt() int { return d.cnt }
 
@@ -59,6 +103,31 @@ func (d *Decoder) Error() error { return d.err }
 // ResetError resets reader error.
 func (d *Decoder) ResetError() { d.err = nil }
 
+// SetError sets the decoder error in case no error is set already.
+// Subsequent decoding calls are not reading any data.
+func (d *Decoder) SetError(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// SetLimit limits the size of the data decoded by subsequent calls to n bytes (n < 0: no limit),
+// so that invalid sizes decoded from the data are detected before allocating memory for them (see CheckSize).
+func (d *Decoder) SetLimit(n int) {
+	d.limited = n >= 0
+	d.maxCnt = d.cnt + n
+}
+
+// CheckSize returns true if size bytes can be decoded within the decoder limit (see SetLimit).
+// Otherwise the decoder error is set and false is returned.
+func (d *Decoder) CheckSize(size int) bool {
+	if size < 0 || (d.limited && d.cnt+size > d.maxCnt) {
+		d.SetError(fmt.Errorf("invalid data size %d", size))
+		return false
+	}
+	return true
+}
+
 // readFull reads data from reader + read counter and error handling.
 func (d *Decoder) readFull(buf []byte) (int, error) {
 	if d.err != nil {
@@ -197,18 +266,41 @@ func (d *Decoder) Float64() float64 {
 // Decimal decodes a decimal.
 // - error is only returned in case of conversion errors.
 func (d *Decoder) Decimal() (*big.Int, int, error) { // m, exp
+	m := new(big.Int)
+	exp, ok, err := d.DecimalInto(m)
+	if !ok || err != nil {
+		return nil, 0, err
+	}
+	return m, exp, nil
+}
+
+// zeroWords returns n zeroed words reusing the memory of m if possible.
+func zeroWords(m *big.Int, n int) []big.Word {
+	ws := m.Bits()
+	if cap(ws) < n {
+		return make([]big.Word, n)
+	}
+	ws = ws[:n]
+	clear(ws)
+	return ws
+}
+
+// DecimalInto decodes a decimal into m reusing the memory of m and returns the exponent.
+// - ok is false in case of a null value.
+// - error is only returned in case of conversion errors.
+func (d *Decoder) DecimalInto(m *big.Int) (int, bool, error) { // exp, ok
 	bs := d.b[:decSize]
 
 	if _, err := d.readFull(bs); err != nil {
-		return nil, 0, nil
+		return 0, false, nil
 	}
 
 	if (bs[15] & 0x70) == 0x70 { // null value (bit 4,5,6 set)
-		return nil, 0, nil
+		return 0, false, nil
 	}
 
 	if (bs[15] & 0x60) == 0x60 {
-		return nil, 0, fmt.Errorf("decimal: format (infinity, nan, ...) not supported : %v", bs)
+		return 0, false, fmt.Errorf("decimal: format (infinity, nan, ...) not supported : %v", bs)
 	}
 
 	neg := (bs[15] & 0x80) != 0
@@ -225,26 +317,36 @@ func (d *Decoder) Decimal() (*big.Int, int, error) { // m, exp
 
 	// calc number of words
 	numWords := (msb / _S) + 1
-	ws := make([]big.Word, numWords)
+	ws := zeroWords(m, numWords)
 
 	bs = bs[:msb+1]
 	for i, b := range bs {
 		ws[i/_S] |= (big.Word(b) << (i % _S * 8))
 	}
 
-	m := new(big.Int).SetBits(ws)
+	m.SetBits(ws)
 	if neg {
-		m = m.Neg(m)
+		m.Neg(m)
 	}
-	return m, exp, nil
+	return exp, true, nil
 }
 
 // Fixed decodes a fixed decimal.
 func (d *Decoder) Fixed(size int) *big.Int { // m, exp
+	m := new(big.Int)
+	if !d.FixedInto(m, size) {
+		return nil
+	}
+	return m
+}
+
+// FixedInto decodes a fixed decimal into m reusing the memory of m.
+// - false is returned in case of a decoding error.
+func (d *Decoder) FixedInto(m *big.Int, size int) bool {
 	bs := d.b[:size]
 
 	if _, err := d.readFull(bs); err != nil {
-		return nil
+		return false
 	}
 
 	neg := (bs[size-1] & 0x80) != 0 // is negative number (2s complement)
@@ -257,7 +359,7 @@ func (d *Decoder) Fixed(size int) *big.Int { // m, exp
 
 	// calc number of words
 	numWords := (msb / _S) + 1
-	ws := make([]big.Word, numWords)
+	ws := zeroWords(m, numWords)
 
 	bs = bs[:msb+1]
 	for i, b := range bs {
@@ -268,13 +370,13 @@ func (d *Decoder) Fixed(size int) *big.Int { // m, exp
 		ws[i/_S] |= (big.Word(b) << (i % _S * 8))
 	}
 
-	m := new(big.Int).SetBits(ws)
+	m.SetBits(ws)
 
 	if neg {
 		m.Add(m, natOne) // 2s complement - add 1
 		m.Neg(m)         // set sign
 	}
-	return m
+	return true
 }
 
 // CESU8Bytes decodes CESU-8 into UTF-8 bytes.
@@ -284,6 +386,10 @@ func (d *Decoder) CESU8Bytes(size int) ([]byte, error) {
 		return nil, nil
 	}
 
+	if !d.CheckSize(size) {
+		return nil, nil
+	}
+
 	var p []byte
 	if size > readScratchSize {
 		p = make([]byte, size)
@@ -322,6 +428,9 @@ func (d *Decoder) LIBytes() (n int, b []byte) {
 	if null {
 		return n, nil
 	}
+	if !d.CheckSize(size) {
+		return n, nil
+	}
 	b = make([]byte, size)
 	d.Bytes(b)
 	return n + size, b
@@ -364,22 +473,40 @@ func (d *Decoder) BooleanField() (any, error) {
 	}
 }
 
-// RealField decodes a real field.
-func (d *Decoder) RealField() (any, error) {
+// RealValue decodes a real field and returns false in case of a null value.
+func (d *Decoder) RealValue() (float64, bool) {
 	v := d.Uint32()
 	if v == realNullValue {
+		return 0, false
+	}
+	return float64(math.Float32frombits(v)), true
+}
+
+// RealField decodes a real field.
+func (d *Decoder) RealField() (any, error) {
+	v, ok := d.RealValue()
+	if !ok {
 		return nil, nil
 	}
-	return float64(math.Float32frombits(v)), nil
+	return v, nil
 }
 
-// DoubleField decodes a double field.
-func (d *Decoder) DoubleField() (any, error) {
+// DoubleValue decodes a double field and returns false in case of a null value.
+func (d *Decoder) DoubleValue() (float64, bool) {
 	v := d.Uint64()
 	if v == doubleNullValue {
+		return 0, false
+	}
+	return math.Float64frombits(v), true
+}
+
+// DoubleField decodes a double field.
+func (d *Decoder) DoubleField() (any, error) {
+	v, ok := d.DoubleValue()
+	if !ok {
 		return nil, nil
 	}
-	return math.Float64frombits(v), nil
+	return v, nil
 }
 
 func (d *Decoder) decodeDate() (int, time.Month, int, bool) {
@@ -440,7 +567,7 @@ func (d *Decoder) TimestampField() (any, error) {
 	if dateNull || timeNull {
 		return nil, nil
 	}
-	return time.Date(year, month, day, hour, min, sec, nsec, time.UTC), nil
+	return d.inTimeLocation(time.Date(year, month, day, hour, min, sec, nsec, time.UTC)), nil
 }
 
 // LongdateField decodes a longdate field.
@@ -449,7 +576,7 @@ func (d *Decoder) LongdateField() (any, error) {
 	if longdate == longdateNullValue {
 		return nil, nil
 	}
-	return convertLongdateToTime(longdate), nil
+	return d.inTimeLocation(convertLongdateToTime(longdate)), nil
 }
 
 // SeconddateField decodes a seconddate field.
@@ -458,7 +585,7 @@ func (d *Decoder) SeconddateField() (any, error) {
 	if seconddate == seconddateNullValue {
 		return nil, nil
 	}
-	return convertSeconddateToTime(seconddate), nil
+	return d.inTimeLocation(convertSeconddateToTime(seconddate)), nil
 }
 
 // DaydateField decodes a daydate field.
@@ -492,10 +619,23 @@ func (d *Decoder) DecimalField() (any, error) {
 }
 
 func (d *Decoder) decodeFixed(size, scale int) (any, error) {
+	if size == Fixed8FieldSize { // fixed8 mantissa fits into int64
+		i := d.Int64()
+		if d.err != nil {
+			return nil, nil
+		}
+		if scale < 0 {
+			return nil, fmt.Errorf("fixed: invalid scale: %d", scale)
+		}
+		return convertFixedToRat(big.NewInt(i), scale), nil
+	}
 	m := d.Fixed(size)
 	if m == nil { // important: return nil and not m (as m is of type *big.Int)
 		return nil, nil
 	}
+	if scale < 0 {
+		return nil, fmt.Errorf("fixed: invalid scale: %d", scale)
+	}
 	return convertFixedToRat(m, scale), nil
 }
 
@@ -551,19 +691,34 @@ func (d *Decoder) AlphanumField() (any, error) {
 	if b == nil {
 		return nil, nil
 	}
+	if len(b) == 0 { // no indicator byte (empty value)
+		return b, nil
+	}
 	/*
 	   first byte:
 	   - high bit set -> numeric
 	   - high bit unset -> alpha
 	   - bits 0-6: field size
-
-	   ignore first byte for now
 	*/
-	return b[1:], nil
+	numeric, fieldSize := b[0]&0x80 != 0, int(b[0]&0x7f)
+	b = b[1:]
+	if !d.alphanumPadding || !numeric || len(b) >= fieldSize {
+		return b, nil
+	}
+	// pad numeric values with leading zeroes to the field size (like data format version 1)
+	v := make([]byte, fieldSize)
+	n := copy(v[fieldSize-len(b):], b)
+	for i := 0; i < fieldSize-n; i++ {
+		v[i] = '0'
+	}
+	return v, nil
 }
 
 // Cesu8Field decodes a cesu8 field.
 func (d *Decoder) Cesu8Field() (any, error) {
+	if d.interner != nil {
+		return d.cesu8InternField()
+	}
 	_, b, err := d.CESU8LIBytes()
 	if err != nil {
 		return nil, err
@@ -579,6 +734,29 @@ func (d *Decoder) Cesu8Field() (any, error) {
 	return b, nil
 }
 
+func (d *Decoder) cesu8InternField() (any, error) {
+	_, size, null := d.varFieldInd()
+	if null || d.err != nil {
+		return nil, nil
+	}
+
+	if !d.CheckSize(size) {
+		return nil, nil
+	}
+
+	var p []byte
+	if size > readScratchSize {
+		p = make([]byte, size)
+	} else {
+		p = d.b[:size]
+	}
+	if _, err := d.readFull(p); err != nil {
+		return nil, nil
+	}
+
+	return d.interner.internTransformed(d.tr, p)
+}
+
 // HexField decodes a hex field.
 func (d *Decoder) HexField() (any, error) {
 	_, b := d.LIBytes()
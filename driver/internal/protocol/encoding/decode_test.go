@@ -0,0 +1,106 @@
+package encoding
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func testAlphanumField(t *testing.T) {
+	testData := []struct {
+		ind     byte // alphanum indicator byte
+		value   string
+		padding bool
+		s       string
+	}{
+		{0x0a, "abc", false, "abc"},
+		{0x0a, "0a1b2c", false, "0a1b2c"},
+		{0x80 | 0x0a, "123", false, "123"},
+		{0x0a, "abc", true, "abc"},
+		{0x80 | 0x0a, "123", true, "0000000123"},
+		{0x80 | 0x0a, "1234567890", true, "1234567890"},
+		{0x80 | 0x03, "0", true, "000"},
+	}
+
+	for i, d := range testData {
+		b := append([]byte{byte(len(d.value) + 1), d.ind}, d.value...)
+		dec := NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder)
+		dec.SetAlphanumPadding(d.padding)
+		v, err := dec.AlphanumField()
+		if err != nil {
+			t.Fatalf("test %d: %s", i, err)
+		}
+		if s := string(v.([]byte)); s != d.s {
+			t.Fatalf("test %d: value %s - expected %s", i, s, d.s)
+		}
+	}
+
+	// dfv 1: alphanum is varchar
+	dec := NewDecoder(bytes.NewReader([]byte{3, '1', '2', '3'}), cesu8.DefaultDecoder)
+	dec.SetAlphanumDfv1(true)
+	v, err := dec.AlphanumField()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(v.([]byte)); s != "123" {
+		t.Fatalf("value %s - expected %s", s, "123")
+	}
+}
+
+func testCesu8InternField(t *testing.T) {
+	values := []string{"abc", "def", "abc", "def", ""}
+
+	var b []byte
+	for _, v := range values {
+		b = append(append(b, byte(len(v))), v...)
+	}
+	b = append(b, 0xff) // null value
+
+	interner := NewStringInterner(1)
+	dec := NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder)
+	dec.SetStringInterner(interner)
+
+	var decoded []string
+	for i, v := range values {
+		f, err := dec.Cesu8Field()
+		if err != nil {
+			t.Fatalf("test %d: %s", i, err)
+		}
+		s, ok := f.(string)
+		if !ok || s != v {
+			t.Fatalf("test %d: value %v - expected %s", i, f, v)
+		}
+		decoded = append(decoded, s)
+	}
+	if f, err := dec.Cesu8Field(); err != nil || f != nil {
+		t.Fatalf("value %v error %v - expected nil", f, err)
+	}
+
+	if interner.Len() != 1 {
+		t.Fatalf("number of interned values %d - expected %d", interner.Len(), 1)
+	}
+	if unsafe.StringData(decoded[0]) != unsafe.StringData(decoded[2]) {
+		t.Fatal("value abc is not interned")
+	}
+	if unsafe.StringData(decoded[1]) == unsafe.StringData(decoded[3]) {
+		t.Fatal("value def exceeding the interner limit is interned")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"alphanumField", testAlphanumField},
+		{"cesu8InternField", testCesu8InternField},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -22,6 +22,10 @@ type Encoder struct {
 	wr io.Writer
 	b  []byte // scratch buffer (min 15 Bytes - Decimal)
 	tr transform.Transformer
+
+	// encoder options
+	loc      *time.Location
+	rounding RoundingMode
 }
 
 // NewEncoder creates a new Encoder instance.
@@ -33,6 +37,12 @@ func NewEncoder(wr io.Writer, encoder func() transform.Transformer) *Encoder {
 	}
 }
 
+// SetTimeLocation sets the location timestamp values are stored in (nil: UTC).
+func (e *Encoder) SetTimeLocation(loc *time.Location) { e.loc = loc }
+
+// SetRoundingMode sets the rounding mode of decimal values (default: RoundHalfUp).
+func (e *Encoder) SetRoundingMode(mode RoundingMode) { e.rounding = mode }
+
 // Zeroes encodes cnt zero byte values.
 func (e *Encoder) Zeroes(cnt int) {
 	// zero out scratch area
@@ -320,6 +330,14 @@ func asTime(v any) time.Time {
 	return t.UTC()
 }
 
+// asTimestamp returns the wall clock of v in the encoder time location.
+func (e *Encoder) asTimestamp(v any) time.Time {
+	if e.loc == nil {
+		return asTime(v)
+	}
+	return asTime(v).In(e.loc)
+}
+
 // BooleanField encodes a boolean field.
 func (e *Encoder) BooleanField(v any) error {
 	if v == nil {
@@ -412,7 +430,7 @@ func (e *Encoder) TimeField(v any) error {
 
 // TimestampField encodes a timestamp field.
 func (e *Encoder) TimestampField(v any) error {
-	t := asTime(v)
+	t := e.asTimestamp(v)
 	e.encodeDate(t)
 	e.encodeTime(t)
 	return nil
@@ -420,13 +438,13 @@ func (e *Encoder) TimestampField(v any) error {
 
 // LongdateField encodea a longdate field.
 func (e *Encoder) LongdateField(v any) error {
-	e.Int64(convertTimeToLongdate(asTime(v)))
+	e.Int64(convertTimeToLongdate(e.asTimestamp(v)))
 	return nil
 }
 
 // SeconddateField encodes a seconddate field.
 func (e *Encoder) SeconddateField(v any) error {
-	e.Int64(convertTimeToSeconddate(asTime(v)))
+	e.Int64(convertTimeToSeconddate(e.asTimestamp(v)))
 	return nil
 }
 
@@ -453,12 +471,19 @@ func (e *Encoder) encodeFixed(v any, size, prec, scale int) error {
 	}
 
 	var m big.Int
-	df := convertRatToFixed(r, &m, prec, scale)
+	df := convertRatToFixed(r, &m, prec, scale, e.rounding)
 
 	if df&dfOverflow != 0 {
 		return ErrDecimalOutOfRange
 	}
+	if df&dfNotExact != 0 && e.rounding == RoundExact {
+		return ErrDecimalNotExact
+	}
 
+	if size == Fixed8FieldSize && m.IsInt64() { // fixed8 mantissa fits into int64 (precision <= 18)
+		e.Int64(m.Int64())
+		return nil
+	}
 	e.Fixed(&m, size)
 	return nil
 }
@@ -471,11 +496,14 @@ func (e *Encoder) DecimalField(v any) error {
 	}
 
 	var m big.Int
-	exp, df := convertRatToDecimal(r, &m, dec128Digits, dec128MinExp, dec128MaxExp)
+	exp, df := convertRatToDecimal(r, &m, dec128Digits, dec128MinExp, dec128MaxExp, e.rounding)
 
 	if df&dfOverflow != 0 {
 		return ErrDecimalOutOfRange
 	}
+	if df&(dfNotExact|dfUnderflow) != 0 && e.rounding == RoundExact {
+		return ErrDecimalNotExact
+	}
 
 	if df&dfUnderflow != 0 { // set to zero
 		e.Decimal(natZero, 0)
@@ -543,3 +571,29 @@ func (e *Encoder) HexField(v any) error {
 		panic(formatInvalidValue("hex", v)) // should never happen
 	}
 }
+
+// Null values of result fields (server side encoding, e.g. mock server).
+
+// RealNull encodes a real null value.
+func (e *Encoder) RealNull() { e.Uint32(realNullValue) }
+
+// DoubleNull encodes a double null value.
+func (e *Encoder) DoubleNull() { e.Uint64(doubleNullValue) }
+
+// LongdateNull encodes a longdate null value.
+func (e *Encoder) LongdateNull() { e.Int64(longdateNullValue) }
+
+// SeconddateNull encodes a seconddate null value.
+func (e *Encoder) SeconddateNull() { e.Int64(seconddateNullValue) }
+
+// DaydateNull encodes a daydate null value.
+func (e *Encoder) DaydateNull() { e.Int32(daydateNullValue) }
+
+// DecimalNull encodes a decimal null value.
+func (e *Encoder) DecimalNull() {
+	e.Zeroes(decSize - 1)
+	e.Byte(0x70) // bit 4,5,6 set
+}
+
+// VarNull encodes the null value of a field with length indicator.
+func (e *Encoder) VarNull() { e.Byte(bytesLenIndNullValue) }
@@ -0,0 +1,52 @@
+package encoding
+
+import (
+	"sync"
+
+	"golang.org/x/text/transform"
+)
+
+// A StringInterner interns the decoded values of character fields, so that repeated values
+// share the same string instead of allocating a new value per field.
+// The number of interned values is bounded - values exceeding the limit are decoded as usual.
+// A StringInterner is safe for concurrent use by decoders decoding parts in parallel.
+type StringInterner struct {
+	mu        sync.Mutex
+	maxValues int
+	m         map[string]string
+	buf       []byte // transformation buffer
+}
+
+// NewStringInterner returns a new StringInterner instance interning at most maxValues distinct values.
+func NewStringInterner(maxValues int) *StringInterner {
+	return &StringInterner{maxValues: maxValues, m: make(map[string]string), buf: make([]byte, 0, readScratchSize)}
+}
+
+// Len returns the number of interned values.
+func (i *StringInterner) Len() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return len(i.m)
+}
+
+// internTransformed interns the value of the encoded bytes p transformed by tr.
+func (i *StringInterner) internTransformed(tr transform.Transformer, p []byte) (string, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var err error
+	if i.buf, _, err = transform.Append(tr, i.buf[:0], p); err != nil {
+		return "", err
+	}
+	return i.intern(i.buf), nil
+}
+
+func (i *StringInterner) intern(b []byte) string {
+	if s, ok := i.m[string(b)]; ok { // does not allocate
+		return s
+	}
+	s := string(b)
+	if len(i.m) < i.maxValues {
+		i.m[s] = s
+	}
+	return s
+}
@@ -35,12 +35,6 @@ const (
 	fixLength = 2
 )
 
-// HANA Database errors.
-const (
-	HdbErrAuthenticationFailed = 10
-	HdbErrWhileParsingProtocol = 1033
-)
-
 type sqlState [sqlStateSize]byte
 
 // HdbError represents a single error returned by the server.
@@ -180,6 +174,9 @@ func (e *HdbErrors) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 		//	if e.errorText, err = rd.ReadCesu8(int(e.errorTextLength)); err != nil {
 		//		return err
 		//	}
+		if !dec.CheckSize(int(err.errorTextLength)) {
+			return dec.Error()
+		}
 		err.errorText = make([]byte, int(err.errorTextLength))
 		dec.Bytes(err.errorText)
 
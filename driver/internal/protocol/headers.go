@@ -194,6 +194,9 @@ const (
 // MaxNumArg is the maximum number of arguments allowed to send in a part.
 const MaxNumArg = math.MaxInt32
 
+// MaxPartSize is the maximum size of a part buffer.
+const MaxPartSize = math.MaxInt32 - segmentHeaderSize - partHeaderSize
+
 // PartAttributes represents the part attributes.
 type PartAttributes int8
 
@@ -55,7 +55,7 @@ func (r *initRequest) decode(dec *encoding.Decoder) error {
 
 	switch r.numOptions {
 	default:
-		panic(fmt.Sprintf("invalid number of options %d", r.numOptions))
+		return fmt.Errorf("invalid number of options %d", r.numOptions)
 
 	case 0:
 		dec.Skip(2)
@@ -63,7 +63,7 @@ func (r *initRequest) decode(dec *encoding.Decoder) error {
 	case 1:
 		cnt := dec.Int8()
 		if cnt != 1 {
-			panic(fmt.Sprintf("invalid number of options %d - 1 expected", cnt))
+			return fmt.Errorf("invalid number of options %d - 1 expected", cnt)
 		}
 		r.endianess = endianess(dec.Int8())
 	}
@@ -111,3 +111,12 @@ func (r *initReply) decode(dec *encoding.Decoder) error {
 	dec.Skip(2) // commitInitReplySize
 	return dec.Error()
 }
+
+func (r *initReply) encode(enc *encoding.Encoder) error {
+	enc.Int8(r.product.major)
+	enc.Int16(r.product.minor)
+	enc.Int8(r.protocol.major)
+	enc.Int16(r.protocol.minor)
+	enc.Zeroes(2) // commitInitReplySize
+	return nil
+}
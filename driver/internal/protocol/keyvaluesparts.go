@@ -6,11 +6,12 @@ import (
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
 )
 
-type clientInfo map[string]string
+// ClientInfo represents the client info part setting session context values (e.g. APPLICATIONUSER).
+type ClientInfo map[string]string
 
-func (c clientInfo) String() string { return fmt.Sprintf("%v", map[string]string(c)) }
+func (c ClientInfo) String() string { return fmt.Sprintf("%v", map[string]string(c)) }
 
-func (c clientInfo) size() int {
+func (c ClientInfo) size() int {
 	size := 0
 	for k, v := range c {
 		size += encoding.Cesu8FieldSize(k)
@@ -19,10 +20,10 @@ func (c clientInfo) size() int {
 	return size
 }
 
-func (c clientInfo) numArg() int { return len(c) }
+func (c ClientInfo) numArg() int { return len(c) }
 
-func (c *clientInfo) decodeNumArg(dec *encoding.Decoder, numArg int) error {
-	*c = clientInfo{} // no reuse of maps - create new one
+func (c *ClientInfo) decodeNumArg(dec *encoding.Decoder, numArg int) error {
+	*c = ClientInfo{} // no reuse of maps - create new one
 
 	for i := 0; i < numArg; i++ {
 		k, err := dec.Cesu8Field()
@@ -39,7 +40,7 @@ func (c *clientInfo) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 	return dec.Error()
 }
 
-func (c clientInfo) encode(enc *encoding.Encoder) error {
+func (c ClientInfo) encode(enc *encoding.Encoder) error {
 	for k, v := range c {
 		if err := enc.Cesu8Field(k); err != nil {
 			return err
@@ -138,6 +138,8 @@ type LobOutDescr struct {
 	numByte int64
 	ID      LocatorID
 	B       []byte
+	// lob locator was released on the database server by reading the last data
+	released bool
 }
 
 func (d *LobOutDescr) String() string {
@@ -152,6 +154,12 @@ func (d *LobOutDescr) SetDecoder(decoder func(descr *LobOutDescr, wr io.Writer)
 // Scan implements the LobScanner interface.
 func (d *LobOutDescr) Scan(wr io.Writer) error { return d.decoder(d, wr) }
 
+// IsOpen returns true if the lob locator is open on the database server (lob data not read completely), false otherwise.
+func (d *LobOutDescr) IsOpen() bool { return !d.Opt.IsLastData() && !d.released }
+
+// Release marks the lob locator as released on the database server.
+func (d *LobOutDescr) Release() { d.released = true }
+
 /*
 write lobs:
 - write lob field to database in chunks
@@ -189,7 +197,10 @@ func (d *WriteLobDescr) decode(dec *encoding.Decoder) error {
 	d.ID = LocatorID(dec.Uint64())
 	d.Opt = LobOptions(dec.Int8())
 	d.ofs = dec.Int64()
-	size := dec.Int32()
+	size := int(dec.Int32())
+	if !dec.CheckSize(size) {
+		return dec.Error()
+	}
 	d.b = make([]byte, size)
 	dec.Bytes(d.b)
 	return nil
@@ -313,12 +324,15 @@ func (r *ReadLobReply) String() string {
 
 func (r *ReadLobReply) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 	if numArg != 1 {
-		panic("numArg == 1 expected")
+		return fmt.Errorf("invalid number of lob read replies %d - expected 1", numArg)
 	}
 	r.ID = LocatorID(dec.Uint64())
 	r.Opt = LobOptions(dec.Int8())
 	size := int(dec.Int32())
 	dec.Skip(3)
+	if !dec.CheckSize(size) {
+		return dec.Error()
+	}
 	r.B = slices.Grow(r.B, size)[:size]
 	dec.Bytes(r.B)
 	return nil
@@ -53,3 +53,6 @@ func (mt MessageType) ClientInfoSupported() bool {
 	*/
 	return mt == MtPrepare || mt == MtExecuteDirect || mt == MtExecute
 }
+
+// resultsetSupported returns true if message might open a resultset, false otherwise.
+func (mt MessageType) resultsetSupported() bool { return mt == MtExecuteDirect || mt == MtExecute }
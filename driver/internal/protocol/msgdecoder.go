@@ -0,0 +1,200 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// redacted is the value of decoded parts containing secrets.
+const redacted = "<redacted>"
+
+// DecodedPart is a decoded protocol part.
+type DecodedPart struct {
+	Kind       string `json:"kind"`
+	Attributes string `json:"attributes,omitempty"`
+	NumArg     int    `json:"numArg"`
+	Size       int    `json:"size"`
+	// Value is the string representation of the decoded part (empty if the part cannot be decoded).
+	Value string `json:"value,omitempty"`
+}
+
+// DecodedMessage is a decoded protocol message.
+type DecodedMessage struct {
+	SessionID    int64          `json:"sessionID"`
+	SegmentKind  string         `json:"segmentKind"`
+	MessageType  string         `json:"messageType,omitempty"`  // request
+	FunctionCode string         `json:"functionCode,omitempty"` // reply
+	Parts        []*DecodedPart `json:"parts"`
+}
+
+// stmtFields are the parameter and result fields of a prepared statement.
+type stmtFields struct {
+	prmFields []*ParameterField
+	resFields []*ResultField
+}
+
+/*
+MessageDecoder decodes the raw protocol messages of a session in the order of the exchange
+(request, reply, request, ...), e.g. for a sniffer.
+
+Parts depending on metadata of previous messages (parameters and resultsets) are decoded by means of
+the metadata of the respective prepare and query replies. Authentication parts are redacted unless secrets
+are requested to be shown.
+*/
+type MessageDecoder struct {
+	showSecrets bool
+
+	stmts      map[uint64]*stmtFields    // fields by statement id
+	resultsets map[uint64][]*ResultField // result fields by resultset id
+
+	// attributes of the last request
+	stmtID uint64
+	rsID   uint64
+}
+
+// NewMessageDecoder returns a new MessageDecoder instance.
+func NewMessageDecoder(showSecrets bool) *MessageDecoder {
+	return &MessageDecoder{showSecrets: showSecrets, stmts: map[uint64]*stmtFields{}, resultsets: map[uint64][]*ResultField{}}
+}
+
+func (d *MessageDecoder) decode(rd *Reader, fn func(kind PartKind, read func(part Part) string) string) *DecodedMessage {
+	msg := &DecodedMessage{}
+	var warnings []string
+	rd.SetWarningHandler(func(ctx context.Context, err *HdbError) { warnings = append(warnings, err.Error()) })
+
+	err := rd.IterateParts(context.Background(), func(kind PartKind, attrs PartAttributes, read func(part Part)) {
+		part := &DecodedPart{Kind: kind.String(), NumArg: rd.ph.numArg(), Size: rd.ph.bufLen()}
+		if attrs != 0 {
+			part.Attributes = attrs.String()
+		}
+		part.Value = fn(kind, func(p Part) string { read(p); return p.String() })
+		msg.Parts = append(msg.Parts, part)
+	})
+	msg.SessionID = rd.mh.sessionID
+	msg.SegmentKind = rd.sh.segmentKind.String()
+
+	var hdbErrors *HdbErrors
+	switch {
+	case errors.As(err, &hdbErrors):
+		msg.Parts = append(msg.Parts, &DecodedPart{Kind: PkError.String(), NumArg: hdbErrors.NumError(), Value: hdbErrors.Error()})
+	case err != nil:
+		msg.Parts = append(msg.Parts, &DecodedPart{Kind: "decodeError", Value: err.Error()})
+	}
+	for _, warning := range warnings {
+		msg.Parts = append(msg.Parts, &DecodedPart{Kind: PkError.String(), NumArg: 1, Value: warning})
+	}
+	return msg
+}
+
+// decodeGeneric decodes parts which can be instantiated generically.
+func (d *MessageDecoder) decodeGeneric(kind PartKind, read func(part Part) string) string {
+	if kind == PkAuthentication && !d.showSecrets {
+		return redacted
+	}
+	if part := newGenPartReader(kind); part != nil {
+		return read(part)
+	}
+	return ""
+}
+
+// DecodeRequest decodes the raw client request b.
+func (d *MessageDecoder) DecodeRequest(b []byte) *DecodedMessage {
+	rd := NewClientReader(encoding.NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder), false, discardLogger)
+	d.stmtID, d.rsID = 0, 0
+	msg := d.decode(rd, func(kind PartKind, read func(part Part) string) string {
+		switch kind {
+		case PkStatementID:
+			return read((*StatementID)(&d.stmtID))
+		case PkResultsetID:
+			return read((*ResultsetID)(&d.rsID))
+		case PkAuthentication:
+			if !d.showSecrets {
+				return redacted
+			}
+			if rd.MessageType() == MtAuthenticate {
+				return read(&AuthInitRequest{})
+			}
+			return "" // final request cannot be decoded without authentication method
+		case PkParameters:
+			fields, ok := d.stmts[d.stmtID]
+			if !ok {
+				return ""
+			}
+			prms := &InputParameters{}
+			for _, f := range fields.prmFields {
+				if f.In() {
+					prms.InputFields = append(prms.InputFields, f)
+				}
+			}
+			return read(prms)
+		default:
+			return d.decodeGeneric(kind, read)
+		}
+	})
+	switch rd.MessageType() {
+	case MtDropStatementID:
+		delete(d.stmts, d.stmtID)
+	case MtCloseResultset:
+		delete(d.resultsets, d.rsID)
+	}
+	msg.MessageType = rd.MessageType().String()
+	return msg
+}
+
+// DecodeReply decodes the raw database reply b to the last decoded request.
+func (d *MessageDecoder) DecodeReply(b []byte) *DecodedMessage {
+	rd := NewDBReader(encoding.NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder), false, discardLogger)
+	var stmtID, rsID uint64
+	fields := &stmtFields{}
+	if f, ok := d.stmts[d.stmtID]; ok { // execute of prepared statement
+		*fields = *f
+	}
+	if f, ok := d.resultsets[d.rsID]; ok { // fetch
+		fields.resFields = f
+	}
+	msg := d.decode(rd, func(kind PartKind, read func(part Part) string) string {
+		switch kind {
+		case PkStatementID:
+			return read((*StatementID)(&stmtID))
+		case PkResultsetID:
+			return read((*ResultsetID)(&rsID))
+		case PkResultMetadata:
+			meta := &ResultMetadata{}
+			s := read(meta)
+			fields.resFields = meta.ResultFields
+			return s
+		case PkParameterMetadata:
+			meta := &ParameterMetadata{}
+			s := read(meta)
+			fields.prmFields = meta.ParameterFields
+			return s
+		case PkResultset:
+			if fields.resFields == nil {
+				return ""
+			}
+			return read(&Resultset{ResultFields: fields.resFields})
+		case PkOutputParameters:
+			var outFields []*ParameterField
+			for _, f := range fields.prmFields {
+				if f.Out() {
+					outFields = append(outFields, f)
+				}
+			}
+			return read(&OutputParameters{OutputFields: outFields})
+		default:
+			return d.decodeGeneric(kind, read)
+		}
+	})
+	msg.FunctionCode = rd.FunctionCode().String()
+	if stmtID != 0 { // prepare
+		d.stmts[stmtID] = fields
+	}
+	if rsID != 0 {
+		d.resultsets[rsID] = fields.resFields
+	}
+	return msg
+}
@@ -3,6 +3,8 @@ package protocol
 import (
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
 )
@@ -166,6 +168,45 @@ func (co *ConnectOptions) FullVersionOrZero() string {
 	return v
 }
 
+// ConnectionIDOrZero returns the connection id option if available, the zero value otherwise.
+func (co *ConnectOptions) ConnectionIDOrZero() int {
+	var v int32
+	co.options.get(coConnectionID, &v)
+	return int(v)
+}
+
+// Snapshot returns a copy of the connect options using the option names as keys.
+func (co *ConnectOptions) Snapshot() map[string]any {
+	const unknownPrefix = "connectOption("
+
+	m := make(map[string]any, len(co.options))
+	for k, v := range co.options {
+		name := k.String()
+		if !strings.HasPrefix(name, unknownPrefix) {
+			name = name[2:] // cut 'co' prefix
+		}
+		if b, ok := v.([]byte); ok {
+			v = slices.Clone(b)
+		}
+		m[name] = v
+	}
+	return m
+}
+
+// SetQueryTimeoutSupported sets the query timeout supported option.
+func (co *ConnectOptions) SetQueryTimeoutSupported(v bool) {
+	co.options.set(coQueryTimeoutSupported, v)
+}
+
+// SetConnectionID sets the connection id option.
+func (co *ConnectOptions) SetConnectionID(v int) { co.options.set(coConnectionID, int32(v)) }
+
+// SetFullVersion sets the full version option.
+func (co *ConnectOptions) SetFullVersion(v string) { co.options.set(coFullVersionString, v) }
+
+// SetDatabaseName sets the database name option.
+func (co *ConnectOptions) SetDatabaseName(v string) { co.options.set(coDatabaseName, v) }
+
 // SetClientLocale sets the client locale option.
 func (co *ConnectOptions) SetClientLocale(v string) { co.options.set(coClientLocale, v) }
 
@@ -192,6 +233,16 @@ type DBConnectInfo struct {
 // SetDatabaseName sets the database name option.
 func (ci *DBConnectInfo) SetDatabaseName(v string) { ci.options.set(ciDatabaseName, v) }
 
+// DatabaseNameOrZero returns the database name option, the zero value otherwise.
+func (ci *DBConnectInfo) DatabaseNameOrZero() string {
+	var v string
+	ci.options.get(ciDatabaseName, &v)
+	return v
+}
+
+// SetIsConnected sets the IsConnected option.
+func (ci *DBConnectInfo) SetIsConnected(v bool) { ci.options.set(ciIsConnected, v) }
+
 // HostOrZero returns the host option, the zero value otherwise.
 func (ci *DBConnectInfo) HostOrZero() string { var v string; ci.options.get(ciHost, &v); return v }
 
@@ -222,10 +273,57 @@ const (
 	scServerMemoryUsage             statementContextType = 8
 )
 
-type statementContext struct {
+// StatementContext represents a statement context part.
+type StatementContext struct {
 	options[statementContextType]
 }
 
+// SetQueryTimeout sets the query timeout option (in seconds).
+func (sc *StatementContext) SetQueryTimeout(v int64) { sc.options.set(scQueryTimeout, v) }
+
+// QueryTimeoutOrZero returns the query timeout option (in seconds) if available, the zero value otherwise.
+func (sc *StatementContext) QueryTimeoutOrZero() int64 {
+	if sc == nil {
+		return 0
+	}
+	var v int64
+	sc.options.get(scQueryTimeout, &v)
+	return v
+}
+
+// ServerProcessingTimeOrZero returns the server processing time option if available, the zero value otherwise.
+func (sc *StatementContext) ServerProcessingTimeOrZero() time.Duration {
+	if sc == nil {
+		return 0
+	}
+	var v int64
+	sc.options.get(scServerProcessingTime, &v)
+	return time.Duration(v) * time.Microsecond
+}
+
+// ServerCPUTimeOrZero returns the server cpu time option if available, the zero value otherwise.
+func (sc *StatementContext) ServerCPUTimeOrZero() time.Duration {
+	if sc == nil {
+		return 0
+	}
+	var v int64
+	sc.options.get(scServerCPUTime, &v)
+	return time.Duration(v) * time.Microsecond
+}
+
+// ServerMemoryUsageOrZero returns the server memory usage option (in bytes) if available, the zero value otherwise.
+func (sc *StatementContext) ServerMemoryUsageOrZero() int64 {
+	if sc == nil {
+		return 0
+	}
+	var v int64
+	sc.options.get(scServerMemoryUsage, &v)
+	return v
+}
+
+// isEmpty returns true if no statement context option is set, false otherwise.
+func (sc *StatementContext) isEmpty() bool { return sc == nil || len(sc.options) == 0 }
+
 // transaction flags.
 type transactionFlagType int8
 
@@ -245,10 +343,43 @@ const (
 	tfReadOnlyMode                    transactionFlagType = 8
 )
 
-type transactionFlags struct {
+// TransactionFlags represents a transaction flags part reporting transaction state changes.
+type TransactionFlags struct {
 	options[transactionFlagType]
 }
 
+func (tf *TransactionFlags) flag(k transactionFlagType) bool {
+	var v bool
+	tf.options.get(k, &v)
+	return v
+}
+
+// RolledBackOrZero returns true if the transaction was rolled back, the zero value otherwise.
+func (tf *TransactionFlags) RolledBackOrZero() bool { return tf.flag(tfRolledback) }
+
+// CommittedOrZero returns true if the transaction was committed, the zero value otherwise.
+func (tf *TransactionFlags) CommittedOrZero() bool { return tf.flag(tfCommited) }
+
+// WriteTransactionStartedOrZero returns true if a write transaction was started, the zero value otherwise.
+func (tf *TransactionFlags) WriteTransactionStartedOrZero() bool {
+	return tf.flag(tfWriteTransactionStarted)
+}
+
+// SessionClosingTransactionErrorOrZero returns true if a transaction error closing the session occurred, the zero value otherwise.
+func (tf *TransactionFlags) SessionClosingTransactionErrorOrZero() bool {
+	return tf.flag(tfSessionClosingTransactionError)
+}
+
+// ReadOnlyModeOrZero returns true if the transaction is in read only mode, the zero value otherwise.
+func (tf *TransactionFlags) ReadOnlyModeOrZero() bool { return tf.flag(tfReadOnlyMode) }
+
+// DDLCommitMode returns the DDL autocommit mode and true if the DDL commit mode was changed, false otherwise.
+func (tf *TransactionFlags) DDLCommitMode() (bool, bool) {
+	var v bool
+	ok := tf.options.get(tfDDLCommitmodeChanged, &v)
+	return v, ok
+}
+
 type topologyOption int8
 
 func (k topologyOption) valueString(v any) string {
@@ -343,15 +474,24 @@ func (ops *options[K]) get(k K, v any) bool {
 	}
 	switch v := v.(type) {
 	case *string:
-		*v = mv.(string)
+		*v, ok = mv.(string)
 	case *bool:
-		*v = mv.(bool)
+		*v, ok = mv.(bool)
 	case *int32:
-		*v = mv.(int32)
+		*v, ok = mv.(int32)
+	case *int64:
+		switch mv := mv.(type) {
+		case int64:
+			*v = mv
+		case int32:
+			*v = int64(mv)
+		default:
+			return false
+		}
 	default:
 		panic("")
 	}
-	return true
+	return ok
 }
 
 func (ops *options[K]) set(k K, v any) {
@@ -378,6 +518,9 @@ func (ops *options[K]) decodeNumArg(dec *encoding.Decoder, numArg int) error {
 		k := K(dec.Int8())
 		tc := typeCode(dec.Byte())
 		ot := optTypeViaTypeCode(tc)
+		if ot == nil {
+			return fmt.Errorf("invalid type code %s of option %v", tc, k)
+		}
 		(*ops)[k] = ot.decode(dec)
 	}
 	return dec.Error()
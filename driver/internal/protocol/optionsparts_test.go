@@ -0,0 +1,31 @@
+package protocol
+
+import (
+	"testing"
+)
+
+func TestConnectOptionsSnapshot(t *testing.T) {
+	co := &ConnectOptions{}
+	co.options.set(coConnectionID, int32(42))
+	co.options.set(coDatabaseName, "HXE")
+	co.options.set(connectOption(99), true)
+
+	snapshot := co.Snapshot()
+
+	if v := co.ConnectionIDOrZero(); v != 42 {
+		t.Fatalf("connection id %d - expected %d", v, 42)
+	}
+	tests := map[string]any{
+		"ConnectionID":      int32(42),
+		"DatabaseName":      "HXE",
+		"connectOption(99)": true,
+	}
+	if len(snapshot) != len(tests) {
+		t.Fatalf("snapshot length %d - expected %d", len(snapshot), len(tests))
+	}
+	for k, v := range tests {
+		if snapshot[k] != v {
+			t.Fatalf("snapshot %s value %v - expected %v", k, snapshot[k], v)
+		}
+	}
+}
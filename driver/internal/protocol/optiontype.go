@@ -140,6 +140,6 @@ func optTypeViaTypeCode(tc typeCode) optType {
 	case tcBstring:
 		return optBstringType
 	default:
-		panic(fmt.Sprintf("missing optType for typeCode %s", tc))
+		return nil // invalid type code
 	}
 }
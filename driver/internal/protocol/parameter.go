@@ -1,11 +1,16 @@
 package protocol
 
 import (
+	"bytes"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
+	"unicode/utf8"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/internal/unsafe"
 	"golang.org/x/text/transform"
 )
 
@@ -115,18 +120,61 @@ func (f *ParameterField) Convert(t transform.Transformer, v any) (any, error) {
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeDatabaseTypeName
 func (f *ParameterField) TypeName() string { return f.tc.typeName() }
 
+/*
+CheckLength checks the length of the converted value v against the maximum length of the field
+and returns the value length, the maximum length and false if v exceeds the field length.
+  - character types: the length is the number of UTF-16 code units (CESU-8 characters)
+  - single byte character and binary types: the length is the number of bytes
+*/
+func (f *ParameterField) CheckLength(v any) (int, int, bool) {
+	if f.prec <= 0 {
+		return 0, 0, true
+	}
+	var b []byte
+	switch v := v.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = unsafe.String2ByteSlice(v)
+	default:
+		return 0, 0, true
+	}
+	var length int
+	switch f.tc {
+	case tcChar, tcVarchar, tcBinary, tcVarbinary:
+		length = len(b)
+	case tcNchar, tcNvarchar, tcShorttext:
+		length = utf16Len(b)
+	default:
+		return 0, 0, true
+	}
+	return length, f.prec, length <= f.prec
+}
+
+// utf16Len returns the number of UTF-16 code units of the UTF-8 encoded b.
+func utf16Len(b []byte) int {
+	n := 0
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r >= 0x10000 { // surrogate pair
+			n++
+		}
+		n++
+		b = b[size:]
+	}
+	return n
+}
+
 // ScanType returns the scan type of the field.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeScanType
 func (f *ParameterField) ScanType() reflect.Type { return f.tc.dataType().ScanType(f.Nullable()) }
 
+// DataType returns the data type of the field.
+func (f *ParameterField) DataType() DataType { return f.tc.dataType() }
+
 // TypeLength returns the type length of the field.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeLength
-func (f *ParameterField) TypeLength() (int64, bool) {
-	if f.tc.isVariableLength() {
-		return int64(f.prec), true
-	}
-	return 0, false
-}
+func (f *ParameterField) TypeLength() (int64, bool) { return f.tc.typeLength(f.prec) }
 
 // TypePrecisionScale returns the type precision and scale (decimal types) of the field.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypePrecisionScale
@@ -289,11 +337,8 @@ func (f *ParameterField) decodeResult(dec *encoding.Decoder) (any, error) {
 
 /*
 decode parameter
-- currently not used
 - type code is first byte (see encodePrm).
 */
-var _ = (*ParameterField)(nil).decodeParameter // mark decodeParameter as used
-
 func (f *ParameterField) decodeParameter(dec *encoding.Decoder) (any, error) {
 	tc := typeCode(dec.Byte())
 	if tc&0x80 != 0 { // high bit set -> null value
@@ -329,6 +374,7 @@ func (m *ParameterMetadata) decodeNumArg(dec *encoding.Decoder, numArg int) erro
 type InputParameters struct {
 	InputFields []*ParameterField
 	nvargs      []driver.NamedValue
+	buf         []byte // pre-encoded parameters
 }
 
 // NewInputParameters returns a InputParameters instance.
@@ -340,7 +386,27 @@ func (p *InputParameters) String() string {
 	return fmt.Sprintf("fields %s len(args) %d args %v", p.InputFields, len(p.nvargs), p.nvargs)
 }
 
+/*
+Preencode encodes the input parameters into an internal buffer which is written as is
+by a subsequent message write. This allows to encode the parameters independently
+(e.g. concurrently) of the protocol writer.
+*/
+func (p *InputParameters) Preencode(encoder func() transform.Transformer, loc *time.Location, rounding encoding.RoundingMode) error {
+	buf := bytes.NewBuffer(make([]byte, 0, p.size())) // size sets the lob data positions
+	enc := encoding.NewEncoder(buf, encoder)
+	enc.SetTimeLocation(loc)
+	enc.SetRoundingMode(rounding)
+	if err := p.encode(enc); err != nil {
+		return err
+	}
+	p.buf = buf.Bytes()
+	return nil
+}
+
 func (p *InputParameters) size() int {
+	if p.buf != nil {
+		return len(p.buf)
+	}
 	size := 0
 	numColumns := len(p.InputFields)
 	if numColumns == 0 { // avoid divide-by-zero (e.g. prepare without parameters)
@@ -373,6 +439,18 @@ func (p *InputParameters) size() int {
 	return size
 }
 
+// RowSize returns the encoded size of the input parameter values nvargs of one row including the first chunk of lob data.
+func RowSize(inputFields []*ParameterField, nvargs []driver.NamedValue) int {
+	size := len(inputFields)
+	for i, f := range inputFields {
+		size += f.prmSize(nvargs[i].Value)
+		if lobInDescr, ok := nvargs[i].Value.(*LobInDescr); ok {
+			size += lobInDescr.size()
+		}
+	}
+	return size
+}
+
 func (p *InputParameters) numArg() int {
 	numColumns := len(p.InputFields)
 	if numColumns == 0 { // avoid divide-by-zero (e.g. prepare without parameters)
@@ -381,13 +459,78 @@ func (p *InputParameters) numArg() int {
 	return len(p.nvargs) / numColumns
 }
 
+/*
+decodeNumArg decodes the input parameters of numArg rows (server side).
+  - InputFields need to be set (otherwise the parameters are skipped, e.g. sniffer)
+  - lob parameters are decoded into []byte, where lob data which is not completely included
+    in the parameters part (lob streaming) is not supported
+*/
 func (p *InputParameters) decodeNumArg(dec *encoding.Decoder, numArg int) error {
-	// TODO Sniffer
-	// return fmt.Errorf("not implemented")
-	return nil
+	numColumns := len(p.InputFields)
+	if numColumns == 0 {
+		return nil
+	}
+	p.nvargs = make([]driver.NamedValue, numArg*numColumns)
+	type lobPrm struct {
+		idx  int
+		opt  LobOptions
+		size int
+	}
+	var lobPrms []lobPrm
+	for i := 0; i < numArg; i++ { // row-by-row
+		lobPrms = lobPrms[:0]
+		for j, f := range p.InputFields {
+			idx := i*numColumns + j
+			p.nvargs[idx].Ordinal = idx%numColumns + 1
+			if f.IsLob() {
+				tc := typeCode(dec.Byte())
+				if tc&0x80 != 0 { // high bit set -> null value
+					continue
+				}
+				opt := LobOptions(dec.Byte())
+				size := int(dec.Int32())
+				dec.Int32() // position
+				lobPrms = append(lobPrms, lobPrm{idx: idx, opt: opt, size: size})
+				continue
+			}
+			v, err := f.decodeParameter(dec)
+			if err != nil {
+				return err
+			}
+			p.nvargs[idx].Value = v
+		}
+		// lob data follows the row
+		for _, lp := range lobPrms {
+			if !lp.opt.IsLastData() {
+				return errors.New("lob streaming is not supported")
+			}
+			if !dec.CheckSize(lp.size) {
+				return dec.Error()
+			}
+			if p.InputFields[lp.idx%numColumns].tc.isCharBased() {
+				b, err := dec.CESU8Bytes(lp.size)
+				if err != nil {
+					return err
+				}
+				p.nvargs[lp.idx].Value = b
+			} else {
+				b := make([]byte, lp.size)
+				dec.Bytes(b)
+				p.nvargs[lp.idx].Value = b
+			}
+		}
+	}
+	return dec.Error()
 }
 
+// Args returns the input parameter arguments (server side: the decoded parameter values).
+func (p *InputParameters) Args() []driver.NamedValue { return p.nvargs }
+
 func (p *InputParameters) encode(enc *encoding.Encoder) error {
+	if p.buf != nil {
+		enc.Bytes(p.buf)
+		return nil
+	}
 	numColumns := len(p.InputFields)
 	if numColumns == 0 { // avoid divide-by-zero (e.g. prepare without parameters)
 		return nil
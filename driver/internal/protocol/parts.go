@@ -26,9 +26,13 @@ type bufLenPart interface {
 	Part
 	decodeBufLen(dec *encoding.Decoder, bufLen int) error
 }
+type numArgBufLenPart interface {
+	Part
+	decodeNumArgBufLen(dec *encoding.Decoder, numArg, bufLen int) error
+}
 
-// writablePart represents a protocol part the driver is able to write.
-type writablePart interface {
+// WritablePart represents a protocol part the driver is able to write.
+type WritablePart interface {
 	Part
 	numArg() int
 	size() int
@@ -41,7 +45,7 @@ func (*AuthInitReply) kind() PartKind       { return PkAuthentication }
 func (*AuthFinalRequest) kind() PartKind    { return PkAuthentication }
 func (*AuthFinalReply) kind() PartKind      { return PkAuthentication }
 func (ClientID) kind() PartKind             { return PkClientID }
-func (clientInfo) kind() PartKind           { return PkClientInfo }
+func (ClientInfo) kind() PartKind           { return PkClientInfo }
 func (*TopologyInformation) kind() PartKind { return PkTopologyInformation }
 func (Command) kind() PartKind              { return PkCommand }
 func (*RowsAffected) kind() PartKind        { return PkRowsAffected }
@@ -60,8 +64,8 @@ func (*WriteLobReply) kind() PartKind       { return PkWriteLobReply }
 func (*ClientContext) kind() PartKind       { return PkClientContext }
 func (*ConnectOptions) kind() PartKind      { return PkConnectOptions }
 func (*DBConnectInfo) kind() PartKind       { return PkDBConnectInfo }
-func (*statementContext) kind() PartKind    { return PkStatementContext }
-func (*transactionFlags) kind() PartKind    { return PkTransactionFlags }
+func (*StatementContext) kind() PartKind    { return PkStatementContext }
+func (*TransactionFlags) kind() PartKind    { return PkTransactionFlags }
 
 // numArg methods (result == 1).
 func (*AuthInitRequest) numArg() int  { return 1 }
@@ -90,20 +94,20 @@ func (ReadLobRequest) size() int { return readLobRequestSize }
 
 // check if part types implement WritablePart interface.
 var (
-	_ writablePart = (*AuthInitRequest)(nil)
-	_ writablePart = (*AuthFinalRequest)(nil)
-	_ writablePart = (*ClientID)(nil)
-	_ writablePart = (*clientInfo)(nil)
-	_ writablePart = (*Command)(nil)
-	_ writablePart = (*StatementID)(nil)
-	_ writablePart = (*InputParameters)(nil)
-	_ writablePart = (*ResultsetID)(nil)
-	_ writablePart = (*Fetchsize)(nil)
-	_ writablePart = (*ReadLobRequest)(nil)
-	_ writablePart = (*WriteLobRequest)(nil)
-	_ writablePart = (*ClientContext)(nil)
-	_ writablePart = (*ConnectOptions)(nil)
-	_ writablePart = (*DBConnectInfo)(nil)
+	_ WritablePart = (*AuthInitRequest)(nil)
+	_ WritablePart = (*AuthFinalRequest)(nil)
+	_ WritablePart = (*ClientID)(nil)
+	_ WritablePart = (*ClientInfo)(nil)
+	_ WritablePart = (*Command)(nil)
+	_ WritablePart = (*StatementID)(nil)
+	_ WritablePart = (*InputParameters)(nil)
+	_ WritablePart = (*ResultsetID)(nil)
+	_ WritablePart = (*Fetchsize)(nil)
+	_ WritablePart = (*ReadLobRequest)(nil)
+	_ WritablePart = (*WriteLobRequest)(nil)
+	_ WritablePart = (*ClientContext)(nil)
+	_ WritablePart = (*ConnectOptions)(nil)
+	_ WritablePart = (*DBConnectInfo)(nil)
 )
 
 // check if part types implement the right part interface.
@@ -114,7 +118,7 @@ var (
 	_ defPart    = (*AuthFinalRequest)(nil)
 	_ defPart    = (*AuthFinalReply)(nil)
 	_ bufLenPart = (*ClientID)(nil)
-	_ numArgPart = (*clientInfo)(nil)
+	_ numArgPart = (*ClientInfo)(nil)
 	_ numArgPart = (*TopologyInformation)(nil)
 	_ bufLenPart = (*Command)(nil)
 	_ numArgPart = (*RowsAffected)(nil)
@@ -133,14 +137,14 @@ var (
 	_ numArgPart = (*ClientContext)(nil)
 	_ numArgPart = (*ConnectOptions)(nil)
 	_ numArgPart = (*DBConnectInfo)(nil)
-	_ numArgPart = (*statementContext)(nil)
-	_ numArgPart = (*transactionFlags)(nil)
+	_ numArgPart = (*StatementContext)(nil)
+	_ numArgPart = (*TransactionFlags)(nil)
 )
 
 var genPartTypeMap = map[PartKind]reflect.Type{
 	PkError:               hdbreflect.TypeFor[HdbErrors](),
 	PkClientID:            hdbreflect.TypeFor[ClientID](),
-	PkClientInfo:          hdbreflect.TypeFor[clientInfo](),
+	PkClientInfo:          hdbreflect.TypeFor[ClientInfo](),
 	PkTopologyInformation: hdbreflect.TypeFor[TopologyInformation](),
 	PkCommand:             hdbreflect.TypeFor[Command](),
 	PkRowsAffected:        hdbreflect.TypeFor[RowsAffected](),
@@ -153,8 +157,8 @@ var genPartTypeMap = map[PartKind]reflect.Type{
 	PkWriteLobRequest:     hdbreflect.TypeFor[WriteLobRequest](),
 	PkClientContext:       hdbreflect.TypeFor[ClientContext](),
 	PkConnectOptions:      hdbreflect.TypeFor[ConnectOptions](),
-	PkTransactionFlags:    hdbreflect.TypeFor[transactionFlags](),
-	PkStatementContext:    hdbreflect.TypeFor[statementContext](),
+	PkTransactionFlags:    hdbreflect.TypeFor[TransactionFlags](),
+	PkStatementContext:    hdbreflect.TypeFor[StatementContext](),
 	PkDBConnectInfo:       hdbreflect.TypeFor[DBConnectInfo](),
 	/*
 	   parts that cannot be used generically as additional parameters are needed
@@ -2,12 +2,16 @@ package protocol
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"database/sql/driver"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"math"
+	"slices"
+	"sync"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
 	"golang.org/x/text/transform"
@@ -67,6 +71,15 @@ type Reader struct {
 	ph *partHeader
 
 	partCache partCache
+
+	parallelism int
+
+	stmtCtx StatementContext // statement context of the last reply
+	partOfs int64            // offset of the current part in the message
+
+	warningHandler func(ctx context.Context, err *HdbError)
+	txFlagsHandler func(tf *TransactionFlags)
+	corruptPart    func(kind PartKind) bool
 }
 
 func newReader(dec *encoding.Decoder, protTrace bool, logger *slog.Logger) *Reader {
@@ -103,6 +116,9 @@ func (r *Reader) SkipParts(ctx context.Context) error { return r.IterateParts(ct
 // SessionID returns the session ID.
 func (r *Reader) SessionID() int64 { return r.mh.sessionID }
 
+// MessageType returns the message type of the last read request (client reader).
+func (r *Reader) MessageType() MessageType { return r.sh.messageType }
+
 // FunctionCode returns the function code of the protocol.
 func (r *Reader) FunctionCode() FunctionCode { return r.sh.functionCode }
 
@@ -133,33 +149,85 @@ func (r *Reader) skipPadding() int {
 	return padBytes
 }
 
-func (r *Reader) skipPaddingLastPart(numReadByte int64) {
+func (r *Reader) skipPaddingLastPart(numReadByte int64) error {
 	// last part:
 	// skip difference between real read bytes and message header var part length
 	padBytes := int64(r.mh.varPartLength) - numReadByte
 	switch {
-	case padBytes < 0:
-		panic(fmt.Errorf("protocol error: bytes read %d > variable part length %d", numReadByte, r.mh.varPartLength))
+	case padBytes < 0: // read stream is broken
+		return &protocolError{err: fmt.Errorf("%w: bytes read %d > variable part length %d", driver.ErrBadConn, numReadByte, r.mh.varPartLength)}
 	case padBytes > 0:
 		r.dec.Skip(int(padBytes))
 	}
+	return nil
 }
 
-func (r *Reader) readPart(ctx context.Context, part Part) error {
-	cntBefore := r.dec.Cnt()
+// partError is returned in case a part cannot be decoded.
+type partError struct {
+	kind    PartKind
+	ofs     int64  // offset of the part in the message
+	hdr     string // part header
+	snippet []byte // start of the part data (if available)
+	err     error
+}
+
+func (e *partError) Error() string {
+	if len(e.snippet) == 0 {
+		return fmt.Sprintf("part %s at offset %d (%s): %s", e.kind, e.ofs, e.hdr, e.err)
+	}
+	return fmt.Sprintf("part %s at offset %d (%s) data [% x]: %s", e.kind, e.ofs, e.hdr, e.snippet, e.err)
+}
+
+func (e *partError) Unwrap() error { return e.err }
+
+// maxPartErrorSnippet is the maximum number of part data bytes included in part errors.
+const maxPartErrorSnippet = 32
+
+func (r *Reader) newPartError(kind PartKind, buf []byte, err error) *partError {
+	return &partError{kind: kind, ofs: r.partOfs, hdr: r.ph.String(), snippet: buf[:min(len(buf), maxPartErrorSnippet)], err: err}
+}
+
+// decodePart decodes part. Panics caused by malformed data are returned as errors.
+func decodePart(dec *encoding.Decoder, part Part, numArg, bufLen int) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("decoding failed: %v", v)
+		}
+	}()
+
+	if numArg < 0 || numArg > bufLen { // each argument is encoded by at least one byte
+		return fmt.Errorf("invalid number of arguments %d for buffer length %d", numArg, bufLen)
+	}
 
-	var err error
 	switch part := part.(type) {
-	// do not return here in case of error -> read stream would be broken
 	case defPart:
-		err = part.decode(r.dec)
+		return part.decode(dec)
+	case numArgBufLenPart:
+		return part.decodeNumArgBufLen(dec, numArg, bufLen)
 	case numArgPart:
-		err = part.decodeNumArg(r.dec, r.ph.numArg())
+		return part.decodeNumArg(dec, numArg)
 	case bufLenPart:
-		err = part.decodeBufLen(r.dec, r.ph.bufLen())
+		return part.decodeBufLen(dec, bufLen)
 	default:
-		panic(fmt.Errorf("decoder function part %v not found", part))
+		return fmt.Errorf("decoder function part %v not found", part)
 	}
+}
+
+// setParallelism passes the reader decode parallelism to parts supporting parallel decoding.
+func (r *Reader) setParallelism(part Part) {
+	if rs, ok := part.(*Resultset); ok {
+		rs.parallelism = r.parallelism
+	}
+}
+
+func (r *Reader) readPart(ctx context.Context, part Part) error {
+	r.setParallelism(part)
+	cntBefore := r.dec.Cnt()
+
+	r.dec.SetLimit(r.ph.bufLen())
+	// do not return here in case of error -> read stream would be broken
+	err := decodePart(r.dec, part, r.ph.numArg(), r.ph.bufLen())
+	r.dec.SetLimit(-1)
 
 	cnt := r.dec.Cnt() - cntBefore
 
@@ -171,17 +239,170 @@ func (r *Reader) readPart(ctx context.Context, part Part) error {
 	switch {
 	case cnt < bufferLen: // protocol buffer length > read bytes -> skip the unread bytes
 		r.dec.Skip(bufferLen - cnt)
-	case cnt > bufferLen: // read bytes > protocol buffer length -> should never happen
-		panic(fmt.Errorf("protocol error: read bytes %d > buffer length %d", cnt, bufferLen))
+	case cnt > bufferLen: // read bytes > protocol buffer length -> read stream is broken
+		return r.newPartError(part.kind(), nil, fmt.Errorf("%w: read bytes %d > buffer length %d", driver.ErrBadConn, cnt, bufferLen))
+	}
+	if err != nil {
+		return r.newPartError(part.kind(), nil, err)
+	}
+	return nil
+}
+
+// isParallelPart returns true if part might be decoded in parallel to other parts.
+func isParallelPart(part Part) bool {
+	switch part.(type) {
+	case *Resultset, *OutputParameters:
+		return true
+	default:
+		return false
+	}
+}
+
+// readPartAsync reads the encoded part and decodes it in a separate goroutine of group g.
+func (r *Reader) readPartAsync(ctx context.Context, part Part, g *partGroup) error {
+	buf := make([]byte, r.ph.bufferLength)
+	r.dec.Bytes(buf)
+	if err := r.dec.Error(); err != nil {
+		return err
+	}
+	dec := r.dec.SubDecoder(bytes.NewReader(buf))
+	numArg, bufLen := r.ph.numArg(), r.ph.bufLen()
+	dec.SetLimit(bufLen)
+	partErr := r.newPartError(part.kind(), buf, nil)
+	r.setParallelism(part)
+	if r.protTrace { // trace after decoding (see IteratePartsParallel) - the logger must not be called concurrently
+		g.parts = append(g.parts, part)
+	}
+	g.do(func() error {
+		err := decodePart(dec, part, numArg, bufLen)
+		if err == nil {
+			err = dec.Error()
+		}
+		if err != nil {
+			partErr.err = err
+			return partErr
+		}
+		return nil
+	})
+	return nil
+}
+
+// partGroup decodes parts in at most limit goroutines and keeps the first error.
+type partGroup struct {
+	wg    sync.WaitGroup
+	sem   chan struct{}
+	mu    sync.Mutex
+	err   error
+	parts []Part // traced parts
+}
+
+func newPartGroup(limit int) *partGroup { return &partGroup{sem: make(chan struct{}, limit)} }
+
+func (g *partGroup) do(fn func() error) {
+	g.wg.Add(1)
+	g.sem <- struct{}{}
+	go func() {
+		defer func() { <-g.sem; g.wg.Done() }()
+		if err := fn(); err != nil {
+			g.mu.Lock()
+			if g.err == nil {
+				g.err = err
+			}
+			g.mu.Unlock()
+		}
+	}()
+}
+
+func (g *partGroup) wait() error {
+	g.wg.Wait()
+	return g.err
+}
+
+// StatementContext returns the statement context part of the last reply, nil if the reply did not contain one.
+func (r *Reader) StatementContext() *StatementContext {
+	if r.stmtCtx.isEmpty() {
+		return nil
+	}
+	sc := r.stmtCtx
+	return &sc
+}
+
+// SetWarningHandler sets a function which is called for each warning sent by the database server
+// (default: warnings are logged).
+func (r *Reader) SetWarningHandler(fn func(ctx context.Context, err *HdbError)) {
+	r.warningHandler = fn
+}
+
+// SetTransactionFlagsHandler sets a function which is called for each transaction flags part sent by the database server.
+func (r *Reader) SetTransactionFlagsHandler(fn func(tf *TransactionFlags)) {
+	r.txFlagsHandler = fn
+}
+
+// SetCorruptPart sets a function reporting reply parts to be corrupted (fault injection for testing).
+// The argument count of corrupted parts is invalidated, so that decoding the part fails while the read stream is kept intact.
+func (r *Reader) SetCorruptPart(fn func(kind PartKind) bool) { r.corruptPart = fn }
+
+// SetDecodeParallelism sets the maximum number of goroutines decoding parts in IteratePartsParallel
+// and converting the columns of columnar resultsets.
+func (r *Reader) SetDecodeParallelism(parallelism int) { r.parallelism = parallelism }
+
+// ErrProtocol is wrapped by errors caused by invalid or unsupported protocol data sent by the database server.
+var ErrProtocol = errors.New("protocol error")
+
+// protocolError wraps an error with ErrProtocol keeping the error message.
+type protocolError struct{ err error }
+
+func (e *protocolError) Error() string   { return e.err.Error() }
+func (e *protocolError) Unwrap() []error { return []error{e.err, ErrProtocol} }
+
+// wrapProtocolError wraps reader errors with ErrProtocol unless they are database server or i/o errors.
+func wrapProtocolError(err error) error {
+	var hdbErrors *HdbErrors
+	var partErr *partError
+	var protErr *protocolError
+	switch {
+	case err == nil || errors.As(err, &hdbErrors) || errors.As(err, &protErr):
+		return err
+	case errors.As(err, &partErr):
+		return &protocolError{err: err}
+	case errors.Is(err, driver.ErrBadConn):
+		return err
+	default:
+		return &protocolError{err: err}
 	}
-	return err
 }
 
 // IterateParts iterates through all protocol parts.
 func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs PartAttributes, read func(part Part))) error {
+	return wrapProtocolError(r.iterateParts(ctx, nil, fn))
+}
+
+/*
+IteratePartsParallel iterates through all protocol parts like IterateParts, but resultset and output parameter
+parts might be decoded in parallel goroutines (see SetDecodeParallelism). The content of these parts must
+therefore not be accessed by fn, but only after IteratePartsParallel returns.
+*/
+func (r *Reader) IteratePartsParallel(ctx context.Context, fn func(kind PartKind, attrs PartAttributes, read func(part Part))) error {
+	if r.parallelism <= 1 {
+		return wrapProtocolError(r.iterateParts(ctx, nil, fn))
+	}
+	g := newPartGroup(r.parallelism)
+	err := r.iterateParts(ctx, g, fn)
+	if gErr := g.wait(); err == nil {
+		err = gErr
+	}
+	for _, part := range g.parts {
+		r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textPar, part.String()))
+	}
+	return wrapProtocolError(err)
+}
+
+func (r *Reader) iterateParts(ctx context.Context, g *partGroup, fn func(kind PartKind, attrs PartAttributes, read func(part Part))) error {
 	var lastErrors *HdbErrors
 	var lastRowsAffected *RowsAffected
 
+	r.stmtCtx = StatementContext{}
+
 	if err := r.mh.decode(r.dec); err != nil {
 		return err
 	}
@@ -208,23 +429,35 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 				return err
 			}
 			kind := r.ph.partKind
+			if r.corruptPart != nil && r.corruptPart(kind) {
+				r.ph.argumentCount, r.ph.bigArgumentCount = bigNumArgInd, -1
+			}
 
 			numReadByte += partHeaderSize
 
+			if r.ph.bufferLength < 0 || int64(r.ph.bufferLength) > int64(r.mh.varPartLength)-numReadByte { // read stream is broken
+				return &protocolError{err: fmt.Errorf("%w: part buffer length %d exceeds variable part length %d", driver.ErrBadConn, r.ph.bufferLength, r.mh.varPartLength)}
+			}
+
 			if r.protTrace {
 				r.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(r.prefix+textParHdr, r.ph.String()))
 			}
 
 			cntBefore := r.dec.Cnt()
+			r.partOfs = numReadByte
 
 			partRequested := false
 			if kind != PkError && fn != nil { // caller must not handle hdb errors
 				var err error
 				fn(kind, r.ph.partAttributes, func(part Part) {
 					partRequested = true
+					if g != nil && isParallelPart(part) {
+						err = r.readPartAsync(ctx, part, g)
+						return
+					}
 					err = r.readPart(ctx, part)
-					if part.kind() == PkRowsAffected {
-						lastRowsAffected = part.(*RowsAffected)
+					if rowsAffected, ok := part.(*RowsAffected); ok {
+						lastRowsAffected = rowsAffected
 					}
 				})
 				if err != nil {
@@ -233,7 +466,7 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 			}
 			if !partRequested {
 				// if trace is on or mandatory parts need to be read we cannot skip
-				if !(r.protTrace || kind == PkError || kind == PkRowsAffected) {
+				if !(r.protTrace || kind == PkError || kind == PkRowsAffected || kind == PkStatementContext || (kind == PkTransactionFlags && r.txFlagsHandler != nil)) {
 					r.dec.Skip(int(r.ph.bufferLength))
 				} else {
 					if part, ok := r.partCache.get(kind); ok {
@@ -245,6 +478,12 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 							lastErrors = part.(*HdbErrors)
 						case PkRowsAffected:
 							lastRowsAffected = part.(*RowsAffected)
+						case PkStatementContext:
+							r.stmtCtx = *part.(*StatementContext)
+						case PkTransactionFlags:
+							if r.txFlagsHandler != nil {
+								r.txFlagsHandler(part.(*TransactionFlags))
+							}
 						}
 					} else {
 						r.dec.Skip(int(r.ph.bufferLength))
@@ -264,7 +503,9 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 		}
 	}
 
-	r.skipPaddingLastPart(numReadByte)
+	if err := r.skipPaddingLastPart(numReadByte); err != nil {
+		return err
+	}
 
 	if err := r.dec.Error(); err != nil {
 		r.dec.ResetError()
@@ -286,7 +527,11 @@ func (r *Reader) IterateParts(ctx context.Context, fn func(kind PartKind, attrs
 	}
 	if lastErrors.onlyWarnings {
 		for _, err := range lastErrors.errs {
-			r.logger.LogAttrs(ctx, slog.LevelWarn, err.Error())
+			if r.warningHandler != nil {
+				r.warningHandler(ctx, err)
+			} else {
+				r.logger.LogAttrs(ctx, slog.LevelWarn, err.Error())
+			}
 		}
 		return nil
 	}
@@ -304,6 +549,8 @@ type Writer struct {
 	sv     map[string]string
 	svSent bool
 
+	holdCursorsOverCommit bool
+
 	// reuse header
 	mh *messageHeader
 	sh *segmentHeader
@@ -324,6 +571,9 @@ func NewWriter(wr *bufio.Writer, enc *encoding.Encoder, protTrace bool, logger *
 	}
 }
 
+// SetHoldCursorsOverCommit sets the hold cursors over commit command option for messages which might open a resultset.
+func (w *Writer) SetHoldCursorsOverCommit(hold bool) { w.holdCursorsOverCommit = hold }
+
 const (
 	productVersionMajor  = 4
 	productVersionMinor  = 20
@@ -349,13 +599,51 @@ func (w *Writer) WriteProlog(ctx context.Context) error {
 	return w.wr.Flush()
 }
 
-func (w *Writer) _write(ctx context.Context, sessionID int64, messageType MessageType, commit bool, parts ...writablePart) error {
+func (w *Writer) _write(ctx context.Context, sessionID int64, messageType MessageType, commit bool, parts ...WritablePart) error {
+	// remove empty statement context parts and statement client info parts (merged with session variables)
+	var ci ClientInfo
+	parts = slices.DeleteFunc(parts, func(part WritablePart) bool {
+		switch part := part.(type) {
+		case *StatementContext:
+			return part.isEmpty()
+		case ClientInfo:
+			ci = part
+			return true
+		default:
+			return false
+		}
+	})
+
 	// check on session variables to be send as ClientInfo
 	if w.sv != nil && !w.svSent && messageType.ClientInfoSupported() {
-		parts = append([]writablePart{(*clientInfo)(&w.sv)}, parts...)
+		if len(ci) == 0 {
+			ci = w.sv
+		} else { // statement client info overrides session variables
+			ci = maps.Clone(ci)
+			for k, v := range w.sv {
+				if _, ok := ci[k]; !ok {
+					ci[k] = v
+				}
+			}
+		}
 		w.svSent = true
 	}
+	if len(ci) != 0 {
+		parts = append([]WritablePart{ci}, parts...)
+	}
 
+	w.sh.segmentKind = skRequest
+	w.sh.messageType = messageType
+	w.sh.commit = commit
+	w.sh.commandOptions = coNil
+	if w.holdCursorsOverCommit && messageType.resultsetSupported() {
+		w.sh.commandOptions = coHoldCursorOverCommtit
+	}
+	return w.writeMessage(ctx, prefixClient, sessionID, parts)
+}
+
+// writeMessage writes a message consisting of one segment (segment kind and options need to be set in w.sh) containing parts.
+func (w *Writer) writeMessage(ctx context.Context, prefix string, sessionID int64, parts []WritablePart) error {
 	numPart := len(parts)
 	partSize := make([]int, numPart)
 	size := int64(segmentHeaderSize + numPart*partHeaderSize) // int64 to hold MaxUInt32 in 32bit OS
@@ -381,16 +669,13 @@ func (w *Writer) _write(ctx context.Context, sessionID int64, messageType Messag
 		return err
 	}
 	if w.protTrace {
-		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textMsgHdr, w.mh.String()))
+		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefix+textMsgHdr, w.mh.String()))
 	}
 
 	if size > math.MaxInt32 {
 		return fmt.Errorf("message size %d exceeds maximum part header value %d", size, math.MaxInt32)
 	}
 
-	w.sh.messageType = messageType
-	w.sh.commit = commit
-	w.sh.segmentKind = skRequest
 	w.sh.segmentLength = int32(size)
 	w.sh.segmentOfs = 0
 	w.sh.noOfParts = int16(numPart)
@@ -400,7 +685,7 @@ func (w *Writer) _write(ctx context.Context, sessionID int64, messageType Messag
 		return err
 	}
 	if w.protTrace {
-		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textSegHdr, w.sh.String()))
+		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefix+textSegHdr, w.sh.String()))
 	}
 
 	bufferSize -= segmentHeaderSize
@@ -410,6 +695,10 @@ func (w *Writer) _write(ctx context.Context, sessionID int64, messageType Messag
 		pad := padBytes(size)
 
 		w.ph.partKind = part.kind()
+		w.ph.partAttributes = 0
+		if part, ok := part.(attributesPart); ok {
+			w.ph.partAttributes = part.attributes()
+		}
 		if err := w.ph.setNumArg(part.numArg()); err != nil {
 			return err
 		}
@@ -420,14 +709,14 @@ func (w *Writer) _write(ctx context.Context, sessionID int64, messageType Messag
 			return err
 		}
 		if w.protTrace {
-			w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textParHdr, w.ph.String()))
+			w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefix+textParHdr, w.ph.String()))
 		}
 
 		if err := part.encode(w.enc); err != nil {
 			return err
 		}
 		if w.protTrace {
-			w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixClient+textPar, part.String()))
+			w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefix+textPar, part.String()))
 		}
 
 		w.enc.Zeroes(pad)
@@ -437,7 +726,7 @@ func (w *Writer) _write(ctx context.Context, sessionID int64, messageType Messag
 	return w.wr.Flush()
 }
 
-func (w *Writer) Write(ctx context.Context, sessionID int64, messageType MessageType, commit bool, parts ...writablePart) error {
+func (w *Writer) Write(ctx context.Context, sessionID int64, messageType MessageType, commit bool, parts ...WritablePart) error {
 	if err := w._write(ctx, sessionID, messageType, commit, parts...); err != nil {
 		return errors.Join(err, driver.ErrBadConn)
 	}
@@ -0,0 +1,624 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestWriterEmptyStatementContext(t *testing.T) {
+	buf := new(bytes.Buffer)
+	wr := bufio.NewWriter(buf)
+	w := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, slog.Default(), cesu8.DefaultEncoder, nil)
+
+	tests := []struct {
+		sc       *StatementContext
+		numParts int16
+	}{
+		{nil, 1},
+		{&StatementContext{}, 1},
+		{func() *StatementContext { sc := &StatementContext{}; sc.SetQueryTimeout(1); return sc }(), 2},
+	}
+
+	for _, test := range tests {
+		if err := w.Write(context.Background(), 0, MtExecuteDirect, false, Command("select 1 from dummy"), test.sc); err != nil {
+			t.Fatal(err)
+		}
+		if w.sh.noOfParts != test.numParts {
+			t.Fatalf("number of parts %d - expected %d", w.sh.noOfParts, test.numParts)
+		}
+	}
+}
+
+func TestInputParametersPreencode(t *testing.T) {
+	fields := []*ParameterField{{tc: tcInteger, mode: pmIn}, {tc: tcNvarchar, mode: pmIn}}
+	nvargs := []driver.NamedValue{{Ordinal: 1, Value: int64(42)}, {Ordinal: 2, Value: "preencode"}}
+
+	write := func(prms *InputParameters) []byte {
+		buf := new(bytes.Buffer)
+		wr := bufio.NewWriter(buf)
+		w := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, slog.Default(), cesu8.DefaultEncoder, nil)
+		if err := w.Write(context.Background(), 0, MtExecute, false, prms); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	prms, err := NewInputParameters(fields, nvargs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := write(prms)
+
+	if err := prms.Preencode(cesu8.DefaultEncoder, nil, encoding.RoundHalfUp); err != nil {
+		t.Fatal(err)
+	}
+	if pb := write(prms); !bytes.Equal(pb, b) {
+		t.Fatalf("pre-encoded message %v - expected %v", pb, b)
+	}
+}
+
+func TestWriterHoldCursorsOverCommit(t *testing.T) {
+	buf := new(bytes.Buffer)
+	wr := bufio.NewWriter(buf)
+	w := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, slog.Default(), cesu8.DefaultEncoder, nil)
+	w.SetHoldCursorsOverCommit(true)
+
+	tests := []struct {
+		messageType    MessageType
+		commandOptions commandOptions
+	}{
+		{MtExecuteDirect, coHoldCursorOverCommtit},
+		{MtCommit, coNil},
+	}
+
+	for _, test := range tests {
+		if err := w.Write(context.Background(), 0, test.messageType, false, Command("select 1 from dummy")); err != nil {
+			t.Fatal(err)
+		}
+		if w.sh.commandOptions != test.commandOptions {
+			t.Fatalf("message type %s: command options %s - expected %s", test.messageType, w.sh.commandOptions, test.commandOptions)
+		}
+	}
+}
+
+func TestResultsetMaxRows(t *testing.T) {
+	const numRow = 5
+
+	// encoded integer rows (not null indicator + int32)
+	buf := new(bytes.Buffer)
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		enc.Byte(1)
+		enc.Int32(int32(i))
+	}
+	data := buf.Bytes()
+
+	rs := &Resultset{ResultFields: []*ResultField{{tc: tcInteger}}, MaxRows: 2}
+	if err := rs.decodeNumArgBufLen(encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder), numRow, len(data)); err != nil {
+		t.Fatal(err)
+	}
+	if rs.NumRows() != numRow {
+		t.Fatalf("number of rows %d - expected %d", rs.NumRows(), numRow)
+	}
+
+	var values []driver.Value
+	for {
+		if len(rs.FieldValues) > rs.MaxRows {
+			t.Fatalf("number of decoded rows %d exceeds %d", len(rs.FieldValues), rs.MaxRows)
+		}
+		values = append(values, rs.FieldValues...)
+		ok, err := rs.DecodeNext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+	}
+	if len(values) != numRow {
+		t.Fatalf("number of values %d - expected %d", len(values), numRow)
+	}
+	for i, v := range values {
+		if v != int64(i) {
+			t.Fatalf("value %v - expected %d", v, i)
+		}
+	}
+}
+
+func TestResultsetColumnar(t *testing.T) {
+	const numRow = 5
+
+	// integer, double and boolean column - every third row is NULL
+	buf := new(bytes.Buffer)
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		if i%3 == 0 {
+			enc.Byte(0)
+			enc.Uint64(^uint64(0))
+			enc.Byte(1)
+			continue
+		}
+		enc.Byte(1)
+		enc.Int32(int32(i))
+		enc.Float64(float64(i) / 2)
+		enc.Byte(2)
+	}
+	data := buf.Bytes()
+
+	rs := &Resultset{ResultFields: []*ResultField{{tc: tcInteger}, {tc: tcDouble}, {tc: tcBoolean}}, MaxRows: 2, Columnar: true}
+	if err := rs.decodeNumArgBufLen(encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder), numRow, len(data)); err != nil {
+		t.Fatal(err)
+	}
+
+	row := 0
+	for {
+		if rs.FieldValues != nil {
+			t.Fatal("field values decoded in columnar mode")
+		}
+		for i := 0; i < rs.NumColumnRows(); i++ {
+			valid := row%3 != 0
+			for j, c := range rs.Columns {
+				if c.Valid[i] != valid {
+					t.Fatalf("row %d column %d: valid %t - expected %t", row, j, c.Valid[i], valid)
+				}
+			}
+			if !valid {
+				row++
+				continue
+			}
+			if v := rs.Columns[0].Int64s[i]; v != int64(row) {
+				t.Fatalf("row %d: integer value %d - expected %d", row, v, row)
+			}
+			if v := rs.Columns[1].Float64s[i]; v != float64(row)/2 {
+				t.Fatalf("row %d: double value %f - expected %f", row, v, float64(row)/2)
+			}
+			if v := rs.Columns[2].Values[i]; v != true {
+				t.Fatalf("row %d: boolean value %v - expected true", row, v)
+			}
+			row++
+		}
+		ok, err := rs.DecodeNext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+	}
+	if row != numRow {
+		t.Fatalf("number of rows %d - expected %d", row, numRow)
+	}
+}
+
+func TestResultsetColumnarAllocs(t *testing.T) {
+	const numRow = 100
+
+	// integer, double and fixed8 column
+	buf := new(bytes.Buffer)
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		enc.Byte(1)
+		enc.Int64(int64(i) << 40)
+		enc.Float64(float64(i))
+		enc.Byte(1)
+		enc.Int64(int64(i) * 1000)
+	}
+	data := buf.Bytes()
+
+	rs := &Resultset{ResultFields: []*ResultField{{tc: tcBigint}, {tc: tcDouble}, {tc: tcFixed8, scale: 3}}, Columnar: true}
+	rd := bytes.NewReader(data)
+	dec := encoding.NewDecoder(rd, cesu8.DefaultDecoder)
+	decode := func() {
+		rd.Reset(data)
+		if err := rs.decodeNumArg(dec, numRow); err != nil {
+			t.Fatal(err)
+		}
+	}
+	decode() // grow buffers
+
+	if allocs := testing.AllocsPerRun(10, decode); allocs != 0 {
+		t.Fatalf("number of allocations %f - expected 0", allocs)
+	}
+	for i := 0; i < numRow; i++ {
+		if v := rs.Columns[2].Mantissas[i].Int64(); v != int64(i)*1000 || rs.Columns[2].Exponents[i] != -3 {
+			t.Fatalf("row %d: decimal value %de%d - expected %de%d", i, v, rs.Columns[2].Exponents[i], i*1000, -3)
+		}
+	}
+}
+
+func TestResultsetReuse(t *testing.T) {
+	const numRow = 10
+
+	buf := new(bytes.Buffer)
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		enc.Byte(1)
+		enc.Int32(int32(i))
+	}
+	data := buf.Bytes()
+
+	rs := &Resultset{ResultFields: []*ResultField{{tc: tcInteger}}, MaxRows: 4}
+	rd := bytes.NewReader(data)
+	dec := encoding.NewDecoder(rd, cesu8.DefaultDecoder)
+	decode := func() {
+		rd.Reset(data)
+		if err := rs.decodeNumArgBufLen(dec, numRow, len(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	decode()
+	retainedBytes := rs.RetainedBytes()
+	if retainedBytes < len(data) {
+		t.Fatalf("retained bytes %d - expected at least %d", retainedBytes, len(data))
+	}
+	// decoding the next resultset part reuses the buffers
+	if allocs := testing.AllocsPerRun(10, decode); allocs > 2 { // sub decoder
+		t.Fatalf("number of allocations %f - expected buffer reuse", allocs)
+	}
+	if rs.RetainedBytes() != retainedBytes {
+		t.Fatalf("retained bytes %d - expected %d", rs.RetainedBytes(), retainedBytes)
+	}
+}
+
+func TestResultsetColumnarConversion(t *testing.T) {
+	const numRow = 20
+
+	fields := []*ResultField{{tc: tcDaydate}, {tc: tcLongdate}, {tc: tcFixed8, scale: 2}, {tc: tcSecondtime}}
+
+	buf := new(bytes.Buffer)
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	for i := 0; i < numRow; i++ {
+		enc.Int32(int32(738000 + i))         // daydate
+		enc.Int64(int64(i)*864000000000 + 1) // longdate
+		enc.Byte(1)                          // fixed8 not null
+		enc.Int64(int64(i) * 1234)           // fixed8 mantissa
+		enc.Int32(int32(i*60 + 1))           // secondtime
+	}
+	data := buf.Bytes()
+
+	// row-wise reference values
+	dec := encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder)
+	expected := make([]driver.Value, 0, numRow*len(fields))
+	for i := 0; i < numRow; i++ {
+		for _, f := range fields {
+			v, err := f.decodeResult(dec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected = append(expected, v)
+		}
+	}
+
+	for _, parallelism := range []int{0, 4} {
+		rs := &Resultset{ResultFields: fields, Columnar: true, parallelism: parallelism}
+		if err := rs.decodeNumArg(encoding.NewDecoder(bytes.NewReader(data), cesu8.DefaultDecoder), numRow); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < numRow; i++ {
+			for j, c := range rs.Columns {
+				var v any
+				switch {
+				case c.Times != nil:
+					v = c.Times[i]
+				default:
+					v = &c.Rats[i]
+				}
+				e := expected[i*len(fields)+j]
+				switch e := e.(type) {
+				case time.Time:
+					if !e.Equal(v.(time.Time)) {
+						t.Fatalf("parallelism %d row %d column %d: value %v - expected %v", parallelism, i, j, v, e)
+					}
+				case *big.Rat:
+					if e.Cmp(v.(*big.Rat)) != 0 {
+						t.Fatalf("parallelism %d row %d column %d: value %v - expected %v", parallelism, i, j, v, e)
+					}
+				default:
+					t.Fatalf("unexpected value type %T", e)
+				}
+			}
+		}
+	}
+}
+
+func TestPartGroup(t *testing.T) {
+	const limit = 2
+
+	g := newPartGroup(limit)
+	var mu sync.Mutex
+	active, maxActive := 0, 0
+	errTest := errors.New("test error")
+	for i := 0; i < 10; i++ {
+		i := i
+		g.do(func() error {
+			mu.Lock()
+			active++
+			maxActive = max(maxActive, active)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+			if i == 5 {
+				return errTest
+			}
+			return nil
+		})
+	}
+	if err := g.wait(); !errors.Is(err, errTest) {
+		t.Fatalf("error %v - expected %v", err, errTest)
+	}
+	if maxActive > limit {
+		t.Fatalf("number of parallel goroutines %d - expected at most %d", maxActive, limit)
+	}
+}
+
+func TestReaderDecodeParallel(t *testing.T) {
+	const (
+		numPart = 6
+		numRow  = 50
+	)
+
+	names := &fieldNames{}
+	resultFields := []*ResultField{{names: names, tc: tcNvarchar}, {names: names, tc: tcAlphanum, prec: 5}, {names: names, tc: tcDaydate}}
+	outputFields := []*ParameterField{{names: names, tc: tcNvarchar}, {names: names, tc: tcDaydate}}
+
+	encodeRow := func(enc *encoding.Encoder, i int) {
+		s := fmt.Sprintf("value %d", i%3)
+		enc.Byte(byte(len(s))) // nvarchar
+		enc.Bytes([]byte(s))
+		enc.Byte(3) // alphanum: length, indicator (numeric, field size 5), digits
+		enc.Byte(0x80 | 5)
+		enc.Bytes([]byte("12"))
+		enc.Int32(0) // empty daydate
+	}
+
+	parts := make([]Part, 0, numPart+1)
+	for k := 0; k < numPart; k++ {
+		part, err := newReplyPart(PkResultset, 0, numRow, func(enc *encoding.Encoder) error {
+			for i := 0; i < numRow; i++ {
+				encodeRow(enc, i)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, part)
+	}
+	part, err := newReplyPart(PkOutputParameters, 0, 1, func(enc *encoding.Encoder) error {
+		enc.Byte(7)
+		enc.Bytes([]byte("value 0"))
+		enc.Int32(0)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts = append(parts, part)
+
+	for _, parallelism := range []int{0, 4} {
+		buf := new(bytes.Buffer)
+		wr := bufio.NewWriter(buf)
+		w := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, slog.Default(), cesu8.DefaultEncoder, nil)
+		if err := w.WriteReply(context.Background(), 0, fcDBProcedureCall, parts...); err != nil {
+			t.Fatal(err)
+		}
+
+		// non default decoder options need to be applied to the sub decoders of parallel decoded parts
+		interner := encoding.NewStringInterner(10)
+		dec := encoding.NewDecoder(buf, cesu8.DefaultDecoder)
+		dec.SetStringInterner(interner)
+		dec.SetAlphanumPadding(true)
+		dec.SetEmptyDateAsNull(true)
+
+		logBuf := new(bytes.Buffer)
+		r := NewDBReader(dec, true, slog.New(slog.NewTextHandler(logBuf, nil)))
+		r.SetDecodeParallelism(parallelism)
+
+		var resultsets []*Resultset
+		outputParameters := &OutputParameters{OutputFields: outputFields}
+		if err := r.IteratePartsParallel(context.Background(), func(kind PartKind, attrs PartAttributes, read func(part Part)) {
+			switch kind {
+			case PkResultset:
+				rs := &Resultset{ResultFields: resultFields}
+				read(rs)
+				resultsets = append(resultsets, rs)
+			case PkOutputParameters:
+				read(outputParameters)
+			}
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(resultsets) != numPart {
+			t.Fatalf("parallelism %d: number of resultsets %d - expected %d", parallelism, len(resultsets), numPart)
+		}
+		for k, rs := range resultsets {
+			for i := 0; i < numRow; i++ {
+				row := rs.FieldValues[i*len(resultFields) : (i+1)*len(resultFields)]
+				if s, e := row[0], fmt.Sprintf("value %d", i%3); s != e {
+					t.Fatalf("parallelism %d part %d row %d: value %v - expected %s", parallelism, k, i, s, e)
+				}
+				if b, e := string(row[1].([]byte)), "00012"; b != e {
+					t.Fatalf("parallelism %d part %d row %d: alphanum value %s - expected %s", parallelism, k, i, b, e)
+				}
+				if row[2] != nil {
+					t.Fatalf("parallelism %d part %d row %d: date value %v - expected nil", parallelism, k, i, row[2])
+				}
+			}
+		}
+		if v := outputParameters.FieldValues; v[0] != "value 0" || v[1] != nil {
+			t.Fatalf("parallelism %d: output parameter values %v - expected [value 0 <nil>]", parallelism, v)
+		}
+		if n := interner.Len(); n != 3 {
+			t.Fatalf("parallelism %d: number of interned values %d - expected %d", parallelism, n, 3)
+		}
+		if n := strings.Count(logBuf.String(), prefixDB+textPar+"="); n != numPart+1 {
+			t.Fatalf("parallelism %d: number of traced parts %d - expected %d", parallelism, n, numPart+1)
+		}
+	}
+}
+
+func TestParameterFieldCheckLength(t *testing.T) {
+	tests := []struct {
+		field     *ParameterField
+		v         any
+		length    int
+		maxLength int
+		ok        bool
+	}{
+		{&ParameterField{tc: tcVarbinary, prec: 4}, []byte{1, 2, 3, 4}, 4, 4, true},
+		{&ParameterField{tc: tcVarbinary, prec: 4}, []byte{1, 2, 3, 4, 5}, 5, 4, false},
+		{&ParameterField{tc: tcVarchar, prec: 3}, "abcd", 4, 3, false},
+		{&ParameterField{tc: tcNvarchar, prec: 3}, "äöü", 3, 3, true},
+		{&ParameterField{tc: tcNvarchar, prec: 3}, "ä😀", 3, 3, true}, // surrogate pair
+		{&ParameterField{tc: tcNvarchar, prec: 2}, "ä😀", 3, 2, false},
+		{&ParameterField{tc: tcNvarchar}, "no length", 0, 0, true},
+		{&ParameterField{tc: tcInteger, prec: 1}, int64(42), 0, 0, true},
+	}
+
+	for i, test := range tests {
+		length, maxLength, ok := test.field.CheckLength(test.v)
+		if length != test.length || maxLength != test.maxLength || ok != test.ok {
+			t.Fatalf("test %d: length %d maxLength %d ok %t - expected %d %d %t", i, length, maxLength, ok, test.length, test.maxLength, test.ok)
+		}
+	}
+}
+
+func TestReaderStatementContext(t *testing.T) {
+	buf := new(bytes.Buffer)
+	wr := bufio.NewWriter(buf)
+	w := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, slog.Default(), cesu8.DefaultEncoder, nil)
+	r := NewDBReader(encoding.NewDecoder(buf, cesu8.DefaultDecoder), false, slog.Default())
+
+	sc := &StatementContext{}
+	sc.options.set(scServerProcessingTime, int64(1500))
+	sc.options.set(scServerCPUTime, int64(1000))
+	sc.options.set(scServerMemoryUsage, int64(4096))
+
+	tests := []struct {
+		sc  *StatementContext
+		d   time.Duration
+		cpu time.Duration
+		mem int64
+	}{
+		{sc, 1500 * time.Microsecond, time.Millisecond, 4096},
+		{nil, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		if err := w.Write(context.Background(), 0, MtExecuteDirect, false, Command("select 1 from dummy"), test.sc); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.SkipParts(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		rsc := r.StatementContext()
+		if (rsc == nil) != (test.sc == nil) {
+			t.Fatalf("statement context %v - expected %v", rsc, test.sc)
+		}
+		if d := rsc.ServerProcessingTimeOrZero(); d != test.d {
+			t.Fatalf("server processing time %s - expected %s", d, test.d)
+		}
+		if cpu := rsc.ServerCPUTimeOrZero(); cpu != test.cpu {
+			t.Fatalf("server cpu time %s - expected %s", cpu, test.cpu)
+		}
+		if mem := rsc.ServerMemoryUsageOrZero(); mem != test.mem {
+			t.Fatalf("server memory usage %d - expected %d", mem, test.mem)
+		}
+	}
+}
+
+func TestReaderTransactionFlags(t *testing.T) {
+	buf := new(bytes.Buffer)
+	wr := bufio.NewWriter(buf)
+	w := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, slog.Default(), cesu8.DefaultEncoder, nil)
+	r := NewDBReader(encoding.NewDecoder(buf, cesu8.DefaultDecoder), false, slog.Default())
+
+	var rtf *TransactionFlags
+	r.SetTransactionFlagsHandler(func(tf *TransactionFlags) { rtf = tf })
+
+	tf := &TransactionFlags{}
+	tf.options.set(tfWriteTransactionStarted, true)
+	tf.options.set(tfDDLCommitmodeChanged, false)
+
+	if err := w.Write(context.Background(), 0, MtExecuteDirect, false, Command("insert into t values (1)"), tf); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SkipParts(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if rtf == nil {
+		t.Fatal("transaction flags handler not called")
+	}
+	if !rtf.WriteTransactionStartedOrZero() || rtf.CommittedOrZero() || rtf.RolledBackOrZero() {
+		t.Fatalf("transaction flags %v", rtf)
+	}
+	if ddlAutocommit, ok := rtf.DDLCommitMode(); !ok || ddlAutocommit {
+		t.Fatalf("ddl commit mode %t %t - expected %t %t", ddlAutocommit, ok, false, true)
+	}
+}
+
+// malformedMessage returns a reply message containing one part of kind with random numArg and data.
+func malformedMessage(rnd *rand.Rand, kind PartKind) []byte {
+	data := make([]byte, rnd.Intn(64))
+	rnd.Read(data)
+
+	buf := new(bytes.Buffer)
+	enc := encoding.NewEncoder(buf, cesu8.DefaultEncoder)
+	size := segmentHeaderSize + partHeaderSize + len(data)
+	mh := &messageHeader{varPartLength: uint32(size), varPartSize: uint32(size), noOfSegm: 1}
+	sh := &segmentHeader{segmentLength: int32(size), noOfParts: 1, segmentNo: 1, segmentKind: skReply}
+	ph := &partHeader{partKind: kind, argumentCount: int16(rnd.Intn(8)), bufferLength: int32(len(data)), bufferSize: int32(len(data))}
+	mh.encode(enc) //nolint:errcheck
+	sh.encode(enc) //nolint:errcheck
+	ph.encode(enc) //nolint:errcheck
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func TestReaderMalformedParts(t *testing.T) {
+	const numMessage = 200
+
+	newPart := func(kind PartKind) Part {
+		if kind == PkResultset {
+			return &Resultset{ResultFields: []*ResultField{{tc: tcInteger}, {tc: tcDecimal}, {tc: tcFixed16, scale: -1}, {tc: tcNvarchar}, {tc: tcLongdate}, {tc: typeCode(99)}}}
+		}
+		return newGenPartReader(kind)
+	}
+
+	rnd := rand.New(rand.NewSource(42)) //nolint:gosec
+	for kind := range genPartTypeMap {
+		for i := 0; i < numMessage; i++ {
+			msg := malformedMessage(rnd, kind)
+			r := NewDBReader(encoding.NewDecoder(bytes.NewReader(msg), cesu8.DefaultDecoder), false, slog.Default())
+			r.SetWarningHandler(func(ctx context.Context, err *HdbError) {})
+			// must not panic
+			err := r.IterateParts(context.Background(), func(kind PartKind, attrs PartAttributes, read func(part Part)) {
+				if part := newPart(kind); part != nil {
+					read(part)
+				}
+			})
+			var hdbErrors *HdbErrors
+			if err != nil && !errors.Is(err, ErrProtocol) && !errors.As(err, &hdbErrors) {
+				t.Fatalf("kind %s message %d: unexpected error %v", kind, i, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package protocol
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"slices"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+// Raw protocol messages (record and replay of protocol sessions).
+
+// Sizes of the protocol prolog.
+const (
+	InitRequestSize = 14 // initial client request
+	InitReplySize   = 8  // initial database reply
+)
+
+const (
+	messageHeaderSize = 32
+	varPartLengthOfs  = 12 // offset of the variable part length in the message header
+)
+
+// ReadRawMessage reads a complete protocol message (message header and variable part) from rd.
+func ReadRawMessage(rd io.Reader) ([]byte, error) {
+	b := make([]byte, messageHeaderSize)
+	if _, err := io.ReadFull(rd, b); err != nil {
+		return nil, err
+	}
+	varPartLength := int(binary.LittleEndian.Uint32(b[varPartLengthOfs:]))
+	b = slices.Grow(b, varPartLength)[:messageHeaderSize+varPartLength]
+	if _, err := io.ReadFull(rd, b[messageHeaderSize:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// Handshake exchanges the protocol prolog with the database server via rw, e.g. for probing
+// whether a server is ready to accept connections.
+func Handshake(ctx context.Context, rw io.ReadWriter) error {
+	wr := bufio.NewWriter(rw)
+	pw := NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, discardLogger, cesu8.DefaultEncoder, nil)
+	if err := pw.WriteProlog(ctx); err != nil {
+		return err
+	}
+	return NewDBReader(encoding.NewDecoder(rw, cesu8.DefaultDecoder), false, discardLogger).ReadProlog(ctx)
+}
+
+// RawMessageInfo are the statement related attributes of a raw protocol message.
+type RawMessageInfo struct {
+	MessageType MessageType // request only
+	Command     string
+	StatementID uint64
+	ResultsetID uint64
+}
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func parseRawMessage(rd *Reader) *RawMessageInfo {
+	info := &RawMessageInfo{}
+	// database errors are returned by IterateParts after reading all parts - ignore
+	rd.IterateParts(context.Background(), func(kind PartKind, attrs PartAttributes, read func(part Part)) { //nolint:errcheck
+		switch kind {
+		case PkCommand:
+			var cmd Command
+			read(&cmd)
+			info.Command = string(cmd)
+		case PkStatementID:
+			read((*StatementID)(&info.StatementID))
+		case PkResultsetID:
+			read((*ResultsetID)(&info.ResultsetID))
+		}
+	})
+	return info
+}
+
+// ParseRawRequest returns the statement related attributes of the raw client request b.
+func ParseRawRequest(b []byte) *RawMessageInfo {
+	rd := NewClientReader(encoding.NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder), false, discardLogger)
+	info := parseRawMessage(rd)
+	info.MessageType = rd.MessageType()
+	return info
+}
+
+// ParseRawReply returns the statement related attributes of the raw database reply b.
+func ParseRawReply(b []byte) *RawMessageInfo {
+	return parseRawMessage(NewDBReader(encoding.NewDecoder(bytes.NewReader(b), cesu8.DefaultDecoder), false, discardLogger))
+}
@@ -1,11 +1,9 @@
 package protocol
 
+import "slices"
+
+// resizeSlice returns a slice of length n reusing the memory of s if possible.
+// In contrast to append the content of s is not copied in case the slice needs to grow.
 func resizeSlice[S ~[]E, E any](s S, n int) S {
-	switch {
-	case s == nil:
-		s = make(S, n)
-	case n > cap(s):
-		s = append(s, make(S, n-len(s))...)
-	}
-	return s[:n]
+	return slices.Grow(s[:0], n)[:n]
 }
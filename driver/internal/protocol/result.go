@@ -1,9 +1,11 @@
 package protocol
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"unsafe"
 
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
 )
@@ -80,14 +82,12 @@ func (f *ResultField) TypeName() string { return f.tc.typeName() }
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeScanType
 func (f *ResultField) ScanType() reflect.Type { return f.tc.dataType().ScanType(f.Nullable()) }
 
+// DataType returns the data type of the field.
+func (f *ResultField) DataType() DataType { return f.tc.dataType() }
+
 // TypeLength returns the type length of the field.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeLength
-func (f *ResultField) TypeLength() (int64, bool) {
-	if f.tc.isVariableLength() {
-		return int64(f.prec), true
-	}
-	return 0, false
-}
+func (f *ResultField) TypeLength() (int64, bool) { return f.tc.typeLength(f.prec) }
 
 // TypePrecisionScale returns the type precision and scale (decimal types) of the field.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypePrecisionScale
@@ -105,6 +105,15 @@ func (f *ResultField) Nullable() bool { return f.columnOptions == coOptional }
 // Name returns the result field name.
 func (f *ResultField) Name() string { return f.names.name(f.columnDisplayNameOfs) }
 
+// SchemaName returns the schema name of the table the field originates from (empty if not provided).
+func (f *ResultField) SchemaName() string { return f.names.name(f.schemaNameOfs) }
+
+// TableName returns the name of the table the field originates from (empty if not provided).
+func (f *ResultField) TableName() string { return f.names.name(f.tableNameOfs) }
+
+// ColumnName returns the name of the table column the field originates from (empty if not provided).
+func (f *ResultField) ColumnName() string { return f.names.name(f.columnNameOfs) }
+
 func (f *ResultField) decode(dec *encoding.Decoder) {
 	f.columnOptions = columnOptions(dec.Int8())
 	f.tc = typeCode(dec.Int8())
@@ -154,13 +163,143 @@ type Resultset struct {
 	ResultFields []*ResultField
 	FieldValues  []driver.Value
 	DecodeErrors DecodeErrors
+	// MaxRows limits the number of decoded rows (0: no limit). In case a resultset part contains more rows,
+	// the encoded rows are kept and decoded in chunks of at most MaxRows rows (see DecodeNext).
+	MaxRows int
+	// Columnar decodes the rows into Columns instead of FieldValues.
+	Columnar bool
+	Columns  []Column
+	// Interner interns the values of character fields (nil: no interning).
+	Interner *encoding.StringInterner
+	// MaxBytes limits the number of bytes of the encoded and decoded rows buffered by the resultset (0: no limit).
+	// In case the limit is exceeded the rows are dropped (see Exceeded).
+	MaxBytes int
+
+	// parallelism is the maximum number of goroutines converting columns in columnar mode (<= 1: sequential conversion).
+	// It is set by the reader (see Reader.SetDecodeParallelism).
+	parallelism int
+
+	buf    []byte // encoded rows
+	rd     *bytes.Reader
+	dec    *encoding.Decoder
+	numRow int // number of encoded rows not decoded yet
+	numArg int // number of rows of the resultset part
+
+	numColumnRow int // number of rows decoded into Columns
+
+	exceeded bool // MaxBytes exceeded by the last read resultset part
 }
 
 func (r *Resultset) String() string {
 	return fmt.Sprintf("result fields %v field values %v", r.ResultFields, r.FieldValues)
 }
 
+// RetainedBytes returns the number of bytes of the decoding buffers retained by the resultset
+// for reuse by subsequent resultset parts.
+func (r *Resultset) RetainedBytes() int {
+	n := cap(r.buf) + cap(r.FieldValues)*int(unsafe.Sizeof(driver.Value(nil)))
+	for _, c := range r.Columns {
+		n += c.retainedBytes()
+	}
+	return n
+}
+
+// NumRows returns the number of rows of the last read resultset part.
+func (r *Resultset) NumRows() int { return r.numArg }
+
+// Exceeded returns true if the rows of the last read resultset part exceeded MaxBytes, false otherwise.
+func (r *Resultset) Exceeded() bool { return r.exceeded }
+
+// valueBytes returns the number of bytes of the variable length field values.
+func valueBytes[T any](values []T) int {
+	n := 0
+	for _, v := range values {
+		switch v := any(v).(type) {
+		case string:
+			n += len(v)
+		case []byte:
+			n += len(v)
+		}
+	}
+	return n
+}
+
+// checkMaxBytes drops the decoded rows in case they exceed MaxBytes.
+func (r *Resultset) checkMaxBytes() {
+	if r.MaxBytes <= 0 {
+		return
+	}
+	n := r.RetainedBytes() + valueBytes(r.FieldValues)
+	for _, c := range r.Columns {
+		n += valueBytes(c.Values)
+	}
+	if n > r.MaxBytes {
+		r.drop()
+	}
+}
+
+// drop drops the rows of the resultset part marking MaxBytes as exceeded.
+func (r *Resultset) drop() {
+	r.exceeded = true
+	r.numRow, r.numArg, r.numColumnRow = 0, 0, 0
+	clear(r.FieldValues) // release values
+	r.FieldValues = r.FieldValues[:0]
+	r.DecodeErrors = r.DecodeErrors[:0]
+}
+
+func (r *Resultset) decodeNumArgBufLen(dec *encoding.Decoder, numArg, bufLen int) error {
+	r.numRow, r.numArg = 0, numArg
+	r.DecodeErrors = r.DecodeErrors[:0]
+	r.exceeded = false
+	if r.MaxBytes > 0 && bufLen > r.MaxBytes { // fail fast: the encoded rows exceed the limit already - skip decoding
+		r.drop()
+		return nil
+	}
+	if r.MaxRows <= 0 || numArg <= r.MaxRows {
+		err := r.decodeNumArg(dec, numArg)
+		r.checkMaxBytes()
+		return err
+	}
+
+	r.buf = resizeSlice(r.buf, bufLen)
+	dec.Bytes(r.buf)
+	if err := dec.Error(); err != nil {
+		return err
+	}
+	if r.rd == nil {
+		r.rd = bytes.NewReader(r.buf)
+	} else {
+		r.rd.Reset(r.buf)
+	}
+	r.dec = dec.SubDecoder(r.rd)
+	r.dec.SetLimit(bufLen)
+	r.numRow = numArg
+	_, err := r.DecodeNext()
+	return err
+}
+
+// DecodeNext decodes the next chunk of at most MaxRows encoded rows into FieldValues (Columns).
+// It returns false if all rows of the resultset part are decoded already.
+func (r *Resultset) DecodeNext() (bool, error) {
+	if r.numRow == 0 {
+		return false, nil
+	}
+	numArg := min(r.numRow, r.MaxRows)
+	r.numRow -= numArg
+	r.DecodeErrors = nil
+	err := r.decodeNumArg(r.dec, numArg)
+	r.checkMaxBytes()
+	return true, err
+}
+
 func (r *Resultset) decodeNumArg(dec *encoding.Decoder, numArg int) error {
+	if r.Interner != nil {
+		dec.SetStringInterner(r.Interner)
+		defer dec.SetStringInterner(nil)
+	}
+	if r.Columnar {
+		return r.decodeColumns(dec, numArg)
+	}
 	cols := len(r.ResultFields)
 	r.FieldValues = resizeSlice(r.FieldValues, numArg*cols)
 
@@ -0,0 +1,530 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/auth"
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+/*
+Server side protocol support.
+
+The functions and types of this file encode database server replies and are used by the mock server of
+package hdbtest. Only the subset of the protocol needed to serve the driver is supported.
+*/
+
+// attributesPart is implemented by parts setting part attributes.
+type attributesPart interface {
+	WritablePart
+	attributes() PartAttributes
+}
+
+// WriteInitReply writes the reply to the protocol prolog of a client.
+func (w *Writer) WriteInitReply(ctx context.Context) error {
+	rep := &initReply{}
+	rep.product.major = productVersionMajor
+	rep.product.minor = productVersionMinor
+	rep.protocol.major = protocolVersionMajor
+	rep.protocol.minor = protocolVersionMinor
+	if err := rep.encode(w.enc); err != nil {
+		return err
+	}
+	if w.protTrace {
+		w.logger.LogAttrs(ctx, slog.LevelInfo, traceMsg, slog.String(prefixDB+textIni, rep.String()))
+	}
+	return w.wr.Flush()
+}
+
+// WriteReply writes a reply message with function code fc.
+func (w *Writer) WriteReply(ctx context.Context, sessionID int64, fc FunctionCode, parts ...Part) error {
+	writableParts := make([]WritablePart, len(parts))
+	for i, part := range parts {
+		WritablePart, ok := part.(WritablePart)
+		if !ok {
+			return fmt.Errorf("part kind %s cannot be written", part.kind())
+		}
+		writableParts[i] = WritablePart
+	}
+	w.sh.segmentKind = skReply
+	w.sh.functionCode = fc
+	return w.writeMessage(ctx, prefixDB, sessionID, writableParts)
+}
+
+// WriteErrorReply writes an error reply message containing a single database error.
+func (w *Writer) WriteErrorReply(ctx context.Context, sessionID int64, code int, text string) error {
+	part, err := errorPart(code, text)
+	if err != nil {
+		return err
+	}
+	w.sh.segmentKind = skError
+	return w.writeMessage(ctx, prefixDB, sessionID, []WritablePart{part})
+}
+
+var (
+	selectStmt = regexp.MustCompile(`(?i)^\s*(select|with)\s`)
+	insertStmt = regexp.MustCompile(`(?i)^\s*(insert|upsert|replace|merge)\s`)
+	updateStmt = regexp.MustCompile(`(?i)^\s*update\s`)
+	deleteStmt = regexp.MustCompile(`(?i)^\s*delete\s`)
+	callStmt   = regexp.MustCompile(`(?i)^\s*call\s`)
+	ddlStmt    = regexp.MustCompile(`(?i)^\s*(create|drop|alter|truncate|rename|comment|grant|revoke|set)\s`)
+)
+
+// ReplyFunctionCode returns the function code of the reply to a request of message type mt and sql statement query.
+func ReplyFunctionCode(mt MessageType, query string) FunctionCode {
+	switch mt {
+	case MtExecuteDirect, MtPrepare, MtExecute:
+		switch {
+		case selectStmt.MatchString(query):
+			return fcSelect
+		case insertStmt.MatchString(query):
+			return fcInsert
+		case updateStmt.MatchString(query):
+			return fcUpdate
+		case deleteStmt.MatchString(query):
+			return fcDelete
+		case callStmt.MatchString(query):
+			return fcDBProcedureCall
+		case ddlStmt.MatchString(query):
+			return FcDDL
+		default:
+			return fcNil
+		}
+	case MtAuthenticate, MtConnect:
+		return fcConnect
+	case MtCommit:
+		return fcCommit
+	case MtRollback:
+		return fcRollback
+	case MtFetchNext:
+		return fcFetch
+	case MtCloseResultset:
+		return fcCloseCursor
+	case MtReadLob:
+		return fcWriteLob
+	case MtWriteLob:
+		return fcReadLob
+	case MtDisconnect:
+		return fcDisconnect
+	default:
+		return fcNil
+	}
+}
+
+// Methods returns the logon name and the authentication method types offered by the client.
+func (r *AuthInitRequest) Methods() (string, []string) {
+	if r.prms == nil {
+		return "", nil
+	}
+	return r.prms.InitRequestMethods()
+}
+
+// ReplyPart is a part of a reply message (see WriteReply).
+type ReplyPart struct {
+	pk    PartKind
+	pa    PartAttributes
+	count int
+	buf   []byte
+}
+
+func newReplyPart(pk PartKind, pa PartAttributes, count int, encode func(enc *encoding.Encoder) error) (*ReplyPart, error) {
+	buf := new(bytes.Buffer)
+	if err := encode(encoding.NewEncoder(buf, cesu8.DefaultEncoder)); err != nil {
+		return nil, err
+	}
+	return &ReplyPart{pk: pk, pa: pa, count: count, buf: buf.Bytes()}, nil
+}
+
+func (p *ReplyPart) String() string {
+	return fmt.Sprintf("kind %s attributes %s numArg %d size %d", p.pk, p.pa, p.count, len(p.buf))
+}
+func (p *ReplyPart) kind() PartKind                     { return p.pk }
+func (p *ReplyPart) attributes() PartAttributes         { return p.pa }
+func (p *ReplyPart) numArg() int                        { return p.count }
+func (p *ReplyPart) size() int                          { return len(p.buf) }
+func (p *ReplyPart) encode(enc *encoding.Encoder) error { enc.Bytes(p.buf); return nil }
+
+// check if ReplyPart implements the attributesPart interface.
+var _ attributesPart = (*ReplyPart)(nil)
+
+// AuthInitReplyPart returns the initial authentication reply part of an authentication stub for method type mt.
+func AuthInitReplyPart(mt, logonname string) (*ReplyPart, error) {
+	prms, err := auth.StubInitReply(mt, logonname)
+	if err != nil {
+		return nil, err
+	}
+	return newReplyPart(PkAuthentication, 0, 1, prms.Encode)
+}
+
+// AuthFinalReplyPart returns the final authentication reply part of an authentication stub for method type mt.
+func AuthFinalReplyPart(mt string) (*ReplyPart, error) {
+	prms, err := auth.StubFinalReply(mt)
+	if err != nil {
+		return nil, err
+	}
+	return newReplyPart(PkAuthentication, 0, 1, prms.Encode)
+}
+
+func errorPart(code int, text string) (*ReplyPart, error) {
+	return newReplyPart(PkError, 0, 1, func(enc *encoding.Encoder) error {
+		enc.Int32(int32(code))
+		enc.Int32(0) // position
+		enc.Int32(int32(len(text)))
+		enc.Int8(int8(errorLevelError))
+		enc.String("HY000") // sql state
+		enc.String(text)
+		enc.Zeroes(1) // see HdbErrors.decodeNumArg (single error)
+		return nil
+	})
+}
+
+// RowsAffectedPart returns a rows affected part.
+func RowsAffectedPart(rows ...int64) (*ReplyPart, error) {
+	return newReplyPart(PkRowsAffected, 0, len(rows), func(enc *encoding.Encoder) error {
+		for _, n := range rows {
+			enc.Int32(int32(n))
+		}
+		return nil
+	})
+}
+
+// ReadLobReplyPart returns a read lob reply part containing the lob data b of lob id.
+func ReadLobReplyPart(id LocatorID, b []byte, last bool) (*ReplyPart, error) {
+	return newReplyPart(PkReadLobReply, 0, 1, func(enc *encoding.Encoder) error {
+		opt := loDataincluded
+		if last {
+			opt |= loLastdata
+		}
+		enc.Uint64(uint64(id))
+		enc.Int8(int8(opt))
+		enc.Int32(int32(len(b)))
+		enc.Zeroes(3)
+		enc.Bytes(b)
+		return nil
+	})
+}
+
+// serverTypeCodes maps the database type names supported by ServerField to type codes.
+var serverTypeCodes = map[string]typeCode{
+	"BOOLEAN":    tcBoolean,
+	"TINYINT":    tcTinyint,
+	"SMALLINT":   tcSmallint,
+	"INTEGER":    tcInteger,
+	"BIGINT":     tcBigint,
+	"REAL":       tcReal,
+	"DOUBLE":     tcDouble,
+	"DECIMAL":    tcDecimal,
+	"DATE":       tcDaydate,
+	"DAYDATE":    tcDaydate,
+	"TIME":       tcSecondtime,
+	"SECONDTIME": tcSecondtime,
+	"SECONDDATE": tcSeconddate,
+	"TIMESTAMP":  tcLongdate,
+	"LONGDATE":   tcLongdate,
+	"VARCHAR":    tcVarchar,
+	"NVARCHAR":   tcNvarchar,
+	"VARBINARY":  tcVarbinary,
+	"CLOB":       tcClob,
+	"NCLOB":      tcNclob,
+	"BLOB":       tcBlob,
+}
+
+// ServerField describes a result or parameter field of a server reply.
+type ServerField struct {
+	Name string
+	// TypeName is the database type name of the field (BOOLEAN, TINYINT, SMALLINT, INTEGER, BIGINT, REAL, DOUBLE, DECIMAL,
+	// DATE, TIME, SECONDDATE, TIMESTAMP, VARCHAR, NVARCHAR, VARBINARY, CLOB, NCLOB or BLOB).
+	TypeName string
+	Nullable bool
+	Length   int // length of variable length types respectively precision of decimals
+	Scale    int
+}
+
+func (f *ServerField) typeCode() (typeCode, error) {
+	tc, ok := serverTypeCodes[strings.ToUpper(f.TypeName)]
+	if !ok {
+		return 0, fmt.Errorf("field %s: type %s not supported", f.Name, f.TypeName)
+	}
+	return tc, nil
+}
+
+// Check returns an error if the field type is not supported.
+func (f *ServerField) Check() error { _, err := f.typeCode(); return err }
+
+// DataType returns the data type of the field (DtUnknown if the field type is not supported).
+func (f *ServerField) DataType() DataType {
+	tc, err := f.typeCode()
+	if err != nil {
+		return DtUnknown
+	}
+	return tc.dataType()
+}
+
+// IsCharBased returns true if the field is a character based lob, false otherwise.
+func (f *ServerField) IsCharBased() bool {
+	tc, err := f.typeCode()
+	return err == nil && tc.isCharBased()
+}
+
+func serverTypeCodesOf(fields []ServerField) ([]typeCode, error) {
+	tcs := make([]typeCode, len(fields))
+	for i, f := range fields {
+		var err error
+		if tcs[i], err = f.typeCode(); err != nil {
+			return nil, err
+		}
+	}
+	return tcs, nil
+}
+
+func encodeServerFieldNames(enc *encoding.Encoder, fields []ServerField) error {
+	for _, f := range fields {
+		if err := enc.CESU8LIString(f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResultMetadataPart returns a result metadata part describing the result fields.
+func ResultMetadataPart(fields []ServerField) (*ReplyPart, error) {
+	tcs, err := serverTypeCodesOf(fields)
+	if err != nil {
+		return nil, err
+	}
+	return newReplyPart(PkResultMetadata, 0, len(fields), func(enc *encoding.Encoder) error {
+		ofs := uint32(0)
+		for i, f := range fields {
+			co := coMandatory
+			if f.Nullable {
+				co = coOptional
+			}
+			enc.Int8(int8(co))
+			enc.Int8(int8(tcs[i]))
+			enc.Int16(int16(f.Scale))
+			enc.Int16(int16(f.Length))
+			enc.Zeroes(2)           // filler
+			enc.Uint32(noFieldName) // table name
+			enc.Uint32(noFieldName) // schema name
+			enc.Uint32(ofs)         // column name
+			enc.Uint32(ofs)         // column display name
+			ofs += uint32(encoding.Cesu8FieldSize(f.Name))
+		}
+		return encodeServerFieldNames(enc, fields)
+	})
+}
+
+// ParameterMetadataPart returns a parameter metadata part describing the input parameter fields.
+func ParameterMetadataPart(fields []ServerField) (*ReplyPart, error) {
+	tcs, err := serverTypeCodesOf(fields)
+	if err != nil {
+		return nil, err
+	}
+	return newReplyPart(PkParameterMetadata, 0, len(fields), func(enc *encoding.Encoder) error {
+		ofs := uint32(0)
+		for i, f := range fields {
+			po := poMandatory
+			if f.Nullable {
+				po = poOptional
+			}
+			enc.Int8(int8(po))
+			enc.Int8(int8(tcs[i]))
+			enc.Int8(int8(pmIn))
+			enc.Zeroes(1) // filler
+			enc.Uint32(ofs)
+			enc.Int16(int16(f.Length))
+			enc.Int16(int16(f.Scale))
+			enc.Zeroes(4) // filler
+			ofs += uint32(encoding.Cesu8FieldSize(f.Name))
+		}
+		return encodeServerFieldNames(enc, fields)
+	})
+}
+
+// ParameterFields returns the input parameter fields of fields (e.g. to decode input parameters).
+func ParameterFields(fields []ServerField) ([]*ParameterField, error) {
+	tcs, err := serverTypeCodesOf(fields)
+	if err != nil {
+		return nil, err
+	}
+	names := &fieldNames{}
+	prmFields := make([]*ParameterField, len(fields))
+	ofs := uint32(0)
+	for i, f := range fields {
+		po := poMandatory
+		if f.Nullable {
+			po = poOptional
+		}
+		names.items = append(names.items, ofsName{ofs: ofs, name: f.Name})
+		prmFields[i] = &ParameterField{names: names, ofs: int(ofs), prec: f.Length, scale: f.Scale, parameterOptions: po, tc: tcs[i], mode: pmIn}
+		ofs += uint32(encoding.Cesu8FieldSize(f.Name))
+	}
+	return prmFields, nil
+}
+
+// ServerLob is a lob value of a server resultset (see ResultsetPart).
+type ServerLob struct {
+	ID      LocatorID
+	NumChar int64  // number of characters (character based lobs) respectively bytes
+	NumByte int64  // number of bytes
+	B       []byte // lob data included in the resultset
+	Last    bool   // B contains the end of the lob data
+}
+
+func (l *ServerLob) encode(enc *encoding.Encoder) {
+	enc.Int8(int8(ltcUndefined))
+	if l == nil {
+		enc.Int8(int8(loNullindicator))
+		return
+	}
+	opt := loDataincluded
+	if l.Last {
+		opt |= loLastdata
+	}
+	enc.Int8(int8(opt))
+	enc.Zeroes(2)
+	enc.Int64(l.NumChar)
+	enc.Int64(l.NumByte)
+	enc.Uint64(uint64(l.ID))
+	enc.Int32(int32(len(l.B)))
+	enc.Bytes(l.B)
+}
+
+func encodeResultNull(enc *encoding.Encoder, tc typeCode) {
+	switch tc {
+	case tcBoolean:
+		enc.BooleanField(nil) //nolint:errcheck // no error for nil values
+	case tcTinyint, tcSmallint, tcInteger, tcBigint:
+		enc.Bool(false)
+	case tcReal:
+		enc.RealNull()
+	case tcDouble:
+		enc.DoubleNull()
+	case tcDecimal:
+		enc.DecimalNull()
+	case tcDaydate:
+		enc.DaydateNull()
+	case tcSecondtime:
+		enc.SecondtimeField(nil) //nolint:errcheck // no error for nil values
+	case tcSeconddate:
+		enc.SeconddateNull()
+	case tcLongdate:
+		enc.LongdateNull()
+	default: // variable length types
+		enc.VarNull()
+	}
+}
+
+func encodeResult(enc *encoding.Encoder, tc typeCode, v any) error {
+	if tc.isLob() {
+		switch v := v.(type) {
+		case nil:
+			(*ServerLob)(nil).encode(enc)
+		case *ServerLob:
+			v.encode(enc)
+		default:
+			return fmt.Errorf("invalid lob value %v - *ServerLob expected", v)
+		}
+		return nil
+	}
+
+	v, err := convertField(tc, v, nil)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		encodeResultNull(enc, tc)
+		return nil
+	}
+	switch tc {
+	case tcBoolean:
+		return enc.BooleanField(v)
+	case tcTinyint:
+		enc.Bool(true)
+		return enc.TinyintField(v)
+	case tcSmallint:
+		enc.Bool(true)
+		return enc.SmallintField(v)
+	case tcInteger:
+		enc.Bool(true)
+		return enc.IntegerField(v)
+	case tcBigint:
+		enc.Bool(true)
+		return enc.BigintField(v)
+	case tcReal:
+		return enc.RealField(v)
+	case tcDouble:
+		return enc.DoubleField(v)
+	case tcDecimal:
+		return enc.DecimalField(v)
+	case tcDaydate:
+		return enc.DaydateField(v)
+	case tcSecondtime:
+		return enc.SecondtimeField(v)
+	case tcSeconddate:
+		return enc.SeconddateField(v)
+	case tcLongdate:
+		return enc.LongdateField(v)
+	case tcVarchar, tcVarbinary:
+		return enc.VarField(v)
+	case tcNvarchar:
+		return enc.Cesu8Field(v)
+	default:
+		return fmt.Errorf("invalid type code %s", tc)
+	}
+}
+
+/*
+ResultsetPart returns a resultset part containing rows of the result fields.
+  - values of lob fields need to be of type *ServerLob
+  - last marks the part as last part of the resultset (closing the resultset)
+*/
+func ResultsetPart(fields []ServerField, rows [][]driver.Value, last bool) (*ReplyPart, error) {
+	tcs, err := serverTypeCodesOf(fields)
+	if err != nil {
+		return nil, err
+	}
+	var pa PartAttributes
+	if last {
+		pa = paLastPacket | paResultsetClosed
+	}
+	return newReplyPart(PkResultset, pa, len(rows), func(enc *encoding.Encoder) error {
+		for i, row := range rows {
+			if len(row) != len(fields) {
+				return fmt.Errorf("row %d: invalid number of values %d - expected %d", i, len(row), len(fields))
+			}
+			for j, v := range row {
+				if err := encodeResult(enc, tcs[j], v); err != nil {
+					return fmt.Errorf("row %d field %s: %w", i, fields[j].Name, err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// OutputParametersPart returns an output parameters part containing the values of the output parameter fields.
+func OutputParametersPart(fields []ServerField, values []driver.Value) (*ReplyPart, error) {
+	if len(values) != len(fields) {
+		return nil, fmt.Errorf("invalid number of values %d - expected %d", len(values), len(fields))
+	}
+	tcs, err := serverTypeCodesOf(fields)
+	if err != nil {
+		return nil, err
+	}
+	return newReplyPart(PkOutputParameters, 0, 1, func(enc *encoding.Encoder) error {
+		for i, v := range values {
+			if err := encodeResult(enc, tcs[i], v); err != nil {
+				return fmt.Errorf("field %s: %w", fields[i].Name, err)
+			}
+		}
+		return nil
+	})
+}
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -86,10 +87,44 @@ func (tc typeCode) isLob() bool {
 	return tc == tcClob || tc == tcNclob || tc == tcBlob || tc == tcText || tc == tcBintext || tc == tcLocator || tc == tcNlocator
 }
 
+// isCharBased returns true if the TypeCode represents a character based (unicode) Lob, false otherwise.
+func (tc typeCode) isCharBased() bool { return tc == tcText || tc == tcNclob || tc == tcNlocator }
+
 func (tc typeCode) isVariableLength() bool {
 	return tc == tcChar || tc == tcNchar || tc == tcVarchar || tc == tcNvarchar || tc == tcBinary || tc == tcVarbinary || tc == tcShorttext || tc == tcAlphanum
 }
 
+func (tc typeCode) isSpatialType() bool { return tc == tcStGeometry || tc == tcStPoint }
+
+/*
+typeLength returns the type length of a field with precision prec.
+  - variable length types: the length of the field
+  - lob and spatial types: math.MaxInt64 (length only limited by system limits)
+  - other types: false (no variable length type)
+
+see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeLength
+*/
+func (tc typeCode) typeLength(prec int) (int64, bool) {
+	switch {
+	case tc.isVariableLength():
+		return int64(prec), true
+	case tc.isLob(), tc.isSpatialType():
+		return math.MaxInt64, true
+	default:
+		return 0, false
+	}
+}
+
+func (tc typeCode) isInteger() bool {
+	return tc == tcTinyint || tc == tcSmallint || tc == tcInteger || tc == tcBigint
+}
+
+func (tc typeCode) isFloat() bool { return tc == tcReal || tc == tcDouble }
+
+func (tc typeCode) isDatetime() bool {
+	return tc == tcLongdate || tc == tcSeconddate || tc == tcDaydate || tc == tcSecondtime
+}
+
 func (tc typeCode) isDecimalType() bool {
 	return tc == tcSmalldecimal || tc == tcDecimal || tc == tcFixed8 || tc == tcFixed12 || tc == tcFixed16
 }
@@ -173,5 +208,12 @@ func (tc typeCode) dataType() DataType {
 // typeName returns the database type name.
 // see https://golang.org/pkg/database/sql/driver/#RowsColumnTypeDatabaseTypeName
 func (tc typeCode) typeName() string {
-	return strings.ToUpper(tc.String()[2:])
+	switch tc {
+	case tcStGeometry:
+		return "ST_GEOMETRY"
+	case tcStPoint:
+		return "ST_POINT"
+	default:
+		return strings.ToUpper(tc.String()[2:])
+	}
 }
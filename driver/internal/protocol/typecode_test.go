@@ -0,0 +1,38 @@
+package protocol
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTypeLength(t *testing.T) {
+	tests := []struct {
+		tc     typeCode
+		prec   int
+		length int64
+		ok     bool
+	}{
+		{tcInteger, 10, 0, false},
+		{tcBoolean, 1, 0, false},
+		{tcDecimal, 34, 0, false},
+		{tcVarchar, 25, 25, true},
+		{tcNvarchar, 25, 25, true},
+		{tcAlphanum, 15, 15, true},
+		{tcShorttext, 15, 15, true},
+		{tcVarbinary, 10, 10, true},
+		{tcClob, 0, math.MaxInt64, true},
+		{tcNclob, 0, math.MaxInt64, true},
+		{tcBlob, 0, math.MaxInt64, true},
+		{tcText, 0, math.MaxInt64, true},
+		{tcLocator, 0, math.MaxInt64, true},
+		{tcStGeometry, 0, math.MaxInt64, true},
+		{tcStPoint, 0, math.MaxInt64, true},
+	}
+
+	for _, test := range tests {
+		length, ok := test.tc.typeLength(test.prec)
+		if length != test.length || ok != test.ok {
+			t.Fatalf("type code %s: length %d %t - expected %d %t", test.tc, length, ok, test.length, test.ok)
+		}
+	}
+}
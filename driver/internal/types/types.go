@@ -3,6 +3,7 @@ package types
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
@@ -236,10 +237,15 @@ type basicColumn struct {
 	nullable bool
 }
 
-func (t *basicColumn) IsSupported(version, dfv int) bool                 { return t.dt.isSupported(version, dfv) }
-func (t *basicColumn) TypeName() string                                  { return t.dt.typeName }
-func (t *basicColumn) DataType() string                                  { return formatColumn(t.TypeName(), t.nullable) }
-func (t *basicColumn) Length() (length int64, ok bool)                   { return 0, false }
+func (t *basicColumn) IsSupported(version, dfv int) bool { return t.dt.isSupported(version, dfv) }
+func (t *basicColumn) TypeName() string                  { return t.dt.typeName }
+func (t *basicColumn) DataType() string                  { return formatColumn(t.TypeName(), t.nullable) }
+func (t *basicColumn) Length() (length int64, ok bool) {
+	if t.dt.dataType == p.DtLob {
+		return math.MaxInt64, true
+	}
+	return 0, false
+}
 func (t *basicColumn) PrecisionScale() (precision, scale int64, ok bool) { return 0, 0, false }
 func (t *basicColumn) Nullable() (nullable, ok bool)                     { return t.nullable, true }
 func (t *basicColumn) DatabaseTypeName(version, dfv int) string {
@@ -324,7 +330,7 @@ type spatialColumn struct {
 
 func (t *spatialColumn) IsSupported(version, dfv int) bool                 { return t.dt.isSupported(version, dfv) }
 func (t *spatialColumn) TypeName() string                                  { return t.dt.typeName }
-func (t *spatialColumn) Length() (length int64, ok bool)                   { return 0, false }
+func (t *spatialColumn) Length() (length int64, ok bool)                   { return math.MaxInt64, true }
 func (t *spatialColumn) PrecisionScale() (precision, scale int64, ok bool) { return 0, 0, false }
 func (t *spatialColumn) Nullable() (nullable, ok bool)                     { return t.nullable, true }
 func (t *spatialColumn) SRID() int32                                       { return t.srid }
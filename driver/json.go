@@ -0,0 +1,60 @@
+package driver
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+JSON is the driver representation of a JSON document stored in a character field (e.g. NCLOB, NVARCHAR)
+or in a document store collection.
+
+When used as parameter, V is marshaled by encoding/json (a nil V is bound as NULL value).
+When used as scan destination, V needs to be a pointer (e.g. to a struct or map) and the database value
+is unmarshaled into V, whereby a NULL value is treated like a JSON null.
+Use a *json.RawMessage as V to scan the document without unmarshaling it.
+*/
+type JSON struct {
+	V any
+}
+
+// Value implements the database/sql/Valuer interface.
+func (j JSON) Value() (driver.Value, error) {
+	if j.V == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, fmt.Errorf("json: %w", err)
+	}
+	return b, nil
+}
+
+// Scan implements the database/sql/Scanner interface.
+func (j *JSON) Scan(src any) error {
+	var b []byte
+	switch src := src.(type) {
+	case nil:
+		b = []byte("null")
+	case []byte:
+		b = src
+	case string:
+		b = []byte(src)
+	case p.LobScanner:
+		wr := new(bytes.Buffer)
+		if err := scanLob(src, wr); err != nil {
+			return err
+		}
+		b = wr.Bytes()
+	default:
+		return fmt.Errorf("json: invalid data type %T", src)
+	}
+	if err := json.Unmarshal(b, j.V); err != nil {
+		return fmt.Errorf("json: %w", err)
+	}
+	return nil
+}
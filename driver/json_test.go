@@ -0,0 +1,90 @@
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+type testJSONDoc struct {
+	Attr1 string `json:"attr1"`
+	Attr2 bool   `json:"attr2"`
+}
+
+func testJSONValue(t *testing.T) {
+	v, err := JSON{V: testJSONDoc{Attr1: "test text", Attr2: true}}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(v.([]byte)); s != `{"attr1":"test text","attr2":true}` {
+		t.Fatalf("value %s - expected %s", s, `{"attr1":"test text","attr2":true}`)
+	}
+	if v, err := (JSON{}).Value(); err != nil || v != nil {
+		t.Fatalf("value %v error %v - expected nil", v, err)
+	}
+	if _, err := (JSON{V: make(chan int)}).Value(); err == nil {
+		t.Fatal("expected marshal error")
+	}
+}
+
+type testLobScanner []byte
+
+func (s testLobScanner) Scan(wr io.Writer) error {
+	_, err := wr.Write(s)
+	return err
+}
+
+func testJSONScan(t *testing.T) {
+	const doc = `{"attr1":"test text","attr2":true}`
+	expected := testJSONDoc{Attr1: "test text", Attr2: true}
+
+	for _, src := range []any{doc, []byte(doc), testLobScanner(doc)} {
+		var d testJSONDoc
+		if err := (&JSON{V: &d}).Scan(src); err != nil {
+			t.Fatalf("%T: %s", src, err)
+		}
+		if d != expected {
+			t.Fatalf("%T: value %v - expected %v", src, d, expected)
+		}
+	}
+
+	// raw message
+	var raw json.RawMessage
+	if err := (&JSON{V: &raw}).Scan(doc); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, []byte(doc)) {
+		t.Fatalf("raw message %s - expected %s", raw, doc)
+	}
+
+	// NULL
+	m := map[string]any{"a": 1}
+	if err := (&JSON{V: &m}).Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if m != nil {
+		t.Fatalf("map %v - expected nil", m)
+	}
+
+	// invalid type
+	if err := (&JSON{V: &m}).Scan(42); err == nil {
+		t.Fatal("expected invalid data type error")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"value", testJSONValue},
+		{"scan", testJSONScan},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
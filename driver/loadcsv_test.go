@@ -0,0 +1,47 @@
+package driver
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestLoadCSVRecordLength(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	params := []hdbtest.Column{{Name: "I", Type: "INTEGER"}, {Name: "S", Type: "NVARCHAR"}}
+	srv.Handle("insert into T (I, S) values (?, ?)", &hdbtest.Response{Params: params, RowsAffected: 1})
+
+	db := OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		data string
+		err  string
+	}{
+		{"I,S\n1,one\n2,two,three\n", "csv line 3: number of fields 3 - expected 2"},
+		{"I,S\n1,one\n\"2\nx\",two\n3\n", "csv line 5: number of fields 1 - expected 2"},
+	}
+	for _, test := range tests {
+		rd := csv.NewReader(strings.NewReader(test.data))
+		rd.FieldsPerRecord = -1
+		_, err := LoadCSV(ctx, conn, "T", rd)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Fatalf("error %v - expected %s", err, test.err)
+		}
+	}
+}
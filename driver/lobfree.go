@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	"slices"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+lobLocators keeps track of the lob locators of a result handed to the application.
+
+Lob data exceeding the inline size of a result row is read via a lob locator, which is kept open on the database
+server until the lob data is read completely. Lob locators the application did not read (e.g. rows not scanned or
+scanning aborted) would therefore retain server memory until the session is closed. To prevent this the open
+locators are released when the result is closed.
+*/
+type lobLocators []*p.LobOutDescr
+
+// track adds the lob locator of value v if open.
+func (l *lobLocators) track(v any) {
+	descr, ok := v.(*p.LobOutDescr)
+	if !ok || !descr.IsOpen() {
+		return
+	}
+	if len(*l) == cap(*l) { // remove locators read in the meantime before growing
+		*l = slices.DeleteFunc(*l, func(descr *p.LobOutDescr) bool { return !descr.IsOpen() })
+	}
+	*l = append(*l, descr)
+}
+
+// free releases the open lob locators via connection c.
+func (l *lobLocators) free(ctx context.Context, c *conn) error {
+	if len(*l) == 0 {
+		return nil
+	}
+	err := c.freeLobs(ctx, *l)
+	*l = nil
+	return err
+}
+
+/*
+freeLobs releases the open lob locators of descrs on the database server.
+
+As the database server releases a lob locator as soon as the last lob data is read, the last character respectively
+byte is requested for each open locator. The read lob requests of all open locators are sent as parts of one message,
+so that the locators are released by a single roundtrip.
+*/
+func (c *conn) freeLobs(ctx context.Context, descrs []*p.LobOutDescr) error {
+	if c.isBad() { // server session might be lost - locators are released with the session
+		return nil
+	}
+	var open []*p.LobOutDescr
+	var parts []p.WritablePart
+	for _, descr := range descrs {
+		if !descr.IsOpen() {
+			continue
+		}
+		open = append(open, descr)
+		parts = append(parts, &p.ReadLobRequest{ID: descr.ID, Ofs: max(descr.NumChar-1, 0), ChunkSize: 1})
+	}
+	if len(open) == 0 {
+		return nil
+	}
+	if err := c.pw.Write(ctx, c.sessionID, p.MtWriteLob, false, parts...); err != nil {
+		return err
+	}
+	lobReply := &p.ReadLobReply{}
+	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+		if kind == p.PkReadLobReply {
+			read(lobReply)
+		}
+	}); err != nil {
+		return err
+	}
+	for _, descr := range open {
+		descr.Release()
+	}
+	c.metrics.msgCh <- counterMsg{idx: counterFreedLobs, v: uint64(len(open))}
+	return nil
+}
@@ -0,0 +1,62 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+type freeLobsString string
+
+func (s *freeLobsString) Scan(src any) error { return ScanLobString(src, (*string)(s)) }
+
+func TestFreeLobs(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	text := strings.Repeat("abc€😀", 20000) // lob exceeding inline size
+	srv.Handle("select text from lobs", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "TEXT", Type: "NCLOB"}},
+		Rows:    [][]any{{text}, {text}, {text}},
+	})
+
+	db := OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	db.SetMaxOpenConns(1)
+
+	for i := 0; i < 2; i++ {
+		rows, err := db.Query("select text from lobs")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// read first lob only
+		rows.Next()
+		var s freeLobsString
+		if err := rows.Scan(&s); err != nil {
+			t.Fatal(err)
+		}
+		if string(s) != text {
+			t.Fatal("invalid lob value")
+		}
+		rows.Next()
+		rows.Next()
+		roundtrips := db.ExStats().Roundtrips
+		if err := rows.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// locators of both unread lobs are released by one roundtrip
+		if n := db.ExStats().Roundtrips - roundtrips; n != 1 {
+			t.Fatalf("close roundtrips %d - expected %d", n, 1)
+		}
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if freedLobs := db.ExStats().FreedLobs; freedLobs != 4 {
+		t.Fatalf("freed lobs %d - expected %d", freedLobs, 4)
+	}
+}
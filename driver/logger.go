@@ -0,0 +1,39 @@
+package driver
+
+import (
+	"context"
+	"log/slog"
+)
+
+// levelHandler is a slog.Handler discarding records below a minimum level.
+type levelHandler struct {
+	level   slog.Leveler
+	handler slog.Handler
+}
+
+func newLevelLogger(logger *slog.Logger, level slog.Leveler) *slog.Logger {
+	if level == nil {
+		return logger
+	}
+	handler := logger.Handler()
+	if h, ok := handler.(*levelHandler); ok { // avoid nesting
+		handler = h.handler
+	}
+	return slog.New(&levelHandler{level: level, handler: handler})
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithAttrs(attrs)}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{level: h.level, handler: h.handler.WithGroup(name)}
+}
@@ -0,0 +1,29 @@
+package driver
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLevelLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if newLevelLogger(logger, nil) != logger {
+		t.Fatal("logger without level should not be wrapped")
+	}
+
+	levelLogger := newLevelLogger(logger, slog.LevelWarn).With(slog.Int("connectionID", 42))
+	levelLogger.Info("info")
+	levelLogger.Warn("warn")
+
+	s := buf.String()
+	if strings.Contains(s, "msg=info") {
+		t.Fatalf("unexpected info record in %s", s)
+	}
+	if !strings.Contains(s, "msg=warn connectionID=42") {
+		t.Fatalf("missing warn record in %s", s)
+	}
+}
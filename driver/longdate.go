@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+const (
+	longdateTicksPerSecond = int64(time.Second / longdateTick)
+	longdateTick           = 100 * time.Nanosecond
+)
+
+// unix time of 0001-01-01 00:00:00 UTC.
+var longdateEpochUnix = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+/*
+A Longdate is the driver representation of a LONGDATE (TIMESTAMP) database field value as number of
+100 nanosecond ticks since 0001-01-01 00:00:00 UTC, which is the precision the database stores
+timestamps with.
+
+A Longdate represents an absolute point in time like a time.Time value and is scanned from respectively
+bound as the time.Time value provided by the driver. With the default time zone policy TzUTC the Longdate
+ticks are equal to the ticks stored by the database. With the time zone policies TzSession and TzLocation
+the database wall clock values are interpreted in the respective time location (see TimeZonePolicy),
+so that the ticks differ from the stored ticks by the UTC offset of the location.
+When binding time.Time values, fractions of a tick are truncated. SECONDDATE values are scanned
+with a precision of whole seconds.
+*/
+type Longdate int64
+
+// NewLongdate returns the Longdate of the point in time t (independent of the location of t).
+// Fractions of a tick are truncated.
+func NewLongdate(t time.Time) Longdate {
+	return Longdate((t.Unix()-longdateEpochUnix)*longdateTicksPerSecond + int64(t.Nanosecond())/int64(longdateTick))
+}
+
+// Time returns the UTC time of the Longdate.
+func (ld Longdate) Time() time.Time {
+	secs, ticks := int64(ld)/longdateTicksPerSecond, int64(ld)%longdateTicksPerSecond
+	return time.Unix(secs+longdateEpochUnix, ticks*int64(longdateTick)).UTC()
+}
+
+// String implements the fmt.Stringer interface.
+func (ld Longdate) String() string { return ld.Time().Format("2006-01-02 15:04:05.0000000") }
+
+// Scan implements the database/sql/Scanner interface.
+func (ld *Longdate) Scan(src any) error {
+	t, ok := src.(time.Time)
+	if !ok {
+		return fmt.Errorf("longdate: invalid data type %T", src)
+	}
+	*ld = NewLongdate(t)
+	return nil
+}
+
+// Value implements the database/sql/Valuer interface.
+func (ld Longdate) Value() (driver.Value, error) { return ld.Time(), nil }
+
+// NullLongdate represents a Longdate that may be null.
+// NullLongdate implements the Scanner interface so
+// it can be used as a scan destination, similar to NullString.
+type NullLongdate struct {
+	Longdate Longdate
+	Valid    bool // Valid is true if Longdate is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (n *NullLongdate) Scan(value any) error {
+	if value == nil {
+		n.Longdate, n.Valid = 0, false
+		return nil
+	}
+	if err := n.Longdate.Scan(value); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (n NullLongdate) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Longdate.Value()
+}
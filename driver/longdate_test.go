@@ -0,0 +1,78 @@
+package driver
+
+import (
+	"testing"
+	"time"
+)
+
+func testLongdateConversion(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		longdate Longdate
+	}{
+		{time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(1, 1, 1, 0, 0, 1, 100, time.UTC), 10000001},
+		{time.Date(1, 1, 2, 0, 0, 0, 0, time.UTC), 24 * 60 * 60 * 10000000},
+		{time.Date(2024, 2, 29, 23, 59, 59, 999999900, time.UTC), 638448479999999999},
+		{time.Date(9999, 12, 31, 23, 59, 59, 999999900, time.UTC), 3155378975999999999},
+	}
+	for _, test := range tests {
+		ld := NewLongdate(test.t)
+		if ld != test.longdate {
+			t.Fatalf("time %s: longdate %d - expected %d", test.t, ld, test.longdate)
+		}
+		if !ld.Time().Equal(test.t) {
+			t.Fatalf("longdate %d: time %s - expected %s", ld, ld.Time(), test.t)
+		}
+	}
+
+	// fractions of a tick are truncated.
+	if ld := NewLongdate(time.Date(1, 1, 1, 0, 0, 0, 199, time.UTC)); ld != 1 {
+		t.Fatalf("longdate %d - expected %d", ld, 1)
+	}
+	// time zones
+	loc := time.FixedZone("test", 2*60*60)
+	if ld := NewLongdate(time.Date(1, 1, 1, 2, 0, 0, 0, loc)); ld != 0 {
+		t.Fatalf("longdate %d - expected %d", ld, 0)
+	}
+}
+
+func testNullLongdate(t *testing.T) {
+	var n NullLongdate
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("null longdate: valid %t error %v", n.Valid, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Fatalf("null longdate: value %v error %v", v, err)
+	}
+	tm := time.Date(2024, 2, 29, 23, 59, 59, 123456700, time.UTC)
+	if err := n.Scan(tm); err != nil {
+		t.Fatal(err)
+	}
+	v, err := n.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n.Valid || !v.(time.Time).Equal(tm) {
+		t.Fatalf("longdate value %v - expected %s", v, tm)
+	}
+	if err := n.Scan("2024-02-29"); err == nil {
+		t.Fatal("invalid data type: error expected")
+	}
+}
+
+func TestLongdate(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"conversion", testLongdateConversion},
+		{"nullLongdate", testNullLongdate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
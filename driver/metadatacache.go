@@ -0,0 +1,108 @@
+package driver
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// metadataCacheEntry is the prepared statement metadata of a query cached by metadataCache.
+type metadataCacheEntry struct {
+	query           string
+	prmBuf          []byte // encoded parameter metadata
+	resBuf          []byte // encoded result metadata
+	parameterFields []*p.ParameterField
+	resultFields    []*p.ResultField
+}
+
+/*
+metadataCache is a least recently used cache of prepared statement metadata keyed by sql text.
+
+The cache is shared by all connections of a connector. As statement ids are session specific, each connection
+needs to prepare a statement nevertheless, but the metadata returned by the database server is decoded only if
+the encoded metadata differs from the cached one. Different encoded metadata (the 'schema version' of the
+statement, e.g. after a table was altered or if the statement refers to tables of different schemas) replaces the
+cached metadata. The cached fields are read-only and therefore safe to be used by multiple connections.
+*/
+type metadataCache struct {
+	size    int
+	mu      sync.Mutex
+	ll      *list.List // front: most recently used
+	entries map[string]*list.Element
+}
+
+func newMetadataCache(size int) *metadataCache {
+	return &metadataCache{size: size, ll: list.New(), entries: make(map[string]*list.Element, size)}
+}
+
+// get returns the cache entry of query (nil if not cached).
+func (c *metadataCache) get(query string) *metadataCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[query]
+	if !ok {
+		return nil
+	}
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*metadataCacheEntry)
+}
+
+// add adds or replaces the cache entry of entry.query.
+func (c *metadataCache) add(entry *metadataCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[entry.query]; ok {
+		c.ll.Remove(elem)
+	}
+	c.entries[entry.query] = c.ll.PushFront(entry)
+	for c.ll.Len() > c.size {
+		elem := c.ll.Back()
+		c.ll.Remove(elem)
+		delete(c.entries, elem.Value.(*metadataCacheEntry).query)
+	}
+}
+
+// len returns the number of cached entries.
+func (c *metadataCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// encodedBuf returns the encoded data of part (nil if the part was not received).
+func encodedBuf(part *p.EncodedPart) []byte {
+	if part == nil {
+		return nil
+	}
+	return part.Buf
+}
+
+/*
+decode returns the parameter and result fields of the prepared statement of query from the encoded metadata parts
+prmPart and resPart (nil if not received) and true if the fields were served by the cache.
+*/
+func (c *metadataCache) decode(query string, prmPart, resPart *p.EncodedPart) ([]*p.ParameterField, []*p.ResultField, bool, error) {
+	prmBuf, resBuf := encodedBuf(prmPart), encodedBuf(resPart)
+	if entry := c.get(query); entry != nil && bytes.Equal(entry.prmBuf, prmBuf) && bytes.Equal(entry.resBuf, resBuf) {
+		return entry.parameterFields, entry.resultFields, true, nil
+	}
+	entry := &metadataCacheEntry{query: query, prmBuf: prmBuf, resBuf: resBuf}
+	if prmPart != nil {
+		prmMeta := &p.ParameterMetadata{}
+		if err := prmPart.Decode(prmMeta); err != nil {
+			return nil, nil, false, err
+		}
+		entry.parameterFields = prmMeta.ParameterFields
+	}
+	if resPart != nil {
+		resMeta := &p.ResultMetadata{}
+		if err := resPart.Decode(resMeta); err != nil {
+			return nil, nil, false, err
+		}
+		entry.resultFields = resMeta.ResultFields
+	}
+	c.add(entry)
+	return entry.parameterFields, entry.resultFields, false, nil
+}
@@ -0,0 +1,90 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestMetadataCache(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	const query = "select name from t where id = ?"
+
+	handle := func(column string) {
+		srv.Handle(query, &hdbtest.Response{Columns: []hdbtest.Column{{Name: column, Type: "NVARCHAR", Length: 20}}, Rows: [][]any{{"name"}}})
+	}
+
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetMetadataCacheSize(1)
+	db := OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	columns := func(conn *sql.Conn) []string {
+		stmt, err := conn.PrepareContext(ctx, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stmt.Close()
+		rows, err := stmt.QueryContext(ctx, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		columns, err := rows.Columns()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return columns
+	}
+
+	checkStats := func(hits, misses uint64) {
+		t.Helper()
+		stats := db.ExStats()
+		if stats.MetadataHits != hits || stats.MetadataMisses != misses {
+			t.Fatalf("metadata hits %d misses %d - expected %d %d", stats.MetadataHits, stats.MetadataMisses, hits, misses)
+		}
+	}
+
+	conns := make([]*sql.Conn, 2) // different physical connections
+	for i := range conns {
+		if conns[i], err = db.Conn(ctx); err != nil {
+			t.Fatal(err)
+		}
+		defer conns[i].Close()
+	}
+
+	handle("NAME")
+	for _, conn := range conns {
+		if columns := columns(conn); columns[0] != "NAME" {
+			t.Fatalf("column %s - expected %s", columns[0], "NAME")
+		}
+	}
+	checkStats(1, 1)
+
+	handle("NEW_NAME") // changed metadata
+	if columns := columns(conns[0]); columns[0] != "NEW_NAME" {
+		t.Fatalf("column %s - expected %s", columns[0], "NEW_NAME")
+	}
+	checkStats(1, 2)
+
+	stmt, err := conns[1].PrepareContext(ctx, "select 1 from dummy") // evicts metadata of query
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt.Close()
+	if _, ok := connector._metadataCache.entries[query]; ok {
+		t.Fatal("metadata not evicted")
+	}
+	if l := connector._metadataCache.len(); l != 1 {
+		t.Fatalf("cache length %d - expected %d", l, 1)
+	}
+}
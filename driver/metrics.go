@@ -10,6 +10,16 @@ import (
 const (
 	counterBytesRead = iota
 	counterBytesWritten
+	counterStmtCacheHits
+	counterStmtCacheMisses
+	counterMetadataHits
+	counterMetadataMisses
+	counterRoundtrips
+	counterLobChunks
+	counterErrors
+	counterReconnects
+	counterFreedLobs
+	counterReprepares
 	numCounter
 )
 
@@ -17,6 +27,7 @@ const (
 	gaugeConn = iota
 	gaugeTx
 	gaugeStmt
+	gaugeRetainedBytes
 	numGauge
 )
 
@@ -177,8 +188,19 @@ func (m *metrics) stats() *Stats {
 		OpenConnections:  int(m.gauges[gaugeConn]),
 		OpenTransactions: int(m.gauges[gaugeTx]),
 		OpenStatements:   int(m.gauges[gaugeStmt]),
+		RetainedBytes:    int(m.gauges[gaugeRetainedBytes]),
 		ReadBytes:        m.counters[counterBytesRead],
 		WrittenBytes:     m.counters[counterBytesWritten],
+		StmtCacheHits:    m.counters[counterStmtCacheHits],
+		StmtCacheMisses:  m.counters[counterStmtCacheMisses],
+		MetadataHits:     m.counters[counterMetadataHits],
+		MetadataMisses:   m.counters[counterMetadataMisses],
+		Roundtrips:       m.counters[counterRoundtrips],
+		LobChunks:        m.counters[counterLobChunks],
+		Errors:           m.counters[counterErrors],
+		Reconnects:       m.counters[counterReconnects],
+		FreedLobs:        m.counters[counterFreedLobs],
+		Reprepares:       m.counters[counterReprepares],
 		TimeUnit:         m.timeUnit,
 		ReadTime:         m.times[timeRead].stats(),
 		WriteTime:        m.times[timeWrite].stats(),
@@ -0,0 +1,93 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+/*
+Execer is the interface of the statement operations wrapped by middlewares.
+
+  - Prepare prepares a statement.
+  - Exec executes a statement, either directly (statement without arguments) or as prepared statement.
+  - Query executes a query, either directly (query without arguments) or as prepared statement.
+  - Fetch reads the next row of a query resultset into dest (io.EOF if there are no further rows).
+
+For prepared statements query is the statement text provided to Prepare. As the statement is prepared already,
+changing query in Exec or Query does not have any effect - statements of prepared statements need to be rewritten
+in Prepare.
+*/
+type Execer interface {
+	Prepare(ctx context.Context, query string) (driver.Stmt, error)
+	Exec(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error)
+	Query(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error)
+	Fetch(rows driver.Rows, dest []driver.Value) error
+}
+
+/*
+Middleware wraps the Execer next, so that statement operations can be intercepted uniformly
+(e.g. to implement caching, shadow traffic, metrics or sql rewriting).
+
+A middleware usually embeds next and overwrites the methods to be intercepted:
+
+	type queryCounter struct {
+		driver.Execer
+		n atomic.Int64
+	}
+
+	func (c *queryCounter) Query(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+		c.n.Add(1)
+		return c.Execer.Query(ctx, query, nvargs)
+	}
+*/
+type Middleware func(next Execer) Execer
+
+// chainMiddlewares returns execer wrapped by middlewares, where the first middleware is the outermost one.
+func chainMiddlewares(middlewares []Middleware, execer Execer) Execer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		execer = middlewares[i](execer)
+	}
+	return execer
+}
+
+// fetch reads the next row of rows.
+func fetch(rows driver.Rows, dest []driver.Value) error {
+	if qr, ok := rows.(*queryResult); ok {
+		return qr.next(dest)
+	}
+	return rows.Next(dest)
+}
+
+// connExecer is the Execer of direct statement executions of a connection.
+type connExecer struct{ c *conn }
+
+func (e connExecer) Prepare(ctx context.Context, query string) (driver.Stmt, error) {
+	return e.c.prepareContext(ctx, query)
+}
+
+func (e connExecer) Exec(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	return e.c.execContext(ctx, query, nvargs)
+}
+
+func (e connExecer) Query(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	return e.c.queryContext(ctx, query, nvargs)
+}
+
+func (e connExecer) Fetch(rows driver.Rows, dest []driver.Value) error { return fetch(rows, dest) }
+
+// stmtExecer is the Execer of prepared statement executions.
+type stmtExecer struct{ s *stmt }
+
+func (e stmtExecer) Prepare(ctx context.Context, query string) (driver.Stmt, error) {
+	return e.s.conn.prepareContext(ctx, query)
+}
+
+func (e stmtExecer) Exec(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	return e.s.execContext(ctx, nvargs)
+}
+
+func (e stmtExecer) Query(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	return e.s.queryContext(ctx, nvargs)
+}
+
+func (e stmtExecer) Fetch(rows driver.Rows, dest []driver.Value) error { return fetch(rows, dest) }
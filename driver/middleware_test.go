@@ -0,0 +1,85 @@
+//go:build !unit
+
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+	sqldriver "database/sql/driver"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+type testMiddleware struct {
+	driver.Execer
+	numPrepare, numExec, numQuery, numFetch atomic.Int64
+}
+
+func (m *testMiddleware) Prepare(ctx context.Context, query string) (sqldriver.Stmt, error) {
+	m.numPrepare.Add(1)
+	// rewrite query
+	return m.Execer.Prepare(ctx, strings.ReplaceAll(query, "__dummy__", "dummy"))
+}
+
+func (m *testMiddleware) Exec(ctx context.Context, query string, nvargs []sqldriver.NamedValue) (sqldriver.Result, error) {
+	m.numExec.Add(1)
+	return m.Execer.Exec(ctx, query, nvargs)
+}
+
+func (m *testMiddleware) Query(ctx context.Context, query string, nvargs []sqldriver.NamedValue) (sqldriver.Rows, error) {
+	m.numQuery.Add(1)
+	return m.Execer.Query(ctx, strings.ReplaceAll(query, "__dummy__", "dummy"), nvargs)
+}
+
+func (m *testMiddleware) Fetch(rows sqldriver.Rows, dest []sqldriver.Value) error {
+	m.numFetch.Add(1)
+	return m.Execer.Fetch(rows, dest)
+}
+
+func TestMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var middlewares []*testMiddleware
+
+	connector := driver.MT.NewConnector()
+	connector.SetMiddlewares(func(next driver.Execer) driver.Execer {
+		mu.Lock()
+		defer mu.Unlock()
+		m := &testMiddleware{Execer: next}
+		middlewares = append(middlewares, m)
+		return m
+	})
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	var s string
+	// direct query
+	if err := db.QueryRow("select * from __dummy__").Scan(&s); err != nil {
+		t.Fatal(err)
+	}
+	// prepared query
+	if err := db.QueryRow("select * from __dummy__ where dummy = ?", "X").Scan(&s); err != nil {
+		t.Fatal(err)
+	}
+	// direct exec
+	if _, err := db.Exec("set 'middleware' = 'test'"); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var numPrepare, numExec, numQuery, numFetch int64
+	for _, m := range middlewares {
+		numPrepare += m.numPrepare.Load()
+		numExec += m.numExec.Load()
+		numQuery += m.numQuery.Load()
+		numFetch += m.numFetch.Load()
+	}
+	if numPrepare != 1 || numExec != 1 || numQuery != 2 || numFetch != 2 {
+		t.Fatalf("prepare %d exec %d query %d fetch %d - expected 1 1 2 2", numPrepare, numExec, numQuery, numFetch)
+	}
+}
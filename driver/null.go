@@ -0,0 +1,47 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+/*
+Null represents a value of type T that may be null.
+Null implements the Scanner and the Valuer interface, so it can be used as scan destination and as argument
+for all types supported by the driver, including driver specific types like Decimal or Longdate, e.g.
+
+	var n driver.Null[int32]
+	var d driver.Null[driver.Decimal]
+
+Scanning follows the driver value assignment rules of StructScanner.QueryAll: types implementing
+the sql.Scanner interface scan themselves, other values need to be assignable or convertible within the
+same kind (integer, floating point and string/bytes kinds) to T.
+*/
+type Null[T any] struct {
+	V     T
+	Valid bool // Valid is true if V is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (n *Null[T]) Scan(value any) error {
+	if value == nil {
+		var zero T
+		n.V, n.Valid = zero, false
+		return nil
+	}
+	if err := assignValue(reflect.ValueOf(&n.V).Elem(), value); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+// Values of T implementing the driver Valuer interface are converted by the driver when binding the argument.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.V, nil
+}
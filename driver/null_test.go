@@ -0,0 +1,86 @@
+package driver
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testNullScan(t *testing.T) {
+	var i Null[int32]
+	if err := i.Scan(int64(42)); err != nil {
+		t.Fatal(err)
+	}
+	if !i.Valid || i.V != 42 {
+		t.Fatalf("value %v - expected %d", i, 42)
+	}
+	if err := i.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if i.Valid || i.V != 0 {
+		t.Fatalf("value %v - expected NULL", i)
+	}
+	if err := i.Scan("text"); err == nil {
+		t.Fatal("invalid data type: error expected")
+	}
+
+	var s Null[string]
+	if err := s.Scan([]byte("text")); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Valid || s.V != "text" {
+		t.Fatalf("value %v - expected %s", s, "text")
+	}
+
+	now := time.Now()
+	var tm Null[time.Time]
+	if err := tm.Scan(now); err != nil {
+		t.Fatal(err)
+	}
+	if !tm.Valid || !tm.V.Equal(now) {
+		t.Fatalf("value %v - expected %s", tm, now)
+	}
+
+	// driver type implementing the Scanner interface
+	r := big.NewRat(1, 2)
+	var d Null[Decimal]
+	if err := d.Scan(r); err != nil {
+		t.Fatal(err)
+	}
+	if !d.Valid || (*big.Rat)(&d.V).Cmp(r) != 0 {
+		t.Fatalf("value %v - expected %s", d, r)
+	}
+}
+
+func testNullValue(t *testing.T) {
+	if v, err := (Null[int64]{}).Value(); v != nil || err != nil {
+		t.Fatalf("value %v error %v - expected nil", v, err)
+	}
+	if v, err := (Null[int64]{V: 42, Valid: true}).Value(); v != int64(42) || err != nil {
+		t.Fatalf("value %v error %v - expected %d", v, err, 42)
+	}
+	// nested valuer is converted by the driver
+	v, err := valuerValue(Null[Decimal]{V: Decimal(*big.NewRat(1, 2)), Valid: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := v.(*big.Rat); !ok || r.Cmp(big.NewRat(1, 2)) != 0 {
+		t.Fatalf("value %v - expected %s", v, big.NewRat(1, 2))
+	}
+}
+
+func TestNull(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"scan", testNullScan},
+		{"value", testNullValue},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+)
+
+// A Queryer executes queries and is implemented by sql.DB, sql.Conn and sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+/*
+A Paginator reads the rows of a query result in pages of a fixed number of rows across multiple calls
+(e.g. the requests of a paginated API), keeping the resultset (server side cursor) open in between.
+
+The fetch size of the query is set to the page size, so that reading a page requires at most one fetch roundtrip.
+The connection executing the query is reserved for the paginator until
+  - all rows are read,
+  - the paginator is closed or
+  - the context of NewPaginator is done (e.g. by a timeout releasing paginators abandoned by clients).
+
+In all cases the resultset is closed on the database server and the connection is returned to the connection pool.
+*/
+type Paginator struct {
+	rows     *sql.Rows
+	pageSize int
+	numRow   int
+	done     bool
+}
+
+// NewPaginator executes query with args via queryer and returns a paginator reading the result in pages of pageSize rows.
+// The result is released as soon as ctx is done.
+func NewPaginator(ctx context.Context, queryer Queryer, pageSize int, query string, args ...any) (*Paginator, error) {
+	if pageSize <= 0 {
+		return nil, errors.New("paginator: page size needs to be greater zero")
+	}
+	rows, err := queryer.QueryContext(hdbctx.WithFetchSize(ctx, pageSize), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &Paginator{rows: rows, pageSize: pageSize}, nil
+}
+
+// Columns returns the column names of the query result.
+func (p *Paginator) Columns() ([]string, error) { return p.rows.Columns() }
+
+// ColumnTypes returns the column types of the query result.
+func (p *Paginator) ColumnTypes() ([]*sql.ColumnType, error) { return p.rows.ColumnTypes() }
+
+// PageSize returns the number of rows of a page.
+func (p *Paginator) PageSize() int { return p.pageSize }
+
+// NumRow returns the number of rows read so far.
+func (p *Paginator) NumRow() int { return p.numRow }
+
+// Done returns true if all rows are read or the paginator is closed, false otherwise.
+func (p *Paginator) Done() bool { return p.done }
+
+/*
+NextPage calls scan for each row of the next page and returns the number of rows of the page.
+scan is called with the result rows positioned at the row to be scanned (see sql.Rows.Scan).
+
+The last page might contain less than page size rows. After reading the last page the paginator is done and closed.
+NextPage returns the error of scan or the error of reading the result (e.g. the context error in case the context of
+NewPaginator is done) closing the paginator.
+*/
+func (p *Paginator) NextPage(scan func(rows *sql.Rows) error) (int, error) {
+	if p.done {
+		return 0, nil
+	}
+	n := 0
+	for n < p.pageSize {
+		if !p.rows.Next() {
+			return n, p.close(p.rows.Err())
+		}
+		if err := scan(p.rows); err != nil {
+			return n, p.close(err)
+		}
+		n++
+		p.numRow++
+	}
+	return n, nil
+}
+
+func (p *Paginator) close(err error) error {
+	p.done = true
+	return errors.Join(err, p.rows.Close())
+}
+
+// Close closes the paginator releasing the query result. Close is idempotent.
+func (p *Paginator) Close() error {
+	if p.done {
+		return nil
+	}
+	return p.close(nil)
+}
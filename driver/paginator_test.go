@@ -0,0 +1,105 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestPaginator(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	const numRow = 45
+	rows := make([][]any, numRow)
+	for i := range rows {
+		rows[i] = []any{fmt.Sprintf("name %d", i)}
+	}
+	srv.Handle("select name from t", &hdbtest.Response{Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}}, Rows: rows})
+
+	db := OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	defer db.Close()
+
+	scanNames := func(names *[]string) func(rows *sql.Rows) error {
+		return func(rows *sql.Rows) error {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return err
+			}
+			*names = append(*names, name)
+			return nil
+		}
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		p, err := NewPaginator(context.Background(), db, 10, "select name from t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+
+		var names []string
+		for _, size := range []int{10, 10, 10, 10, 5} {
+			n, err := p.NextPage(scanNames(&names))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != size {
+				t.Fatalf("page size %d - expected %d", n, size)
+			}
+		}
+		if !p.Done() {
+			t.Fatal("paginator not done")
+		}
+		if p.NumRow() != numRow || len(names) != numRow {
+			t.Fatalf("number of rows %d - expected %d", p.NumRow(), numRow)
+		}
+		for i, name := range names {
+			if name != rows[i][0] {
+				t.Fatalf("row %d: name %s - expected %s", i, name, rows[i][0])
+			}
+		}
+		if n, err := p.NextPage(scanNames(&names)); n != 0 || err != nil {
+			t.Fatalf("page after done: %d rows error %v", n, err)
+		}
+		if inUse := db.Stats().InUse; inUse != 0 {
+			t.Fatalf("connections in use %d - expected 0", inUse)
+		}
+	})
+
+	t.Run("release", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		p, err := NewPaginator(ctx, db, 10, "select name from t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+
+		var names []string
+		if _, err := p.NextPage(scanNames(&names)); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
+		// result is released asynchronously by database/sql
+		for i := 0; db.Stats().InUse != 0; i++ {
+			if i == 100 {
+				t.Fatal("connection not released")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if _, err := p.NextPage(scanNames(&names)); !errors.Is(err, context.Canceled) {
+			t.Fatalf("error %v - expected %v", err, context.Canceled)
+		}
+		if !p.Done() {
+			t.Fatal("paginator not done")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package driver
+
+import (
+	"reflect"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+A ParameterType describes a parameter of a prepared statement as provided by the
+parameter metadata of the database server.
+
+The methods follow the ones of sql.ColumnType so that arguments can be validated or
+converted the same way as query results are introspected.
+*/
+type ParameterType struct {
+	field *p.ParameterField
+}
+
+func newParameterTypes(fields []*p.ParameterField) []*ParameterType {
+	types := make([]*ParameterType, len(fields))
+	for i, field := range fields {
+		types[i] = &ParameterType{field: field}
+	}
+	return types
+}
+
+// Name returns the parameter name (procedure parameters) or an empty string if not provided.
+func (t *ParameterType) Name() string { return t.field.Name() }
+
+// DatabaseTypeName returns the database type name of the parameter (e.g. "NVARCHAR", "DECIMAL").
+func (t *ParameterType) DatabaseTypeName() string { return t.field.TypeName() }
+
+// ScanType returns the Go type suitable for scanning an output parameter value.
+func (t *ParameterType) ScanType() reflect.Type { return t.field.ScanType() }
+
+// Length returns the parameter type length for variable length types and true, otherwise false.
+func (t *ParameterType) Length() (length int64, ok bool) { return t.field.TypeLength() }
+
+// DecimalSize returns the precision and scale of decimal types and true, otherwise false.
+func (t *ParameterType) DecimalSize() (precision, scale int64, ok bool) {
+	return t.field.TypePrecisionScale()
+}
+
+// Nullable returns true if the parameter value may be null.
+func (t *ParameterType) Nullable() bool { return t.field.Nullable() }
+
+// In returns true if the parameter is an input (in or inout) parameter.
+func (t *ParameterType) In() bool { return t.field.In() }
+
+// Out returns true if the parameter is an output (out or inout) parameter.
+func (t *ParameterType) Out() bool { return t.field.Out() }
@@ -0,0 +1,97 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestQueryAll(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	const numRow = 100 // needs more than one fetch roundtrip
+	rows := make([][]any, numRow)
+	for i := range rows {
+		rows[i] = []any{i}
+	}
+	columns := []hdbtest.Column{{Name: "ID", Type: "INTEGER"}}
+	srv.Handle("select id from t", &hdbtest.Response{Columns: columns, Rows: rows})
+	srv.Handle("select id from t where id >= ?", &hdbtest.Response{Params: columns, Columns: columns, Rows: rows})
+
+	db := OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	type row struct{ ID int }
+	scanner, err := NewStructScanner[row]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// direct and prepared query path (the prepared statement must be kept open until all rows are fetched)
+	for _, args := range [][]any{nil, {0}} {
+		query := "select id from t"
+		if args != nil {
+			query += " where id >= ?"
+		}
+		result, err := scanner.QueryAll(ctx, conn, query, args...)
+		if err != nil {
+			t.Fatalf("args %v: %s", args, err)
+		}
+		if len(result) != numRow {
+			t.Fatalf("args %v: number of rows %d - expected %d", args, len(result), numRow)
+		}
+		for i, r := range result {
+			if r.ID != i {
+				t.Fatalf("args %v: row %d id %d - expected %d", args, i, r.ID, i)
+			}
+		}
+	}
+}
+
+func TestAssignValueOverflow(t *testing.T) {
+	var s struct {
+		I8  int8
+		U   uint
+		U8  uint8
+		I64 int64
+		F32 float32
+	}
+	rv := reflect.ValueOf(&s).Elem()
+
+	tests := []struct {
+		field string
+		v     driver.Value
+		ok    bool
+	}{
+		{"I8", int64(127), true},
+		{"I8", int64(128), false},
+		{"I8", int64(-129), false},
+		{"U", int64(-1), false},
+		{"U", int64(1), true},
+		{"U8", int64(256), false},
+		{"I64", uint64(math.MaxUint64), false},
+		{"F32", float64(math.MaxFloat64), false},
+		{"F32", float64(1.5), true},
+	}
+	for _, test := range tests {
+		err := assignValue(rv.FieldByName(test.field), test.v)
+		if (err == nil) != test.ok {
+			t.Fatalf("field %s value %v: error %v - expected ok %t", test.field, test.v, err, test.ok)
+		}
+	}
+}
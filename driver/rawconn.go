@@ -0,0 +1,379 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+/*
+RawAPIVersion is the version of the raw connection API (see RawConn).
+
+The major version 0 marks the API as unstable: types, methods and the returned values might change incompatibly
+with any go-hdb release.
+*/
+const RawAPIVersion = "0.2.0"
+
+/*
+RawConn provides protocol level access to the database session of a connection for power users
+(e.g. fetch control of resultsets or chunked lob reads), bypassing the database/sql abstractions.
+
+The raw connection API is UNSTABLE (see RawAPIVersion).
+
+A RawConn is obtained from a driver connection via sql.Conn.Raw:
+
+	err := conn.Raw(func(driverConn any) error {
+		rc, err := driver.NewRawConn(driverConn)
+		if err != nil {
+			return err
+		}
+		reply, err := rc.ExecuteDirect(ctx, "select * from t", true)
+		...
+	})
+
+The RawConn must only be used within the function passed to sql.Conn.Raw. Resources allocated on the database
+server by raw operations (open resultsets and lob locators) are not tracked by the driver and need to be released
+by the application (see RawResultset.Close and RawConn.ReadLob). Statements executed via a raw connection do not
+change the connection state known to database/sql (e.g. transactions).
+*/
+type RawConn struct {
+	c *conn
+}
+
+// NewRawConn returns the raw connection of the driver connection driverConn (see sql.Conn.Raw).
+func NewRawConn(driverConn any) (*RawConn, error) {
+	c, ok := driverConn.(*conn)
+	if !ok {
+		return nil, fmt.Errorf("raw connection: invalid driver connection type %T", driverConn)
+	}
+	return &RawConn{c: c}, nil
+}
+
+// SessionID returns the database session id of the connection.
+func (rc *RawConn) SessionID() int64 { return rc.c.sessionID }
+
+// do executes fn as database call of the connection honoring the cancellation of ctx.
+func (rc *RawConn) do(ctx context.Context, fn func() error) error {
+	c := rc.c
+	done := make(chan struct{})
+	var err error
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		err = fn()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		c.cancel()
+		return classifyError(ctx.Err())
+	case <-done:
+		c.setLastError(err)
+		return classifyError(err)
+	}
+}
+
+/*
+A RawPart is a protocol part of a raw request or reply in its encoded form.
+Kind, attributes and the encoding of the data are defined by the SAP HANA SQL command network protocol.
+*/
+type RawPart struct {
+	Kind       int8   // part kind
+	Attributes int8   // part attributes (e.g. last packet of a resultset)
+	NumArg     int    // number of arguments
+	Data       []byte // encoded part data
+}
+
+// KindName returns the name of the part kind (e.g. ResultMetadata).
+func (rp *RawPart) KindName() string { return protocolName(p.PartKind(rp.Kind)) }
+
+// A RawReply is the reply of a statement executed via a raw connection.
+type RawReply struct {
+	FunctionCode string     // function code of the reply (e.g. Select, Insert or DDL)
+	Parts        []*RawPart // reply parts in encoded form
+	RowsAffected int64
+	Resultset    *RawResultset // resultset of a query (nil if the statement does not return a resultset)
+}
+
+// execute writes a request message of type mt and reads the reply parts in encoded form.
+func (rc *RawConn) execute(ctx context.Context, mt p.MessageType, commit bool, parts []p.WritablePart, reply *RawReply) ([]*p.EncodedPart, error) {
+	c := rc.c
+	if err := c.pw.Write(ctx, c.sessionID, mt, commit, parts...); err != nil {
+		return nil, err
+	}
+	var encodedParts []*p.EncodedPart
+	if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+		part := p.NewEncodedPart(kind)
+		read(part)
+		part.SetAttributes(attrs)
+		encodedParts = append(encodedParts, part)
+		reply.Parts = append(reply.Parts, &RawPart{Kind: int8(kind), Attributes: int8(attrs), NumArg: part.NumArg(), Data: part.Buf})
+	}); err != nil {
+		return nil, err
+	}
+	reply.FunctionCode = protocolName(c.pr.FunctionCode())
+	return encodedParts, nil
+}
+
+/*
+Execute sends a request message of messageType containing the encoded parts and returns the reply of the database server.
+If commit is true, the statement is committed by the database server (autocommit).
+
+Message type and parts are sent as provided without any checks. The reply parts are returned in encoded form
+(see RawReply.Parts), errors sent by the database server are returned as error. RowsAffected and Resultset
+of the reply are not set.
+*/
+func (rc *RawConn) Execute(ctx context.Context, messageType int8, commit bool, parts ...*RawPart) (*RawReply, error) {
+	writableParts := make([]p.WritablePart, len(parts))
+	for i, part := range parts {
+		writableParts[i] = p.NewWritableEncodedPart(p.PartKind(part.Kind), p.PartAttributes(part.Attributes), part.NumArg, part.Data)
+	}
+	reply := &RawReply{}
+	if err := rc.do(ctx, func() error {
+		_, err := rc.execute(ctx, p.MessageType(messageType), commit, writableParts, reply)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+/*
+ExecuteDirect executes query without preparing it and returns the reply of the database server.
+If commit is true, the statement is committed by the database server (autocommit).
+
+The statement context (e.g. statement timeout) and session context values of ctx are sent with the execution
+(see package hdbctx). The resultset of a query contains the rows of the first fetch roundtrip only.
+*/
+func (rc *RawConn) ExecuteDirect(ctx context.Context, query string, commit bool) (*RawReply, error) {
+	reply := &RawReply{}
+	if err := rc.do(ctx, func() error {
+		c := rc.c
+		sc, err := c.stmtContext(ctx)
+		if err != nil {
+			return err
+		}
+		encodedParts, err := rc.execute(ctx, p.MtExecuteDirect, commit, []p.WritablePart{p.Command(query), sc, c.clientInfo(ctx)}, reply)
+		if err != nil {
+			return err
+		}
+		c.numStmt++
+
+		rs := &RawResultset{rc: rc}
+		meta := &p.ResultMetadata{}
+		rows := &p.RowsAffected{}
+		resSet := &p.Resultset{}
+
+		for _, part := range encodedParts {
+			switch part.Kind() {
+			case p.PkRowsAffected:
+				err = part.Decode(rows)
+				reply.RowsAffected = rows.Total()
+			case p.PkResultMetadata:
+				err = part.Decode(meta)
+				rs.fields = meta.ResultFields
+			case p.PkResultsetID:
+				err = part.Decode((*p.ResultsetID)(&rs.id))
+			case p.PkResultset:
+				resSet.ResultFields = rs.fields
+				err = part.Decode(resSet)
+				rs.lastPacket, rs.closed = part.Attributes().LastPacket(), part.Attributes().ResultsetClosed()
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if rs.id == 0 { // non select query
+			return nil
+		}
+		reply.Resultset = rs
+		return rs.setRows(resSet)
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// protocolName returns the name of the protocol constant v without its prefix (e.g. fc or Pk).
+func protocolName(v fmt.Stringer) string {
+	name := v.String()
+	if len(name) > 2 && (strings.EqualFold(name[:2], "fc") || strings.EqualFold(name[:2], "pk")) {
+		return name[2:]
+	}
+	return name
+}
+
+/*
+A RawResultset is a resultset opened by a raw connection.
+
+Row values are provided as decoded by the driver with the exception of lob values, which are provided as *RawLob.
+The resultset is open on the database server until all rows are fetched or the resultset is closed.
+*/
+type RawResultset struct {
+	rc         *RawConn
+	id         uint64
+	fields     []*p.ResultField
+	rows       [][]any
+	lastPacket bool
+	closed     bool
+}
+
+// ID returns the resultset id.
+func (rs *RawResultset) ID() uint64 { return rs.id }
+
+// Columns returns the column names of the resultset.
+func (rs *RawResultset) Columns() []string {
+	columns := make([]string, len(rs.fields))
+	for i, f := range rs.fields {
+		columns[i] = f.Name()
+	}
+	return columns
+}
+
+// ColumnTypes returns the database type names of the resultset columns.
+func (rs *RawResultset) ColumnTypes() []string {
+	types := make([]string, len(rs.fields))
+	for i, f := range rs.fields {
+		types[i] = f.TypeName()
+	}
+	return types
+}
+
+// Rows returns the rows of the last fetch roundtrip.
+func (rs *RawResultset) Rows() [][]any { return rs.rows }
+
+// LastPacket returns true if the last fetch roundtrip returned the last rows of the resultset.
+func (rs *RawResultset) LastPacket() bool { return rs.lastPacket }
+
+// Closed returns true if the resultset is closed on the database server.
+func (rs *RawResultset) Closed() bool { return rs.closed }
+
+// setRows sets the rows of resultset part resSet.
+func (rs *RawResultset) setRows(resSet *p.Resultset) error {
+	numCol := len(rs.fields)
+	if numCol == 0 {
+		rs.rows = nil
+		return nil
+	}
+	numRow := len(resSet.FieldValues) / numCol
+	rs.rows = make([][]any, numRow)
+	for i := 0; i < numRow; i++ {
+		if err := resSet.DecodeErrors.RowError(i); err != nil {
+			return err
+		}
+		row := make([]any, numCol)
+		for j, v := range resSet.FieldValues[i*numCol : (i+1)*numCol] {
+			if descr, ok := v.(*p.LobOutDescr); ok {
+				v = newRawLob(descr)
+			}
+			row[j] = v
+		}
+		rs.rows[i] = row
+	}
+	return nil
+}
+
+/*
+Fetch fetches the next fetchSize rows of the resultset, which replace the rows of the last fetch roundtrip (see Rows).
+Fetch returns zero rows if the last packet was read already.
+*/
+func (rs *RawResultset) Fetch(ctx context.Context, fetchSize int) error {
+	if rs.lastPacket || rs.closed {
+		rs.rows = nil
+		return nil
+	}
+	if fetchSize <= 0 {
+		return fmt.Errorf("raw resultset: invalid fetch size %d", fetchSize)
+	}
+	return rs.rc.do(ctx, func() error {
+		c := rs.rc.c
+		if err := c.pw.Write(ctx, c.sessionID, p.MtFetchNext, false, p.ResultsetID(rs.id), p.Fetchsize(fetchSize)); err != nil {
+			return err
+		}
+		resSet := &p.Resultset{ResultFields: rs.fields}
+		if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+			if kind == p.PkResultset {
+				read(resSet)
+				rs.lastPacket, rs.closed = attrs.LastPacket(), attrs.ResultsetClosed()
+			}
+		}); err != nil {
+			return err
+		}
+		return rs.setRows(resSet)
+	})
+}
+
+// Close closes the resultset on the database server. Close is idempotent.
+func (rs *RawResultset) Close(ctx context.Context) error {
+	if rs.closed {
+		return nil
+	}
+	return rs.rc.do(ctx, func() error {
+		if err := rs.rc.c.closeResultsetID(ctx, rs.id); err != nil {
+			return err
+		}
+		rs.closed = true
+		return nil
+	})
+}
+
+/*
+A RawLob is a lob value of a raw resultset.
+
+Data contains the lob data returned inline with the row (CESU-8 encoded for character based lobs). If Last is false,
+the lob locator is open on the database server and the remaining data needs to be read via RawConn.ReadLob.
+*/
+type RawLob struct {
+	Locator   uint64
+	CharBased bool  // character based lob (Length in characters, otherwise bytes)
+	Length    int64 // total lob length
+	Data      []byte
+	Last      bool
+}
+
+func newRawLob(descr *p.LobOutDescr) *RawLob {
+	return &RawLob{
+		Locator:   uint64(descr.ID),
+		CharBased: descr.IsCharBased,
+		Length:    descr.NumChar,
+		Data:      descr.B,
+		Last:      descr.Opt.IsLastData(),
+	}
+}
+
+/*
+ReadLob reads a chunk of at most size characters (character based lobs) respectively bytes of lob starting at ofs
+(zero based, in characters respectively bytes) and returns the lob data and true, if the chunk contains the last data
+of the lob. Reading the last data releases the lob locator on the database server.
+*/
+func (rc *RawConn) ReadLob(ctx context.Context, lob *RawLob, ofs int64, size int32) ([]byte, bool, error) {
+	if size <= 0 {
+		return nil, false, fmt.Errorf("raw lob: invalid chunk size %d", size)
+	}
+	lobReply := &p.ReadLobReply{}
+	if err := rc.do(ctx, func() error {
+		c := rc.c
+		lobRequest := &p.ReadLobRequest{ID: p.LocatorID(lob.Locator), Ofs: ofs, ChunkSize: size}
+		if err := c.pw.Write(ctx, c.sessionID, p.MtWriteLob, false, lobRequest); err != nil {
+			return err
+		}
+		c.metrics.msgCh <- counterMsg{idx: counterLobChunks, v: 1}
+		if err := c.pr.IterateParts(ctx, func(kind p.PartKind, attrs p.PartAttributes, read func(part p.Part)) {
+			if kind == p.PkReadLobReply {
+				read(lobReply)
+			}
+		}); err != nil {
+			return err
+		}
+		if lobReply.ID != lobRequest.ID {
+			return fmt.Errorf("raw lob: invalid lob locator %d - expected %d", lobReply.ID, lobRequest.ID)
+		}
+		return nil
+	}); err != nil {
+		return nil, false, err
+	}
+	return lobReply.B, lobReply.Opt.IsLastData(), nil
+}
@@ -0,0 +1,118 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+func TestRawConn(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	const numRow = 40
+	data := bytes.Repeat([]byte("0123456789"), 300)
+	rows := make([][]any, numRow)
+	for i := range rows {
+		rows[i] = []any{i, data}
+	}
+	srv.Handle("select id, data from t", &hdbtest.Response{Columns: []hdbtest.Column{{Name: "ID", Type: "INTEGER"}, {Name: "DATA", Type: "BLOB"}}, Rows: rows})
+	srv.Handle("update t set x = 1", &hdbtest.Response{RowsAffected: numRow})
+
+	db := sql.OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := NewRawConn(nil); err == nil {
+		t.Fatal("expected invalid driver connection error")
+	}
+
+	if err := conn.Raw(func(driverConn any) error {
+		rc, err := NewRawConn(driverConn)
+		if err != nil {
+			return err
+		}
+
+		reply, err := rc.ExecuteDirect(ctx, "update t set x = 1", true)
+		if err != nil {
+			return err
+		}
+		if reply.RowsAffected != numRow || reply.Resultset != nil {
+			t.Fatalf("rows affected %d resultset %v - expected %d rows affected without resultset", reply.RowsAffected, reply.Resultset, numRow)
+		}
+
+		// caller encoded request parts
+		command := &RawPart{Kind: int8(p.PkCommand), NumArg: 1, Data: []byte("update t set x = 1")}
+		if reply, err = rc.Execute(ctx, int8(p.MtExecuteDirect), true, command); err != nil {
+			return err
+		}
+		i := slices.IndexFunc(reply.Parts, func(part *RawPart) bool { return part.KindName() == "RowsAffected" })
+		if reply.FunctionCode != "Update" || i == -1 {
+			t.Fatalf("function code %s parts %v - expected Update with rows affected", reply.FunctionCode, reply.Parts)
+		}
+		if part := reply.Parts[i]; part.NumArg != 1 || len(part.Data) != 4 || binary.LittleEndian.Uint32(part.Data) != numRow {
+			t.Fatalf("rows affected part %v - expected %d encoded rows", part, numRow)
+		}
+		if _, err := rc.Execute(ctx, int8(p.MtExecuteDirect), true, &RawPart{Kind: int8(p.PkCommand), NumArg: 1, Data: []byte("unknown")}); err == nil {
+			t.Fatal("expected database server error")
+		}
+
+		reply, err = rc.ExecuteDirect(ctx, "select id, data from t", true)
+		if err != nil {
+			return err
+		}
+		rs := reply.Resultset
+		if reply.FunctionCode != "Select" || !slices.ContainsFunc(reply.Parts, func(part *RawPart) bool { return part.KindName() == "ResultMetadata" }) {
+			t.Fatalf("function code %s parts %v", reply.FunctionCode, reply.Parts)
+		}
+		if columns := rs.Columns(); !slices.Equal(columns, []string{"ID", "DATA"}) {
+			t.Fatalf("columns %v", columns)
+		}
+		n := len(rs.Rows())
+		if n == 0 || n == numRow || rs.LastPacket() { // first roundtrip does not return all rows
+			t.Fatalf("number of rows %d last packet %t", n, rs.LastPacket())
+		}
+
+		// lob
+		lob, ok := rs.Rows()[0][1].(*RawLob)
+		if !ok {
+			t.Fatalf("invalid lob type %T", rs.Rows()[0][1])
+		}
+		if lob.Length != int64(len(data)) || lob.Last {
+			t.Fatalf("lob length %d last %t - expected %d not last", lob.Length, lob.Last, len(data))
+		}
+		b, last, err := rc.ReadLob(ctx, lob, int64(len(lob.Data)), int32(len(data)))
+		if err != nil {
+			return err
+		}
+		if !last || !bytes.Equal(append(lob.Data, b...), data) {
+			t.Fatalf("lob data %d bytes last %t - expected %d bytes", len(lob.Data)+len(b), last, len(data))
+		}
+
+		// fetch remaining rows
+		if err := rs.Fetch(ctx, numRow); err != nil {
+			return err
+		}
+		if n += len(rs.Rows()); n != numRow || !rs.LastPacket() {
+			t.Fatalf("number of rows %d last packet %t - expected %d rows and last packet", n, rs.LastPacket(), numRow)
+		}
+		return rs.Close(ctx)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
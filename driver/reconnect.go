@@ -0,0 +1,103 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// errStmtInvalidated is returned for statements prepared on a session which was replaced by a reconnect.
+var errStmtInvalidated = fmt.Errorf("%w: %w", driver.ErrBadConn, errors.New("statement invalidated by reconnect"))
+
+/*
+A ConnLostError is returned if the database connection was lost and the statement could not
+be replayed transparently on a new connection (see SetReplayable).
+
+Reasons are that the connection was lost within a transaction, the statement is not classified
+as replayable, the statement was prepared on the lost connection or the reconnect failed.
+A ConnLostError does not unwrap to driver.ErrBadConn, so that database/sql does not retry
+the statement on another connection.
+*/
+type ConnLostError struct {
+	InTx bool  // connection was lost within a transaction
+	Err  error // connection error
+}
+
+func (e *ConnLostError) Error() string {
+	if e.InTx {
+		return fmt.Sprintf("connection lost in transaction: %s", e.Err)
+	}
+	return fmt.Sprintf("connection lost: %s", e.Err)
+}
+
+// Unwrap returns the connection error causes excluding driver.ErrBadConn.
+func (e *ConnLostError) Unwrap() []error {
+	var errs []error
+	if u, ok := e.Err.(interface{ Unwrap() []error }); ok {
+		errs = u.Unwrap()
+	} else {
+		errs = []error{e.Err}
+	}
+	return slices.DeleteFunc(slices.Clone(errs), func(err error) bool { return err == driver.ErrBadConn }) //nolint:errorlint
+}
+
+// connLostError returns a ConnLostError for lost connections in case reconnect is enabled, err otherwise.
+func (c *conn) connLostError(err error) error {
+	if c.reconnectSession == nil || !errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	return &ConnLostError{InTx: c.inTx, Err: err}
+}
+
+// replay re-establishes a lost connection and calls fn again in case query is classified as replayable.
+func (c *conn) replay(ctx context.Context, query string, err error, fn func() error) error {
+	if c.reconnectSession == nil || !errors.Is(err, driver.ErrBadConn) {
+		return err
+	}
+	if c.inTx || !c.attrs._replayable(query) {
+		return c.connLostError(err)
+	}
+	if rerr := c.reconnect(ctx, err); rerr != nil {
+		c.logger.LogAttrs(ctx, slog.LevelError, "reconnect error", slog.String("error", rerr.Error()))
+		return c.connLostError(err)
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "connection re-established - replay statement", slog.String("query", query), slog.String("error", err.Error()))
+	return fn()
+}
+
+// reconnect replaces the database session lost by error lostErr by a new one.
+func (c *conn) reconnect(ctx context.Context, lostErr error) error {
+	start := time.Now()
+	nc, err := c.reconnectSession(ctx)
+	if err != nil {
+		c.connEvent(ctx, ConnOpenFailed, connEventReason(err), time.Since(start), err)
+		return err
+	}
+
+	// release lost session
+	c.dbConn.close()
+	stdConnTracker.release()                           // the call db is still in use by the new session
+	c.metrics.msgCh <- gaugeMsg{idx: gaugeConn, v: -1} // decrement open connections.
+	c.connEvent(ctx, ConnClosed, ConnReasonConnLost, time.Since(c.opened), lostErr)
+
+	c.dbConn, c.dec, c.pr, c.pw = nc.dbConn, nc.dec, nc.pr, nc.pw
+	// reader handlers are bound to nc
+	c.pr.SetWarningHandler(c.handleWarning)
+	c.pr.SetTransactionFlagsHandler(c.handleTransactionFlags)
+	c.sessionID, c.serverOptions, c.hdbVersion, c.host = nc.sessionID, nc.serverOptions, nc.hdbVersion, nc.host
+	c.logger, c.cancelSession = nc.logger, nc.cancelSession
+	c.numStmt = 0
+	c.sessCtx = nil // session context of lost session
+	c.reconnects++
+	c.metrics.msgCh <- counterMsg{idx: counterReconnects, v: 1}
+	c.stmtCache.clear() // statement ids of lost session
+	if !c.opened.IsZero() {
+		c.opened = time.Now()
+		c.connEvent(ctx, ConnOpened, ConnReasonNone, c.opened.Sub(start), nil)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestConnLostError(t *testing.T) {
+	var err error = &ConnLostError{InTx: true, Err: fmt.Errorf("%w: %w", driver.ErrBadConn, io.EOF)}
+
+	if errors.Is(err, driver.ErrBadConn) {
+		t.Fatal("connection lost error should not unwrap to driver.ErrBadConn")
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("connection lost error should unwrap to %v", io.EOF)
+	}
+	var connLostError *ConnLostError
+	if !errors.As(err, &connLostError) || !connLostError.InTx {
+		t.Fatal("connection lost error in transaction expected")
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	srv.Handle("select 1 from dummy", &hdbtest.Response{Columns: []hdbtest.Column{{Name: "1", Type: "INTEGER"}}, Rows: [][]any{{int64(1)}}})
+
+	var numBeforeConnect, numAfterConnect int
+	subscriber := &testConnEventSubscriber{}
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetReplayable(func(query string) bool { return true })
+	connector.SetBeforeConnect(func(ctx context.Context, connector *Connector) error { numBeforeConnect++; return nil })
+	connector.SetAfterConnect(func(ctx context.Context, conn driver.Conn) error { numAfterConnect++; return nil })
+	connector.SetConnEventSubscriber(subscriber)
+
+	ctx := context.Background()
+	dc, err := connector.Connect(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := dc.(*conn)
+	defer c.Close()
+
+	c.dbConn.conn.Close() // simulate lost connection
+	rows, err := c.QueryContext(ctx, "select 1 from dummy", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+
+	if c.reconnects != 1 {
+		t.Fatalf("number of reconnects %d - expected 1", c.reconnects)
+	}
+	if numBeforeConnect != 2 || numAfterConnect != 2 {
+		t.Fatalf("number of before connect calls %d after connect calls %d - expected 2 2", numBeforeConnect, numAfterConnect)
+	}
+	if kinds, expected := subscriber.kinds(), []ConnEventKind{ConnOpened, ConnClosed, ConnOpened}; !slices.Equal(kinds, expected) {
+		t.Fatalf("event kinds %v - expected %v", kinds, expected)
+	}
+	if event := subscriber.events[1]; event.Reason != ConnReasonConnLost || event.Err == nil {
+		t.Fatalf("closed event reason %s error %v - expected reason %s", event.Reason, event.Err, ConnReasonConnLost)
+	}
+}
@@ -0,0 +1,149 @@
+package driver
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// prologKey is the key of the recorded protocol prolog.
+const prologKey = "prolog"
+
+// exchange is a recorded client request and the corresponding database reply.
+type exchange struct {
+	Key     string `json:"key"`
+	Request []byte `json:"request"`
+	Reply   []byte `json:"reply"`
+}
+
+/*
+exchangeKeyer derives the keys of protocol exchanges.
+
+Requests are keyed by message type and statement text. Requests referring to a statement by id (e.g. execute
+of a prepared statement or fetch of a resultset) are keyed by the statement text the id was returned for.
+*/
+type exchangeKeyer struct {
+	stmts      map[uint64]string // statement text by statement id
+	resultsets map[uint64]string // statement text by resultset id
+}
+
+func newExchangeKeyer() *exchangeKeyer {
+	return &exchangeKeyer{stmts: map[uint64]string{}, resultsets: map[uint64]string{}}
+}
+
+// statement returns the statement text of the request.
+func (k *exchangeKeyer) statement(req *p.RawMessageInfo) string {
+	switch req.MessageType {
+	case p.MtExecuteDirect, p.MtPrepare:
+		return req.Command
+	case p.MtExecute, p.MtDropStatementID:
+		return k.stmts[req.StatementID]
+	case p.MtFetchNext, p.MtCloseResultset:
+		return k.resultsets[req.ResultsetID]
+	default:
+		return ""
+	}
+}
+
+// key returns the key of the request.
+func (k *exchangeKeyer) key(req *p.RawMessageInfo) string {
+	if stmt := k.statement(req); stmt != "" {
+		return req.MessageType.String() + " " + stmt
+	}
+	return req.MessageType.String()
+}
+
+// update registers the statement and resultset ids returned by reply.
+func (k *exchangeKeyer) update(req *p.RawMessageInfo, reply []byte) {
+	rep := p.ParseRawReply(reply)
+	stmt := k.statement(req)
+	if rep.StatementID != 0 {
+		k.stmts[rep.StatementID] = stmt
+	}
+	if rep.ResultsetID != 0 {
+		k.resultsets[rep.ResultsetID] = stmt
+	}
+}
+
+/*
+A Replayer replays a protocol session recorded by a Sniffer (see Sniffer.SetRecorder) to a client without
+a database server, e.g. for deterministic integration tests or for reproducing issues from production traces.
+
+Client requests are answered by the recorded reply of the request with the same key (message type and statement
+text) in the recorded order. Other request attributes like statement arguments are not compared.
+As a recording covers a single database connection, a client should not open more than one connection
+to the replayer (e.g. sql.DB.SetMaxOpenConns(1)).
+*/
+type Replayer struct {
+	prolog    *exchange
+	exchanges map[string][]*exchange // exchanges by key in recorded order
+}
+
+// NewReplayer returns a replayer of the protocol session recorded in rd.
+func NewReplayer(rd io.Reader) (*Replayer, error) {
+	r := &Replayer{exchanges: map[string][]*exchange{}}
+	dec := json.NewDecoder(rd)
+	for {
+		e := &exchange{}
+		if err := dec.Decode(e); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if e.Key == prologKey {
+			r.prolog = e
+			continue
+		}
+		r.exchanges[e.Key] = append(r.exchanges[e.Key], e)
+	}
+	if r.prolog == nil {
+		return nil, errors.New("replay: recorded protocol prolog missing")
+	}
+	return r, nil
+}
+
+// Serve replays the recorded protocol session to the client connection conn until the client closes the connection.
+func (r *Replayer) Serve(conn net.Conn) error {
+	rd := bufio.NewReader(conn)
+
+	req := make([]byte, p.InitRequestSize)
+	if _, err := io.ReadFull(rd, req); err != nil {
+		return err
+	}
+	if _, err := conn.Write(r.prolog.Reply); err != nil {
+		return err
+	}
+
+	pos := map[string]int{}
+	keyer := newExchangeKeyer()
+	for {
+		req, err := p.ReadRawMessage(rd)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		info := p.ParseRawRequest(req)
+		if info.MessageType == p.MtDisconnect { // client does not read the reply
+			return nil
+		}
+		key := keyer.key(info)
+		exchanges := r.exchanges[key]
+		if pos[key] >= len(exchanges) {
+			return fmt.Errorf("replay: no recorded reply for request %s", key)
+		}
+		e := exchanges[pos[key]]
+		pos[key]++
+		if _, err := conn.Write(e.Reply); err != nil {
+			return err
+		}
+		keyer.update(info, e.Reply)
+	}
+}
@@ -0,0 +1,127 @@
+package driver
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+// listen starts serving client connections of a local listener by serve.
+func listen(t *testing.T, serve func(conn net.Conn)) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wg := &sync.WaitGroup{}
+	t.Cleanup(func() {
+		ln.Close()
+		wg.Wait()
+	})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close()
+				serve(conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// runReplaySession executes the statements of the recorded session and returns the query results.
+func runReplaySession(t *testing.T, addr string) []string {
+	connector := NewBasicAuthConnector(addr, "MOCK", "MOCK")
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("insert into t values (?)", "a"); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for i := 0; i < 2; i++ { // same statement executed twice
+		rows, err := db.Query("select name from t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				t.Fatal(err)
+			}
+			names = append(names, name)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+	}
+	return names
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	numQuery := 0
+	srv.Handle("insert into t values (?)", &hdbtest.Response{RowsAffected: 1})
+	srv.Handle("select name from t", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 10}},
+		Func: func(args []driver.Value) ([][]any, int64, error) {
+			numQuery++
+			if numQuery == 1 {
+				return [][]any{{"a"}}, 0, nil
+			}
+			return [][]any{{"a"}, {"b"}}, 0, nil
+		},
+	})
+
+	// record
+	recording := &bytes.Buffer{}
+	done := make(chan struct{})
+	sniffer := listen(t, func(conn net.Conn) {
+		defer close(done)
+		dbConn, err := net.Dial("tcp", srv.Addr())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer dbConn.Close()
+		NewSniffer(conn, dbConn).SetRecorder(recording).Run() //nolint:errcheck
+	})
+	recorded := runReplaySession(t, sniffer)
+	<-done      // recording completed
+	srv.Close() // replay without database
+
+	// replay
+	replayer, err := NewReplayer(bytes.NewReader(recording.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay := listen(t, func(conn net.Conn) {
+		if err := replayer.Serve(conn); err != nil {
+			t.Error(err)
+		}
+	})
+	replayed := runReplaySession(t, replay)
+
+	expected := []string{"a", "a", "b"}
+	if !slices.Equal(recorded, expected) || !slices.Equal(replayed, expected) {
+		t.Fatalf("recorded %v replayed %v - expected %v", recorded, replayed, expected)
+	}
+}
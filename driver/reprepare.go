@@ -0,0 +1,52 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// isStmtInvalidated returns true if err reports a prepared statement invalidated by the database server,
+// e.g. because a view or procedure referenced by the statement was dropped and re-created.
+func isStmtInvalidated(err error) bool {
+	return hasErrorCode(err, HdbErrInvalidatedView, HdbErrInvalidatedProcedure)
+}
+
+/*
+reprepareOnInvalidation calls fn and, in case the database server reports the prepared statement as invalidated,
+re-prepares the statement and calls fn once more.
+
+Executions which might have been applied partially (bulk executions) or consumed their arguments (e.g. lob readers)
+are not repeated.
+*/
+func (s *stmt) reprepareOnInvalidation(ctx context.Context, nvargs []driver.NamedValue, fn func() error) error {
+	if s.cacheEntry != nil { // statement might have been re-prepared by another statement of the cache entry
+		s.pr = s.cacheEntry.pr
+	}
+	err := fn()
+	if err == nil || !isStmtInvalidated(err) || !retryableArgs(nvargs, s.pr.numField()) {
+		return err
+	}
+	if err := s.reprepare(ctx); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// reprepare replaces the invalidated prepared statement by a newly prepared one.
+func (s *stmt) reprepare(ctx context.Context) error {
+	c := s.conn
+	// drop the invalidated statement id first - statements of the cache entry switch to the re-prepared statement
+	if err := c.dropStatementID(ctx, s.pr.stmtID); err != nil {
+		return err
+	}
+	pr, err := c.prepare(ctx, s.query)
+	if err != nil {
+		return err
+	}
+	c.metrics.msgCh <- counterMsg{idx: counterReprepares, v: 1}
+	s.pr = pr
+	if s.cacheEntry != nil {
+		s.cacheEntry.pr = pr
+	}
+	return nil
+}
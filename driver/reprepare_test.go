@@ -0,0 +1,91 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestReprepare(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	invalidated := false
+	srv.Handle("select name from v where id = ?", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}},
+		Func: func(args []driver.Value) ([][]any, int64, error) {
+			if !invalidated {
+				invalidated = true
+				return nil, 0, &hdbtest.Error{Code: int(HdbErrInvalidatedView), Text: "invalidated view"}
+			}
+			return [][]any{{"name"}}, 0, nil
+		},
+	})
+
+	db := OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	defer db.Close()
+
+	var name string
+	if err := db.QueryRowContext(context.Background(), "select name from v where id = ?", "1").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "name" {
+		t.Fatalf("name %s - expected %s", name, "name")
+	}
+	if reprepares := db.ExStats().Reprepares; reprepares != 1 {
+		t.Fatalf("reprepares %d - expected %d", reprepares, 1)
+	}
+}
+
+func TestReprepareCached(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	invalidated := false
+	srv.Handle("call p(?)", &hdbtest.Response{
+		Func: func(args []driver.Value) ([][]any, int64, error) {
+			if !invalidated {
+				invalidated = true
+				return nil, 0, &hdbtest.Error{Code: int(HdbErrInvalidatedProcedure), Text: "invalidated procedure"}
+			}
+			return nil, 1, nil
+		},
+	})
+
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetStmtCacheSize(10)
+	db := OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	// statements sharing the cached prepared statement
+	stmt1, err := db.Prepare("call p(?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt1.Close()
+	stmt2, err := db.Prepare("call p(?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt2.Close()
+
+	if _, err := stmt1.Exec("1"); err != nil {
+		t.Fatal(err)
+	}
+	// the invalidated statement id is dropped - stmt2 needs to use the re-prepared statement
+	if _, err := stmt2.Exec("2"); err != nil {
+		t.Fatal(err)
+	}
+	if reprepares := db.ExStats().Reprepares; reprepares != 1 {
+		t.Fatalf("reprepares %d - expected %d", reprepares, 1)
+	}
+}
@@ -0,0 +1,149 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+/*
+ResetPolicy defines the actions performed on a pooled connection before it is reused (see SetResetPolicy),
+so that state left by a previous user of the connection does not leak into the next one (e.g. in multi-tenant
+applications).
+
+Each action requires at least one database roundtrip when a connection is reset. If an action fails the connection
+is discarded by the connection pool.
+*/
+type ResetPolicy struct {
+	// Rollback rolls back the open transaction of the session (e.g. opened by a 'set transaction autocommit off' statement).
+	Rollback bool
+	// DropTempTables drops the local temporary tables (#table) created by the session.
+	DropTempTables bool
+	// ResetSessionVariables unsets the session variables set by the application and restores
+	// the session variables of the connector (see SetSessionVariables).
+	ResetSessionVariables bool
+	// ResetSchema restores the default schema of the connector (see SetDefaultSchema) or the user schema
+	// if no default schema is set.
+	ResetSchema bool
+}
+
+const (
+	resetTempTablesQuery        = "select schema_name, table_name from m_temporary_tables where connection_id = current_connection and table_name like '#%'"
+	resetSessionVariablesQuery  = "select key from m_session_context where connection_id = current_connection and section = 'USER'"
+	resetCurrentUserQuery       = "select current_user from dummy"
+	resetUnsetSessionVariable   = "unset '%s'"
+	resetSetSessionVariable     = "set '%s' = '%s'"
+	resetDropTempTableStatement = "drop table %s.%s"
+)
+
+// quoteString returns s as the content of a SQL string literal.
+func quoteString(s string) string { return strings.ReplaceAll(s, "'", "''") }
+
+// queryStrings executes query and returns the string values of all rows.
+func (c *conn) queryStrings(ctx context.Context, query string) ([][]string, error) {
+	rows, err := c.queryDirect(ctx, query, true)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values [][]string
+	dest := make([]driver.Value, len(rows.Columns()))
+	for {
+		if err := rows.Next(dest); err != nil {
+			if errors.Is(err, io.EOF) {
+				return values, nil
+			}
+			return nil, err
+		}
+		row := make([]string, len(dest))
+		for i, v := range dest {
+			row[i] = explainString(v)
+		}
+		values = append(values, row)
+	}
+}
+
+// resetRollback rolls back the open transaction of the session.
+func (c *conn) resetRollback(ctx context.Context) error {
+	c.inTx = false
+	return c.rollback(ctx)
+}
+
+// resetTempTables drops the local temporary tables of the session.
+func (c *conn) resetTempTables(ctx context.Context) error {
+	tables, err := c.queryStrings(ctx, resetTempTablesQuery)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if _, err := c.execDirect(ctx, fmt.Sprintf(resetDropTempTableStatement, Identifier(table[0]), Identifier(table[1])), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetSessionVariables unsets the application session variables and restores the connector session variables.
+func (c *conn) resetSessionVariables(ctx context.Context) error {
+	keys, err := c.queryStrings(ctx, resetSessionVariablesQuery)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := c.attrs._sessionVariables[key[0]]; ok {
+			continue
+		}
+		if _, err := c.execDirect(ctx, fmt.Sprintf(resetUnsetSessionVariable, quoteString(key[0])), true); err != nil {
+			return err
+		}
+	}
+	for k, v := range c.attrs._sessionVariables {
+		if _, err := c.execDirect(ctx, fmt.Sprintf(resetSetSessionVariable, quoteString(k), quoteString(v)), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetSchema restores the default schema of the session.
+func (c *conn) resetSchema(ctx context.Context) error {
+	schema := c.attrs._defaultSchema
+	if schema == "" {
+		users, err := c.queryStrings(ctx, resetCurrentUserQuery)
+		if err != nil {
+			return err
+		}
+		if len(users) != 1 {
+			return errors.New("reset schema: current user not available")
+		}
+		schema = users[0][0]
+	}
+	_, err := c.execDirect(ctx, strings.Join([]string{setDefaultSchema, Identifier(schema).String()}, " "), true)
+	return err
+}
+
+// reset performs the actions of the reset policy.
+func (c *conn) reset(ctx context.Context, policy *ResetPolicy) error {
+	actions := []struct {
+		enabled bool
+		fn      func(ctx context.Context) error
+	}{
+		{policy.Rollback, c.resetRollback},
+		{policy.DropTempTables, c.resetTempTables},
+		{policy.ResetSessionVariables, c.resetSessionVariables},
+		{policy.ResetSchema, c.resetSchema},
+	}
+	for _, action := range actions {
+		if !action.enabled {
+			continue
+		}
+		if err := action.fn(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build !unit
+
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+func TestResetPolicy(t *testing.T) {
+	connector := driver.MT.NewConnector()
+	connector.SetSessionVariables(driver.SessionVariables{"k1": "v1"})
+	connector.SetResetPolicy(&driver.ResetPolicy{DropTempTables: true, ResetSessionVariables: true, ResetSchema: true})
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1) // reuse connection
+
+	ctx := context.Background()
+
+	table := driver.RandomIdentifier("#testResetPolicy_")
+	// use connection: create local temporary table, set session variables and schema
+	if err := func() error {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		for _, stmt := range []string{
+			fmt.Sprintf("create local temporary table %s (i integer)", table),
+			"set 'k1' = 'changed'",
+			"set 'k2' = 'v2'",
+			"set schema sys",
+		} {
+			if _, err := conn.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}(); err != nil {
+		t.Fatal(err)
+	}
+
+	// reuse connection: check reset
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var n int
+	if err := conn.QueryRowContext(ctx, "select count(*) from m_temporary_tables where connection_id = current_connection and table_name like '#%'").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("number of local temporary tables %d - expected 0", n)
+	}
+
+	var k1 string
+	if err := conn.QueryRowContext(ctx, "select session_context('k1') from dummy").Scan(&k1); err != nil {
+		t.Fatal(err)
+	}
+	if k1 != "v1" {
+		t.Fatalf("session variable k1 value %s - expected v1", k1)
+	}
+	var k2 sql.NullString
+	if err := conn.QueryRowContext(ctx, "select session_context('k2') from dummy").Scan(&k2); err != nil {
+		t.Fatal(err)
+	}
+	if k2.Valid {
+		t.Fatalf("session variable k2 value %s - expected NULL", k2.String)
+	}
+
+	var schema string
+	if err := conn.QueryRowContext(ctx, "select current_schema from dummy").Scan(&schema); err != nil {
+		t.Fatal(err)
+	}
+	if expected := connector.DefaultSchema(); expected != "" && schema != expected {
+		t.Fatalf("schema %s - expected %s", schema, expected)
+	}
+	if schema == "SYS" {
+		t.Fatal("schema not reset")
+	}
+}
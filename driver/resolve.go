@@ -0,0 +1,149 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"sync"
+)
+
+// IPFamily is the IP address family used to connect to the database server.
+type IPFamily byte
+
+// IPFamily constants.
+const (
+	IPFamilyAny  IPFamily = iota // IPv4 and IPv6 addresses (default)
+	IPFamilyIPv4                 // IPv4 addresses only
+	IPFamilyIPv6                 // IPv6 addresses only
+)
+
+func (f IPFamily) network() string {
+	switch f {
+	case IPFamilyIPv4:
+		return "ip4"
+	case IPFamilyIPv6:
+		return "ip6"
+	default:
+		return "ip"
+	}
+}
+
+// HostResolution controls the resolution of database server host names (see SetHostResolution).
+type HostResolution struct {
+	// ResolveOnce resolves each host name once and reuses the addresses for all connection attempts
+	// (until connecting to all addresses of a host failed). Otherwise host names are resolved per connection attempt.
+	ResolveOnce bool
+	// IPFamily is the IP address family of the resolved addresses.
+	IPFamily IPFamily
+	// HostAddresses maps host names to addresses (IP addresses or host names with optional port) and is applied before
+	// the resolution, e.g. for internal host names returned by the database server which clients cannot resolve.
+	HostAddresses map[string]string
+}
+
+// hostResolver resolves database server addresses according to the host resolution configuration.
+type hostResolver struct {
+	cfg   HostResolution
+	mu    sync.Mutex
+	addrs map[string][]string // resolved addresses by host (ResolveOnce)
+}
+
+func newHostResolver(cfg HostResolution) *hostResolver {
+	cfg.HostAddresses = maps.Clone(cfg.HostAddresses)
+	return &hostResolver{cfg: cfg, addrs: map[string][]string{}}
+}
+
+// mapHost applies the host address mapping to host and port.
+func (r *hostResolver) mapHost(host, port string) (string, string) {
+	mapped, ok := r.cfg.HostAddresses[host]
+	if !ok {
+		return host, port
+	}
+	if mappedHost, mappedPort, err := net.SplitHostPort(mapped); err == nil {
+		return mappedHost, mappedPort
+	}
+	return mapped, port
+}
+
+// resolve returns the addresses of address (host:port). Host names are returned unresolved if resolution is not needed.
+func (r *hostResolver) resolve(ctx context.Context, address string) ([]string, error) {
+	if r == nil {
+		return []string{address}, nil
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return []string{address}, nil // let the dialer report invalid addresses
+	}
+	host, port = r.mapHost(host, port)
+	if _, err := netip.ParseAddr(host); err == nil || (!r.cfg.ResolveOnce && r.cfg.IPFamily == IPFamilyAny) {
+		return []string{net.JoinHostPort(host, port)}, nil
+	}
+
+	r.mu.Lock()
+	ips, ok := r.addrs[host]
+	r.mu.Unlock()
+	if !ok {
+		addrs, err := net.DefaultResolver.LookupNetIP(ctx, r.cfg.IPFamily.network(), host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no %s address found for host %s", r.cfg.IPFamily.network(), host)
+		}
+		ips = make([]string, len(addrs))
+		for i, addr := range addrs {
+			ips[i] = addr.Unmap().String()
+		}
+		if r.cfg.ResolveOnce {
+			r.mu.Lock()
+			r.addrs[host] = ips
+			r.mu.Unlock()
+		}
+	}
+	addrs := make([]string, len(ips))
+	for i, ip := range ips {
+		addrs[i] = net.JoinHostPort(ip, port)
+	}
+	return addrs, nil
+}
+
+// forget removes the resolved addresses of address, so that the host name is resolved again by the next connection attempt.
+func (r *hostResolver) forget(address string) {
+	if r == nil {
+		return
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return
+	}
+	host, _ = r.mapHost(host, port)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.addrs, host)
+}
+
+// dial connects to the database server host via the dialer of the connector trying all resolved addresses in order.
+func (c *connAttrs) dial(ctx context.Context, host string) (net.Conn, error) {
+	addrs, err := c._hostResolver.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, addr := range addrs {
+		netConn, err := c._dialer.DialContext(ctx, addr, c.dialerOptions())
+		if err == nil {
+			return netConn, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	c._hostResolver.forget(host)
+	if len(errs) == 1 {
+		return nil, errs[0]
+	}
+	return nil, errors.Join(errs...)
+}
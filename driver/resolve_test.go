@@ -0,0 +1,67 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/dial"
+)
+
+func TestHostResolver(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		cfg      *HostResolution
+		address  string
+		expected []string
+	}{
+		{nil, "myhost:30015", []string{"myhost:30015"}},
+		{&HostResolution{}, "myhost:30015", []string{"myhost:30015"}},
+		{&HostResolution{HostAddresses: map[string]string{"myhost": "10.0.0.1"}}, "myhost:30015", []string{"10.0.0.1:30015"}},
+		{&HostResolution{HostAddresses: map[string]string{"myhost": "proxy:40000"}}, "myhost:30015", []string{"proxy:40000"}},
+		{&HostResolution{HostAddresses: map[string]string{"myhost": "::1"}}, "myhost:30015", []string{"[::1]:30015"}},
+		{&HostResolution{IPFamily: IPFamilyIPv4}, "127.0.0.1:30015", []string{"127.0.0.1:30015"}},
+		{&HostResolution{IPFamily: IPFamilyIPv4, HostAddresses: map[string]string{"myhost": "localhost"}}, "myhost:30015", []string{"127.0.0.1:30015"}},
+	}
+
+	for _, test := range tests {
+		var r *hostResolver
+		if test.cfg != nil {
+			r = newHostResolver(*test.cfg)
+		}
+		addrs, err := r.resolve(ctx, test.address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(addrs, test.expected) {
+			t.Fatalf("address %s: resolved addresses %v - expected %v", test.address, addrs, test.expected)
+		}
+	}
+}
+
+func TestHostResolverDial(t *testing.T) {
+	ctx := context.Background()
+
+	errDial := errors.New("dial error")
+	var dialed []string
+
+	attrs := newConnAttrs()
+	attrs.SetDialer(dial.DialerFunc(func(ctx context.Context, address string, options dial.DialerOptions) (net.Conn, error) {
+		dialed = append(dialed, address)
+		return nil, errDial
+	}))
+	attrs.SetHostResolution(&HostResolution{ResolveOnce: true, IPFamily: IPFamilyIPv4})
+
+	if _, err := attrs.dial(ctx, "localhost:30015"); !errors.Is(err, errDial) {
+		t.Fatalf("error %v - expected %v", err, errDial)
+	}
+	if !slices.Contains(dialed, "127.0.0.1:30015") {
+		t.Fatalf("dialed addresses %v - expected %s", dialed, "127.0.0.1:30015")
+	}
+	if len(attrs._hostResolver.addrs) != 0 {
+		t.Fatalf("resolved addresses %v not removed after failed dial", attrs._hostResolver.addrs)
+	}
+}
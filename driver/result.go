@@ -3,8 +3,10 @@ package driver
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"io"
 	"reflect"
+	"time"
 
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 )
@@ -19,13 +21,21 @@ var (
 	_ driver.RowsColumnTypeNullable         = (*queryResult)(nil)
 	_ driver.RowsColumnTypePrecisionScale   = (*queryResult)(nil)
 	_ driver.RowsColumnTypeScanType         = (*queryResult)(nil)
-	/*
-		currently not used
-		could be implemented as pointer to next queryResult (advancing by copying data from next)
-		_ driver.RowsNextResultSet = (*queryResult)(nil)
-	*/
 
-	_ driver.Rows = (*callResult)(nil)
+	_ driver.Rows                           = (*callResult)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*callResult)(nil)
+	_ driver.RowsColumnTypeLength           = (*callResult)(nil)
+	_ driver.RowsColumnTypeNullable         = (*callResult)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*callResult)(nil)
+	_ driver.RowsColumnTypeScanType         = (*callResult)(nil)
+
+	_ driver.Rows                           = (*resultSets)(nil)
+	_ driver.RowsNextResultSet              = (*resultSets)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*resultSets)(nil)
+	_ driver.RowsColumnTypeLength           = (*resultSets)(nil)
+	_ driver.RowsColumnTypeNullable         = (*resultSets)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*resultSets)(nil)
+	_ driver.RowsColumnTypeScanType         = (*resultSets)(nil)
 )
 
 type prepareResult struct {
@@ -55,15 +65,29 @@ func (r *noResultType) Next(dest []driver.Value) error { return io.EOF }
 // queryResult represents the resultset of a query.
 type queryResult struct {
 	// field alignment
-	fields       []*p.ResultField
-	fieldValues  []driver.Value
-	decodeErrors p.DecodeErrors
-	_columns     []string
-	lastErr      error
-	conn         *conn
-	rsID         uint64
-	pos          int
-	attrs        p.PartAttributes
+	fields        []*p.ResultField
+	fieldValues   []driver.Value
+	decodeErrors  p.DecodeErrors
+	_columns      []string
+	lastErr       error
+	conn          *conn
+	rsID          uint64
+	pos           int
+	attrs         p.PartAttributes
+	resSet        *p.Resultset // last read resultset part (rows might be decoded in chunks)
+	converters    []*Converter // scan converters of fields (nil if none)
+	convertersOK  bool         // converters are evaluated
+	retainedBytes int          // decoding buffer bytes retained by resSet
+	lobs          lobLocators  // lob locators handed to the application
+	ctxFetchSize  int          // fetch size set by the query context (static fetch size)
+	maxBytes      int          // maximum number of buffered result bytes (see SetMaxResultBytes)
+	replyBytes    int64        // bytes of the query reply (see ResultSize)
+	// adaptive fetch size
+	fetchSize  int
+	fetchTime  time.Duration // duration of last fetch roundtrip
+	fetchBytes int64         // bytes read by last fetch roundtrip
+	fetchRows  int           // rows read by last fetch roundtrip
+	fetched    time.Time     // end of last fetch roundtrip
 }
 
 // Columns implements the driver.Rows interface.
@@ -74,19 +98,39 @@ func (qr *queryResult) Columns() []string {
 		for i := 0; i < numField; i++ {
 			qr._columns[i] = qr.fields[i].Name()
 		}
+		qr._columns = columnNames(qr.conn.attrs, qr._columns)
 	}
 	return qr._columns
 }
 
+// updateRetainedBytes updates the retained buffer bytes metric by the decoding buffers of the last read resultset part.
+func (qr *queryResult) updateRetainedBytes() {
+	n := 0
+	if qr.resSet != nil {
+		n = qr.resSet.RetainedBytes()
+	}
+	if n != qr.retainedBytes {
+		qr.conn.metrics.msgCh <- gaugeMsg{idx: gaugeRetainedBytes, v: int64(n - qr.retainedBytes)}
+		qr.retainedBytes = n
+	}
+}
+
 // Close implements the driver.Rows interface.
 func (qr *queryResult) Close() error {
-	if qr.attrs.ResultsetClosed() {
-		return nil
-	}
+	// release decoding buffers
+	qr.resSet, qr.fieldValues = nil, nil
+	qr.updateRetainedBytes()
+
 	// if lastError is set, attrs are nil
 	if qr.lastErr != nil {
 		return qr.lastErr
 	}
+	if err := qr.lobs.free(context.Background(), qr.conn); err != nil {
+		return err
+	}
+	if qr.attrs.ResultsetClosed() {
+		return nil
+	}
 	return qr.conn.closeResultsetID(context.Background(), qr.rsID)
 }
 
@@ -102,18 +146,47 @@ func (qr *queryResult) copyRow(idx int, dest []driver.Value) {
 	copy(dest, qr.fieldValues[idx*cols:(idx+1)*cols])
 }
 
+// decodeNext decodes the next chunk of rows of the last read resultset part and returns false if there are none.
+func (qr *queryResult) decodeNext() (bool, error) {
+	if qr.resSet == nil {
+		return false, nil
+	}
+	ok, err := qr.resSet.DecodeNext()
+	if err := qr.checkResultBytes(); err != nil {
+		return false, err
+	}
+	if ok {
+		qr.fieldValues, qr.decodeErrors = qr.resSet.FieldValues, qr.resSet.DecodeErrors
+	}
+	return ok, err
+}
+
 // Next implements the driver.Rows interface.
-func (qr *queryResult) Next(dest []driver.Value) error {
+func (qr *queryResult) Next(dest []driver.Value) error { return qr.conn.execer.Fetch(qr, dest) }
+
+// errColumnarRows is returned if a query result decoded columnar (see QueryColumns) is read row by row.
+var errColumnarRows = errors.New("columnar query result cannot be read row by row")
+
+func (qr *queryResult) next(dest []driver.Value) error {
+	if qr.resSet != nil && qr.resSet.Columnar {
+		return errColumnarRows
+	}
 	if qr.pos >= qr.numRow() {
-		if qr.attrs.LastPacket() {
-			return io.EOF
-		}
-		if err := qr.conn.fetchNext(context.Background(), qr); err != nil {
-			qr.lastErr = err // fieldValues and attrs are nil
+		ok, err := qr.decodeNext()
+		if err != nil {
 			return err
 		}
-		if qr.numRow() == 0 {
-			return io.EOF
+		if !ok {
+			if qr.attrs.LastPacket() {
+				return io.EOF
+			}
+			if err := qr.conn.fetchNext(context.Background(), qr); err != nil {
+				qr.lastErr = err // fieldValues and attrs are nil
+				return classifyError(err)
+			}
+			if qr.numRow() == 0 {
+				return io.EOF
+			}
 		}
 		qr.pos = 0
 	}
@@ -125,8 +198,15 @@ func (qr *queryResult) Next(dest []driver.Value) error {
 	for _, v := range dest {
 		if v, ok := v.(p.LobDecoderSetter); ok {
 			v.SetDecoder(qr.conn.decodeLob)
+			qr.lobs.track(v)
 		}
 	}
+	if !qr.convertersOK {
+		qr.converters, qr.convertersOK = fromDBConverters(qr.fields), true
+	}
+	if err == nil {
+		err = convertFromDB(qr.converters, dest)
+	}
 	return err
 }
 
@@ -148,7 +228,7 @@ func (qr *queryResult) ColumnTypePrecisionScale(idx int) (int64, int64, bool) {
 
 // ColumnTypeScanType implements the driver.RowsColumnTypeScanType interface.
 func (qr *queryResult) ColumnTypeScanType(idx int) reflect.Type {
-	return qr.fields[idx].ScanType()
+	return scanType(qr.conn.attrs, qr.fields[idx])
 }
 
 type callResult struct { // call output parameters
@@ -158,6 +238,8 @@ type callResult struct { // call output parameters
 	decodeErrors p.DecodeErrors
 	_columns     []string
 	eof          bool
+	outputPos    int         // number of table results read before the output parameters
+	lobs         lobLocators // lob locators handed to the application
 }
 
 // Columns implements the driver.Rows interface.
@@ -168,6 +250,7 @@ func (cr *callResult) Columns() []string {
 		for i := 0; i < numField; i++ {
 			cr._columns[i] = cr.outputFields[i].Name()
 		}
+		cr._columns = columnNames(cr.conn.attrs, cr._columns)
 	}
 	return cr._columns
 }
@@ -184,10 +267,150 @@ func (cr *callResult) Next(dest []driver.Value) error {
 	for _, v := range dest {
 		if v, ok := v.(p.LobDecoderSetter); ok {
 			v.SetDecoder(cr.conn.decodeLob)
+			cr.lobs.track(v)
 		}
 	}
+	if err == nil {
+		err = convertFromDB(fromDBConverters(cr.outputFields), dest)
+	}
 	return err
 }
 
 // Close implements the driver.Rows interface.
-func (cr *callResult) Close() error { return nil }
+func (cr *callResult) Close() error { return cr.lobs.free(context.Background(), cr.conn) }
+
+// ColumnTypeDatabaseTypeName implements the driver.RowsColumnTypeDatabaseTypeName interface.
+func (cr *callResult) ColumnTypeDatabaseTypeName(idx int) string {
+	return cr.outputFields[idx].TypeName()
+}
+
+// ColumnTypeLength implements the driver.RowsColumnTypeLength interface.
+func (cr *callResult) ColumnTypeLength(idx int) (int64, bool) {
+	return cr.outputFields[idx].TypeLength()
+}
+
+// ColumnTypeNullable implements the driver.RowsColumnTypeNullable interface.
+func (cr *callResult) ColumnTypeNullable(idx int) (bool, bool) {
+	return cr.outputFields[idx].Nullable(), true
+}
+
+// ColumnTypePrecisionScale implements the driver.RowsColumnTypePrecisionScale interface.
+func (cr *callResult) ColumnTypePrecisionScale(idx int) (int64, int64, bool) {
+	return cr.outputFields[idx].TypePrecisionScale()
+}
+
+// ColumnTypeScanType implements the driver.RowsColumnTypeScanType interface.
+func (cr *callResult) ColumnTypeScanType(idx int) reflect.Type {
+	return scanType(cr.conn.attrs, cr.outputFields[idx])
+}
+
+/*
+resultSets represents the result sets of a procedure call executed via Query.
+
+The result sets are provided in the order returned by the database server. Scalar output
+parameters are provided as a single row result set at the position they were returned.
+*/
+type resultSets struct {
+	sets []driver.Rows
+	idx  int
+}
+
+func newResultSets(cr *callResult, numOutputField int) *resultSets {
+	rs := &resultSets{}
+	tables := cr.fieldValues[numOutputField:]
+	for i, v := range tables {
+		if numOutputField != 0 && i == cr.outputPos {
+			rs.sets = append(rs.sets, cr.outputResult(numOutputField))
+		}
+		rs.sets = append(rs.sets, v.(*queryResult))
+	}
+	if numOutputField != 0 && cr.outputPos >= len(tables) {
+		rs.sets = append(rs.sets, cr.outputResult(numOutputField))
+	}
+	if len(rs.sets) == 0 {
+		rs.sets = append(rs.sets, noResult)
+	}
+	return rs
+}
+
+// outputResult returns the scalar output parameters of a call result.
+func (cr *callResult) outputResult(numOutputField int) *callResult {
+	return &callResult{
+		conn:         cr.conn,
+		outputFields: cr.outputFields[:numOutputField],
+		fieldValues:  cr.fieldValues[:numOutputField],
+		decodeErrors: cr.decodeErrors,
+	}
+}
+
+func (rs *resultSets) rows() driver.Rows { return rs.sets[rs.idx] }
+
+// Columns implements the driver.Rows interface.
+func (rs *resultSets) Columns() []string { return rs.rows().Columns() }
+
+// Next implements the driver.Rows interface.
+func (rs *resultSets) Next(dest []driver.Value) error { return rs.rows().Next(dest) }
+
+// Close implements the driver.Rows interface.
+func (rs *resultSets) Close() error {
+	var errs []error
+	for _, rows := range rs.sets[rs.idx:] {
+		if err := rows.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasNextResultSet implements the driver.RowsNextResultSet interface.
+func (rs *resultSets) HasNextResultSet() bool { return rs.idx < len(rs.sets)-1 }
+
+// NextResultSet implements the driver.RowsNextResultSet interface.
+func (rs *resultSets) NextResultSet() error {
+	if !rs.HasNextResultSet() {
+		return io.EOF
+	}
+	err := rs.rows().Close()
+	rs.idx++
+	return err
+}
+
+// ColumnTypeDatabaseTypeName implements the driver.RowsColumnTypeDatabaseTypeName interface.
+func (rs *resultSets) ColumnTypeDatabaseTypeName(idx int) string {
+	if rows, ok := rs.rows().(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return rows.ColumnTypeDatabaseTypeName(idx)
+	}
+	return ""
+}
+
+// ColumnTypeLength implements the driver.RowsColumnTypeLength interface.
+func (rs *resultSets) ColumnTypeLength(idx int) (int64, bool) {
+	if rows, ok := rs.rows().(driver.RowsColumnTypeLength); ok {
+		return rows.ColumnTypeLength(idx)
+	}
+	return 0, false
+}
+
+// ColumnTypeNullable implements the driver.RowsColumnTypeNullable interface.
+func (rs *resultSets) ColumnTypeNullable(idx int) (bool, bool) {
+	if rows, ok := rs.rows().(driver.RowsColumnTypeNullable); ok {
+		return rows.ColumnTypeNullable(idx)
+	}
+	return false, false
+}
+
+// ColumnTypePrecisionScale implements the driver.RowsColumnTypePrecisionScale interface.
+func (rs *resultSets) ColumnTypePrecisionScale(idx int) (int64, int64, bool) {
+	if rows, ok := rs.rows().(driver.RowsColumnTypePrecisionScale); ok {
+		return rows.ColumnTypePrecisionScale(idx)
+	}
+	return 0, 0, false
+}
+
+// ColumnTypeScanType implements the driver.RowsColumnTypeScanType interface.
+func (rs *resultSets) ColumnTypeScanType(idx int) reflect.Type {
+	if rows, ok := rs.rows().(driver.RowsColumnTypeScanType); ok {
+		return rows.ColumnTypeScanType(idx)
+	}
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
@@ -0,0 +1,135 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"io"
+	"log/slog"
+	"testing"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+	"github.com/SAP/go-hdb/driver/unicode/cesu8"
+)
+
+func TestResultSets(t *testing.T) {
+	t1, t2 := &queryResult{}, &queryResult{}
+
+	tests := []struct {
+		outputPos int
+		expected  []driver.Rows // nil: output parameters
+	}{
+		{0, []driver.Rows{nil, t1, t2}},
+		{1, []driver.Rows{t1, nil, t2}},
+		{2, []driver.Rows{t1, t2, nil}},
+	}
+
+	for _, test := range tests {
+		cr := &callResult{
+			outputFields: []*p.ParameterField{{}, p.NewTableRowsParameterField(0), p.NewTableRowsParameterField(1)},
+			fieldValues:  []driver.Value{int64(42), t1, t2},
+			outputPos:    test.outputPos,
+		}
+		rs := newResultSets(cr, 1)
+		if len(rs.sets) != len(test.expected) {
+			t.Fatalf("number of result sets %d - expected %d", len(rs.sets), len(test.expected))
+		}
+		for i, expected := range test.expected {
+			if i != 0 {
+				if !rs.HasNextResultSet() {
+					t.Fatalf("output position %d: next result set %d expected", test.outputPos, i)
+				}
+				rs.idx++ // skip Close of result sets without connection
+			}
+			rows := rs.rows()
+			if expected == nil {
+				if _, ok := rows.(*callResult); !ok {
+					t.Fatalf("output position %d: result set %d type %T - expected output parameters", test.outputPos, i, rows)
+				}
+				continue
+			}
+			if rows != expected {
+				t.Fatalf("output position %d: result set %d mismatch", test.outputPos, i)
+			}
+		}
+		if rs.HasNextResultSet() {
+			t.Fatalf("output position %d: no next result set expected", test.outputPos)
+		}
+	}
+
+	if rs := newResultSets(&callResult{}, 0); rs.rows() != noResult {
+		t.Fatal("no result expected")
+	}
+}
+
+func TestExecCall(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	outFields := []p.ServerField{{Name: "OUT1", TypeName: "INTEGER"}, {Name: "OUT2", TypeName: "NVARCHAR", Length: 10}}
+	tableFields := []p.ServerField{{Name: "C", TypeName: "INTEGER"}}
+
+	replyParts := func(t *testing.T, outputPos int) []p.Part {
+		outPrms, err := p.OutputParametersPart(outFields, []driver.Value{int64(42), "abc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parts []p.Part
+		for i := 0; i <= 2; i++ {
+			if i == outputPos {
+				parts = append(parts, outPrms)
+			}
+			if i == 2 {
+				break
+			}
+			meta, err := p.ResultMetadataPart(tableFields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resSet, err := p.ResultsetPart(tableFields, [][]driver.Value{{int64(i)}}, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parts = append(parts, meta, p.ResultsetID(i+1), resSet)
+		}
+		return parts
+	}
+
+	for outputPos := 0; outputPos <= 2; outputPos++ {
+		var buf bytes.Buffer
+		wr := bufio.NewWriter(&buf)
+		if err := p.NewWriter(wr, encoding.NewEncoder(wr, cesu8.DefaultEncoder), false, logger, cesu8.DefaultEncoder, nil).WriteReply(ctx, 1, p.ReplyFunctionCode(p.MtExecute, "call p"), replyParts(t, outputPos)...); err != nil {
+			t.Fatal(err)
+		}
+		outputFields, err := p.ParameterFields(outFields)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := &conn{attrs: newConnAttrs(), pr: p.NewDBReader(encoding.NewDecoder(&buf, cesu8.DefaultDecoder), false, logger)}
+		cr, _, _, err := c.execCall(ctx, outputFields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(outputFields) != len(outFields) {
+			t.Fatalf("output position %d: number of output fields %d - expected %d", outputPos, len(outputFields), len(outFields))
+		}
+		if len(cr.outputFields) != 4 || len(cr.fieldValues) != 4 || cr.outputPos != outputPos {
+			t.Fatalf("output position %d: number of fields %d values %d output position %d - expected 4 4 %d", outputPos, len(cr.outputFields), len(cr.fieldValues), cr.outputPos, outputPos)
+		}
+		if b, ok := cr.fieldValues[1].([]byte); cr.fieldValues[0] != int64(42) || !ok || string(b) != "abc" {
+			t.Fatalf("output position %d: output values %v - expected [42 abc]", outputPos, cr.fieldValues[:2])
+		}
+		for i, v := range cr.fieldValues[2:] {
+			qr, ok := v.(*queryResult)
+			if !ok {
+				t.Fatalf("output position %d: table %d type %T - expected query result", outputPos, i, v)
+			}
+			if len(qr.fieldValues) != 1 || qr.fieldValues[0] != int64(i) {
+				t.Fatalf("output position %d: table %d values %v - expected [%d]", outputPos, i, qr.fieldValues, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package driver
+
+import (
+	"container/list"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+)
+
+const (
+	defaultResultCacheMaxEntries = 1000 // Default value of ResultCacheConfig.MaxEntries.
+	defaultResultCacheMaxRows    = 1000 // Default value of ResultCacheConfig.MaxRows.
+)
+
+// ResultCacheConfig is the configuration of the client side result cache (see SetResultCache).
+type ResultCacheConfig struct {
+	// TTL is the time a cached query result is valid.
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached query results (default 1000).
+	// If exceeded, the least recently used result is evicted.
+	MaxEntries int
+	// MaxRows is the maximum number of rows of a cached query result (default 1000).
+	// Query results with more rows are not cached.
+	MaxRows int
+}
+
+// resultCacheEntry is a query result cached by resultCache.
+type resultCacheEntry struct {
+	key     string
+	expires time.Time
+	columns []string
+	meta    *queryResult // column metadata (nil if not available)
+	rows    [][]driver.Value
+}
+
+/*
+resultCache is a least recently used cache of query results keyed by sql text and query arguments.
+
+The cache is shared by all connections of a connector. Any statement which is not a select query invalidates
+all cached results, so that query results are never served after a write through the connector.
+*/
+type resultCache struct {
+	cfg     ResultCacheConfig
+	mu      sync.Mutex
+	gen     uint64     // incremented by each invalidation
+	ll      *list.List // front: most recently used
+	entries map[string]*list.Element
+}
+
+func newResultCache(cfg ResultCacheConfig) *resultCache {
+	return &resultCache{cfg: cfg, ll: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the valid cache entry of key and the current cache generation.
+func (c *resultCache) get(key string) (*resultCacheEntry, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, c.gen
+	}
+	entry := elem.Value.(*resultCacheEntry)
+	if time.Now().After(entry.expires) {
+		c.ll.Remove(elem)
+		delete(c.entries, key)
+		return nil, c.gen
+	}
+	c.ll.MoveToFront(elem)
+	return entry, c.gen
+}
+
+// add adds entry if the cache was not invalidated since generation gen.
+func (c *resultCache) add(entry *resultCacheEntry, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	if elem, ok := c.entries[entry.key]; ok {
+		c.ll.Remove(elem)
+	}
+	entry.expires = time.Now().Add(c.cfg.TTL)
+	c.entries[entry.key] = c.ll.PushFront(entry)
+	for c.ll.Len() > c.cfg.MaxEntries {
+		elem := c.ll.Back()
+		c.ll.Remove(elem)
+		delete(c.entries, elem.Value.(*resultCacheEntry).key)
+	}
+}
+
+// invalidate removes all cached results.
+func (c *resultCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.ll.Init()
+	clear(c.entries)
+}
+
+// len returns the number of cached results.
+func (c *resultCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+var (
+	// sessionStmt matches statements changing the session state (e.g. set schema, set session variables).
+	sessionStmt = regexp.MustCompile(`(?i)^\s*(set|unset|alter\s+session)\s`)
+	// volatileQuery matches queries calling functions returning a different result on each execution.
+	volatileQuery = regexp.MustCompile(`(?i)\b(nextval|currval|current_(date|time|timestamp|utcdate|utctime|utctimestamp|user|schema|connection|transaction_isolation_level)|now|rand|rand_secure|sysuuid|newuid|session_context|session_user)\b`)
+)
+
+/*
+resultCacheKey returns the cache key of query and nvargs executed with ctx on connection c, false if the arguments cannot be
+part of a key.
+The key contains the session state of the connection (default schema, session variables and locale) and the context values
+changing the provided values (string interning), as the cache is shared by all connections of the connector.
+*/
+func resultCacheKey(ctx context.Context, c *conn, query string, nvargs []driver.NamedValue) (string, bool) {
+	var b strings.Builder
+	b.WriteString(query)
+	if attrs := c.attrs; attrs != nil {
+		fmt.Fprintf(&b, "\x00schema:%s\x00locale:%s", attrs._defaultSchema, attrs._locale)
+		keys := make([]string, 0, len(attrs._sessionVariables))
+		for k := range attrs._sessionVariables {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "\x00var:%s=%s", k, attrs._sessionVariables[k])
+		}
+	}
+	if maxValues, ok := hdbctx.StringInterningFrom(ctx); ok {
+		fmt.Fprintf(&b, "\x00interning:%d", maxValues)
+	}
+	for _, nv := range nvargs {
+		switch v := nv.Value.(type) {
+		case nil, int64, float64, bool, string, []byte, time.Time:
+			fmt.Fprintf(&b, "\x00%s:%d:%T:%v", nv.Name, nv.Ordinal, v, v)
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// errResultNotCacheable is returned if a query result cannot be cached.
+var errResultNotCacheable = errors.New("result not cacheable")
+
+// cacheValue returns a copy of value v to be cached.
+func cacheValue(v driver.Value) (driver.Value, error) {
+	switch v := v.(type) {
+	case []byte:
+		return append([]byte(nil), v...), nil // decoding buffers might be reused
+	case p.LobDecoderSetter, io.Reader:
+		return nil, errResultNotCacheable
+	default:
+		return v, nil
+	}
+}
+
+// execer returns next wrapped by the result cache (next if the cache is not enabled).
+func (c *resultCache) execer(conn *conn, next Execer) Execer {
+	if c == nil {
+		return next
+	}
+	return resultCacheExecer{Execer: next, c: conn, cache: c}
+}
+
+/*
+resultCacheExecer is the Execer serving select queries from the result cache.
+
+Queries executed within a transaction are neither served from nor added to the cache, as they might see
+uncommitted changes. Queries calling functions with a different result on each execution (e.g. sequence.nextval,
+current_timestamp) are not cached. After a statement changing the session state (e.g. set schema) the connection
+does not use the cache anymore, as the cache key only contains the session state set by the connector.
+*/
+type resultCacheExecer struct {
+	Execer
+	c     *conn
+	cache *resultCache
+}
+
+func (e resultCacheExecer) Exec(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	defer e.cache.invalidate() // invalidate after the write, so that concurrent queries cannot cache the old state
+	if sessionStmt.MatchString(query) {
+		e.c.sessionChanged = true
+	}
+	return e.Execer.Exec(ctx, query, nvargs)
+}
+
+func (e resultCacheExecer) Query(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	if !selectStmt.MatchString(query) || hintStmt.MatchString(query) {
+		defer e.cache.invalidate()
+		if sessionStmt.MatchString(query) {
+			e.c.sessionChanged = true
+		}
+		return e.Execer.Query(ctx, query, nvargs)
+	}
+	if _, ok := hdbctx.SessionContextFrom(ctx); ok { // session context values might change the result
+		return e.Execer.Query(ctx, query, nvargs)
+	}
+	if e.c.inTx || e.c.sessionChanged || volatileQuery.MatchString(query) {
+		return e.Execer.Query(ctx, query, nvargs)
+	}
+	key, ok := resultCacheKey(ctx, e.c, query, nvargs)
+	if !ok {
+		return e.Execer.Query(ctx, query, nvargs)
+	}
+	entry, gen := e.cache.get(key)
+	if entry != nil {
+		return &cachedRows{resultCacheEntry: entry}, nil
+	}
+
+	rows, err := e.Execer.Query(ctx, query, nvargs)
+	if err != nil {
+		return nil, err
+	}
+	entry = &resultCacheEntry{key: key, columns: rows.Columns()}
+	if qr, ok := rows.(*queryResult); ok {
+		entry.meta = &queryResult{fields: qr.fields}
+	}
+	for {
+		dest := make([]driver.Value, len(entry.columns))
+		err := e.Fetch(rows, dest)
+		if err == io.EOF {
+			break
+		}
+		if err == nil && len(entry.rows) >= e.cache.cfg.MaxRows {
+			err = errResultNotCacheable
+		}
+		row := make([]driver.Value, len(dest))
+		for i := 0; err == nil && i < len(dest); i++ {
+			row[i], err = cacheValue(dest[i])
+		}
+		if err != nil {
+			// return the rows read so far followed by the remaining rows
+			return &cachedRows{resultCacheEntry: entry, rest: rows, restRow: dest, restErr: err}, nil
+		}
+		entry.rows = append(entry.rows, row)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	e.cache.add(entry, gen)
+	return &cachedRows{resultCacheEntry: entry}, nil
+}
+
+// cachedRows provides the rows of a cached query result.
+type cachedRows struct {
+	*resultCacheEntry
+	pos int
+	// uncached query results
+	rest    driver.Rows    // remaining rows
+	restRow []driver.Value // row read when detecting that the query result is not cacheable
+	restErr error          // error reading restRow
+}
+
+// check if cachedRows implements all required interfaces.
+var (
+	_ driver.Rows                           = (*cachedRows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*cachedRows)(nil)
+	_ driver.RowsColumnTypeLength           = (*cachedRows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*cachedRows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*cachedRows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*cachedRows)(nil)
+)
+
+// Columns implements the driver.Rows interface.
+func (r *cachedRows) Columns() []string { return r.columns }
+
+// Close implements the driver.Rows interface.
+func (r *cachedRows) Close() error {
+	if r.rest != nil {
+		return r.rest.Close()
+	}
+	return nil
+}
+
+// Next implements the driver.Rows interface.
+func (r *cachedRows) Next(dest []driver.Value) error {
+	if r.pos < len(r.rows) {
+		copy(dest, r.rows[r.pos])
+		r.pos++
+		return nil
+	}
+	if r.rest == nil {
+		return io.EOF
+	}
+	if r.restRow != nil {
+		copy(dest, r.restRow)
+		r.restRow = nil
+		if !errors.Is(r.restErr, errResultNotCacheable) {
+			return r.restErr
+		}
+		return nil
+	}
+	return r.rest.Next(dest)
+}
+
+// ColumnTypeDatabaseTypeName implements the driver.RowsColumnTypeDatabaseTypeName interface.
+func (r *cachedRows) ColumnTypeDatabaseTypeName(idx int) string {
+	if r.meta == nil {
+		return ""
+	}
+	return r.meta.ColumnTypeDatabaseTypeName(idx)
+}
+
+// ColumnTypeLength implements the driver.RowsColumnTypeLength interface.
+func (r *cachedRows) ColumnTypeLength(idx int) (int64, bool) {
+	if r.meta == nil {
+		return 0, false
+	}
+	return r.meta.ColumnTypeLength(idx)
+}
+
+// ColumnTypeNullable implements the driver.RowsColumnTypeNullable interface.
+func (r *cachedRows) ColumnTypeNullable(idx int) (bool, bool) {
+	if r.meta == nil {
+		return false, false
+	}
+	return r.meta.ColumnTypeNullable(idx)
+}
+
+// ColumnTypePrecisionScale implements the driver.RowsColumnTypePrecisionScale interface.
+func (r *cachedRows) ColumnTypePrecisionScale(idx int) (int64, int64, bool) {
+	if r.meta == nil {
+		return 0, 0, false
+	}
+	return r.meta.ColumnTypePrecisionScale(idx)
+}
+
+// ColumnTypeScanType implements the driver.RowsColumnTypeScanType interface.
+func (r *cachedRows) ColumnTypeScanType(idx int) reflect.Type {
+	if r.meta == nil {
+		return hdbreflect.TypeFor[any]()
+	}
+	return r.meta.ColumnTypeScanType(idx)
+}
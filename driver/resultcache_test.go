@@ -0,0 +1,208 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+)
+
+type testRows struct {
+	values []driver.Value
+	pos    int
+}
+
+func (r *testRows) Columns() []string { return []string{"C"} }
+func (r *testRows) Close() error      { return nil }
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	dest[0] = r.values[r.pos]
+	r.pos++
+	return nil
+}
+
+type testExecer struct {
+	values   []driver.Value
+	numQuery int
+}
+
+func (e *testExecer) Prepare(ctx context.Context, query string) (driver.Stmt, error) { return nil, nil }
+func (e *testExecer) Exec(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (e *testExecer) Query(ctx context.Context, query string, nvargs []driver.NamedValue) (driver.Rows, error) {
+	e.numQuery++
+	return &testRows{values: e.values}, nil
+}
+func (e *testExecer) Fetch(rows driver.Rows, dest []driver.Value) error { return rows.Next(dest) }
+
+func TestResultCache(t *testing.T) {
+	ctx := context.Background()
+
+	readAllRows := func(t *testing.T, rows driver.Rows) []driver.Value {
+		defer rows.Close()
+		var values []driver.Value
+		dest := make([]driver.Value, 1)
+		for {
+			if err := rows.Next(dest); err == io.EOF {
+				return values
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			values = append(values, dest[0])
+		}
+	}
+
+	readAll := func(t *testing.T, execer Execer, query string, nvargs []driver.NamedValue) []driver.Value {
+		rows, err := execer.Query(ctx, query, nvargs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return readAllRows(t, rows)
+	}
+
+	check := func(t *testing.T, execer Execer, query string, nvargs []driver.NamedValue, numValue int) {
+		if values := readAll(t, execer, query, nvargs); len(values) != numValue {
+			t.Fatalf("number of values %d - expected %d", len(values), numValue)
+		}
+	}
+
+	newExecer := func(cfg ResultCacheConfig) (*conn, *resultCache, *testExecer, Execer) {
+		c, te := &conn{}, &testExecer{values: []driver.Value{int64(1), []byte("abc"), "xyz"}}
+		rc := newResultCache(cfg)
+		return c, rc, te, rc.execer(c, te)
+	}
+
+	const query = "select c from t where k = ?"
+	nvargs := []driver.NamedValue{{Ordinal: 1, Value: "key"}}
+
+	t.Run("cached", func(t *testing.T) {
+		_, rc, te, execer := newExecer(ResultCacheConfig{TTL: time.Hour, MaxEntries: 10, MaxRows: 10})
+		check(t, execer, query, nvargs, 3)
+		check(t, execer, query, nvargs, 3)
+		if te.numQuery != 1 || rc.len() != 1 {
+			t.Fatalf("number of queries %d cache entries %d - expected 1 1", te.numQuery, rc.len())
+		}
+		// different arguments
+		check(t, execer, query, []driver.NamedValue{{Ordinal: 1, Value: "otherKey"}}, 3)
+		if te.numQuery != 2 || rc.len() != 2 {
+			t.Fatalf("number of queries %d cache entries %d - expected 2 2", te.numQuery, rc.len())
+		}
+	})
+
+	t.Run("stringInterning", func(t *testing.T) {
+		_, rc, te, execer := newExecer(ResultCacheConfig{TTL: time.Hour, MaxEntries: 10, MaxRows: 10})
+		check(t, execer, query, nvargs, 3)
+		rows, err := execer.Query(hdbctx.WithStringInterning(ctx, 10), query, nvargs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+		if te.numQuery != 2 || rc.len() != 2 {
+			t.Fatalf("number of queries %d cache entries %d - expected 2 2", te.numQuery, rc.len())
+		}
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		_, rc, te, execer := newExecer(ResultCacheConfig{TTL: time.Hour, MaxEntries: 10, MaxRows: 10})
+		check(t, execer, query, nvargs, 3)
+		if _, err := execer.Exec(ctx, "update t set c = 1", nil); err != nil {
+			t.Fatal(err)
+		}
+		if rc.len() != 0 {
+			t.Fatalf("cache entries %d - expected 0", rc.len())
+		}
+		check(t, execer, query, nvargs, 3)
+		check(t, execer, "insert into t values (1)", nil, 3) // query with non select statement
+		if te.numQuery != 3 || rc.len() != 0 {
+			t.Fatalf("number of queries %d cache entries %d - expected 3 0", te.numQuery, rc.len())
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, _, te, execer := newExecer(ResultCacheConfig{TTL: time.Millisecond, MaxEntries: 10, MaxRows: 10})
+		check(t, execer, query, nvargs, 3)
+		time.Sleep(5 * time.Millisecond)
+		check(t, execer, query, nvargs, 3)
+		if te.numQuery != 2 {
+			t.Fatalf("number of queries %d - expected 2", te.numQuery)
+		}
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		_, rc, _, execer := newExecer(ResultCacheConfig{TTL: time.Hour, MaxEntries: 1, MaxRows: 10})
+		check(t, execer, query, nvargs, 3)
+		check(t, execer, "select c from t", nil, 3)
+		if rc.len() != 1 {
+			t.Fatalf("cache entries %d - expected 1", rc.len())
+		}
+	})
+
+	t.Run("notCached", func(t *testing.T) {
+		c, rc, te, execer := newExecer(ResultCacheConfig{TTL: time.Hour, MaxEntries: 10, MaxRows: 2})
+		check(t, execer, query, nvargs, 3) // too many rows
+		check(t, execer, query, []driver.NamedValue{{Ordinal: 1, Value: struct{}{}}}, 3)
+		rows, err := execer.Query(hdbctx.WithSessionContext(ctx, map[string]string{"APPLICATIONUSER": "u"}), query, nvargs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		readAllRows(t, rows)
+		c.inTx = true
+		te.values = te.values[:1]
+		check(t, execer, query, nvargs, 1)
+		if te.numQuery != 4 || rc.len() != 0 {
+			t.Fatalf("number of queries %d cache entries %d - expected 4 0", te.numQuery, rc.len())
+		}
+	})
+
+	t.Run("sessionState", func(t *testing.T) {
+		rc := newResultCache(ResultCacheConfig{TTL: time.Hour, MaxEntries: 10, MaxRows: 10})
+		te := &testExecer{values: []driver.Value{int64(1)}}
+		newConnExecer := func(schema string, sessionVariables map[string]string) (*conn, Execer) {
+			c := &conn{attrs: &connAttrs{_defaultSchema: schema, _sessionVariables: sessionVariables}}
+			return c, rc.execer(c, te)
+		}
+		_, execer1 := newConnExecer("S1", map[string]string{"k": "v1"})
+		_, execer2 := newConnExecer("S2", map[string]string{"k": "v1"})
+		_, execer3 := newConnExecer("S1", map[string]string{"k": "v2"})
+		c4, execer4 := newConnExecer("S1", map[string]string{"k": "v1"})
+		check(t, execer1, query, nvargs, 1)
+		check(t, execer2, query, nvargs, 1)
+		check(t, execer3, query, nvargs, 1)
+		check(t, execer4, query, nvargs, 1) // same session state as connection 1
+		if te.numQuery != 3 || rc.len() != 3 {
+			t.Fatalf("number of queries %d cache entries %d - expected 3 3", te.numQuery, rc.len())
+		}
+		if _, err := execer4.Exec(ctx, "set schema S2", nil); err != nil {
+			t.Fatal(err)
+		}
+		if !c4.sessionChanged {
+			t.Fatal("session state change not detected")
+		}
+		check(t, execer4, query, nvargs, 1)
+		check(t, execer4, query, nvargs, 1)
+		if te.numQuery != 5 || rc.len() != 0 {
+			t.Fatalf("number of queries %d cache entries %d - expected 5 0", te.numQuery, rc.len())
+		}
+	})
+
+	t.Run("volatile", func(t *testing.T) {
+		_, rc, te, execer := newExecer(ResultCacheConfig{TTL: time.Hour, MaxEntries: 10, MaxRows: 10})
+		for _, query := range []string{
+			"select s.nextval from dummy",
+			"select current_timestamp from dummy",
+			"select c from t where d < now()",
+		} {
+			check(t, execer, query, nil, 3)
+			check(t, execer, query, nil, 3)
+		}
+		if te.numQuery != 6 || rc.len() != 0 {
+			t.Fatalf("number of queries %d cache entries %d - expected 6 0", te.numQuery, rc.len())
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+)
+
+// ErrResultTooLarge is returned if a query result exceeds the maximum number of bytes buffered by the client
+// (see SetMaxResultBytes).
+var ErrResultTooLarge = errors.New("result too large")
+
+// maxResultBytes returns the maximum number of result bytes set by ctx, the maximum number of result bytes of the connector otherwise.
+func (c *conn) maxResultBytes(ctx context.Context) int {
+	if maxResultBytes, ok := hdbctx.MaxResultBytesFrom(ctx); ok {
+		return maxResultBytes
+	}
+	return c.attrs._maxResultBytes
+}
+
+// checkResultBytes returns ErrResultTooLarge if the last read resultset part exceeded the maximum number of result bytes.
+// In this case the decoding buffers are released and the resultset is closed on the database server.
+func (qr *queryResult) checkResultBytes() error {
+	if qr.resSet == nil || !qr.resSet.Exceeded() {
+		return nil
+	}
+	err := fmt.Errorf("%w: more than %d bytes", ErrResultTooLarge, qr.maxBytes)
+	qr.resSet, qr.fieldValues, qr.decodeErrors = nil, nil, nil
+	qr.updateRetainedBytes()
+	if !qr.attrs.ResultsetClosed() {
+		err = errors.Join(err, qr.conn.closeResultsetID(context.Background(), qr.rsID))
+	}
+	qr.lastErr = err // Close must not close the resultset again
+	return err
+}
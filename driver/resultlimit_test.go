@@ -0,0 +1,70 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestMaxResultBytes(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	rows := make([][]any, 100)
+	for i := range rows {
+		rows[i] = []any{fmt.Sprintf("name %d", i)}
+	}
+	srv.Handle("select name from t", &hdbtest.Response{
+		Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}},
+		Rows:    rows,
+	})
+
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetMaxResultBytes(100)
+	db := OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	count := func(ctx context.Context) (int, error) {
+		r, err := db.QueryContext(ctx, "select name from t")
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+		n := 0
+		for r.Next() {
+			n++
+		}
+		return n, r.Err()
+	}
+
+	ctx := context.Background()
+	if _, err := count(ctx); !errors.Is(err, ErrResultTooLarge) {
+		t.Fatalf("error %v - expected %v", err, ErrResultTooLarge)
+	}
+	// connection is still usable
+	n, err := count(hdbctx.WithMaxResultBytes(ctx, 1<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(rows) {
+		t.Fatalf("number of rows %d - expected %d", n, len(rows))
+	}
+	// limit applies per fetch roundtrip
+	if _, err := count(hdbctx.WithFetchSize(hdbctx.WithMaxResultBytes(ctx, 1000), 10)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := count(hdbctx.WithFetchSize(hdbctx.WithMaxResultBytes(ctx, 1000), 50)); !errors.Is(err, ErrResultTooLarge) {
+		t.Fatalf("error %v - expected %v", err, ErrResultTooLarge)
+	}
+	if stats := db.Stats(); stats.OpenConnections != 1 {
+		t.Fatalf("open connections %d - expected %d", stats.OpenConnections, 1)
+	}
+}
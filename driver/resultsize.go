@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+)
+
+// setResultSize sets the result size requested by ctx (if any) from the query result rows (see hdbctx.WithResultSize).
+func setResultSize(ctx context.Context, rows driver.Rows) {
+	size, ok := hdbctx.ResultSizeFrom(ctx)
+	if !ok {
+		return
+	}
+	switch rows := rows.(type) {
+	case *noResultType:
+		*size = hdbctx.ResultSize{Complete: true}
+	case *queryResult:
+		*size = hdbctx.ResultSize{Bytes: rows.replyBytes}
+		if rows.resSet != nil {
+			size.Rows = rows.resSet.NumRows()
+		}
+		size.Complete = rows.attrs.LastPacket()
+	default:
+		*size = hdbctx.ResultSize{}
+	}
+}
+
+/*
+EstimateResultRows returns the number of result rows of query estimated by the database optimizer without executing
+query, i.e. the output size of the root operator of the execution plan (see ExplainPlan).
+
+Like all optimizer estimates the value might differ considerably from the actual number of result rows,
+but it allows to reject or paginate queries with very large results before executing them.
+*/
+func EstimateResultRows(ctx context.Context, sqlConn *sql.Conn, query string) (float64, error) {
+	root, err := ExplainPlan(ctx, sqlConn, query)
+	if err != nil {
+		return 0, err
+	}
+	return root.OutputSize, nil
+}
@@ -0,0 +1,56 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbctx"
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestResultSize(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	const numRow = 100
+	rows := make([][]any, numRow)
+	for i := range rows {
+		rows[i] = []any{fmt.Sprintf("name %d", i)}
+	}
+	columns := []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 20}}
+	srv.Handle("select name from t", &hdbtest.Response{Columns: columns, Rows: rows})
+	srv.Handle("select top 5 name from t", &hdbtest.Response{Columns: columns, Rows: rows[:5]})
+
+	db := OpenDB(NewBasicAuthConnector(srv.Addr(), "MOCK", "password"))
+	defer db.Close()
+
+	tests := []struct {
+		query    string
+		complete bool
+	}{
+		{"select name from t", false},
+		{"select top 5 name from t", true},
+	}
+
+	for _, test := range tests {
+		size := &hdbctx.ResultSize{}
+		r, err := db.QueryContext(hdbctx.WithResultSize(context.Background(), size), test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Close()
+		if size.Complete != test.complete {
+			t.Fatalf("%s: complete %t - expected %t", test.query, size.Complete, test.complete)
+		}
+		if size.Rows == 0 || (test.complete && size.Rows != 5) || (!test.complete && size.Rows >= numRow) {
+			t.Fatalf("%s: invalid number of rows %d", test.query, size.Rows)
+		}
+		if size.Bytes == 0 || size.BytesPerRow() == 0 {
+			t.Fatalf("%s: invalid number of bytes %d", test.query, size.Bytes)
+		}
+	}
+}
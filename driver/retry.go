@@ -0,0 +1,206 @@
+package driver
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net"
+	"reflect"
+	"time"
+)
+
+// retry policy default values.
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 10 * time.Second
+	defaultRetryMultiplier     = 2.0
+)
+
+/*
+A RetryPolicy defines the retry behavior for establishing a database connection
+(TCP connect, protocol handshake and authentication) respectively for executing statements
+(see SetStatementRetryPolicy).
+
+Attempt n (starting with 1) waits
+
+	min(InitialBackoff * Multiplier^(n-1), MaxBackoff)
+
+before the next attempt. In case Jitter is greater zero, the wait time is randomly
+reduced by up to Jitter * wait time to avoid connect storms of concurrent clients.
+*/
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// Values less than 1 are treated as 1 (no retry).
+	MaxAttempts int
+	// InitialBackoff is the wait time before the first retry (default 100ms).
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper limit of the wait time between two attempts (default 10s).
+	MaxBackoff time.Duration
+	// Multiplier is the factor the wait time is increased by after each attempt (default 2).
+	Multiplier float64
+	// Jitter is the randomization factor in the range [0, 1] applied to the wait time.
+	Jitter float64
+	// Retryable classifies errors. If nil, IsRetryableConnectError is used for connect errors
+	// and IsRetryableStatementError for statement errors.
+	Retryable func(err error) bool
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) retryable(err error, defRetryable func(err error) bool) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return defRetryable(err)
+}
+
+// Backoff returns the wait time after the given (1-based) attempt.
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	initialBackoff, maxBackoff, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initialBackoff <= 0 {
+		initialBackoff = defaultRetryInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	if multiplier < 1 {
+		multiplier = defaultRetryMultiplier
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	backoff := float64(initialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if backoff > float64(maxBackoff) {
+		backoff = float64(maxBackoff)
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		backoff -= backoff * jitter * rand.Float64() //nolint:gosec
+	}
+	return time.Duration(backoff)
+}
+
+/*
+IsRetryableConnectError returns true if a connect attempt failing with error err
+might succeed on a subsequent attempt (e.g. network errors or connections closed
+by the server during the handshake), false otherwise.
+
+Authentication errors, certificate validation errors and context errors are not retryable.
+*/
+func IsRetryableConnectError(err error) bool {
+	if err == nil || isAuthError(err) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var certVerificationError *tls.CertificateVerificationError
+	if errors.As(err, &certVerificationError) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retry calls fn until it succeeds, returns a non retryable error or the maximum number of attempts is reached.
+func (p *RetryPolicy) retry(ctx context.Context, logger *slog.Logger, fn func() (driver.Conn, error)) (driver.Conn, error) {
+	return retryCall(ctx, p, logger, "connect retry", IsRetryableConnectError, fn)
+}
+
+// retryCall calls fn until it succeeds, returns an error not classified as retryable by the policy
+// (default classification: defRetryable) or the maximum number of attempts of the policy is reached.
+func retryCall[T any](ctx context.Context, p *RetryPolicy, logger *slog.Logger, msg string, defRetryable func(err error) bool, fn func() (T, error)) (T, error) {
+	maxAttempts := p.maxAttempts()
+	for attempt := 1; ; attempt++ {
+		v, err := fn()
+		if err == nil || attempt >= maxAttempts || !p.retryable(err, defRetryable) {
+			return v, err
+		}
+
+		backoff := p.Backoff(attempt)
+		logger.LogAttrs(ctx, slog.LevelWarn, msg, slog.Int("attempt", attempt), slog.Duration("backoff", backoff), slog.String("error", err.Error()))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+/*
+IsRetryableStatementError returns true if a statement failing with error err might succeed on a subsequent attempt,
+which is the case for transactions rolled back by the database server because of a deadlock, a lock wait timeout
+or a serialization failure, false otherwise.
+*/
+func IsRetryableStatementError(err error) bool {
+	return hasErrorCode(err, HdbErrDeadlock, HdbErrLockWaitTimeout, HdbErrSerializationFailure)
+}
+
+// retryableArgs returns true if the arguments of a statement with numField parameters can be sent again, false otherwise
+// (e.g. lob readers or bulk functions providing the arguments are consumed by the first attempt, or the rows of
+// a bulk execution might be applied partially as the batches are sent and committed separately).
+func retryableArgs(nvargs []driver.NamedValue, numField int) bool {
+	if len(nvargs) > numField {
+		return false
+	}
+	for _, nv := range nvargs {
+		switch nv.Value.(type) {
+		case io.Reader, Lob, *Lob:
+			return false
+		}
+		if nv.Value != nil && reflect.TypeOf(nv.Value).Kind() == reflect.Func {
+			return false
+		}
+	}
+	return true
+}
+
+// retryStatement calls fn and retries it according to the statement retry policy of the connection.
+// Statements are only retried outside of explicit transactions, as a rolled back transaction needs to be
+// repeated as a whole (see RunTx).
+func (c *conn) retryStatement(ctx context.Context, nvargs []driver.NamedValue, numField int, fn func() error) error {
+	policy := c.attrs._stmtRetryPolicy
+	if policy == nil || c.inTx || !retryableArgs(nvargs, numField) {
+		return fn()
+	}
+	_, err := retryCall(ctx, policy, c.logger, "statement retry", IsRetryableStatementError, func() (struct{}, error) { return struct{}{}, fn() })
+	return err
+}
+
+/*
+RunTx executes fn in a transaction started on db with options opts and commits the transaction if fn succeeds.
+If fn or the commit fails the transaction is rolled back and, in case of a retryable error according to policy
+(see IsRetryableStatementError), the transaction is executed again. fn therefore needs to be idempotent
+apart from its database operations executed within tx.
+Retries are logged by logger (slog.Default if nil), e.g. the logger of the connector db was opened with
+(see Connector.Logger).
+*/
+func RunTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, policy *RetryPolicy, logger *slog.Logger, fn func(tx *sql.Tx) error) error {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	_, err := retryCall(ctx, policy, logger, "transaction retry", IsRetryableStatementError, func() (struct{}, error) {
+		tx, err := db.BeginTx(ctx, opts)
+		if err != nil {
+			return struct{}{}, err
+		}
+		if err := fn(tx); err != nil {
+			tx.Rollback() //nolint:errcheck
+			return struct{}{}, err
+		}
+		return struct{}{}, tx.Commit()
+	})
+	return err
+}
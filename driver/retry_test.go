@@ -0,0 +1,190 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func testRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond, Multiplier: 2}
+
+	tests := []struct {
+		attempt int
+		backoff time.Duration
+	}{
+		{1, 10 * time.Millisecond},
+		{2, 20 * time.Millisecond},
+		{3, 40 * time.Millisecond},
+		{4, 50 * time.Millisecond},
+		{10, 50 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if backoff := p.Backoff(test.attempt); backoff != test.backoff {
+			t.Fatalf("attempt %d: backoff %s - expected %s", test.attempt, backoff, test.backoff)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if backoff := p.Backoff(1); backoff < 5*time.Millisecond || backoff > 10*time.Millisecond {
+			t.Fatalf("jitter backoff %s out of range", backoff)
+		}
+	}
+}
+
+func testRetryableConnectError(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{errors.New("some error"), false},
+		{context.Canceled, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{errors.Join(io.EOF, driver.ErrBadConn), true},
+	}
+	for _, test := range tests {
+		if retryable := IsRetryableConnectError(test.err); retryable != test.retryable {
+			t.Fatalf("error %v: retryable %t - expected %t", test.err, retryable, test.retryable)
+		}
+	}
+}
+
+func testRetryPolicyRetry(t *testing.T) {
+	errRetry := errors.New("retry")
+	p := &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		Retryable:      func(err error) bool { return errors.Is(err, errRetry) },
+	}
+
+	attempts := 0
+	_, err := p.retry(context.Background(), slog.Default(), func() (driver.Conn, error) {
+		attempts++
+		return nil, errRetry
+	})
+	if !errors.Is(err, errRetry) {
+		t.Fatalf("error %v - expected %v", err, errRetry)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts %d - expected %d", attempts, 3)
+	}
+
+	attempts = 0
+	_, err = (*RetryPolicy)(nil).retry(context.Background(), slog.Default(), func() (driver.Conn, error) {
+		attempts++
+		return nil, errRetry
+	})
+	if err == nil || attempts != 1 {
+		t.Fatalf("attempts %d - expected %d", attempts, 1)
+	}
+}
+
+func testRetryStatement(t *testing.T) {
+	deadlock := &testDBError{code: int(HdbErrDeadlock)}
+
+	tests := []struct {
+		inTx     bool
+		nvargs   []driver.NamedValue
+		err      error
+		attempts int
+	}{
+		{false, nil, deadlock, 3},
+		{false, nil, &testDBError{code: int(HdbErrUniqueViolation)}, 1},
+		{true, nil, deadlock, 1},
+		{false, []driver.NamedValue{{Ordinal: 1, Value: new(Lob)}}, deadlock, 1},
+		{false, []driver.NamedValue{{Ordinal: 1, Value: func(args []any) error { return nil }}}, deadlock, 1},
+		{false, []driver.NamedValue{{Ordinal: 1, Value: int64(42)}}, deadlock, 3},
+		{false, []driver.NamedValue{{Ordinal: 1, Value: int64(42)}, {Ordinal: 2, Value: int64(43)}}, deadlock, 1}, // bulk execution
+	}
+
+	attrs := newConnAttrs()
+	attrs.SetStatementRetryPolicy(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	for i, test := range tests {
+		c := &conn{attrs: attrs, logger: slog.Default(), inTx: test.inTx}
+		attempts := 0
+		err := c.retryStatement(context.Background(), test.nvargs, 1, func() error {
+			attempts++
+			return test.err
+		})
+		if !errors.Is(err, test.err) {
+			t.Fatalf("test %d: error %v - expected %v", i, err, test.err)
+		}
+		if attempts != test.attempts {
+			t.Fatalf("test %d: attempts %d - expected %d", i, attempts, test.attempts)
+		}
+	}
+}
+
+func testRunTxLogger(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	srv.Handle("insert into t values (1)", &hdbtest.Response{RowsAffected: 1})
+
+	var buf bytes.Buffer
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1) // retries must not need an additional connection
+
+	errRetry := errors.New("retry")
+	policy := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Retryable: func(err error) bool { return errors.Is(err, errRetry) }}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	attempts := 0
+	if err := RunTx(ctx, db, nil, policy, connector.Logger(), func(tx *sql.Tx) error {
+		attempts++
+		if _, err := tx.ExecContext(ctx, "insert into t values (1)"); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return errRetry
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Fatalf("attempts %d - expected %d", attempts, 2)
+	}
+	if !strings.Contains(buf.String(), "transaction retry") {
+		t.Fatalf("transaction retry not logged: %s", buf.String())
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"backoff", testRetryPolicyBackoff},
+		{"retryableConnectError", testRetryableConnectError},
+		{"retry", testRetryPolicyRetry},
+		{"retryStatement", testRetryStatement},
+		{"runTxLogger", testRunTxLogger},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
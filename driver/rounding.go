@@ -0,0 +1,59 @@
+package driver
+
+import (
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
+)
+
+// Decimal conversion errors wrapped by the errors of statement executions.
+var (
+	// ErrDecimalOutOfRange is returned if a parameter value exceeds the precision of a DECIMAL parameter.
+	ErrDecimalOutOfRange = encoding.ErrDecimalOutOfRange
+	// ErrDecimalNotExact is returned by decimal rounding policy DecimalExact if a parameter value would need to be rounded.
+	ErrDecimalNotExact = encoding.ErrDecimalNotExact
+)
+
+// A DecimalRoundingPolicy defines how parameter values exceeding the scale of DECIMAL parameters are rounded.
+type DecimalRoundingPolicy int
+
+// DecimalRoundingPolicy constants.
+const (
+	// DecimalRoundHalfUp rounds half away from zero (default), e.g. 2.5 to 3 and -2.5 to -3.
+	DecimalRoundHalfUp DecimalRoundingPolicy = iota
+	// DecimalRoundHalfEven rounds half to the nearest even value (banker's rounding), e.g. 2.5 to 2 and 3.5 to 4.
+	DecimalRoundHalfEven
+	// DecimalTruncate rounds towards zero, e.g. 2.9 to 2 and -2.9 to -2.
+	DecimalTruncate
+	// DecimalExact does not round but returns ErrDecimalNotExact.
+	DecimalExact
+)
+
+func (p DecimalRoundingPolicy) String() string {
+	switch p {
+	case DecimalRoundHalfUp:
+		return "roundHalfUp"
+	case DecimalRoundHalfEven:
+		return "roundHalfEven"
+	case DecimalTruncate:
+		return "truncate"
+	case DecimalExact:
+		return "exact"
+	default:
+		return fmt.Sprintf("DecimalRoundingPolicy(%d)", int(p))
+	}
+}
+
+// mode returns the encoding rounding mode of the policy.
+func (p DecimalRoundingPolicy) mode() encoding.RoundingMode {
+	switch p {
+	case DecimalRoundHalfEven:
+		return encoding.RoundHalfEven
+	case DecimalTruncate:
+		return encoding.RoundDown
+	case DecimalExact:
+		return encoding.RoundExact
+	default:
+		return encoding.RoundHalfUp
+	}
+}
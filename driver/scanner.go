@@ -1,8 +1,13 @@
 package driver
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"strings"
 	"time"
@@ -210,6 +215,222 @@ func (sc StructScanner[S]) Scan(rows *sql.Rows, s *S) error {
 	return rows.Scan(values...)
 }
 
+/*
+QueryAll executes query with args on connection sqlConn and scans all rows of the result set into a slice of structs of type S.
+
+In contrast to calling Scan for each row, the decoded database values are assigned to the struct fields
+directly without the per row conversion overhead of database/sql. Supported are fields where the decoded
+value is assignable or convertible within the same kind (integer, floating point and string/bytes kinds)
+without exceeding the range of the field type (otherwise an error is returned),
+bool fields for integer values (BOOLEAN columns are represented as TINYINT for data format versions < 7),
+pointers to such fields and fields implementing the sql.Scanner interface.
+*/
+func (sc StructScanner[S]) QueryAll(ctx context.Context, sqlConn *sql.Conn, query string, args ...any) ([]S, error) {
+	var result []S
+	err := sqlConn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*conn)
+		if !ok {
+			return fmt.Errorf("invalid driver connection type %T", driverConn)
+		}
+
+		rows, err := c.queryAll(ctx, query, args)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		columns := rows.Columns()
+		indexes := make([][]int, len(columns))
+		for i, name := range columns {
+			column, ok := sc.nameColumnMap[name]
+			if !ok {
+				return fmt.Errorf("field for column name %s not found", name)
+			}
+			indexes[i] = column.fieldIndex
+		}
+
+		dest := make([]driver.Value, len(columns))
+		for {
+			if err := rows.Next(dest); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+			var s S
+			rv := reflect.ValueOf(&s).Elem()
+			for i, v := range dest {
+				if err := assignValue(rv.FieldByIndex(indexes[i]), v); err != nil {
+					return fmt.Errorf("column %s: %w", columns[i], err)
+				}
+			}
+			result = append(result, s)
+		}
+	})
+	return result, err
+}
+
+/*
+LoadTempTable creates the local temporary table table with the columns of struct type S
+and inserts rows via connection sqlConn.
+
+Table typed input parameters of procedures cannot be passed as arguments, as the driver does not
+implement the encoding of table typed parameter values. Instead, the name of a (temporary) table
+with matching columns can be used in the call statement, e.g.
+
+	call myproc(#mytable, ?)
+
+The table name needs to start with '#' and the table is only visible in the session of sqlConn.
+The column types are taken from the sql struct field tags (e.g. `sql:"name,nvarchar(20)"`) or
+inferred from the field types.
+*/
+func (sc StructScanner[S]) LoadTempTable(ctx context.Context, sqlConn *sql.Conn, table Identifier, rows []S) error {
+	if !strings.HasPrefix(string(table), "#") {
+		return fmt.Errorf("invalid local temporary table name %s - needs to start with '#'", table)
+	}
+	columnDefs, err := sc.columnDefs()
+	if err != nil {
+		return err
+	}
+	if _, err := sqlConn.ExecContext(ctx, fmt.Sprintf("create local temporary column table %s %s", table, columnDefs)); err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	stmt, err := sqlConn.PrepareContext(ctx, fmt.Sprintf("insert into %s values %s", table, sc.queryPlaceholders()))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	i := 0
+	_, err = stmt.ExecContext(ctx, func(args []any) error {
+		if i >= len(rows) {
+			return ErrEndOfRows
+		}
+		rv := reflect.ValueOf(rows[i])
+		for j, column := range sc.columns {
+			args[j] = rv.FieldByIndex(column.fieldIndex).Interface()
+		}
+		i++
+		return nil
+	})
+	return err
+}
+
+// stmtRows are the rows of a prepared statement, which is closed when the rows are closed (like database/sql).
+type stmtRows struct {
+	driver.Rows
+	stmt driver.Stmt
+}
+
+func (r *stmtRows) Close() error { return errors.Join(r.Rows.Close(), r.stmt.Close()) }
+
+// unwrapRows returns the driver rows of rows returned by queryAll.
+func unwrapRows(rows driver.Rows) driver.Rows {
+	if sr, ok := rows.(*stmtRows); ok {
+		return sr.Rows
+	}
+	return rows
+}
+
+// queryAll executes query with args via the direct or prepared query path.
+// In case of the prepared query path the statement is closed by closing the returned rows.
+func (c *conn) queryAll(ctx context.Context, query string, args []any) (driver.Rows, error) {
+	if len(args) == 0 {
+		return c.QueryContext(ctx, query, nil)
+	}
+	ds, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	nvargs := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		nvargs[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+		if namedArg, ok := arg.(sql.NamedArg); ok {
+			nvargs[i].Name, nvargs[i].Value = namedArg.Name, namedArg.Value
+		}
+	}
+	rows, err := ds.(*stmt).QueryContext(ctx, nvargs)
+	if err != nil {
+		ds.Close()
+		return nil, err
+	}
+	return &stmtRows{Rows: rows, stmt: ds}, nil
+}
+
+func isIntKind(k reflect.Kind) bool      { return k >= reflect.Int && k <= reflect.Uint64 }
+func isSignedKind(k reflect.Kind) bool   { return k >= reflect.Int && k <= reflect.Int64 }
+func isUnsignedKind(k reflect.Kind) bool { return k >= reflect.Uint && k <= reflect.Uint64 }
+func isFloatKind(k reflect.Kind) bool    { return k == reflect.Float32 || k == reflect.Float64 }
+func isBytesKind(t reflect.Type) bool {
+	return t.Kind() == reflect.String || (t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8)
+}
+
+// assignValue assigns the decoded database value v to the struct field fv.
+func assignValue(fv reflect.Value, v driver.Value) error {
+	if scanner, ok := fv.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(v)
+	}
+	if v == nil {
+		fv.SetZero()
+		return nil
+	}
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		return assignValue(fv.Elem(), v)
+	}
+
+	rv := reflect.ValueOf(v)
+	vt, ft := rv.Type(), fv.Type()
+	switch {
+	case vt.AssignableTo(ft):
+		fv.Set(rv)
+	case isIntKind(vt.Kind()) && ft.Kind() == reflect.Bool: // BOOLEAN represented as TINYINT
+		fv.SetBool(!rv.IsZero())
+	case isIntKind(vt.Kind()) && (isIntKind(ft.Kind()) || isFloatKind(ft.Kind())),
+		isFloatKind(vt.Kind()) && isFloatKind(ft.Kind()),
+		isBytesKind(vt) && isBytesKind(ft):
+		if !vt.ConvertibleTo(ft) {
+			return fmt.Errorf("cannot convert %s to %s", vt, ft)
+		}
+		if err := checkOverflow(fv, rv); err != nil {
+			return err
+		}
+		fv.Set(rv.Convert(ft))
+	default:
+		return fmt.Errorf("cannot assign %s to %s", vt, ft)
+	}
+	return nil
+}
+
+// checkOverflow returns an error if the numeric value rv does not fit into the field fv (like database/sql does).
+func checkOverflow(fv, rv reflect.Value) error {
+	fk := fv.Kind()
+	switch {
+	case isSignedKind(rv.Kind()):
+		i := rv.Int()
+		if (isSignedKind(fk) && fv.OverflowInt(i)) || (isUnsignedKind(fk) && (i < 0 || fv.OverflowUint(uint64(i)))) {
+			return fmt.Errorf("converting value %d to %s: value out of range", i, fv.Type())
+		}
+	case isUnsignedKind(rv.Kind()):
+		u := rv.Uint()
+		if (isSignedKind(fk) && (u > math.MaxInt64 || fv.OverflowInt(int64(u)))) || (isUnsignedKind(fk) && fv.OverflowUint(u)) {
+			return fmt.Errorf("converting value %d to %s: value out of range", u, fv.Type())
+		}
+	case isFloatKind(rv.Kind()):
+		if f := rv.Float(); isFloatKind(fk) && fv.OverflowFloat(f) {
+			return fmt.Errorf("converting value %g to %s: value out of range", f, fv.Type())
+		}
+	}
+	return nil
+}
+
 // columnDefs returns the column definitions for a sql create statement.
 // experimental: before 'export' completion of inferSQLType is needed
 func (sc StructScanner[S]) columnDefs() (string, error) { return sc.columns.defs() }
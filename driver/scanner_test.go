@@ -3,6 +3,8 @@
 package driver
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 )
@@ -85,12 +87,74 @@ func TestScanStruct(t *testing.T) {
 		return nil
 	}
 
+	testQueryAll := func() error {
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		for _, args := range [][]any{nil, {testRow.B}} {
+			query := fmt.Sprintf("select * from %s", tableName)
+			if args != nil {
+				query += " where i = ?"
+			}
+			rows, err := scanner.QueryAll(context.Background(), conn, query, args...)
+			if err != nil {
+				return err
+			}
+			if len(rows) != 1 {
+				return fmt.Errorf("number of rows %d - expected %d", len(rows), 1)
+			}
+			if rows[0] != testRow {
+				return fmt.Errorf("row %v not equal to %v", rows[0], testRow)
+			}
+		}
+		return nil
+	}
+
+	testLoadTempTable := func() error {
+		ctx := context.Background()
+
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		procName := RandomIdentifier("loadTempTable_")
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf(`create procedure %s (in t table (s varchar(30), i integer, c boolean, x varchar(30)), out n integer)
+language SQLSCRIPT as
+begin
+	select count(*) into n from :t where i = 42;
+end
+`, procName)); err != nil {
+			return err
+		}
+
+		tableName := Identifier("#" + RandomIdentifier("loadTempTable_"))
+		if err := scanner.LoadTempTable(ctx, conn, tableName, []testScanRow{testRow, testRow}); err != nil {
+			return err
+		}
+
+		var n int
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("call %s(%s, ?)", procName, tableName), sql.Out{Dest: &n}); err != nil {
+			return err
+		}
+		if n != 2 {
+			return fmt.Errorf("number of rows %d - expected %d", n, 2)
+		}
+		return nil
+	}
+
 	tests := []struct {
 		name string
 		fn   func() error
 	}{
 		{"testScanStructRows", testScanStructRows},
 		{"testScanStructRow", testScanStructRow},
+		{"testQueryAll", testQueryAll},
+		{"testLoadTempTable", testLoadTempTable},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,47 @@
+package driver
+
+import (
+	"fmt"
+	"reflect"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// A ScanTypePolicy defines the scan types reported for nullable columns (see sql.ColumnType.ScanType).
+type ScanTypePolicy int
+
+// ScanTypePolicy constants.
+const (
+	// ScanTypeNullTypes reports null types for nullable columns (default), e.g. sql.NullInt32, sql.NullTime
+	// or NullDecimal.
+	ScanTypeNullTypes ScanTypePolicy = iota
+	// ScanTypePointers reports pointer types for nullable columns, e.g. *int32, *time.Time or *Decimal.
+	// Types representing NULL by a nil value (e.g. []byte) are reported unchanged.
+	ScanTypePointers
+)
+
+func (p ScanTypePolicy) String() string {
+	switch p {
+	case ScanTypeNullTypes:
+		return "nullTypes"
+	case ScanTypePointers:
+		return "pointers"
+	default:
+		return fmt.Sprintf("ScanTypePolicy(%d)", int(p))
+	}
+}
+
+// scanTypeField is implemented by result and parameter fields.
+type scanTypeField interface {
+	ScanType() reflect.Type
+	DataType() p.DataType
+	Nullable() bool
+}
+
+// scanType returns the scan type of field according to the scan type policy of attrs.
+func scanType(attrs *connAttrs, field scanTypeField) reflect.Type {
+	if attrs._scanTypePolicy == ScanTypePointers && field.Nullable() {
+		return field.DataType().ScanPointerType()
+	}
+	return field.ScanType()
+}
@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+	hdbreflect "github.com/SAP/go-hdb/driver/internal/reflect"
+)
+
+func TestScanTypePolicy(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	columns := []hdbtest.Column{
+		{Name: "ID", Type: "INTEGER"},
+		{Name: "N", Type: "INTEGER", Nullable: true},
+		{Name: "TS", Type: "TIMESTAMP", Nullable: true},
+		{Name: "D", Type: "DECIMAL", Nullable: true, Length: 10, Scale: 2},
+		{Name: "B", Type: "VARBINARY", Nullable: true, Length: 10},
+	}
+	srv.Handle("select * from t", &hdbtest.Response{Columns: columns, Rows: [][]any{{int64(1), nil, nil, nil, nil}}})
+
+	tests := []struct {
+		policy   ScanTypePolicy
+		expected []reflect.Type
+	}{
+		{ScanTypeNullTypes, []reflect.Type{
+			hdbreflect.TypeFor[int32](),
+			hdbreflect.TypeFor[sql.NullInt32](),
+			hdbreflect.TypeFor[sql.NullTime](),
+			hdbreflect.TypeFor[NullDecimal](),
+			hdbreflect.TypeFor[NullBytes](),
+		}},
+		{ScanTypePointers, []reflect.Type{
+			hdbreflect.TypeFor[int32](),
+			hdbreflect.TypeFor[*int32](),
+			hdbreflect.TypeFor[*time.Time](),
+			hdbreflect.TypeFor[*Decimal](),
+			hdbreflect.TypeFor[[]byte](),
+		}},
+	}
+
+	for _, test := range tests {
+		connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+		connector.SetScanTypePolicy(test.policy)
+		db := OpenDB(connector)
+
+		rows, err := db.QueryContext(context.Background(), "select * from t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		types, err := rows.ColumnTypes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dest := make([]any, len(types))
+		for i, ct := range types {
+			if ct.ScanType() != test.expected[i] {
+				t.Fatalf("%s column %s: scan type %s - expected %s", test.policy, ct.Name(), ct.ScanType(), test.expected[i])
+			}
+			dest[i] = reflect.New(ct.ScanType()).Interface()
+		}
+		for rows.Next() { // scan into destinations allocated by scan type
+			if err := rows.Scan(dest...); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+		db.Close()
+	}
+}
@@ -0,0 +1,97 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrConnectorShutdown is returned by Connect after the connector was shut down (see Connector.Shutdown).
+var ErrConnectorShutdown = errors.New("connector is shut down")
+
+// connRegistry keeps track of the open connections of a connector.
+type connRegistry struct {
+	mu       sync.Mutex
+	shutdown bool
+	conns    map[*conn]struct{}
+	closed   chan struct{} // closed after shutdown as soon as all connections are closed
+}
+
+func newConnRegistry() *connRegistry { return &connRegistry{conns: map[*conn]struct{}{}} }
+
+func (r *connRegistry) isShutdown() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.shutdown
+}
+
+// add registers connection c. It returns false if the connector was shut down in the meantime.
+func (r *connRegistry) add(c *conn) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutdown {
+		return false
+	}
+	r.conns[c] = struct{}{}
+	c.registry = r
+	return true
+}
+
+func (r *connRegistry) remove(c *conn) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.conns, c)
+	if r.shutdown && len(r.conns) == 0 {
+		r.close()
+	}
+}
+
+func (r *connRegistry) close() {
+	select {
+	case <-r.closed: // closed already
+	default:
+		close(r.closed)
+	}
+}
+
+// markShutdown marks the registry and all open connections as shut down and returns a channel, which is closed
+// as soon as all connections are closed.
+func (r *connRegistry) markShutdown() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.shutdown {
+		r.shutdown = true
+		r.closed = make(chan struct{})
+		for c := range r.conns {
+			c.isShutdown.Store(true)
+		}
+		if len(r.conns) == 0 {
+			r.close()
+		}
+	}
+	return r.closed
+}
+
+/*
+Shutdown shuts the connector down gracefully, e.g. on application termination.
+
+After calling Shutdown the connector does not open new connections (Connect returns ErrConnectorShutdown) and
+the open connections are marked as not reusable. The connections are not closed by Shutdown, as they are owned
+by the connection pool: connections in use are closed as soon as they are released to the pool, idle connections
+are closed on their next checkout or by sql.DB.Close. Closing a connection waits for its in-flight db calls to
+complete and sends a protocol disconnect message to the database server, so that the server session is
+terminated properly instead of by a TCP reset.
+
+Shutdown waits until all connections of the connector are closed or ctx is done, in which case the context error
+is returned. A typical shutdown sequence is to stop issuing new db calls (e.g. by an http server shutdown), to call
+sql.DB.Close and to wait for the connections in use to be released by calling Shutdown with a deadline.
+*/
+func (c *Connector) Shutdown(ctx context.Context) error {
+	closed := c.registry.markShutdown()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-closed:
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+func TestShutdown(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	subscriber := &testConnEventSubscriber{}
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	connector.SetConnEventSubscriber(subscriber)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+	// open two connections
+	conn1, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn2, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn2.Close() // idle connection in pool
+
+	shutdownErr := make(chan error)
+	go func() { shutdownErr <- connector.Shutdown(ctx) }()
+	for !connector.registry.isShutdown() {
+		time.Sleep(time.Millisecond)
+	}
+	// idle connection is closed on checkout
+	if err := db.PingContext(ctx); !errors.Is(err, ErrConnectorShutdown) {
+		t.Fatalf("ping error %v - expected %v", err, ErrConnectorShutdown)
+	}
+	conn1.Close() // connection in use is closed on release
+	if err := <-shutdownErr; err != nil {
+		t.Fatal(err)
+	}
+
+	numClosed := 0
+	for _, event := range subscriber.events {
+		if event.Kind != ConnClosed {
+			continue
+		}
+		numClosed++
+		if event.Reason != ConnReasonShutdown || event.Err != nil {
+			t.Fatalf("closed event reason %s error %v - expected reason %s", event.Reason, event.Err, ConnReasonShutdown)
+		}
+	}
+	if numClosed != 2 {
+		t.Fatalf("number of closed connections %d - expected %d", numClosed, 2)
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	connector := NewBasicAuthConnector(srv.Addr(), "MOCK", "password")
+	dc, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := dc.(*conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := connector.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("shutdown error %v - expected %v", err, context.DeadlineExceeded)
+	}
+	// connection is not closed by shutdown but not reusable anymore
+	if c.IsValid() {
+		t.Fatal("connection valid after shutdown")
+	}
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := connector.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
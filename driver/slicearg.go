@@ -0,0 +1,134 @@
+package driver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+ExpandSliceArgs expands the placeholders of slice arguments in query.
+
+The driver does not bind slices as database array (ARRAY) parameters - the encoding of array typed
+parameter values is not implemented. Instead, ExpandSliceArgs replaces each placeholder '?' in query,
+which corresponds to a slice or array argument, by a comma separated list of placeholders (one per
+element) and flattens the arguments accordingly. This allows to use slices for IN-lists without
+building the list as string:
+
+	query, args, err := driver.ExpandSliceArgs("select * from t where id in (?) and name = ?", []int{1, 2, 3}, "x")
+	// query: select * from t where id in (?, ?, ?) and name = ?
+	// args:  1, 2, 3, "x"
+
+[]byte arguments and arguments implementing the driver.Valuer interface are not expanded.
+Placeholders within string literals, quoted identifiers and comments are ignored.
+*/
+func ExpandSliceArgs(query string, args ...any) (string, []any, error) {
+	b := new(strings.Builder)
+	expArgs := make([]any, 0, len(args))
+
+	idx := 0
+	if err := scanPlaceholders(query, func(s string, placeholder bool) error {
+		if !placeholder {
+			b.WriteString(s)
+			return nil
+		}
+		if idx >= len(args) {
+			return fmt.Errorf("invalid number of arguments %d - more placeholders found", len(args))
+		}
+		arg := args[idx]
+		idx++
+
+		if _, ok := arg.(sql.NamedArg); ok {
+			return errors.New("named arguments are not supported")
+		}
+		rv, ok := sliceArg(arg)
+		if !ok {
+			b.WriteString(s)
+			expArgs = append(expArgs, arg)
+			return nil
+		}
+		l := rv.Len()
+		if l == 0 {
+			return fmt.Errorf("empty slice argument %d", idx)
+		}
+		for i := 0; i < l; i++ {
+			if i != 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("?")
+			expArgs = append(expArgs, rv.Index(i).Interface())
+		}
+		return nil
+	}); err != nil {
+		return "", nil, err
+	}
+	if idx != len(args) {
+		return "", nil, fmt.Errorf("invalid number of arguments %d - %d placeholders found", len(args), idx)
+	}
+	return b.String(), expArgs, nil
+}
+
+// sliceArg returns the reflect value of arg and true if arg is a slice or array to be expanded.
+func sliceArg(arg any) (reflect.Value, bool) {
+	if arg == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := arg.(driver.Valuer); ok {
+		return reflect.Value{}, false
+	}
+	rv := reflect.ValueOf(arg)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 { // []byte
+			return reflect.Value{}, false
+		}
+		return rv, true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+/*
+scanPlaceholders splits query into placeholders '?' and remaining text and calls fn for each part.
+String literals, quoted identifiers and comments are not scanned for placeholders.
+*/
+func scanPlaceholders(query string, fn func(s string, placeholder bool) error) error {
+	start := 0
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == '\'' || c == '"': // string literal or quoted identifier (escaped quotes are doubled)
+			if j := strings.IndexByte(query[i+1:], c); j != -1 {
+				i += j + 1
+			} else {
+				i = len(query)
+			}
+		case c == '-' && strings.HasPrefix(query[i:], "--"): // line comment
+			if j := strings.IndexByte(query[i:], '\n'); j != -1 {
+				i += j
+			} else {
+				i = len(query)
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"): // block comment
+			if j := strings.Index(query[i+2:], "*/"); j != -1 {
+				i += j + 3
+			} else {
+				i = len(query)
+			}
+		case c == '?':
+			if err := fn(query[start:i], false); err != nil {
+				return err
+			}
+			if err := fn(query[i:i+1], true); err != nil {
+				return err
+			}
+			start = i + 1
+		}
+	}
+	if start < len(query) {
+		return fn(query[start:], false)
+	}
+	return nil
+}
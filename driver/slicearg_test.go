@@ -0,0 +1,44 @@
+package driver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandSliceArgs(t *testing.T) {
+	tests := []struct {
+		query     string
+		args      []any
+		expQuery  string
+		expArgs   []any
+		expectErr bool
+	}{
+		{"select * from t where i in (?)", []any{[]int{1, 2, 3}}, "select * from t where i in (?, ?, ?)", []any{1, 2, 3}, false},
+		{"select * from t where i in (?) and s = ?", []any{[2]string{"a", "b"}, "c"}, "select * from t where i in (?, ?) and s = ?", []any{"a", "b", "c"}, false},
+		{"select * from t where b = ?", []any{[]byte{1, 2}}, "select * from t where b = ?", []any{[]byte{1, 2}}, false},
+		{"select '?', \"?\" from t -- ?\n where i = ? /* ? */", []any{1}, "select '?', \"?\" from t -- ?\n where i = ? /* ? */", []any{1}, false},
+		{"select 'it''s ?' from t where i = ?", []any{1}, "select 'it''s ?' from t where i = ?", []any{1}, false},
+		{"select * from t where i in (?)", []any{[]int{}}, "", nil, true},
+		{"select * from t where i in (?)", []any{1, 2}, "", nil, true},
+		{"select * from t where i in (?, ?)", []any{1}, "", nil, true},
+	}
+
+	for _, test := range tests {
+		query, args, err := ExpandSliceArgs(test.query, test.args...)
+		if test.expectErr {
+			if err == nil {
+				t.Fatalf("query %s: error expected", test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if query != test.expQuery {
+			t.Fatalf("query %s - expected %s", query, test.expQuery)
+		}
+		if !reflect.DeepEqual(args, test.expArgs) {
+			t.Fatalf("args %v - expected %v", args, test.expArgs)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"log/slog"
+	"time"
+)
+
+// SlowQuery contains the information about a statement exceeding the slow query threshold (see SetSlowQueryHook).
+type SlowQuery struct {
+	// Query is the SQL text of the statement.
+	Query string
+	// Duration is the client side execution time of the statement.
+	Duration time.Duration
+	// Rows is the number of rows affected by an exec statement respectively
+	// the number of rows of the first fetch roundtrip of a query (-1 if not available).
+	Rows int64
+	// ServerProcessingTime is the processing time reported by the database server (zero if not available).
+	ServerProcessingTime time.Duration
+	// Plan is the execution plan of the statement if requested by SetSlowQueryExplainPlan (nil otherwise).
+	Plan *PlanOperator
+}
+
+// execRows returns the number of rows affected of an exec statement result.
+func execRows(result driver.Result) int64 {
+	if result == nil {
+		return -1
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return rows
+}
+
+// queryRows returns the number of rows of the first fetch roundtrip of a query.
+func queryRows(rows driver.Rows) int64 {
+	qr, ok := rows.(*queryResult)
+	if !ok || qr.resSet == nil {
+		return -1
+	}
+	return int64(qr.resSet.NumRows())
+}
+
+// checkSlowQuery calls the slow query hook if the statement execution started at start exceeds the slow query threshold.
+func (c *conn) checkSlowQuery(ctx context.Context, start time.Time, query string, rows func() int64) {
+	hook := c.attrs._slowQueryHook
+	if hook == nil {
+		return
+	}
+	d := time.Since(start)
+	if d < c.attrs._slowQueryThreshold {
+		return
+	}
+	sq := &SlowQuery{
+		Query:                query,
+		Duration:             d,
+		Rows:                 rows(),
+		ServerProcessingTime: c.pr.StatementContext().ServerProcessingTimeOrZero(),
+	}
+	if c.attrs._slowQueryExplainPlan {
+		var err error
+		if sq.Plan, err = c.explainPlan(ctx, query); err != nil {
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "slow query explain plan", slog.String("query", query), slog.String("error", err.Error()))
+		}
+	}
+	hook(ctx, sq)
+}
@@ -1,13 +1,19 @@
 package driver
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"log"
 	"log/slog"
 	"net"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	p "github.com/SAP/go-hdb/driver/internal/protocol"
 	"github.com/SAP/go-hdb/driver/internal/protocol/encoding"
@@ -16,9 +22,12 @@ import (
 
 // A Sniffer is a simple proxy for logging hdb protocol requests and responses.
 type Sniffer struct {
-	logger *slog.Logger
-	conn   net.Conn
-	dbConn net.Conn
+	logger      *slog.Logger
+	conn        net.Conn
+	dbConn      net.Conn
+	recorder    io.Writer
+	jsonWr      io.Writer
+	jsonOptions SnifferJSONOptions
 }
 
 // NewSniffer creates a new sniffer instance. The conn parameter is the net.Conn connection, where the Sniffer
@@ -31,6 +40,165 @@ func NewSniffer(conn net.Conn, dbConn net.Conn) *Sniffer {
 	}
 }
 
+/*
+SetRecorder enables the recording of the protocol session: the exchanged requests and replies are written
+as JSON lines to wr and can be replayed by a Replayer.
+*/
+func (s *Sniffer) SetRecorder(wr io.Writer) *Sniffer {
+	s.recorder = wr
+	return s
+}
+
+/*
+SnifferJSONOptions are the options of the JSON lines output of a sniffer (see Sniffer.SetJSONWriter).
+Empty filter attributes do not filter.
+*/
+type SnifferJSONOptions struct {
+	// SessionIDs are the ids of the sessions to be written.
+	SessionIDs []int64
+	// PartKinds are the names of the part kinds to be written (e.g. PkCommand). Messages without parts
+	// of these kinds are not written.
+	PartKinds []string
+	// Statement is the pattern of the statement text of the exchanges to be written. Exchanges not related
+	// to a statement (e.g. connect or commit) are not written.
+	Statement *regexp.Regexp
+	// ShowSecrets disables the redaction of authentication parts.
+	ShowSecrets bool
+}
+
+// filter returns false if msg is not to be written and removes the parts which are not to be written.
+func (o *SnifferJSONOptions) filter(stmt string, msg *p.DecodedMessage) bool {
+	if len(o.SessionIDs) != 0 && !slices.Contains(o.SessionIDs, msg.SessionID) {
+		return false
+	}
+	if o.Statement != nil && (stmt == "" || !o.Statement.MatchString(stmt)) {
+		return false
+	}
+	if len(o.PartKinds) == 0 {
+		return true
+	}
+	msg.Parts = slices.DeleteFunc(msg.Parts, func(part *p.DecodedPart) bool {
+		return !slices.ContainsFunc(o.PartKinds, func(kind string) bool { return strings.EqualFold(kind, part.Kind) })
+	})
+	return len(msg.Parts) != 0
+}
+
+/*
+SetJSONWriter enables the output of the decoded protocol messages as JSON lines to wr filtered by options
+(nil: no filters). Authentication parts are redacted unless requested otherwise by options.
+If set, the protocol messages are not logged.
+*/
+func (s *Sniffer) SetJSONWriter(wr io.Writer, options *SnifferJSONOptions) *Sniffer {
+	s.jsonWr = wr
+	if options != nil {
+		s.jsonOptions = *options
+	}
+	return s
+}
+
+// snifferMessage is a decoded protocol message written as JSON line.
+type snifferMessage struct {
+	Time      time.Time `json:"time"`
+	Conn      string    `json:"conn"`
+	Direction string    `json:"direction"`
+	Statement string    `json:"statement,omitempty"`
+	*p.DecodedMessage
+}
+
+// Directions of sniffer messages.
+const (
+	dirRequest = "request"
+	dirReply   = "reply"
+)
+
+func (s *Sniffer) writeJSON(enc *json.Encoder, direction, stmt string, msg *p.DecodedMessage) error {
+	if !s.jsonOptions.filter(stmt, msg) {
+		return nil
+	}
+	return enc.Encode(&snifferMessage{Time: time.Now(), Conn: s.conn.RemoteAddr().String(), Direction: direction, Statement: stmt, DecodedMessage: msg})
+}
+
+/*
+proxy forwards the protocol messages between client and database synchronously (request, reply, request, ...),
+so that the exchanges can be recorded respectively decoded in order. The forwarded data is written to clientWr
+and dbWr in addition.
+*/
+func (s *Sniffer) proxy(clientWr, dbWr io.Writer) error {
+	clientRd, dbRd := bufio.NewReader(s.conn), bufio.NewReader(s.dbConn)
+	toDB, toClient := io.MultiWriter(s.dbConn, clientWr), io.MultiWriter(s.conn, dbWr)
+
+	var recEnc, jsonEnc *json.Encoder
+	if s.recorder != nil {
+		recEnc = json.NewEncoder(s.recorder)
+	}
+	var msgDec *p.MessageDecoder
+	if s.jsonWr != nil {
+		jsonEnc = json.NewEncoder(s.jsonWr)
+		msgDec = p.NewMessageDecoder(s.jsonOptions.ShowSecrets)
+	}
+
+	forward := func(rd io.Reader, wr io.Writer, b []byte) error {
+		if _, err := io.ReadFull(rd, b); err != nil {
+			return err
+		}
+		_, err := wr.Write(b)
+		return err
+	}
+
+	req, reply := make([]byte, p.InitRequestSize), make([]byte, p.InitReplySize)
+	if err := forward(clientRd, toDB, req); err != nil {
+		return err
+	}
+	if err := forward(dbRd, toClient, reply); err != nil {
+		return err
+	}
+	if recEnc != nil {
+		if err := recEnc.Encode(&exchange{Key: prologKey, Request: req, Reply: reply}); err != nil {
+			return err
+		}
+	}
+
+	keyer := newExchangeKeyer()
+	for {
+		req, err := p.ReadRawMessage(clientRd)
+		if err != nil {
+			return err
+		}
+		if _, err := toDB.Write(req); err != nil {
+			return err
+		}
+		info := p.ParseRawRequest(req)
+		stmt := keyer.statement(info)
+		if msgDec != nil {
+			if err := s.writeJSON(jsonEnc, dirRequest, stmt, msgDec.DecodeRequest(req)); err != nil {
+				return err
+			}
+		}
+		if info.MessageType == p.MtDisconnect { // client does not read the reply
+			return io.EOF
+		}
+		reply, err := p.ReadRawMessage(dbRd)
+		if err != nil {
+			return err
+		}
+		if _, err := toClient.Write(reply); err != nil {
+			return err
+		}
+		if msgDec != nil {
+			if err := s.writeJSON(jsonEnc, dirReply, stmt, msgDec.DecodeReply(reply)); err != nil {
+				return err
+			}
+		}
+		key := keyer.key(info)
+		keyer.update(info, reply)
+		if recEnc != nil {
+			if err := recEnc.Encode(&exchange{Key: key, Request: req, Reply: reply}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func pipeData(wg *sync.WaitGroup, conn net.Conn, dbConn net.Conn, wr io.Writer) {
 	defer wg.Done()
 
@@ -64,15 +232,31 @@ func logData(ctx context.Context, wg *sync.WaitGroup, prd *p.Reader) {
 
 // Run starts the protocol request and response logging.
 func (s *Sniffer) Run() error {
+	if s.jsonWr != nil {
+		return s.proxy(io.Discard, io.Discard)
+	}
+
 	clientRd, clientWr := io.Pipe()
 	dbRd, dbWr := io.Pipe()
 
 	ctx := context.Background()
 	wg := &sync.WaitGroup{}
 
-	wg.Add(4)
-	go pipeData(wg, s.conn, s.dbConn, clientWr)
-	go pipeData(wg, s.dbConn, s.conn, dbWr)
+	var err error
+	if s.recorder == nil {
+		wg.Add(2)
+		go pipeData(wg, s.conn, s.dbConn, clientWr)
+		go pipeData(wg, s.dbConn, s.conn, dbWr)
+	} else {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err = s.proxy(clientWr, dbWr)
+			// end logging
+			clientWr.Close()
+			dbWr.Close()
+		}()
+	}
 
 	clientDec := encoding.NewDecoder(clientRd, cesu8.DefaultDecoder)
 	dbDec := encoding.NewDecoder(dbRd, cesu8.DefaultDecoder)
@@ -80,11 +264,12 @@ func (s *Sniffer) Run() error {
 	pClientRd := p.NewClientReader(clientDec, true, s.logger)
 	pDBRd := p.NewDBReader(dbDec, true, s.logger)
 
+	wg.Add(2)
 	go logData(ctx, wg, pClientRd)
 	go logData(ctx, wg, pDBRd)
 
 	wg.Wait()
 	log.Println("end run")
 
-	return nil
+	return err
 }
@@ -0,0 +1,109 @@
+package driver
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver/hdbtest"
+)
+
+// sniff executes fn with a database connected via a sniffer writing JSON lines with options.
+func sniff(t *testing.T, options *SnifferJSONOptions, fn func(t *testing.T, db *sql.DB)) []map[string]any {
+	srv, err := hdbtest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	srv.Handle("select name from t where id = ?", &hdbtest.Response{
+		Params:  []hdbtest.Column{{Name: "ID", Type: "INTEGER"}},
+		Columns: []hdbtest.Column{{Name: "NAME", Type: "NVARCHAR", Length: 10}},
+		Rows:    [][]any{{"name"}},
+	})
+
+	out := &bytes.Buffer{}
+	done := make(chan struct{})
+	addr := listen(t, func(conn net.Conn) {
+		defer close(done)
+		dbConn, err := net.Dial("tcp", srv.Addr())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer dbConn.Close()
+		NewSniffer(conn, dbConn).SetJSONWriter(out, options).Run() //nolint:errcheck
+	})
+
+	func() {
+		db := sql.OpenDB(NewBasicAuthConnector(addr, "MOCK", "password"))
+		defer db.Close()
+		db.SetMaxOpenConns(1)
+		fn(t, db)
+	}()
+	<-done
+
+	var msgs []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var msg map[string]any
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+func TestSnifferJSON(t *testing.T) {
+	query := func(t *testing.T, db *sql.DB) {
+		var name string
+		if err := db.QueryRow("select name from t where id = ?", 1).Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("redaction", func(t *testing.T) {
+		msgs := sniff(t, nil, query)
+		if len(msgs) == 0 {
+			t.Fatal("no messages written")
+		}
+		numAuth := 0
+		for _, msg := range msgs {
+			parts, _ := msg["parts"].([]any) // nil if message has no parts
+			for _, part := range parts {
+				part := part.(map[string]any)
+				if part["kind"] == "PkAuthentication" {
+					numAuth++
+					if part["value"] != "<redacted>" {
+						t.Fatalf("authentication part not redacted: %v", part["value"])
+					}
+				}
+			}
+		}
+		if numAuth == 0 {
+			t.Fatal("no authentication parts written")
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		msgs := sniff(t, &SnifferJSONOptions{Statement: regexp.MustCompile(`from t where`), PartKinds: []string{"PkParameters", "PkResultset"}}, query)
+		if len(msgs) != 2 { // execute request and reply
+			t.Fatalf("number of messages %d - expected 2: %v", len(msgs), msgs)
+		}
+		for _, msg := range msgs {
+			if msg["statement"] != "select name from t where id = ?" {
+				t.Fatalf("invalid statement %v", msg["statement"])
+			}
+		}
+		reply := msgs[1]["parts"].([]any)[0].(map[string]any)
+		if reply["kind"] != "PkResultset" || !strings.Contains(reply["value"].(string), "columnname NAME") {
+			t.Fatalf("invalid resultset part %v", reply)
+		}
+	})
+}
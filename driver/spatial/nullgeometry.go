@@ -0,0 +1,57 @@
+package spatial
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+/*
+NullGeometry represents a Geometry that may be null.
+NullGeometry implements the Scanner interface so it can be used as a scan destination
+for ST_GEOMETRY and ST_POINT database fields, similar to NullString.
+
+NullGeometry implements the Valuer interface as well, so that it can be bound directly
+to ST_GEOMETRY and ST_POINT parameters. The geometry is sent in "well known binary" format
+and therefore does not carry a spatial reference system identifier.
+*/
+type NullGeometry struct {
+	Geometry Geometry
+	Valid    bool // Valid is true if Geometry is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (n *NullGeometry) Scan(value any) error {
+	var b []byte
+	switch value := value.(type) {
+	case nil:
+		n.Geometry, n.Valid = nil, false
+		return nil
+	case string:
+		b = []byte(value)
+	case []byte:
+		b = value
+	default:
+		return fmt.Errorf("geometry: invalid data type %T", value)
+	}
+	g, err := DecodeWKB(b)
+	if err != nil {
+		return err
+	}
+	n.Geometry, n.Valid = g, true
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (n NullGeometry) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if n.Geometry == nil {
+		return nil, fmt.Errorf("invalid geometry value %v", n.Geometry)
+	}
+	b, err := EncodeWKB(n.Geometry, false)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
@@ -243,6 +243,71 @@ const (
 	geoCircularString     uint32 = 8
 )
 
+// geometry types by (extended) geo type.
+var geoTypes = map[uint32]reflect.Type{
+	geoPoint:                      hdbreflect.TypeFor[Point](),
+	geoPoint + dimZ:               hdbreflect.TypeFor[PointZ](),
+	geoPoint + dimM:               hdbreflect.TypeFor[PointM](),
+	geoPoint + dimZM:              hdbreflect.TypeFor[PointZM](),
+	geoLineString:                 hdbreflect.TypeFor[LineString](),
+	geoLineString + dimZ:          hdbreflect.TypeFor[LineStringZ](),
+	geoLineString + dimM:          hdbreflect.TypeFor[LineStringM](),
+	geoLineString + dimZM:         hdbreflect.TypeFor[LineStringZM](),
+	geoCircularString:             hdbreflect.TypeFor[CircularString](),
+	geoCircularString + dimZ:      hdbreflect.TypeFor[CircularStringZ](),
+	geoCircularString + dimM:      hdbreflect.TypeFor[CircularStringM](),
+	geoCircularString + dimZM:     hdbreflect.TypeFor[CircularStringZM](),
+	geoPolygon:                    hdbreflect.TypeFor[Polygon](),
+	geoPolygon + dimZ:             hdbreflect.TypeFor[PolygonZ](),
+	geoPolygon + dimM:             hdbreflect.TypeFor[PolygonM](),
+	geoPolygon + dimZM:            hdbreflect.TypeFor[PolygonZM](),
+	geoMultiPoint:                 hdbreflect.TypeFor[MultiPoint](),
+	geoMultiPoint + dimZ:          hdbreflect.TypeFor[MultiPointZ](),
+	geoMultiPoint + dimM:          hdbreflect.TypeFor[MultiPointM](),
+	geoMultiPoint + dimZM:         hdbreflect.TypeFor[MultiPointZM](),
+	geoMultiLineString:            hdbreflect.TypeFor[MultiLineString](),
+	geoMultiLineString + dimZ:     hdbreflect.TypeFor[MultiLineStringZ](),
+	geoMultiLineString + dimM:     hdbreflect.TypeFor[MultiLineStringM](),
+	geoMultiLineString + dimZM:    hdbreflect.TypeFor[MultiLineStringZM](),
+	geoMultiPolygon:               hdbreflect.TypeFor[MultiPolygon](),
+	geoMultiPolygon + dimZ:        hdbreflect.TypeFor[MultiPolygonZ](),
+	geoMultiPolygon + dimM:        hdbreflect.TypeFor[MultiPolygonM](),
+	geoMultiPolygon + dimZM:       hdbreflect.TypeFor[MultiPolygonZM](),
+	geoGeometryCollection:         hdbreflect.TypeFor[GeometryCollection](),
+	geoGeometryCollection + dimZ:  hdbreflect.TypeFor[GeometryCollectionZ](),
+	geoGeometryCollection + dimM:  hdbreflect.TypeFor[GeometryCollectionM](),
+	geoGeometryCollection + dimZM: hdbreflect.TypeFor[GeometryCollectionZM](),
+}
+
+// numCoord returns the number of coordinate values of dimension dim.
+func numCoord(dim uint32) int {
+	switch dim {
+	case dimZ, dimM:
+		return 3
+	case dimZM:
+		return 4
+	default:
+		return 2
+	}
+}
+
+// newCoordValue returns a coordinate (or point) value of type t with the coordinate values fs of dimension dim.
+func newCoordValue(t reflect.Type, dim uint32, fs []float64) reflect.Value {
+	v := reflect.New(t).Elem()
+	v.FieldByName("X").SetFloat(fs[0])
+	v.FieldByName("Y").SetFloat(fs[1])
+	switch dim {
+	case dimZ:
+		v.FieldByName("Z").SetFloat(fs[2])
+	case dimM:
+		v.FieldByName("M").SetFloat(fs[2])
+	case dimZM:
+		v.FieldByName("Z").SetFloat(fs[2])
+		v.FieldByName("M").SetFloat(fs[3])
+	}
+	return v
+}
+
 func geoTypeName(g Geometry) string {
 	name := reflect.TypeOf(g).Name()
 	size := len(name)
@@ -0,0 +1,178 @@
+package spatial
+
+import (
+	"math"
+	"testing"
+)
+
+var testGeometries = []Geometry{
+	Point{X: 2.5, Y: 3.0},
+	PointZ{X: -3.0, Y: -4.5, Z: 5.0},
+	PointM{X: -3.0, Y: -4.5, M: 6.0},
+	PointM{X: -3.0, Y: -4.5, M: math.NaN()},
+	PointZM{X: -3.0, Y: -4.5, Z: 5.0, M: 6.0},
+
+	LineString{},
+	LineString{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}, {X: 6.0, Y: 3.0}},
+	LineStringZM{{X: 3.0, Y: 3.0, Z: 1.0, M: 2.0}, {X: 5.0, Y: 4.0, Z: 1.5, M: 2.5}},
+
+	CircularString{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}, {X: 6.0, Y: 3.0}},
+
+	Polygon{},
+	Polygon{{{X: 6.0, Y: 7.0}, {X: 10.0, Y: 3.0}, {X: 10.0, Y: 10.0}, {X: 6.0, Y: 7.0}}, {{X: 6.0, Y: 7.0}, {X: 10.0, Y: 10.0}, {X: 10.0, Y: 3.0}, {X: 6.0, Y: 7.0}}},
+	PolygonZ{{{X: 6.0, Y: 7.0, Z: 1.0}, {X: 10.0, Y: 3.0, Z: 1.0}, {X: 10.0, Y: 10.0, Z: 1.0}, {X: 6.0, Y: 7.0, Z: 1.0}}},
+
+	MultiPoint{},
+	MultiPoint{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}},
+	MultiPointM{{X: 3.0, Y: 3.0, M: 1.0}, {X: 5.0, Y: 4.0, M: 2.0}},
+
+	MultiLineString{{{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}, {X: 6.0, Y: 3.0}}, {{X: 3.0, Y: 3.0}, {X: 5.0, Y: 4.0}}},
+
+	MultiPolygon{
+		{{{X: 6.0, Y: 7.0}, {X: 10.0, Y: 3.0}, {X: 10.0, Y: 10.0}, {X: 6.0, Y: 7.0}}},
+		{{{X: 6.0, Y: 7.0}, {X: 10.0, Y: 10.0}, {X: 10.0, Y: 3.0}, {X: 6.0, Y: 7.0}}},
+	},
+
+	GeometryCollection{},
+	GeometryCollection{Point{X: 1, Y: 1}, LineString{{X: 1, Y: 1}, {X: 2, Y: 2}}},
+	GeometryCollectionZ{PointZ{X: 1, Y: 1, Z: 1}, MultiPointZ{{X: 1, Y: 1, Z: 1}}},
+}
+
+func testWKB(t *testing.T) {
+	for _, isXDR := range []bool{false, true} {
+		for i, g := range testGeometries {
+			wkb, err := EncodeWKB(g, isXDR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dg, err := DecodeWKB(wkb)
+			if err != nil {
+				t.Fatalf("test %d: %s", i, err)
+			}
+			dwkb, err := EncodeWKB(dg, isXDR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(dwkb) != string(wkb) {
+				t.Fatalf("test %d: wkb %s - expected %s", i, dwkb, wkb)
+			}
+
+			ewkb, err := EncodeEWKB(g, isXDR, 4711)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dg, srid, err := DecodeEWKB(ewkb)
+			if err != nil {
+				t.Fatalf("test %d: %s", i, err)
+			}
+			if srid != 4711 {
+				t.Fatalf("test %d: srid %d - expected %d", i, srid, 4711)
+			}
+			if dwkb, err = EncodeWKB(dg, isXDR); err != nil {
+				t.Fatal(err)
+			}
+			if string(dwkb) != string(wkb) {
+				t.Fatalf("test %d: ewkb geometry %s - expected %s", i, dwkb, wkb)
+			}
+		}
+	}
+
+	if _, err := DecodeWKB([]byte("0101000000")); err == nil {
+		t.Fatal("truncated wkb: error expected")
+	}
+}
+
+func testWKT(t *testing.T) {
+	for i, g := range testGeometries {
+		wkt, err := EncodeWKT(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dg, err := DecodeWKT(wkt)
+		if err != nil {
+			t.Fatalf("test %d: %s: %s", i, wkt, err)
+		}
+		dwkt, err := EncodeWKT(dg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(dwkt) != string(wkt) {
+			t.Fatalf("test %d: wkt %s - expected %s", i, dwkt, wkt)
+		}
+
+		ewkt, err := EncodeEWKT(g, 4711)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dg, srid, err := DecodeEWKT(ewkt)
+		if err != nil {
+			t.Fatalf("test %d: %s: %s", i, ewkt, err)
+		}
+		if srid != 4711 {
+			t.Fatalf("test %d: srid %d - expected %d", i, srid, 4711)
+		}
+		if dwkt, err = EncodeWKT(dg); err != nil {
+			t.Fatal(err)
+		}
+		if string(dwkt) != string(wkt) {
+			t.Fatalf("test %d: ewkt geometry %s - expected %s", i, dwkt, wkt)
+		}
+	}
+
+	// multi points without brackets.
+	g, err := DecodeWKT([]byte("multipoint (1 2, 3 4)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wkt, _ := EncodeWKT(g); string(wkt) != "MULTIPOINT ((1 2),(3 4))" {
+		t.Fatalf("wkt %s - expected %s", wkt, "MULTIPOINT ((1 2),(3 4))")
+	}
+
+	for _, s := range []string{"POINT (1)", "POINT (1 2", "LINESTRING (1 2,)", "CIRCLE (1 2)", "POINT (1 2) x"} {
+		if _, err := DecodeWKT([]byte(s)); err == nil {
+			t.Fatalf("%s: error expected", s)
+		}
+	}
+}
+
+func testNullGeometry(t *testing.T) {
+	var n NullGeometry
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("null geometry: valid %t error %v", n.Valid, err)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Fatalf("null geometry: value %v error %v", v, err)
+	}
+
+	g := LineString{{X: 1, Y: 1}, {X: 2, Y: 2}}
+	v, err := NullGeometry{Geometry: g, Valid: true}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !n.Valid {
+		t.Fatal("geometry: valid expected")
+	}
+	if ls, ok := n.Geometry.(LineString); !ok || len(ls) != 2 || ls[1] != g[1] {
+		t.Fatalf("geometry %v - expected %v", n.Geometry, g)
+	}
+}
+
+func TestSpatial(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T)
+	}{
+		{"WKB", testWKB},
+		{"WKT", testWKT},
+		{"NullGeometry", testNullGeometry},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.fct(t)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
 	"reflect"
 )
@@ -187,3 +189,169 @@ func EncodeEWKB(g Geometry, isXDR bool, srid int32) ([]byte, error) {
 	}
 	return b.bytes(), nil
 }
+
+type wkbReader struct {
+	rd    *bytes.Reader
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	var v uint32
+	err := binary.Read(r.rd, r.order, &v)
+	return v, err
+}
+
+func (r *wkbReader) readSize() (int, error) {
+	size, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if int64(size) > int64(r.rd.Len()) { // each element needs at least one byte
+		return 0, fmt.Errorf("invalid wkb size %d", size)
+	}
+	return int(size), nil
+}
+
+func (r *wkbReader) readType() (reflect.Type, uint32, int32, bool, error) {
+	orderByte, err := r.rd.ReadByte()
+	if err != nil {
+		return nil, 0, 0, false, err
+	}
+	switch orderByte {
+	case XDR:
+		r.order = binary.BigEndian
+	case NDR:
+		r.order = binary.LittleEndian
+	default:
+		return nil, 0, 0, false, fmt.Errorf("invalid wkb byte order %d", orderByte)
+	}
+	typ, err := r.readUint32()
+	if err != nil {
+		return nil, 0, 0, false, err
+	}
+	var srid int32
+	extended := typ&sridFlag != 0
+	if extended {
+		typ &^= sridFlag
+		if err := binary.Read(r.rd, r.order, &srid); err != nil {
+			return nil, 0, 0, false, err
+		}
+	}
+	t, ok := geoTypes[typ]
+	if !ok {
+		return nil, 0, 0, false, fmt.Errorf("invalid wkb geometry type %d", typ)
+	}
+	return t, typ, srid, extended, nil
+}
+
+func (r *wkbReader) decodeCoord(t reflect.Type, dim uint32) (reflect.Value, error) {
+	fs := make([]float64, numCoord(dim))
+	if err := binary.Read(r.rd, r.order, fs); err != nil {
+		return reflect.Value{}, err
+	}
+	return newCoordValue(t, dim, fs), nil
+}
+
+func (r *wkbReader) decodeCoords(t reflect.Type, dim uint32) (reflect.Value, error) {
+	size, err := r.readSize()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.MakeSlice(t, size, size)
+	for i := 0; i < size; i++ {
+		cv, err := r.decodeCoord(t.Elem(), dim)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v.Index(i).Set(cv)
+	}
+	return v, nil
+}
+
+func (r *wkbReader) decodeGeometry() (Geometry, int32, bool, error) {
+	t, typ, srid, extended, err := r.readType()
+	if err != nil {
+		return nil, 0, false, err
+	}
+	gt, dim := typ%1000, typ-typ%1000
+
+	var v reflect.Value
+	switch gt {
+	case geoPoint:
+		v, err = r.decodeCoord(t, dim)
+	case geoLineString, geoCircularString:
+		v, err = r.decodeCoords(t, dim)
+	case geoPolygon:
+		var size int
+		if size, err = r.readSize(); err != nil {
+			break
+		}
+		v = reflect.MakeSlice(t, size, size)
+		for i := 0; i < size && err == nil; i++ {
+			var ringv reflect.Value
+			if ringv, err = r.decodeCoords(t.Elem(), dim); err == nil {
+				v.Index(i).Set(ringv)
+			}
+		}
+	default: // multi geometries and geometry collections
+		var size int
+		if size, err = r.readSize(); err != nil {
+			break
+		}
+		v = reflect.MakeSlice(t, size, size)
+		for i := 0; i < size && err == nil; i++ {
+			var g Geometry
+			if g, _, _, err = r.decodeGeometry(); err != nil {
+				break
+			}
+			gv := reflect.ValueOf(g)
+			if !gv.Type().AssignableTo(t.Elem()) {
+				err = fmt.Errorf("invalid wkb geometry type %s in %s", gv.Type().Name(), t.Name())
+				break
+			}
+			v.Index(i).Set(gv)
+		}
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return v.Interface().(Geometry), srid, extended, nil
+}
+
+func decodeWKB(b []byte) (Geometry, int32, bool, error) {
+	buf := make([]byte, hex.DecodedLen(len(b)))
+	if _, err := hex.Decode(buf, b); err != nil {
+		return nil, 0, false, err
+	}
+	r := &wkbReader{rd: bytes.NewReader(buf)}
+	g, srid, extended, err := r.decodeGeometry()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, false, err
+	}
+	if r.rd.Len() != 0 {
+		return nil, 0, false, fmt.Errorf("invalid wkb: %d trailing bytes", r.rd.Len())
+	}
+	return g, srid, extended, nil
+}
+
+// DecodeWKB decodes a geometry from the hex encoded "well known binary" format.
+// A spatial reference system identifier provided by the extended format is ignored.
+func DecodeWKB(b []byte) (Geometry, error) {
+	g, _, _, err := decodeWKB(b)
+	return g, err
+}
+
+// DecodeEWKB decodes a geometry and its spatial reference system identifier from the hex encoded "extended well known binary" format.
+func DecodeEWKB(b []byte) (Geometry, int32, error) {
+	g, srid, extended, err := decodeWKB(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !extended {
+		return nil, 0, errors.New("invalid ewkb: missing spatial reference system identifier")
+	}
+	return g, srid, nil
+}
@@ -2,6 +2,8 @@ package spatial
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"math"
 	"reflect"
 	"strconv"
@@ -152,3 +154,229 @@ func EncodeEWKT(g Geometry, srid int32) ([]byte, error) {
 	encodeWKT(b, typeFull, g)
 	return b.Bytes(), nil
 }
+
+// geo types by wkt type name.
+var wktGeoTypes = map[string]uint32{
+	"POINT":              geoPoint,
+	"LINESTRING":         geoLineString,
+	"POLYGON":            geoPolygon,
+	"MULTIPOINT":         geoMultiPoint,
+	"MULTILINESTRING":    geoMultiLineString,
+	"MULTIPOLYGON":       geoMultiPolygon,
+	"GEOMETRYCOLLECTION": geoGeometryCollection,
+	"CIRCULARSTRING":     geoCircularString,
+}
+
+// wkt dimensions by dimension name.
+var wktDims = map[string]uint32{"Z": dimZ, "M": dimM, "ZM": dimZM}
+
+type wktReader struct {
+	s   string
+	pos int
+}
+
+func (r *wktReader) skipSpace() {
+	for r.pos < len(r.s) && strings.IndexByte(" \t\r\n", r.s[r.pos]) != -1 {
+		r.pos++
+	}
+}
+
+// token returns the next token (a word, a number or a single delimiter) without consuming it.
+func (r *wktReader) token() string {
+	r.skipSpace()
+	if r.pos >= len(r.s) {
+		return ""
+	}
+	end := r.pos
+	for end < len(r.s) && strings.IndexByte(" \t\r\n(),;=", r.s[end]) == -1 {
+		end++
+	}
+	if end == r.pos { // delimiter
+		end++
+	}
+	return r.s[r.pos:end]
+}
+
+func (r *wktReader) next() string {
+	tk := r.token()
+	r.pos += len(tk)
+	return tk
+}
+
+func (r *wktReader) is(tk string) bool {
+	if strings.EqualFold(r.token(), tk) {
+		r.next()
+		return true
+	}
+	return false
+}
+
+func (r *wktReader) expect(tk string) error {
+	if !r.is(tk) {
+		return r.errorf("%q expected", tk)
+	}
+	return nil
+}
+
+func (r *wktReader) errorf(format string, a ...any) error {
+	return fmt.Errorf("invalid wkt at position %d: %s", r.pos, fmt.Sprintf(format, a...))
+}
+
+func (r *wktReader) decodeFloat() (float64, error) {
+	tk := r.next()
+	if strings.EqualFold(tk, "NULL") {
+		return math.NaN(), nil
+	}
+	f, err := strconv.ParseFloat(tk, 64)
+	if err != nil {
+		return 0, r.errorf("invalid coordinate value %q", tk)
+	}
+	return f, nil
+}
+
+func (r *wktReader) decodeCoord(t reflect.Type, dim uint32) (reflect.Value, error) {
+	fs := make([]float64, numCoord(dim))
+	for i := range fs {
+		f, err := r.decodeFloat()
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		fs[i] = f
+	}
+	return newCoordValue(t, dim, fs), nil
+}
+
+// decodeList decodes a bracketed, comma separated list or EMPTY into a slice of type t.
+func (r *wktReader) decodeList(t reflect.Type, fn func() (reflect.Value, error)) (reflect.Value, error) {
+	v := reflect.MakeSlice(t, 0, 0)
+	if r.is("EMPTY") {
+		return v, nil
+	}
+	if err := r.expect("("); err != nil {
+		return reflect.Value{}, err
+	}
+	for {
+		ev, err := fn()
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		v = reflect.Append(v, ev)
+		if r.is(")") {
+			return v, nil
+		}
+		if err := r.expect(","); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+}
+
+func (r *wktReader) decodeValue(t reflect.Type, gt, dim uint32) (reflect.Value, error) {
+	switch gt {
+	case geoPoint:
+		if r.is("EMPTY") {
+			return newCoordValue(t, dim, []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()}), nil
+		}
+		if err := r.expect("("); err != nil {
+			return reflect.Value{}, err
+		}
+		v, err := r.decodeCoord(t, dim)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return v, r.expect(")")
+	case geoLineString, geoCircularString:
+		return r.decodeList(t, func() (reflect.Value, error) { return r.decodeCoord(t.Elem(), dim) })
+	case geoPolygon:
+		return r.decodeList(t, func() (reflect.Value, error) { return r.decodeValue(t.Elem(), geoLineString, dim) })
+	case geoMultiPoint:
+		return r.decodeList(t, func() (reflect.Value, error) {
+			if r.token() == "(" || strings.EqualFold(r.token(), "EMPTY") {
+				return r.decodeValue(t.Elem(), geoPoint, dim)
+			}
+			return r.decodeCoord(t.Elem(), dim) // points without brackets
+		})
+	case geoMultiLineString:
+		return r.decodeList(t, func() (reflect.Value, error) { return r.decodeValue(t.Elem(), geoLineString, dim) })
+	case geoMultiPolygon:
+		return r.decodeList(t, func() (reflect.Value, error) { return r.decodeValue(t.Elem(), geoPolygon, dim) })
+	default: // geometry collection
+		return r.decodeList(t, func() (reflect.Value, error) {
+			g, err := r.decodeGeometry(dim)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			gv := reflect.ValueOf(g)
+			if !gv.Type().AssignableTo(t.Elem()) {
+				return reflect.Value{}, r.errorf("invalid geometry type %s in %s", gv.Type().Name(), t.Name())
+			}
+			return gv, nil
+		})
+	}
+}
+
+// decodeGeometry decodes a tagged geometry. Geometries without dimension name get dimension dim
+// (geometry collection members are encoded with their short type name).
+func (r *wktReader) decodeGeometry(dim uint32) (Geometry, error) {
+	name := strings.ToUpper(r.next())
+	gt, ok := wktGeoTypes[name]
+	if !ok {
+		return nil, r.errorf("invalid geometry type %q", name)
+	}
+	if d, ok := wktDims[strings.ToUpper(r.token())]; ok {
+		r.next()
+		dim = d
+	}
+	t := geoTypes[gt+dim]
+	v, err := r.decodeValue(t, gt, dim)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface().(Geometry), nil
+}
+
+func decodeWKT(b []byte) (Geometry, int32, bool, error) {
+	r := &wktReader{s: string(b)}
+	var srid int32
+	extended := r.is("SRID")
+	if extended {
+		if err := r.expect("="); err != nil {
+			return nil, 0, false, err
+		}
+		tk := r.next()
+		i, err := strconv.ParseInt(tk, 10, 32)
+		if err != nil {
+			return nil, 0, false, r.errorf("invalid spatial reference system identifier %q", tk)
+		}
+		srid = int32(i)
+		if err := r.expect(";"); err != nil {
+			return nil, 0, false, err
+		}
+	}
+	g, err := r.decodeGeometry(0)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if tk := r.token(); tk != "" {
+		return nil, 0, false, r.errorf("unexpected %q", tk)
+	}
+	return g, srid, extended, nil
+}
+
+// DecodeWKT decodes a geometry from the "well known text" format.
+// A spatial reference system identifier provided by the extended format is ignored.
+func DecodeWKT(b []byte) (Geometry, error) {
+	g, _, _, err := decodeWKT(b)
+	return g, err
+}
+
+// DecodeEWKT decodes a geometry and its spatial reference system identifier from the "extended well known text" format.
+func DecodeEWKT(b []byte) (Geometry, int32, error) {
+	g, srid, extended, err := decodeWKT(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !extended {
+		return nil, 0, errors.New("invalid ewkt: missing spatial reference system identifier")
+	}
+	return g, srid, nil
+}
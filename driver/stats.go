@@ -1,5 +1,11 @@
 package driver
 
+import (
+	"encoding/json"
+	"expvar"
+	"strconv"
+)
+
 // StatsHistogram represents statistic data in a histogram structure.
 type StatsHistogram struct {
 	// Count holds the number of measurements
@@ -17,9 +23,23 @@ type Stats struct {
 	OpenConnections  int // The number of current established driver connections.
 	OpenTransactions int // The number of current open driver transactions.
 	OpenStatements   int // The number of current open driver database statements.
+	RetainedBytes    int // The number of bytes of decoding buffers retained by open result sets for reuse.
 	// Counters
 	ReadBytes    uint64 // Total bytes read by client connection.
 	WrittenBytes uint64 // Total bytes written by client connection.
+	// Prepared statement cache counters
+	StmtCacheHits   uint64 // Total number of prepares served by the statement cache.
+	StmtCacheMisses uint64 // Total number of prepares not found in the statement cache.
+	// Prepared statement metadata cache counters
+	MetadataHits   uint64 // Total number of prepares reusing the metadata of the metadata cache.
+	MetadataMisses uint64 // Total number of prepares decoding the metadata with metadata cache enabled.
+	// Connection counters
+	Roundtrips uint64 // Total number of request / reply roundtrips.
+	LobChunks  uint64 // Total number of lob chunks read or written in separate roundtrips.
+	Errors     uint64 // Total number of errors returned by statement executions.
+	Reconnects uint64 // Total number of connection reconnects.
+	FreedLobs  uint64 // Total number of lob locators not read completely by the application and released by the driver.
+	Reprepares uint64 // Total number of prepared statements re-prepared after being invalidated by the database server.
 	// Time histograms (Sum and upper bounds in Unit)
 	TimeUnit  string                     // Time unit
 	ReadTime  *StatsHistogram            // Time spent on reading from connection.
@@ -27,3 +47,24 @@ type Stats struct {
 	AuthTime  *StatsHistogram            // Time spent on authentication.
 	SQLTimes  map[string]*StatsHistogram // Time spent on different SQL statements.
 }
+
+// MarshalJSON implements the json.Marshaler interface (bucket upper bounds are encoded as JSON object keys).
+func (h *StatsHistogram) MarshalJSON() ([]byte, error) {
+	buckets := make(map[string]uint64, len(h.Buckets))
+	for upperBound, count := range h.Buckets {
+		buckets[strconv.FormatFloat(upperBound, 'g', -1, 64)] = count
+	}
+	return json.Marshal(struct {
+		Count   uint64
+		Sum     float64
+		Buckets map[string]uint64
+	}{h.Count, h.Sum, buckets})
+}
+
+func publishExpvar(name string, stats func() *Stats) {
+	expvar.Publish(name, expvar.Func(func() any { return stats() }))
+}
+
+// PublishExpvar publishes the aggregated driver statistics as expvar variable name.
+// Like expvar.Publish it panics if a variable with the same name is already published.
+func PublishExpvar(name string) { publishExpvar(name, stdHdbDriver.Stats) }
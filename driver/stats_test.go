@@ -0,0 +1,38 @@
+package driver
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestStatsExpvar(t *testing.T) {
+	const name = "go-hdb-test"
+
+	m := newMetrics(nil, statsCfg.TimeUnit, statsCfg.TimeUpperBounds)
+	m.handleMsg(counterMsg{idx: counterRoundtrips, v: 3})
+	m.handleMsg(sqlTimeMsg{idx: sqlTimeQuery, d: 0})
+	publishExpvar(name, m.stats)
+
+	v := expvar.Get(name)
+	if v == nil {
+		t.Fatalf("expvar variable %s not published", name)
+	}
+	var stats struct {
+		Roundtrips uint64
+		SQLTimes   map[string]struct {
+			Count   uint64
+			Buckets map[string]uint64
+		}
+	}
+	if err := json.Unmarshal([]byte(v.String()), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Roundtrips != 3 {
+		t.Fatalf("roundtrips %d - expected %d", stats.Roundtrips, 3)
+	}
+	query := stats.SQLTimes[statsCfg.SQLTimeTexts[sqlTimeQuery]]
+	if query.Count != 1 || len(query.Buckets) != len(statsCfg.TimeUpperBounds) {
+		t.Fatalf("query time count %d buckets %d - expected %d %d", query.Count, len(query.Buckets), 1, len(statsCfg.TimeUpperBounds))
+	}
+}
@@ -18,12 +18,22 @@ var (
 	_ driver.StmtExecContext   = (*stmt)(nil)
 	_ driver.StmtQueryContext  = (*stmt)(nil)
 	_ driver.NamedValueChecker = (*stmt)(nil)
+	_ Stmt                     = (*stmt)(nil)
 )
 
+// Stmt enhances a prepared statement with go-hdb specific statement functions.
+type Stmt interface {
+	ParameterTypes() []*ParameterType
+	ColumnOrigins() []*ColumnOrigin
+}
+
 type stmt struct {
-	conn  *conn
-	query string
-	pr    *prepareResult
+	conn       *conn
+	query      string
+	pr         *prepareResult
+	reconnects int             // number of connection reconnects at prepare time
+	cacheEntry *stmtCacheEntry // statement cache entry (nil if not cached)
+	execer     Execer          // statement execution wrapped by the connector middlewares
 	// rows: stored procedures with table output parameters
 	rows *sql.Rows
 }
@@ -41,9 +51,11 @@ func (t *totalRowsAffected) add(r driver.Result) {
 	*t += totalRowsAffected(rows)
 }
 
-func newStmt(conn *conn, query string, pr *prepareResult) *stmt {
+func newStmt(conn *conn, query string, pr *prepareResult, cacheEntry *stmtCacheEntry) *stmt {
 	conn.metrics.msgCh <- gaugeMsg{idx: gaugeStmt, v: 1} // increment number of statements.
-	return &stmt{conn: conn, query: query, pr: pr}
+	s := &stmt{conn: conn, query: query, pr: pr, reconnects: conn.reconnects, cacheEntry: cacheEntry}
+	s.execer = chainMiddlewares(conn.attrs._middlewares, conn.attrs._resultCache.execer(conn, stmtExecer{s: s}))
+	return s
 }
 
 /*
@@ -66,9 +78,24 @@ func (s *stmt) Close() error {
 	if c.isBad() {
 		return driver.ErrBadConn
 	}
-	return c.dropStatementID(context.Background(), s.pr.stmtID)
+	if s.reconnects != c.reconnects { // statement id of lost session
+		return nil
+	}
+	if s.cacheEntry == nil {
+		return c.dropStatementID(context.Background(), s.pr.stmtID)
+	}
+	if !c.stmtCache.release(s.cacheEntry) { // statement still cached or in use
+		return nil
+	}
+	return c.dropStatementID(context.Background(), s.cacheEntry.pr.stmtID) // statement might have been re-prepared
 }
 
+// ParameterTypes implements the Stmt interface.
+func (s *stmt) ParameterTypes() []*ParameterType { return newParameterTypes(s.pr.parameterFields) }
+
+// ColumnOrigins implements the Stmt interface.
+func (s *stmt) ColumnOrigins() []*ColumnOrigin { return newColumnOrigins(s.pr.resultFields) }
+
 // CheckNamedValue implements NamedValueChecker interface.
 func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
 	// conversion is happening as part of the exec, query call
@@ -76,13 +103,22 @@ func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
 }
 
 func (s *stmt) QueryContext(ctx context.Context, nvargs []driver.NamedValue) (driver.Rows, error) {
-	if s.pr.isProcedureCall() {
-		return nil, fmt.Errorf("invalid procedure call %s - please use Exec instead", s.query)
-	}
+	return s.execer.Query(ctx, s.query, nvargs)
+}
+
+func (s *stmt) queryContext(ctx context.Context, nvargs []driver.NamedValue) (driver.Rows, error) {
 	c := s.conn
+	if s.reconnects != c.reconnects {
+		return nil, errStmtInvalidated
+	}
+	if err := c.audit(ctx, s.query, s.pr.parameterFields, nvargs); err != nil {
+		return nil, err
+	}
+	c.warnings = nil
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), s.query, nvargs)
 	}
+	start := time.Now()
 
 	done := make(chan struct{})
 	var rows driver.Rows
@@ -90,28 +126,57 @@ func (s *stmt) QueryContext(ctx context.Context, nvargs []driver.NamedValue) (dr
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		rows, err = c.query(ctx, s.pr, nvargs, !s.conn.inTx)
+		err = c.retryStatement(ctx, nvargs, s.pr.numField(), func() error {
+			return s.reprepareOnInvalidation(ctx, nvargs, func() (err error) {
+				if s.pr.isProcedureCall() {
+					rows, err = s.queryCall(ctx, s.pr, nvargs)
+				} else {
+					rows, err = c.query(ctx, s.pr, nvargs, !s.conn.inTx)
+				}
+				return err
+			})
+		})
+		err = c.connLostError(err)
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, c.stmtError(s.query, ctx.Err())
 	case <-done:
-		c.lastError = err
-		return rows, err
+		c.setLastError(err)
+		c.setExecInfo(ctx)
+		if err == nil {
+			setResultSize(ctx, rows)
+		}
+		if err == nil && c.attrs._slowQueryHook != nil {
+			c.checkSlowQuery(ctx, start, s.query, func() int64 { return queryRows(rows) })
+		}
+		return rows, c.stmtError(s.query, err)
 	}
 }
 
 func (s *stmt) ExecContext(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
+	return s.execer.Exec(ctx, s.query, nvargs)
+}
+
+func (s *stmt) execContext(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
 	c := s.conn
 	if connHook != nil {
 		connHook(c, choStmtExec)
 	}
+	if s.reconnects != c.reconnects {
+		return nil, errStmtInvalidated
+	}
+	if err := c.audit(ctx, s.query, s.pr.parameterFields, nvargs); err != nil {
+		return nil, err
+	}
+	c.warnings = nil
 	if c.sqlTrace {
 		defer c.logSQLTrace(ctx, time.Now(), s.query, nvargs)
 	}
+	start := time.Now()
 
 	done := make(chan struct{})
 	var result driver.Result
@@ -119,21 +184,31 @@ func (s *stmt) ExecContext(ctx context.Context, nvargs []driver.NamedValue) (dri
 	c.wg.Add(1)
 	go func() {
 		defer c.wg.Done()
-		if s.pr.isProcedureCall() {
-			result, s.rows, err = s.execCall(ctx, s.pr, nvargs)
-		} else {
-			result, err = s.execDefault(ctx, nvargs)
-		}
+		err = c.retryStatement(ctx, nvargs, s.pr.numField(), func() error {
+			return s.reprepareOnInvalidation(ctx, nvargs, func() (err error) {
+				if s.pr.isProcedureCall() {
+					result, s.rows, err = s.execCall(ctx, s.pr, nvargs)
+				} else {
+					result, err = s.execDefault(ctx, nvargs)
+				}
+				return err
+			})
+		})
+		err = c.connLostError(err)
 		close(done)
 	}()
 
 	select {
 	case <-ctx.Done():
-		c.lastError = errCancelled
-		return nil, ctx.Err()
+		c.cancel()
+		return nil, c.stmtError(s.query, ctx.Err())
 	case <-done:
-		c.lastError = err
-		return result, err
+		c.setLastError(err)
+		c.setExecInfo(ctx)
+		if err == nil && c.attrs._slowQueryHook != nil {
+			c.checkSlowQuery(ctx, start, s.query, func() int64 { return execRows(result) })
+		}
+		return result, c.stmtError(s.query, err)
 	}
 }
 
@@ -141,41 +216,15 @@ func (s *stmt) execCall(ctx context.Context, pr *prepareResult, nvargs []driver.
 	c := s.conn
 	defer c.addSQLTimeValue(time.Now(), sqlTimeCall)
 
-	callArgs, err := convertCallArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.attrs._lobChunkSize)
-	if err != nil {
-		return nil, nil, err
-	}
-	inputParameters, err := p.NewInputParameters(callArgs.inFields, callArgs.inArgs)
+	callArgs, err := convertCallArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.lobChunkSize(ctx))
 	if err != nil {
 		return nil, nil, err
 	}
-	if err := c.pw.Write(ctx, c.sessionID, p.MtExecute, false, p.StatementID(pr.stmtID), inputParameters); err != nil {
-		return nil, nil, err
-	}
-
-	/*
-		call without lob input parameters:
-		--> callResult output parameter values are set after read call
-		call with lob output parameters:
-		--> callResult output parameter values are set after last lob input write
-	*/
-
-	cr, ids, numRow, err := c.execCall(ctx, callArgs.outFields)
+	cr, numRow, err := s.call(ctx, pr, callArgs)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if len(ids) != 0 {
-		/*
-			writeLobParameters:
-			- chunkReaders
-			- cr (callResult output parameters are set after all lob input parameters are written)
-		*/
-		if err := c.encodeLobs(cr, ids, callArgs.inFields, callArgs.inArgs); err != nil {
-			return nil, nil, err
-		}
-	}
-
 	// no output fields -> done
 	if len(cr.outputFields) == 0 {
 		return driver.RowsAffected(numRow), nil, nil
@@ -208,6 +257,63 @@ func (s *stmt) execCall(ctx context.Context, pr *prepareResult, nvargs []driver.
 	return driver.RowsAffected(numRow), rows, nil
 }
 
+// queryCall executes a procedure call and returns the table results and output parameters as result sets.
+func (s *stmt) queryCall(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue) (driver.Rows, error) {
+	c := s.conn
+	defer c.addSQLTimeValue(time.Now(), sqlTimeCall)
+
+	callArgs, err := convertQueryCallArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.lobChunkSize(ctx))
+	if err != nil {
+		return nil, err
+	}
+	cr, _, err := s.call(ctx, pr, callArgs)
+	if err != nil {
+		return nil, err
+	}
+	return newResultSets(cr, len(callArgs.outFields)), nil
+}
+
+func (s *stmt) call(ctx context.Context, pr *prepareResult, callArgs *callArgs) (*callResult, int64, error) {
+	c := s.conn
+
+	inputParameters, err := p.NewInputParameters(callArgs.inFields, callArgs.inArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+	sc, err := c.stmtContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := c.pw.Write(ctx, c.sessionID, p.MtExecute, false, p.StatementID(pr.stmtID), inputParameters, sc, c.clientInfo(ctx)); err != nil {
+		return nil, 0, err
+	}
+	c.numStmt++
+
+	/*
+		call without lob input parameters:
+		--> callResult output parameter values are set after read call
+		call with lob output parameters:
+		--> callResult output parameter values are set after last lob input write
+	*/
+
+	cr, ids, numRow, err := c.execCall(ctx, callArgs.outFields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(ids) != 0 {
+		/*
+			writeLobParameters:
+			- chunkReaders
+			- cr (callResult output parameters are set after all lob input parameters are written)
+		*/
+		if err := c.encodeLobs(cr, ids, callArgs.inFields, callArgs.inArgs, c.lobChunkSize(ctx)); err != nil {
+			return nil, 0, err
+		}
+	}
+	return cr, numRow, nil
+}
+
 func (s *stmt) execDefault(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
 	c := s.conn
 
@@ -244,8 +350,6 @@ execMany data might only be written partially to the database in case of hdb stm
 func (s *stmt) execFct(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
 	c := s.conn
 
-	totalRowsAffected := totalRowsAffected(0)
-	args := make([]driver.NamedValue, 0, s.pr.numField())
 	scanArgs := make([]any, s.pr.numField())
 
 	fct, ok := nvargs[0].Value.(func(args []any) error)
@@ -254,17 +358,17 @@ func (s *stmt) execFct(ctx context.Context, nvargs []driver.NamedValue) (driver.
 	}
 
 	done := false
-	batch := 0
-	for !done {
-		args = args[:0]
-		for i := 0; i < c.attrs._bulkSize; i++ {
+	return s.execBatches(ctx, func() ([]driver.NamedValue, error) {
+		// allocate new args per batch, as in case of pipelining the previous batch might still be in use
+		var args []driver.NamedValue
+		for i := 0; !done && i < c.attrs._bulkSize; i++ {
 			err := fct(scanArgs)
 			if errors.Is(err, ErrEndOfRows) {
 				done = true
 				break
 			}
 			if err != nil {
-				return driver.RowsAffected(totalRowsAffected), err
+				return nil, err
 			}
 
 			args = slices.Grow(args, len(scanArgs))
@@ -280,17 +384,8 @@ func (s *stmt) execFct(ctx context.Context, nvargs []driver.NamedValue) (driver.
 				args = append(args, nv)
 			}
 		}
-
-		if len(args) != 0 {
-			r, err := s.exec(ctx, s.pr, args, !c.inTx, batch*c.attrs._bulkSize)
-			totalRowsAffected.add(r)
-			if err != nil {
-				return driver.RowsAffected(totalRowsAffected), err
-			}
-		}
-		batch++
-	}
-	return driver.RowsAffected(totalRowsAffected), nil
+		return args, nil
+	})
 }
 
 /*
@@ -298,31 +393,72 @@ Non 'atomic' (transactional) operation due to the split in packages (bulkSize),
 execMany data might only be written partially to the database in case of hdb stmt errors.
 */
 func (s *stmt) execMany(ctx context.Context, nvargs []driver.NamedValue) (driver.Result, error) {
+	batchSize := s.pr.numField() * s.conn.attrs._bulkSize
+
+	from := 0
+	return s.execBatches(ctx, func() ([]driver.NamedValue, error) {
+		to := min(from+batchSize, len(nvargs))
+		args := nvargs[from:to]
+		from = to
+		return args, nil
+	})
+}
+
+/*
+execBatches executes the batches of arguments returned by next until next returns no arguments.
+
+In case of bulk pipelining the next batch is read, converted and encoded in a separate goroutine
+while the server call(s) of the current batch are executed.
+*/
+func (s *stmt) execBatches(ctx context.Context, next func() ([]driver.NamedValue, error)) (driver.Result, error) {
 	c := s.conn
-	bulkSize := c.attrs._bulkSize
+	pipeline := c.attrs._bulkPipeline
+	numField := s.pr.numField()
+
+	nextBatch := func() *execBatch {
+		nvargs, err := next()
+		if err != nil || len(nvargs) == 0 {
+			return &execBatch{err: err}
+		}
+		return s.newExecBatch(ctx, s.pr, nvargs, pipeline)
+	}
+
+	var batchCh chan *execBatch
+	if pipeline {
+		batchCh = make(chan *execBatch, 1)
+		go func() { batchCh <- nextBatch() }()
+	}
 
 	totalRowsAffected := totalRowsAffected(0)
-	numField := s.pr.numField()
-	numNVArg := len(nvargs)
-	numRec := numNVArg / numField
-	numBatch := numRec / c.attrs._bulkSize
-	if numRec%c.attrs._bulkSize != 0 {
-		numBatch++
-	}
-
-	for i := 0; i < numBatch; i++ {
-		from := i * numField * bulkSize
-		to := (i + 1) * numField * bulkSize
-		if to > numNVArg {
-			to = numNVArg
+	bulkErr := new(BulkError)
+	ofs := 0
+	for {
+		var batch *execBatch
+		if pipeline {
+			batch = <-batchCh
+		} else {
+			batch = nextBatch()
+		}
+		if batch.nvargs == nil { // end of rows or error reading the arguments
+			if batch.err != nil {
+				return driver.RowsAffected(totalRowsAffected), bulkErr.abort(batch.err)
+			}
+			return driver.RowsAffected(totalRowsAffected), bulkErr.errOrNil()
 		}
-		r, err := s.exec(ctx, s.pr, nvargs[from:to], !c.inTx, i*bulkSize)
+		if pipeline {
+			go func() { batchCh <- nextBatch() }()
+		}
+
+		r, err := s.execBatch(ctx, s.pr, batch, !c.inTx, ofs)
 		totalRowsAffected.add(r)
-		if err != nil {
-			return driver.RowsAffected(totalRowsAffected), err
+		if err != nil && !bulkErr.add(err) {
+			if pipeline {
+				<-batchCh // wait for the pending batch
+			}
+			return driver.RowsAffected(totalRowsAffected), bulkErr.abort(err)
 		}
+		ofs += len(batch.nvargs) / numField
 	}
-	return driver.RowsAffected(totalRowsAffected), nil
 }
 
 /*
@@ -346,27 +482,98 @@ Bulk insert containing LOBs:
     .for all packages except the last one, the last row contains 'incomplete' LOB data ('piecewise' writing)
 */
 func (s *stmt) exec(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, commit bool, ofs int) (driver.Result, error) {
+	return s.execBatch(ctx, pr, s.newExecBatch(ctx, pr, nvargs, false), commit, ofs)
+}
+
+// execBatch represents the converted arguments of an exec split into server calls.
+type execBatch struct {
+	nvargs []driver.NamedValue
+	ends   []int                // end positions of the server call arguments
+	prms   []*p.InputParameters // pre-encoded input parameters of the server calls (bulk pipelining)
+	err    error
+}
+
+// newExecBatch converts nvargs and splits them into server calls.
+func (s *stmt) newExecBatch(ctx context.Context, pr *prepareResult, nvargs []driver.NamedValue, preencode bool) *execBatch {
 	c := s.conn
-	defer c.addSQLTimeValue(time.Now(), sqlTimeExec)
+	b := &execBatch{nvargs: nvargs}
 
-	addLobDataRecs, err := convertExecArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.attrs._lobChunkSize)
+	addLobDataRecs, err := convertExecArgs(pr.parameterFields, nvargs, c.attrs._cesu8Encoder(), c.lobChunkSize(ctx))
 	if err != nil {
-		return driver.ResultNoRows, err
+		b.err = err
+		return b
+	}
+
+	numColumn := len(pr.parameterFields)
+	numRow := len(nvargs) / numColumn
+
+	size := 0
+	for i, j := 0, 0; i < numRow; i++ {
+		start, end := i*numColumn, (i+1)*numColumn
+		// split by encoded byte size
+		if numRow > 1 {
+			rowSize := p.RowSize(pr.parameterFields, nvargs[start:end])
+			if size != 0 && size+rowSize > c.attrs._bulkByteSize {
+				b.ends = append(b.ends, start)
+				size = 0
+			}
+			size += rowSize
+		}
+		// piecewise LOB handling
+		if j < len(addLobDataRecs) && addLobDataRecs[j] == i {
+			b.ends = append(b.ends, end)
+			size = 0
+			j++
+		}
+	}
+
+	if preencode {
+		from := 0
+		for _, to := range b.ends {
+			inputParameters, err := p.NewInputParameters(pr.parameterFields, nvargs[from:to])
+			if err == nil {
+				err = inputParameters.Preencode(c.attrs._cesu8Encoder, c.timeLocation, c.attrs._decimalRounding.mode())
+			}
+			if err != nil {
+				b.err = err
+				return b
+			}
+			b.prms = append(b.prms, inputParameters)
+			from = to
+		}
+	}
+	return b
+}
+
+// execBatch executes the server calls of batch b.
+func (s *stmt) execBatch(ctx context.Context, pr *prepareResult, b *execBatch, commit bool, ofs int) (driver.Result, error) {
+	c := s.conn
+	defer c.addSQLTimeValue(time.Now(), sqlTimeExec)
+
+	if b.err != nil {
+		return driver.ResultNoRows, b.err
 	}
 
-	// piecewise LOB handling
 	numColumn := len(pr.parameterFields)
+
+	var bulkErr *BulkError
+	if len(b.nvargs)/numColumn > 1 {
+		bulkErr = new(BulkError) // collect row errors and continue
+	}
+
 	totalRowsAffected := totalRowsAffected(0)
 	from := 0
-	for i := 0; i < len(addLobDataRecs); i++ {
-		to := (addLobDataRecs[i] + 1) * numColumn
-
-		r, err := c.exec(ctx, pr, nvargs[from:to], commit, ofs)
+	for i, to := range b.ends {
+		var inputParameters *p.InputParameters
+		if b.prms != nil {
+			inputParameters = b.prms[i]
+		}
+		r, err := c.execInputParameters(ctx, pr, inputParameters, b.nvargs[from:to], commit, ofs+from/numColumn)
 		totalRowsAffected.add(r)
-		if err != nil {
-			return driver.RowsAffected(totalRowsAffected), err
+		if err != nil && (bulkErr == nil || !bulkErr.add(err)) {
+			return driver.RowsAffected(totalRowsAffected), bulkErr.abort(err)
 		}
 		from = to
 	}
-	return driver.RowsAffected(totalRowsAffected), nil
+	return driver.RowsAffected(totalRowsAffected), bulkErr.errOrNil()
 }
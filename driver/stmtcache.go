@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"container/list"
+)
+
+// stmtCacheEntry is a prepared statement cached by stmtCache.
+type stmtCacheEntry struct {
+	query   string
+	pr      *prepareResult
+	refs    int  // number of open statements using the prepared statement
+	evicted bool // statement needs to be dropped when the last statement referencing it is closed
+}
+
+/*
+stmtCache is a least recently used cache of the prepared statements of a connection keyed by sql text.
+
+A cached prepared statement is shared by all statements with the same sql text. Evicted prepared statements
+are dropped on the server as soon as they are not referenced by any open statement anymore.
+*/
+type stmtCache struct {
+	size    int
+	ll      *list.List // front: most recently used
+	entries map[string]*list.Element
+}
+
+func newStmtCache(size int) *stmtCache {
+	if size <= 0 {
+		return nil
+	}
+	return &stmtCache{size: size, ll: list.New(), entries: make(map[string]*list.Element, size)}
+}
+
+// get returns the cache entry of query and increments the entry references.
+func (c *stmtCache) get(query string) (*stmtCacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	elem, ok := c.entries[query]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	entry := elem.Value.(*stmtCacheEntry)
+	entry.refs++
+	return entry, true
+}
+
+// add adds the prepared statement of query with one reference and returns the new cache entry
+// and the evicted prepared statements which are not referenced anymore and can be dropped.
+func (c *stmtCache) add(query string, pr *prepareResult) (*stmtCacheEntry, []*prepareResult) {
+	if c == nil {
+		return nil, nil
+	}
+	entry := &stmtCacheEntry{query: query, pr: pr, refs: 1}
+	c.entries[query] = c.ll.PushFront(entry)
+
+	var drops []*prepareResult
+	for c.ll.Len() > c.size {
+		elem := c.ll.Back()
+		evicted := c.ll.Remove(elem).(*stmtCacheEntry)
+		delete(c.entries, evicted.query)
+		evicted.evicted = true
+		if evicted.refs == 0 {
+			drops = append(drops, evicted.pr)
+		}
+	}
+	return entry, drops
+}
+
+// release decrements the references of entry and returns true if the prepared statement can be dropped.
+func (c *stmtCache) release(entry *stmtCacheEntry) bool {
+	entry.refs--
+	return entry.evicted && entry.refs == 0
+}
+
+// clear removes all entries without dropping the prepared statements (e.g. in case of a lost session).
+func (c *stmtCache) clear() {
+	if c == nil {
+		return
+	}
+	for _, elem := range c.entries {
+		elem.Value.(*stmtCacheEntry).evicted = true
+	}
+	c.ll.Init()
+	clear(c.entries)
+}
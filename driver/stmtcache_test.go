@@ -0,0 +1,51 @@
+package driver
+
+import (
+	"testing"
+)
+
+func TestStmtCache(t *testing.T) {
+	if newStmtCache(0) != nil {
+		t.Fatal("nil statement cache expected for size 0")
+	}
+
+	c := newStmtCache(2)
+
+	e1, drops := c.add("q1", &prepareResult{stmtID: 1})
+	if len(drops) != 0 {
+		t.Fatalf("number of drops %d - expected %d", len(drops), 0)
+	}
+	if c.release(e1) {
+		t.Fatal("cached statement must not be dropped")
+	}
+	e2, _ := c.add("q2", &prepareResult{stmtID: 2})
+
+	// use q1 -> q2 is least recently used
+	if e, ok := c.get("q1"); !ok || e != e1 {
+		t.Fatal("cached statement q1 expected")
+	}
+	c.release(e1)
+
+	// q2 still referenced -> evicted but not dropped
+	_, drops = c.add("q3", &prepareResult{stmtID: 3})
+	if len(drops) != 0 {
+		t.Fatalf("number of drops %d - expected %d", len(drops), 0)
+	}
+	if _, ok := c.get("q2"); ok {
+		t.Fatal("evicted statement q2 must not be cached")
+	}
+	if !c.release(e2) {
+		t.Fatal("evicted statement q2 needs to be dropped after release")
+	}
+
+	// q1 not referenced -> evicted and dropped
+	_, drops = c.add("q4", &prepareResult{stmtID: 4})
+	if len(drops) != 1 || drops[0].stmtID != 1 {
+		t.Fatalf("drops %v - expected statement id %d", drops, 1)
+	}
+
+	c.clear()
+	if c.ll.Len() != 0 || len(c.entries) != 0 {
+		t.Fatal("empty statement cache expected")
+	}
+}
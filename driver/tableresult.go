@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// check if types implement the respective interfaces.
+var (
+	_ sql.Scanner = (*TableResult)(nil)
+	_ driver.Rows = (*tableRows)(nil)
+)
+
+/*
+A TableResult is a table output parameter of a stored procedure which is read completely
+when the procedure is executed.
+
+In contrast to sql.Rows output parameters a TableResult does not depend on the lifetime of the
+statement. Therefore procedures with TableResult output parameters can be executed without
+preparing the statement first:
+
+	var table driver.TableResult
+	if _, err := db.Exec("call proc(?)", sql.Out{Dest: &table}); err != nil {
+		...
+	}
+	rows, err := table.Rows()
+
+As all rows are held in memory TableResult should only be used for tables of limited size.
+*/
+type TableResult struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *TableResult) Scan(src any) error {
+	rows, ok := src.(driver.Rows)
+	if !ok {
+		return fmt.Errorf("invalid table result source type %T", src)
+	}
+
+	r.columns = rows.Columns()
+	r.values = nil
+	for {
+		dest := make([]driver.Value, len(r.columns))
+		err := rows.Next(dest)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Join(err, rows.Close())
+		}
+		r.values = append(r.values, dest)
+	}
+	return rows.Close()
+}
+
+// Columns returns the column names of the table.
+func (r *TableResult) Columns() []string { return r.columns }
+
+// NumRow returns the number of rows of the table.
+func (r *TableResult) NumRow() int { return len(r.values) }
+
+// Rows returns the table rows as sql.Rows.
+func (r *TableResult) Rows() (*sql.Rows, error) {
+	return stdConnTracker.callDB().Query("", &tableRows{columns: r.columns, values: r.values})
+}
+
+// tableRows implements driver.Rows for the rows of a TableResult.
+type tableRows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *tableRows) Columns() []string { return r.columns }
+func (r *tableRows) Close() error      { return nil }
+func (r *tableRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestTableResult(t *testing.T) {
+	src := &tableRows{
+		columns: []string{"I", "X"},
+		values:  [][]driver.Value{{int64(0), "A"}, {int64(1), "B"}, {int64(2), "C"}},
+	}
+
+	var table TableResult
+	if err := table.Scan(src); err != nil {
+		t.Fatal(err)
+	}
+	if table.NumRow() != len(src.values) {
+		t.Fatalf("number of rows %d - expected %d", table.NumRow(), len(src.values))
+	}
+
+	rows, err := table.Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	j := 0
+	for rows.Next() {
+		var i int
+		var x string
+		if err := rows.Scan(&i, &x); err != nil {
+			t.Fatal(err)
+		}
+		if i != j || x != src.values[j][1] {
+			t.Fatalf("row %d: values %d %s - expected %d %s", j, i, x, j, src.values[j][1])
+		}
+		j++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if j != len(src.values) {
+		t.Fatalf("number of scanned rows %d - expected %d", j, len(src.values))
+	}
+
+	if err := table.Scan("invalid"); err == nil {
+		t.Fatal("invalid source type error expected")
+	}
+}
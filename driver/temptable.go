@@ -0,0 +1,130 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// A TempColumn is a column definition of a temporary table.
+type TempColumn struct {
+	Name Identifier
+	Type string // SQL data type, e.g. 'integer' or 'nvarchar(100)'.
+}
+
+/*
+A TempTable is a local temporary table bound to the database session of a pinned connection (sql.Conn).
+
+Local temporary tables are visible to the session they were created by only and are dropped by the database server
+when the session is closed, so that they are well suited to stage data before merging it into persistent tables:
+
+	conn, err := db.Conn(ctx) // pin connection
+	...
+	defer conn.Close()
+	tt, err := driver.CreateTempTableLike(ctx, conn, "STAGE", target)
+	...
+	defer tt.Drop(ctx)
+	if _, err := tt.Load(ctx, rows); err != nil {
+		...
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("merge into %s t using %s s on t.id = s.id ...", target, tt.Name())); err != nil {
+		...
+	}
+
+As the connection pool might close idle connections, the table must only be used via the connection it was created by
+and the connection needs to be kept (not closed) as long as the table is used.
+*/
+type TempTable struct {
+	conn    *sql.Conn
+	name    Identifier
+	columns []Identifier
+}
+
+// tempTableName returns the name of a local temporary table (prefixed by '#').
+func tempTableName(name Identifier) Identifier {
+	if strings.HasPrefix(string(name), "#") {
+		return name
+	}
+	return "#" + name
+}
+
+// CreateTempTable creates a local temporary column table with name and columns in the session of connection conn.
+// The name is prefixed by '#' if needed (local temporary table naming convention).
+func CreateTempTable(ctx context.Context, conn *sql.Conn, name Identifier, columns []TempColumn) (*TempTable, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("create temporary table: no columns provided")
+	}
+	t := &TempTable{conn: conn, name: tempTableName(name), columns: make([]Identifier, len(columns))}
+	defs := make([]string, len(columns))
+	for i, column := range columns {
+		t.columns[i] = column.Name
+		defs[i] = column.Name.String() + " " + column.Type
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("create local temporary column table %s (%s)", t.name, strings.Join(defs, ", "))); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CreateTempTableLike creates a local temporary column table with name and the columns of table like in the session of
+// connection conn (e.g. the target table of a merge). The name is prefixed by '#' if needed.
+func CreateTempTableLike(ctx context.Context, conn *sql.Conn, name, like Identifier) (*TempTable, error) {
+	t := &TempTable{conn: conn, name: tempTableName(name)}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("create local temporary column table %s like %s without data", t.name, like)); err != nil {
+		return nil, err
+	}
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf("select * from %s where 1 = 0", t.name))
+	if err != nil {
+		return nil, errors.Join(err, t.Drop(ctx))
+	}
+	names, err := rows.Columns()
+	if err := errors.Join(err, rows.Close()); err != nil {
+		return nil, errors.Join(err, t.Drop(ctx))
+	}
+	t.columns = make([]Identifier, len(names))
+	for i, name := range names {
+		t.columns[i] = Identifier(name)
+	}
+	return t, nil
+}
+
+// Name returns the name of the temporary table.
+func (t *TempTable) Name() Identifier { return t.name }
+
+// Columns returns the column names of the temporary table.
+func (t *TempTable) Columns() []Identifier { return t.columns }
+
+/*
+Load inserts the rows provided by the rows function into all columns of the temporary table via a prepared bulk insert
+and returns the number of inserted rows.
+
+The rows function is called with one argument per column (see Columns) for each row and needs to return ErrEndOfRows
+after the last row (see function based bulk execution). Please see BulkError for the error handling of bulk executions.
+*/
+func (t *TempTable) Load(ctx context.Context, rows func(args []any) error) (int64, error) {
+	stmt, err := t.conn.PrepareContext(ctx, columnsQuery("insert into", t.name, t.columns, ""))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	r, err := stmt.ExecContext(ctx, rows)
+	if err != nil {
+		return 0, err
+	}
+	return r.RowsAffected()
+}
+
+// Truncate deletes all rows of the temporary table, e.g. to reuse the table for the next staging batch.
+func (t *TempTable) Truncate(ctx context.Context) error {
+	_, err := t.conn.ExecContext(ctx, fmt.Sprintf("truncate table %s", t.name))
+	return err
+}
+
+// Drop drops the temporary table.
+func (t *TempTable) Drop(ctx context.Context) error {
+	_, err := t.conn.ExecContext(ctx, fmt.Sprintf("drop table %s", t.name))
+	return err
+}
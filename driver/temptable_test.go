@@ -0,0 +1,125 @@
+//go:build !unit
+
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func testTempTableMerge(t *testing.T, db *sql.DB) {
+	const numRow = 100
+
+	ctx := context.Background()
+
+	target := RandomIdentifier("tempTableTarget")
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("create column table %s (id integer primary key, name nvarchar(20))", target)); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	tt, err := CreateTempTableLike(ctx, conn, RandomIdentifier("stage"), target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tt.Drop(ctx) //nolint:errcheck
+
+	if len(tt.Columns()) != 2 {
+		t.Fatalf("number of columns %d - expected %d", len(tt.Columns()), 2)
+	}
+
+	i := 0
+	rowsAffected, err := tt.Load(ctx, func(args []any) error {
+		if i >= numRow {
+			return ErrEndOfRows
+		}
+		args[0], args[1] = i, fmt.Sprintf("name %d", i)
+		i++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rowsAffected != numRow {
+		t.Fatalf("rows affected %d - expected %d", rowsAffected, numRow)
+	}
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("merge into %[1]s t using %[2]s s on t.id = s.id when not matched then insert values (s.id, s.name)", target, tt.Name())); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s", target)).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != numRow {
+		t.Fatalf("number of merged rows %d - expected %d", n, numRow)
+	}
+
+	if err := tt.Truncate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.QueryRowContext(ctx, fmt.Sprintf("select count(*) from %s", tt.Name())).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("number of rows after truncate %d - expected %d", n, 0)
+	}
+}
+
+func testTempTableColumns(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	tt, err := CreateTempTable(ctx, conn, RandomIdentifier("#stage"), []TempColumn{{Name: "ID", Type: "integer"}, {Name: "NAME", Type: "nvarchar(20)"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := false
+	if _, err := tt.Load(ctx, func(args []any) error {
+		if loaded {
+			return ErrEndOfRows
+		}
+		args[0], args[1] = 1, "one"
+		loaded = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tt.Drop(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTempTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, db *sql.DB)
+	}{
+		{"merge", testTempTableMerge},
+		{"columns", testTempTableColumns},
+	}
+
+	db := MT.DB()
+	for _, test := range tests {
+		test := test // new dfv to run in parallel
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.fct(t, db)
+		})
+	}
+}
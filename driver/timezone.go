@@ -0,0 +1,82 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// A TimeZonePolicy defines how TIMESTAMP (LONGDATE, SECONDDATE) wall clock values are mapped to time.Time values.
+type TimeZonePolicy int
+
+// TimeZonePolicy constants.
+const (
+	// TzUTC interprets database timestamps as UTC and stores bound values in UTC (default).
+	TzUTC TimeZonePolicy = iota
+	// TzSession interprets database timestamps in the time zone of the database session and
+	// stores bound values in the session time zone.
+	TzSession
+	// TzLocation interprets database timestamps in the connector time location and
+	// stores bound values in the connector time location.
+	TzLocation
+)
+
+func (p TimeZonePolicy) String() string {
+	switch p {
+	case TzUTC:
+		return "utc"
+	case TzSession:
+		return "session"
+	case TzLocation:
+		return "location"
+	default:
+		return fmt.Sprintf("TimeZonePolicy(%d)", int(p))
+	}
+}
+
+// sessionTimeZoneQuery returns the offset of the session time zone to UTC in seconds.
+const sessionTimeZoneQuery = "select seconds_between(current_utctimestamp, current_timestamp) from dummy"
+
+/*
+fetchTimeLocation returns the location timestamp values of the connection are interpreted in (nil: UTC).
+
+The session time zone is represented by a fixed zone of the UTC offset at the time the session is opened,
+as the database does not provide a location name a daylight saving time transition could be derived from.
+*/
+func (c *conn) fetchTimeLocation(ctx context.Context, attrs *connAttrs) (*time.Location, error) {
+	switch attrs._timeZonePolicy {
+	case TzSession:
+		return c.sessionTimeLocation(ctx)
+	case TzLocation:
+		return attrs._timeLocation, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (c *conn) sessionTimeLocation(ctx context.Context) (*time.Location, error) {
+	rows, err := c.queryDirect(ctx, sessionTimeZoneQuery, !c.inTx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("session time zone: no rows returned")
+		}
+		return nil, err
+	}
+	offset, ok := dest[0].(int64)
+	if !ok {
+		return nil, fmt.Errorf("session time zone: invalid offset type %T", dest[0])
+	}
+	if offset == 0 {
+		return nil, nil
+	}
+	return time.FixedZone("", int(offset)), nil
+}
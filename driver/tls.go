@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"slices"
+)
+
+// ErrCertificateNotPinned is returned by VerifyPinnedCertificate in case the server certificate does not match any pinned certificate.
+var ErrCertificateNotPinned = errors.New("server certificate is not pinned")
+
+/*
+VerifyPinnedCertificate returns a peer certificate verification function (see SetTLSVerifyPeerCertificate)
+accepting the server leaf certificate only if its SHA-256 fingerprint is one of fingerprints.
+*/
+func VerifyPinnedCertificate(fingerprints ...[sha256.Size]byte) func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	fingerprints = slices.Clone(fingerprints)
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return ErrCertificateNotPinned
+		}
+		if !slices.Contains(fingerprints, sha256.Sum256(rawCerts[0])) {
+			return ErrCertificateNotPinned
+		}
+		return nil
+	}
+}
@@ -3,9 +3,12 @@
 package driver_test
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/SAP/go-hdb/driver"
 )
@@ -109,6 +112,74 @@ func testTransactionRollback(t *testing.T, db *sql.DB) {
 	}
 }
 
+func testTransactionIsolationLevel(t *testing.T, db *sql.DB) {
+	table := driver.RandomIdentifier("testTxIsolationLevel_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i integer)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	supported := []sql.IsolationLevel{sql.LevelDefault, sql.LevelReadCommitted, sql.LevelRepeatableRead, sql.LevelSnapshot, sql.LevelSerializable}
+	for _, level := range supported {
+		for _, readOnly := range []bool{false, true} {
+			tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: level, ReadOnly: readOnly})
+			if err != nil {
+				t.Fatalf("isolation level %s read only %t: %s", level, readOnly, err)
+			}
+			_, err = tx.Exec(fmt.Sprintf("insert into %s values (1)", table))
+			if readOnly && err == nil {
+				t.Fatalf("isolation level %s: insert in read only transaction succeeded", level)
+			}
+			if !readOnly && err != nil {
+				t.Fatalf("isolation level %s: %s", level, err)
+			}
+			if err := tx.Rollback(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	unsupported := []sql.IsolationLevel{sql.LevelReadUncommitted, sql.LevelWriteCommitted, sql.LevelLinearizable}
+	for _, level := range unsupported {
+		_, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: level})
+		var levelErr *driver.UnsupportedIsolationLevelError
+		if !errors.Is(err, driver.ErrUnsupportedIsolationLevel) || !errors.As(err, &levelErr) || levelErr.Level != level {
+			t.Fatalf("isolation level %s: error %v - expected %v", level, err, driver.ErrUnsupportedIsolationLevel)
+		}
+	}
+}
+
+func testRunTx(t *testing.T, db *sql.DB) {
+	table := driver.RandomIdentifier("runTx_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i integer)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	errRetry := errors.New("retry")
+	policy := &driver.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Retryable: func(err error) bool { return errors.Is(err, errRetry) }}
+
+	attempts := 0
+	if err := driver.RunTx(context.Background(), db, nil, policy, nil, func(tx *sql.Tx) error {
+		attempts++
+		if _, err := tx.Exec(fmt.Sprintf("insert into %s values (?)", table), attempts); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return errRetry // first attempt gets rolled back
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var i int
+	if err := db.QueryRow(fmt.Sprintf("select count(*) from %s", table)).Scan(&i); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 || i != 1 {
+		t.Fatalf("attempts %d records %d - expected attempts %d records %d", attempts, i, 2, 1)
+	}
+}
+
 func TestTransaction(t *testing.T) {
 	tests := []struct {
 		name string
@@ -116,6 +187,8 @@ func TestTransaction(t *testing.T) {
 	}{
 		{"transactionCommit", testTransactionCommit},
 		{"transactionRollback", testTransactionRollback},
+		{"transactionIsolationLevel", testTransactionIsolationLevel},
+		{"runTx", testRunTx},
 	}
 
 	db := driver.MT.DB()
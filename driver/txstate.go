@@ -0,0 +1,71 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+const (
+	setDDLAutocommitOn  = "set transaction autocommit ddl on"
+	setDDLAutocommitOff = "set transaction autocommit ddl off"
+)
+
+// TxState represents the transaction state of a connection as reported by the database server via transaction flags.
+type TxState struct {
+	// WriteTransaction is true if a write transaction is open (started and not yet committed or rolled back).
+	WriteTransaction bool
+	// Committed is true if the last transaction state change reported by the database server was a commit.
+	Committed bool
+	// RolledBack is true if the last transaction state change reported by the database server was a rollback
+	// (e.g. a rollback by the database server because of a deadlock).
+	RolledBack bool
+	// ReadOnly is true if the transaction is in read only mode.
+	ReadOnly bool
+	// DDLAutocommit is true if DDL statements are committed automatically (database server default).
+	DDLAutocommit bool
+}
+
+// handleTransactionFlags updates the transaction state of the connection.
+func (c *conn) handleTransactionFlags(tf *p.TransactionFlags) {
+	c.txState.Committed = tf.CommittedOrZero()
+	c.txState.RolledBack = tf.RolledBackOrZero()
+	if c.txState.Committed || c.txState.RolledBack {
+		c.txState.WriteTransaction = false
+	}
+	if tf.WriteTransactionStartedOrZero() {
+		c.txState.WriteTransaction = true
+	}
+	c.txState.ReadOnly = tf.ReadOnlyModeOrZero()
+	if ddlAutocommit, ok := tf.DDLCommitMode(); ok {
+		c.txState.DDLAutocommit = ddlAutocommit
+	}
+}
+
+// TransactionState returns the transaction state of the database connection sqlConn.
+func TransactionState(sqlConn *sql.Conn) (TxState, error) {
+	var txState TxState
+	err := rawConn(sqlConn, func(c *conn) error {
+		txState = c.txState
+		return nil
+	})
+	return txState, err
+}
+
+// SetDDLAutocommit enables or disables the automatic commit of DDL statements for the database connection sqlConn.
+// If disabled, DDL statements executed within a transaction are committed or rolled back together with the transaction.
+func SetDDLAutocommit(ctx context.Context, sqlConn *sql.Conn, autocommit bool) error {
+	query := setDDLAutocommitOff
+	if autocommit {
+		query = setDDLAutocommitOn
+	}
+	return rawConn(sqlConn, func(c *conn) error {
+		if _, err := c.execDirect(ctx, query, !c.inTx); err != nil {
+			c.setLastError(err)
+			return classifyError(err)
+		}
+		c.txState.DDLAutocommit = autocommit
+		return nil
+	})
+}
@@ -0,0 +1,121 @@
+//go:build !unit
+
+package driver_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+func testTransactionState(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	table := driver.RandomIdentifier("txState_")
+	if _, err := db.Exec(fmt.Sprintf("create table %s (i integer)", table)); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	txState, err := driver.TransactionState(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txState.WriteTransaction || !txState.DDLAutocommit {
+		t.Fatalf("initial transaction state %v", txState)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec(fmt.Sprintf("insert into %s values (1)", table)); err != nil {
+		t.Fatal(err)
+	}
+	if txState, err = driver.TransactionState(conn); err != nil {
+		t.Fatal(err)
+	}
+	if !txState.WriteTransaction {
+		t.Fatalf("transaction state %v - expected open write transaction", txState)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if txState, err = driver.TransactionState(conn); err != nil {
+		t.Fatal(err)
+	}
+	if txState.WriteTransaction || !txState.RolledBack {
+		t.Fatalf("transaction state %v - expected rolled back transaction", txState)
+	}
+}
+
+func testDDLAutocommit(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := driver.SetDDLAutocommit(ctx, conn, false); err != nil {
+		t.Fatal(err)
+	}
+	defer driver.SetDDLAutocommit(ctx, conn, true) //nolint:errcheck
+
+	txState, err := driver.TransactionState(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txState.DDLAutocommit {
+		t.Fatal("ddl autocommit not disabled")
+	}
+
+	// create table in transaction and rollback
+	table := driver.RandomIdentifier("ddlAutocommit_")
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec(fmt.Sprintf("create table %s (i integer)", table)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	var n int
+	if err := conn.QueryRowContext(ctx, "select count(*) from tables where schema_name = current_schema and table_name = ?", string(table)).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("table %s created - expected rollback of ddl statement", table)
+	}
+}
+
+func TestTransactionState(t *testing.T) {
+	tests := []struct {
+		name string
+		fct  func(t *testing.T, db *sql.DB)
+	}{
+		{"transactionState", testTransactionState},
+		{"ddlAutocommit", testDDLAutocommit},
+	}
+
+	db := driver.MT.DB()
+	for _, test := range tests {
+		test := test // new test to run in parallel
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.fct(t, db)
+		})
+	}
+}
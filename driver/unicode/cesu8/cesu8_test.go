@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"testing"
 	"unicode/utf8"
+
+	"golang.org/x/text/transform"
 )
 
 func TestCodeLen(t *testing.T) {
@@ -88,3 +90,32 @@ func TestString(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorHandler(t *testing.T) {
+	invalid := []byte("a\x80b")
+
+	tests := []struct {
+		name         string
+		errorHandler func(err *DecodeError) (rune, error)
+		result       string
+		err          bool
+	}{
+		{"error", nil, "", true},
+		{"replace", ReplaceErrorHandler, "a�b", false},
+		{"passThrough", PassThroughErrorHandler, "a\x80b", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, tr := range []transform.Transformer{NewEncoder(test.errorHandler), NewDecoder(test.errorHandler)} {
+				b, _, err := transform.Bytes(tr, invalid)
+				if (err != nil) != test.err {
+					t.Fatalf("%T: error %v - expected error %t", tr, err, test.err)
+				}
+				if err == nil && string(b) != test.result {
+					t.Fatalf("%T: result %q - expected %q", tr, b, test.result)
+				}
+			}
+		})
+	}
+}
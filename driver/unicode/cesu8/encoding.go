@@ -86,6 +86,14 @@ func (e *Encoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err er
 			if err != nil {
 				return j, i, err
 			}
+			if r == passThroughRune {
+				if j+n > len(dst) {
+					return j, i, transform.ErrShortDst
+				}
+				j += copy(dst[j:], src[i:i+n])
+				i += n
+				continue
+			}
 		}
 		m := RuneLen(r)
 		switch {
@@ -144,6 +152,14 @@ func (d *Decoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err er
 			if err != nil {
 				return j, i, err
 			}
+			if r == passThroughRune {
+				if j+n > len(dst) {
+					return j, i, transform.ErrShortDst
+				}
+				j += copy(dst[j:], src[i:i+n])
+				i += n
+				continue
+			}
 		}
 		m := utf8.RuneLen(r)
 		switch {
@@ -173,3 +189,10 @@ func DefaultEncoder() transform.Transformer { return defaultEncoder }
 // ReplaceErrorHandler is a decoding error handling function replacing invalid CESU-8 data with the
 // unicode replacement character '\uFFFD'.
 func ReplaceErrorHandler(err *DecodeError) (rune, error) { return unicode.ReplacementChar, nil }
+
+// passThroughRune is returned by PassThroughErrorHandler to signal that invalid data should be copied unchanged.
+const passThroughRune = -1
+
+// PassThroughErrorHandler is a decoding error handling function copying invalid data unchanged
+// (invalid UTF-8 is sent to the database as is, invalid CESU-8 is returned to the application as is).
+func PassThroughErrorHandler(err *DecodeError) (rune, error) { return passThroughRune, nil }
@@ -108,11 +108,13 @@ const (
 	hdbfNone              uint64 = 1 << iota
 	hdbfServerVersion            // HANA reports server version in connect options
 	hdbfConnectClientInfo        // HANA accepts ClientInfo as part of the connection process
+	hdbfDBConnectInfo            // HANA accepts DBConnectInfo requests for authenticated sessions
 )
 
 var hdbFeatureAvailability = map[uint64]versionNumber{
 	hdbfServerVersion:     parseVersionNumber("2.00.000"),
 	hdbfConnectClientInfo: parseVersionNumber("2.00.042"),
+	hdbfDBConnectInfo:     parseVersionNumber("2.00.000"),
 }
 
 // Version is representing a hdb version.
@@ -0,0 +1,18 @@
+package driver
+
+import (
+	"context"
+	"log/slog"
+
+	p "github.com/SAP/go-hdb/driver/internal/protocol"
+)
+
+// handleWarning is called for each warning sent by the database server.
+func (c *conn) handleWarning(ctx context.Context, warning *p.HdbError) {
+	c.warnings = append(c.warnings, warning)
+	if handler := c.attrs._warningHandler; handler != nil {
+		handler(ctx, warning)
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, warning.Error())
+}
@@ -17,8 +17,19 @@ type collector struct {
 	openConnections  *prometheus.Desc
 	openTransactions *prometheus.Desc
 	openStatements   *prometheus.Desc
+	retainedBytes    *prometheus.Desc
 	readBytes        *prometheus.Desc
 	writtenBytes     *prometheus.Desc
+	stmtCacheHits    *prometheus.Desc
+	stmtCacheMisses  *prometheus.Desc
+	metadataHits     *prometheus.Desc
+	metadataMisses   *prometheus.Desc
+	roundtrips       *prometheus.Desc
+	lobChunks        *prometheus.Desc
+	errors           *prometheus.Desc
+	reconnects       *prometheus.Desc
+	freedLobs        *prometheus.Desc
+	reprepares       *prometheus.Desc
 	readTime         *prometheus.Desc
 	writeTime        *prometheus.Desc
 	authTime         *prometheus.Desc
@@ -50,6 +61,12 @@ func newCollector(fn func() *driver.Stats, subsystem string, labels prometheus.L
 			nil,
 			labels,
 		),
+		retainedBytes: prometheus.NewDesc(
+			fqName("retained_bytes"),
+			fmt.Sprintf("The number of bytes of decoding buffers retained by open %s result sets.", subsystem),
+			nil,
+			labels,
+		),
 		readBytes: prometheus.NewDesc(
 			fqName("bytes_read"),
 			fmt.Sprintf("The total bytes read from the database connection of %s statements.", subsystem),
@@ -62,6 +79,66 @@ func newCollector(fn func() *driver.Stats, subsystem string, labels prometheus.L
 			nil,
 			labels,
 		),
+		stmtCacheHits: prometheus.NewDesc(
+			fqName("stmt_cache_hits"),
+			fmt.Sprintf("The total number of %s prepares served by the statement cache.", subsystem),
+			nil,
+			labels,
+		),
+		stmtCacheMisses: prometheus.NewDesc(
+			fqName("stmt_cache_misses"),
+			fmt.Sprintf("The total number of %s prepares not found in the statement cache.", subsystem),
+			nil,
+			labels,
+		),
+		metadataHits: prometheus.NewDesc(
+			fqName("metadata_cache_hits"),
+			fmt.Sprintf("The total number of %s prepares reusing the metadata of the metadata cache.", subsystem),
+			nil,
+			labels,
+		),
+		metadataMisses: prometheus.NewDesc(
+			fqName("metadata_cache_misses"),
+			fmt.Sprintf("The total number of %s prepares decoding the metadata with metadata cache enabled.", subsystem),
+			nil,
+			labels,
+		),
+		roundtrips: prometheus.NewDesc(
+			fqName("roundtrips"),
+			fmt.Sprintf("The total number of %s request / reply roundtrips.", subsystem),
+			nil,
+			labels,
+		),
+		lobChunks: prometheus.NewDesc(
+			fqName("lob_chunks"),
+			fmt.Sprintf("The total number of %s lob chunks read or written in separate roundtrips.", subsystem),
+			nil,
+			labels,
+		),
+		errors: prometheus.NewDesc(
+			fqName("errors"),
+			fmt.Sprintf("The total number of errors returned by %s statement executions.", subsystem),
+			nil,
+			labels,
+		),
+		reconnects: prometheus.NewDesc(
+			fqName("reconnects"),
+			fmt.Sprintf("The total number of %s connection reconnects.", subsystem),
+			nil,
+			labels,
+		),
+		freedLobs: prometheus.NewDesc(
+			fqName("freed_lobs"),
+			fmt.Sprintf("The total number of %s lob locators not read completely and released by the driver.", subsystem),
+			nil,
+			labels,
+		),
+		reprepares: prometheus.NewDesc(
+			fqName("reprepares"),
+			fmt.Sprintf("The total number of %s prepared statements re-prepared after being invalidated by the database server.", subsystem),
+			nil,
+			labels,
+		),
 		readTime: prometheus.NewDesc(
 			fqName("read_time"),
 			fmt.Sprintf("The time spent measured in %s for reading from the database connection of %s.", stats.TimeUnit, subsystem),
@@ -94,8 +171,19 @@ func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.openConnections
 	ch <- c.openTransactions
 	ch <- c.openStatements
+	ch <- c.retainedBytes
 	ch <- c.readBytes
 	ch <- c.writtenBytes
+	ch <- c.stmtCacheHits
+	ch <- c.stmtCacheMisses
+	ch <- c.metadataHits
+	ch <- c.metadataMisses
+	ch <- c.roundtrips
+	ch <- c.lobChunks
+	ch <- c.errors
+	ch <- c.reconnects
+	ch <- c.freedLobs
+	ch <- c.reprepares
 	ch <- c.readTime
 	ch <- c.writeTime
 	ch <- c.authTime
@@ -108,8 +196,19 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(c.openConnections, prometheus.GaugeValue, float64(stats.OpenConnections))
 	ch <- prometheus.MustNewConstMetric(c.openTransactions, prometheus.GaugeValue, float64(stats.OpenTransactions))
 	ch <- prometheus.MustNewConstMetric(c.openStatements, prometheus.GaugeValue, float64(stats.OpenStatements))
+	ch <- prometheus.MustNewConstMetric(c.retainedBytes, prometheus.GaugeValue, float64(stats.RetainedBytes))
 	ch <- prometheus.MustNewConstMetric(c.readBytes, prometheus.CounterValue, float64(stats.ReadBytes))
 	ch <- prometheus.MustNewConstMetric(c.writtenBytes, prometheus.CounterValue, float64(stats.WrittenBytes))
+	ch <- prometheus.MustNewConstMetric(c.stmtCacheHits, prometheus.CounterValue, float64(stats.StmtCacheHits))
+	ch <- prometheus.MustNewConstMetric(c.stmtCacheMisses, prometheus.CounterValue, float64(stats.StmtCacheMisses))
+	ch <- prometheus.MustNewConstMetric(c.metadataHits, prometheus.CounterValue, float64(stats.MetadataHits))
+	ch <- prometheus.MustNewConstMetric(c.metadataMisses, prometheus.CounterValue, float64(stats.MetadataMisses))
+	ch <- prometheus.MustNewConstMetric(c.roundtrips, prometheus.CounterValue, float64(stats.Roundtrips))
+	ch <- prometheus.MustNewConstMetric(c.lobChunks, prometheus.CounterValue, float64(stats.LobChunks))
+	ch <- prometheus.MustNewConstMetric(c.errors, prometheus.CounterValue, float64(stats.Errors))
+	ch <- prometheus.MustNewConstMetric(c.reconnects, prometheus.CounterValue, float64(stats.Reconnects))
+	ch <- prometheus.MustNewConstMetric(c.freedLobs, prometheus.CounterValue, float64(stats.FreedLobs))
+	ch <- prometheus.MustNewConstMetric(c.reprepares, prometheus.CounterValue, float64(stats.Reprepares))
 	ch <- prometheus.MustNewConstHistogram(c.readTime, stats.ReadTime.Count, stats.ReadTime.Sum, stats.ReadTime.Buckets)
 	ch <- prometheus.MustNewConstHistogram(c.writeTime, stats.WriteTime.Count, stats.WriteTime.Sum, stats.WriteTime.Buckets)
 	ch <- prometheus.MustNewConstHistogram(c.authTime, stats.AuthTime.Count, stats.AuthTime.Sum, stats.AuthTime.Buckets)
@@ -0,0 +1,42 @@
+package collectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type testDriver struct{}
+
+func (d testDriver) Name() string    { return "test" }
+func (d testDriver) Version() string { return "0.0.0" }
+func (d testDriver) Stats() *driver.Stats {
+	h := &driver.StatsHistogram{Buckets: map[float64]uint64{1: 0}}
+	return &driver.Stats{
+		Roundtrips: 42,
+		Errors:     1,
+		TimeUnit:   "ms",
+		ReadTime:   h,
+		WriteTime:  h,
+		AuthTime:   h,
+		SQLTimes:   map[string]*driver.StatsHistogram{"query": h},
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewDriverStatsCollector(testDriver{}, "testDB")
+
+	const expected = `
+# HELP go_hdb_driver_roundtrips The total number of driver request / reply roundtrips.
+# TYPE go_hdb_driver_roundtrips counter
+go_hdb_driver_roundtrips{db_name="testDB"} 42
+# HELP go_hdb_driver_errors The total number of errors returned by driver statement executions.
+# TYPE go_hdb_driver_errors counter
+go_hdb_driver_errors{db_name="testDB"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "go_hdb_driver_roundtrips", "go_hdb_driver_errors"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,4 +1,5 @@
-// Package sqlscript provides functions to scan HDBSQL scripts with the help of bufio.Scanner.
+// Package sqlscript provides functions to scan HDBSQL scripts with the help of bufio.Scanner
+// and to execute them statement by statement.
 // This package is currently experimental and its public interface might be changed
 // in an incompatible way at any time.
 package sqlscript
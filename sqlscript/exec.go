@@ -0,0 +1,59 @@
+package sqlscript
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+)
+
+// maxStatementSize is the maximum size of a single script statement.
+const maxStatementSize = 16 * 1024 * 1024
+
+// An Execer executes sql statements and is implemented by sql.DB, sql.Conn and sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// A StmtResult is the result of the execution of a single script statement.
+type StmtResult struct {
+	No     int        // statement number (0-based)
+	Stmt   string     // statement text
+	Result sql.Result // nil in case of error
+	Err    error
+}
+
+/*
+Exec splits the script read from rd into statements separated by separator (see ScanFunc)
+and executes them sequentially via execer. It returns the results of all executed statements.
+
+As statements of a script usually depend on each other (e.g. temporary tables or session variables)
+execer should be a sql.Conn or sql.Tx, so that all statements are executed on the same connection.
+
+In case of an error the execution stops and the statement error is returned unless continueOnError is true.
+In this case all statements are executed and the errors of all failed statements are returned joined.
+*/
+func Exec(ctx context.Context, execer Execer, rd io.Reader, separator rune, continueOnError bool) ([]StmtResult, error) {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, maxStatementSize)
+	scanner.Split(ScanFunc(separator, false))
+
+	var results []StmtResult
+	var errs []error
+	for no := 0; scanner.Scan(); no++ {
+		stmt := scanner.Text()
+		result, err := execer.ExecContext(ctx, stmt)
+		results = append(results, StmtResult{No: no, Stmt: stmt, Result: result, Err: err})
+		if err != nil {
+			errs = append(errs, err)
+			if !continueOnError {
+				return results, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return results, errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+package sqlscript
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var errTestExec = errors.New("exec error")
+
+type testExecer struct {
+	stmts  []string
+	failNo int
+}
+
+func (e *testExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.stmts = append(e.stmts, query)
+	if len(e.stmts)-1 == e.failNo {
+		return nil, errTestExec
+	}
+	return sql.Result(nil), nil
+}
+
+func TestExec(t *testing.T) {
+	const script = `
+-- comment
+create table t (i integer);
+insert into t values (1);
+insert into t values ('a;b');
+drop table t`
+
+	stmts := []string{"create table t (i integer)", "insert into t values (1)", "insert into t values ('a;b')", "drop table t"}
+
+	tests := []struct {
+		failNo          int
+		continueOnError bool
+		numResult       int
+	}{
+		{-1, false, 4},
+		{1, false, 2},
+		{1, true, 4},
+	}
+
+	for _, test := range tests {
+		execer := &testExecer{failNo: test.failNo}
+		results, err := Exec(context.Background(), execer, strings.NewReader(script), DefaultSeparator, test.continueOnError)
+		if test.failNo == -1 && err != nil {
+			t.Fatal(err)
+		}
+		if test.failNo != -1 && !errors.Is(err, errTestExec) {
+			t.Fatalf("error %v - expected %v", err, errTestExec)
+		}
+		if len(results) != test.numResult {
+			t.Fatalf("number of results %d - expected %d", len(results), test.numResult)
+		}
+		for i, result := range results {
+			if result.No != i || result.Stmt != stmts[i] {
+				t.Fatalf("result %d: statement %d %s - expected %d %s", i, result.No, result.Stmt, i, stmts[i])
+			}
+			if (i == test.failNo) != (result.Err != nil) {
+				t.Fatalf("result %d: unexpected error %v", i, result.Err)
+			}
+		}
+	}
+}
@@ -26,10 +26,11 @@ type scanner struct {
 	data      []byte
 	atEOF     bool
 	token     []byte
+	stmtOfs   int // start of the statement in token (after leading comments), -1 if not started
 }
 
 func (s *scanner) init(data []byte, atEOF bool) {
-	s.data, s.atEOF, s.token = data, atEOF, nil
+	s.data, s.atEOF, s.token, s.stmtOfs = data, atEOF, nil, -1
 }
 
 func (s *scanner) nextRune() (rune, int, error) {
@@ -187,6 +188,7 @@ func (s *scanner) _scan() (bool, error) {
 			s.appendRune(nl)
 		}
 	}
+	s.stmtOfs = len(s.token)
 	return s.scanStatement()
 }
 
@@ -198,6 +200,9 @@ func (s *scanner) scan(data []byte, atEOF bool) (int, []byte, error) {
 
 	ok, err := s._scan()
 	if errors.Is(err, io.EOF) {
+		if atEOF && s.stmtOfs != -1 && len(bytes.TrimSpace(s.token[s.stmtOfs:])) != 0 {
+			return len(data), s.token, nil // last statement without separator
+		}
 		return 0, nil, nil // need more data
 	}
 	if err != nil {
@@ -91,4 +91,8 @@ MULTI LINE STATEMENT WITH DOUBLE QUOTED PARAMER "
 
 	testScan(t, DefaultSeparator, false, testScript, noCommentsResult)
 	testScan(t, DefaultSeparator, true, testScript, commentsResult)
+
+	// last statement without separator
+	testScan(t, DefaultSeparator, false, "STATEMENT;\nLAST STATEMENT\n", []string{"STATEMENT", "LAST STATEMENT"})
+	testScan(t, DefaultSeparator, true, "STATEMENT;\n--TRAILING COMMENT\n", []string{"STATEMENT"})
 }